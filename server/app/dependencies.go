@@ -0,0 +1,433 @@
+// Package app 组装应用依赖，供 main 和测试工具共用
+package app
+
+import (
+	"mvc-demo/config"
+	"mvc-demo/controllers"
+	"mvc-demo/dao"
+	"mvc-demo/search"
+	"mvc-demo/service"
+
+	"gorm.io/gorm"
+)
+
+// Dependencies 应用依赖：DAO、服务和按需创建的控制器
+type Dependencies struct {
+	Config                      *config.AppConfig
+	DB                          *gorm.DB
+	UserDAO                     dao.UserRepository
+	UniversityDAO               dao.UniversityRepository
+	StudentDAO                  dao.StudentRepository
+	CollegeDAO                  dao.CollegeRepository
+	MajorDAO                    dao.MajorRepository
+	StudentStatusHistoryDAO     dao.StudentStatusHistoryRepository
+	AuditLogDAO                 dao.AuditLogRepository
+	StudentChangeRequestDAO     dao.StudentChangeRequestRepository
+	NotificationDAO             dao.NotificationRepository
+	CourseDAO                   dao.CourseRepository
+	CourseSectionDAO            dao.CourseSectionRepository
+	EnrollmentDAO               dao.EnrollmentRepository
+	GradingScaleDAO             dao.GradingScaleRepository
+	GradeDAO                    dao.GradeRepository
+	TranscriptDAO               dao.TranscriptRepository
+	ClassSessionDAO             dao.ClassSessionRepository
+	AttendanceDAO               dao.AttendanceRepository
+	TeacherDAO                  dao.TeacherRepository
+	AdvisorAssignmentDAO        dao.AdvisorAssignmentRepository
+	CompanyDAO                  dao.CompanyRepository
+	JobPostingDAO               dao.JobPostingRepository
+	JobApplicationDAO           dao.JobApplicationRepository
+	EmploymentRecordDAO         dao.EmploymentRecordRepository
+	UserService                 *service.UserService
+	UniversityService           *service.UniversityService
+	UniversityMergeService      *service.UniversityMergeService
+	StudentService              *service.StudentService
+	StudentSearchService        *service.StudentSearchService
+	CollegeService              *service.CollegeService
+	MajorService                *service.MajorService
+	StudentDuplicateService     *service.StudentDuplicateService
+	StudentStatusService        *service.StudentStatusService
+	NotificationService         *service.NotificationService
+	StudentChangeRequestService *service.StudentChangeRequestService
+	CourseService               *service.CourseService
+	CourseSectionService        *service.CourseSectionService
+	EnrollmentService           *service.EnrollmentService
+	GradingScaleService         *service.GradingScaleService
+	GradeService                *service.GradeService
+	TranscriptService           *service.TranscriptService
+	AttendanceService           *service.AttendanceService
+	TeacherService              *service.TeacherService
+	AdvisorService              *service.AdvisorService
+	CompanyService              *service.CompanyService
+	JobPostingService           *service.JobPostingService
+	JobApplicationService       *service.JobApplicationService
+	EmploymentService           *service.EmploymentService
+	userController              *controllers.UserController
+	authController              *controllers.AuthController
+	universityController        *controllers.UniversityController
+	studentController           *controllers.StudentController
+	collegeController           *controllers.CollegeController
+	majorController             *controllers.MajorController
+	studentDuplicateController  *controllers.StudentDuplicateController
+	changeRequestController     *controllers.ChangeRequestController
+	notificationController      *controllers.NotificationController
+	courseController            *controllers.CourseController
+	courseSectionController     *controllers.CourseSectionController
+	enrollmentController        *controllers.EnrollmentController
+	gradingScaleController      *controllers.GradingScaleController
+	gradeController             *controllers.GradeController
+	transcriptController        *controllers.TranscriptController
+	attendanceController        *controllers.AttendanceController
+	teacherController           *controllers.TeacherController
+	advisorController           *controllers.AdvisorController
+	companyController           *controllers.CompanyController
+	jobPostingController        *controllers.JobPostingController
+	jobApplicationController    *controllers.JobApplicationController
+	employmentController        *controllers.EmploymentController
+}
+
+// GetConfig 获取应用配置
+func (d *Dependencies) GetConfig() *config.AppConfig {
+	return d.Config
+}
+
+// GetUserController 获取用户控制器
+func (d *Dependencies) GetUserController() *controllers.UserController {
+	if d.userController == nil {
+		d.userController = controllers.NewUserController(d.UserService)
+	}
+	return d.userController
+}
+
+// GetAuthController 获取认证控制器
+func (d *Dependencies) GetAuthController() *controllers.AuthController {
+	if d.authController == nil {
+		d.authController = controllers.NewAuthController(d.UserService)
+	}
+	return d.authController
+}
+
+// GetUniversityController 获取大学控制器
+func (d *Dependencies) GetUniversityController() *controllers.UniversityController {
+	if d.universityController == nil {
+		d.universityController = controllers.NewUniversityController(d.UniversityService, d.UniversityMergeService)
+	}
+	return d.universityController
+}
+
+// GetStudentController 获取学生控制器
+func (d *Dependencies) GetStudentController() *controllers.StudentController {
+	if d.studentController == nil {
+		d.studentController = controllers.NewStudentController(d.StudentService, d.StudentSearchService, d.StudentStatusService, d.TeacherService)
+	}
+	return d.studentController
+}
+
+// GetCollegeController 获取院系控制器
+func (d *Dependencies) GetCollegeController() *controllers.CollegeController {
+	if d.collegeController == nil {
+		d.collegeController = controllers.NewCollegeController(d.CollegeService, d.UniversityService)
+	}
+	return d.collegeController
+}
+
+// GetMajorController 获取专业控制器
+func (d *Dependencies) GetMajorController() *controllers.MajorController {
+	if d.majorController == nil {
+		d.majorController = controllers.NewMajorController(d.MajorService, d.UniversityService)
+	}
+	return d.majorController
+}
+
+// GetStudentDuplicateController 获取学生查重与合并控制器
+func (d *Dependencies) GetStudentDuplicateController() *controllers.StudentDuplicateController {
+	if d.studentDuplicateController == nil {
+		d.studentDuplicateController = controllers.NewStudentDuplicateController(d.StudentDuplicateService, d.StudentService)
+	}
+	return d.studentDuplicateController
+}
+
+// GetChangeRequestController 获取学生信息变更申请控制器
+func (d *Dependencies) GetChangeRequestController() *controllers.ChangeRequestController {
+	if d.changeRequestController == nil {
+		d.changeRequestController = controllers.NewChangeRequestController(d.StudentChangeRequestService)
+	}
+	return d.changeRequestController
+}
+
+// GetNotificationController 获取站内通知控制器
+func (d *Dependencies) GetNotificationController() *controllers.NotificationController {
+	if d.notificationController == nil {
+		d.notificationController = controllers.NewNotificationController(d.NotificationService)
+	}
+	return d.notificationController
+}
+
+// GetCourseController 获取课程控制器
+func (d *Dependencies) GetCourseController() *controllers.CourseController {
+	if d.courseController == nil {
+		d.courseController = controllers.NewCourseController(d.CourseService, d.UniversityService, d.GradingScaleService)
+	}
+	return d.courseController
+}
+
+// GetCourseSectionController 获取教学班控制器
+func (d *Dependencies) GetCourseSectionController() *controllers.CourseSectionController {
+	if d.courseSectionController == nil {
+		d.courseSectionController = controllers.NewCourseSectionController(d.CourseSectionService, d.CourseService)
+	}
+	return d.courseSectionController
+}
+
+// GetEnrollmentController 获取选课控制器
+func (d *Dependencies) GetEnrollmentController() *controllers.EnrollmentController {
+	if d.enrollmentController == nil {
+		d.enrollmentController = controllers.NewEnrollmentController(d.EnrollmentService)
+	}
+	return d.enrollmentController
+}
+
+// GetGradingScaleController 获取评分标准控制器
+func (d *Dependencies) GetGradingScaleController() *controllers.GradingScaleController {
+	if d.gradingScaleController == nil {
+		d.gradingScaleController = controllers.NewGradingScaleController(d.GradingScaleService)
+	}
+	return d.gradingScaleController
+}
+
+// GetGradeController 获取课程成绩控制器
+func (d *Dependencies) GetGradeController() *controllers.GradeController {
+	if d.gradeController == nil {
+		d.gradeController = controllers.NewGradeController(d.GradeService)
+	}
+	return d.gradeController
+}
+
+// GetTranscriptController 获取成绩单控制器
+func (d *Dependencies) GetTranscriptController() *controllers.TranscriptController {
+	if d.transcriptController == nil {
+		d.transcriptController = controllers.NewTranscriptController(d.TranscriptService)
+	}
+	return d.transcriptController
+}
+
+// GetAttendanceController 获取课次与考勤控制器
+func (d *Dependencies) GetAttendanceController() *controllers.AttendanceController {
+	if d.attendanceController == nil {
+		d.attendanceController = controllers.NewAttendanceController(d.AttendanceService)
+	}
+	return d.attendanceController
+}
+
+// GetTeacherController 获取教师控制器
+func (d *Dependencies) GetTeacherController() *controllers.TeacherController {
+	if d.teacherController == nil {
+		d.teacherController = controllers.NewTeacherController(d.TeacherService)
+	}
+	return d.teacherController
+}
+
+// GetAdvisorController 获取导师分配控制器
+func (d *Dependencies) GetAdvisorController() *controllers.AdvisorController {
+	if d.advisorController == nil {
+		d.advisorController = controllers.NewAdvisorController(d.AdvisorService)
+	}
+	return d.advisorController
+}
+
+// GetCompanyController 获取企业控制器
+func (d *Dependencies) GetCompanyController() *controllers.CompanyController {
+	if d.companyController == nil {
+		d.companyController = controllers.NewCompanyController(d.CompanyService)
+	}
+	return d.companyController
+}
+
+// GetJobPostingController 获取招聘岗位控制器
+func (d *Dependencies) GetJobPostingController() *controllers.JobPostingController {
+	if d.jobPostingController == nil {
+		d.jobPostingController = controllers.NewJobPostingController(d.JobPostingService)
+	}
+	return d.jobPostingController
+}
+
+// GetJobApplicationController 获取岗位申请控制器
+func (d *Dependencies) GetJobApplicationController() *controllers.JobApplicationController {
+	if d.jobApplicationController == nil {
+		d.jobApplicationController = controllers.NewJobApplicationController(d.JobApplicationService)
+	}
+	return d.jobApplicationController
+}
+
+// GetEmploymentController 获取毕业去向控制器
+func (d *Dependencies) GetEmploymentController() *controllers.EmploymentController {
+	if d.employmentController == nil {
+		d.employmentController = controllers.NewEmploymentController(d.EmploymentService)
+	}
+	return d.employmentController
+}
+
+// Repositories 服务依赖的全部数据访问接口，测试时可替换其中任意实现
+type Repositories struct {
+	Tx                    dao.Transactor // 事务管理器，服务通过它开启事务，DAO 通过 WithContext(ctx) 加入事务
+	Users                 dao.UserRepository
+	Universities          dao.UniversityRepository
+	Students              dao.StudentRepository
+	AdvisorAssignments    dao.AdvisorAssignmentRepository
+	Attendance            dao.AttendanceRepository
+	AuditLogs             dao.AuditLogRepository
+	ClassSessions         dao.ClassSessionRepository
+	Colleges              dao.CollegeRepository
+	Companies             dao.CompanyRepository
+	Courses               dao.CourseRepository
+	CourseSections        dao.CourseSectionRepository
+	EmploymentRecords     dao.EmploymentRecordRepository
+	Enrollments           dao.EnrollmentRepository
+	Grades                dao.GradeRepository
+	GradingScales         dao.GradingScaleRepository
+	JobApplications       dao.JobApplicationRepository
+	JobPostings           dao.JobPostingRepository
+	Majors                dao.MajorRepository
+	Notifications         dao.NotificationRepository
+	StudentChangeRequests dao.StudentChangeRequestRepository
+	StudentStatusHistory  dao.StudentStatusHistoryRepository
+	Teachers              dao.TeacherRepository
+	Transcripts           dao.TranscriptRepository
+}
+
+// NewRepositories 创建基于数据库的数据访问实现
+func NewRepositories(db *gorm.DB) *Repositories {
+	return &Repositories{
+		Tx:                    dao.NewTxManager(db),
+		Users:                 dao.NewUserDAO(db),
+		Universities:          dao.NewUniversityDAO(db),
+		Students:              dao.NewStudentDAO(db),
+		AdvisorAssignments:    dao.NewAdvisorAssignmentDAO(db),
+		Attendance:            dao.NewAttendanceDAO(db),
+		AuditLogs:             dao.NewAuditLogDAO(db),
+		ClassSessions:         dao.NewClassSessionDAO(db),
+		Colleges:              dao.NewCollegeDAO(db),
+		Companies:             dao.NewCompanyDAO(db),
+		Courses:               dao.NewCourseDAO(db),
+		CourseSections:        dao.NewCourseSectionDAO(db),
+		EmploymentRecords:     dao.NewEmploymentRecordDAO(db),
+		Enrollments:           dao.NewEnrollmentDAO(db),
+		Grades:                dao.NewGradeDAO(db),
+		GradingScales:         dao.NewGradingScaleDAO(db),
+		JobApplications:       dao.NewJobApplicationDAO(db),
+		JobPostings:           dao.NewJobPostingDAO(db),
+		Majors:                dao.NewMajorDAO(db),
+		Notifications:         dao.NewNotificationDAO(db),
+		StudentChangeRequests: dao.NewStudentChangeRequestDAO(db),
+		StudentStatusHistory:  dao.NewStudentStatusHistoryDAO(db),
+		Teachers:              dao.NewTeacherDAO(db),
+		Transcripts:           dao.NewTranscriptDAO(db),
+	}
+}
+
+// NewDependencies 初始化依赖，数据访问均通过 repos 中的实现
+func NewDependencies(db *gorm.DB, repos *Repositories, index search.Index, appConfig *config.AppConfig) *Dependencies {
+	// 初始化DAO
+	tx := repos.Tx
+	userDAO := repos.Users
+	universityDAO := repos.Universities
+	studentDAO := repos.Students
+	collegeDAO := repos.Colleges
+	majorDAO := repos.Majors
+	auditLogDAO := repos.AuditLogs
+	studentStatusHistoryDAO := repos.StudentStatusHistory
+	studentChangeRequestDAO := repos.StudentChangeRequests
+	notificationDAO := repos.Notifications
+	courseDAO := repos.Courses
+	courseSectionDAO := repos.CourseSections
+	enrollmentDAO := repos.Enrollments
+	gradingScaleDAO := repos.GradingScales
+	gradeDAO := repos.Grades
+	transcriptDAO := repos.Transcripts
+	classSessionDAO := repos.ClassSessions
+	attendanceDAO := repos.Attendance
+	teacherDAO := repos.Teachers
+	advisorAssignmentDAO := repos.AdvisorAssignments
+	companyDAO := repos.Companies
+	jobPostingDAO := repos.JobPostings
+	jobApplicationDAO := repos.JobApplications
+	employmentRecordDAO := repos.EmploymentRecords
+
+	// 初始化服务
+	userService := service.NewUserService(userDAO)
+	universityService := service.NewUniversityService(tx, universityDAO)
+	universityMergeService := service.NewUniversityMergeService(tx, universityDAO, studentDAO, collegeDAO, majorDAO, courseDAO, teacherDAO, auditLogDAO)
+	studentSearchService := service.NewStudentSearchService(index, studentDAO)
+	studentService := service.NewStudentService(studentDAO, universityDAO, majorDAO, studentSearchService)
+	collegeService := service.NewCollegeService(collegeDAO, majorDAO)
+	majorService := service.NewMajorService(majorDAO, collegeDAO, studentDAO)
+	studentDuplicateService := service.NewStudentDuplicateService(tx, studentDAO, majorDAO, auditLogDAO, studentSearchService)
+	studentStatusService := service.NewStudentStatusService(tx, studentDAO, studentStatusHistoryDAO, userDAO)
+	notificationService := service.NewNotificationService(notificationDAO)
+	courseService := service.NewCourseService(courseDAO, courseSectionDAO)
+	courseSectionService := service.NewCourseSectionService(courseSectionDAO, enrollmentDAO, classSessionDAO)
+	enrollmentService := service.NewEnrollmentService(tx, enrollmentDAO, courseSectionDAO, studentDAO)
+	gradingScaleService := service.NewGradingScaleService(tx, gradingScaleDAO)
+	gradeService := service.NewGradeService(tx, gradeDAO, gradingScaleDAO, courseDAO, studentDAO, enrollmentDAO)
+	transcriptService := service.NewTranscriptService(studentService, gradeDAO, transcriptDAO, appConfig.Transcript.FontPath)
+	attendanceService := service.NewAttendanceService(tx, classSessionDAO, attendanceDAO, courseSectionDAO, enrollmentDAO, studentDAO)
+	teacherService := service.NewTeacherService(teacherDAO, userDAO, universityDAO, collegeDAO, advisorAssignmentDAO)
+	advisorService := service.NewAdvisorService(tx, advisorAssignmentDAO, teacherDAO, studentDAO)
+	companyService := service.NewCompanyService(companyDAO, jobPostingDAO, employmentRecordDAO)
+	jobPostingService := service.NewJobPostingService(jobPostingDAO, companyDAO, jobApplicationDAO)
+	jobApplicationService := service.NewJobApplicationService(tx, jobApplicationDAO, jobPostingDAO, studentDAO, employmentRecordDAO)
+	employmentService := service.NewEmploymentService(employmentRecordDAO, studentDAO, companyDAO, universityDAO, majorDAO)
+	studentChangeRequestService := service.NewStudentChangeRequestService(tx, studentChangeRequestDAO, studentDAO, majorDAO, userDAO, auditLogDAO, studentService, studentStatusService, notificationService)
+
+	return &Dependencies{
+		Config:                      appConfig,
+		DB:                          db,
+		UserDAO:                     userDAO,
+		UniversityDAO:               universityDAO,
+		StudentDAO:                  studentDAO,
+		CollegeDAO:                  collegeDAO,
+		MajorDAO:                    majorDAO,
+		StudentStatusHistoryDAO:     studentStatusHistoryDAO,
+		AuditLogDAO:                 auditLogDAO,
+		StudentChangeRequestDAO:     studentChangeRequestDAO,
+		NotificationDAO:             notificationDAO,
+		CourseDAO:                   courseDAO,
+		CourseSectionDAO:            courseSectionDAO,
+		EnrollmentDAO:               enrollmentDAO,
+		GradingScaleDAO:             gradingScaleDAO,
+		GradeDAO:                    gradeDAO,
+		TranscriptDAO:               transcriptDAO,
+		ClassSessionDAO:             classSessionDAO,
+		AttendanceDAO:               attendanceDAO,
+		TeacherDAO:                  teacherDAO,
+		AdvisorAssignmentDAO:        advisorAssignmentDAO,
+		CompanyDAO:                  companyDAO,
+		JobPostingDAO:               jobPostingDAO,
+		JobApplicationDAO:           jobApplicationDAO,
+		EmploymentRecordDAO:         employmentRecordDAO,
+		UserService:                 userService,
+		UniversityService:           universityService,
+		UniversityMergeService:      universityMergeService,
+		StudentService:              studentService,
+		StudentSearchService:        studentSearchService,
+		CollegeService:              collegeService,
+		MajorService:                majorService,
+		StudentDuplicateService:     studentDuplicateService,
+		StudentStatusService:        studentStatusService,
+		NotificationService:         notificationService,
+		StudentChangeRequestService: studentChangeRequestService,
+		CourseService:               courseService,
+		CourseSectionService:        courseSectionService,
+		EnrollmentService:           enrollmentService,
+		GradingScaleService:         gradingScaleService,
+		GradeService:                gradeService,
+		TranscriptService:           transcriptService,
+		AttendanceService:           attendanceService,
+		TeacherService:              teacherService,
+		AdvisorService:              advisorService,
+		CompanyService:              companyService,
+		JobPostingService:           jobPostingService,
+		JobApplicationService:       jobApplicationService,
+		EmploymentService:           employmentService,
+	}
+}
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"mvc-demo/config"
+	"mvc-demo/logging"
+	"mvc-demo/metrics"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// writeTimeoutMargin 按请求超时计算写超时时留出的余量，保证超时响应能写回客户端
+const writeTimeoutMargin = 10 * time.Second
+
+// Server HTTP 服务，支持优雅停止和 TLS 证书热加载
+type Server struct {
+	httpServer      *http.Server
+	shutdownTimeout time.Duration
+	certs           *certReloader // 未启用 TLS 时为 nil
+}
+
+// NewServer 按配置创建 HTTP 服务
+func NewServer(handler http.Handler, appConfig *config.AppConfig) (*Server, error) {
+	cfg := appConfig.Server
+	writeTimeout := cfg.WriteTimeout
+	if writeTimeout == 0 {
+		if max := appConfig.Request.MaxTimeout(); max > 0 {
+			writeTimeout = max + writeTimeoutMargin
+		}
+	}
+
+	s := &Server{
+		httpServer: &http.Server{
+			Addr:              cfg.Port,
+			Handler:           handler,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			ReadTimeout:       cfg.ReadTimeout,
+			WriteTimeout:      writeTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+			MaxHeaderBytes:    cfg.MaxHeaderBytes,
+			// 连接层错误（如 TLS 握手失败）写入 server 组件日志
+			ErrorLog: slog.NewLogLogger(logging.Component(logging.ComponentServer).Handler(), slog.LevelWarn),
+		},
+		shutdownTimeout: cfg.ShutdownTimeout,
+	}
+
+	if cfg.TLSEnabled() {
+		certs, err := newCertReloader(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		s.certs = certs
+		s.httpServer.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: certs.GetCertificate,
+		}
+	}
+	return s, nil
+}
+
+// metricsWriteTimeout 监控指标服务写完响应的超时时间
+const metricsWriteTimeout = 30 * time.Second
+
+// NewMetricsServer 创建独立端口（METRICS_PORT）的监控指标服务，只提供指标路径，不启用 TLS，
+// 可只在内网或监控网络开放该端口
+func NewMetricsServer(appConfig *config.AppConfig) *Server {
+	cfg := appConfig.Metrics
+	mux := http.NewServeMux()
+	mux.Handle(cfg.Path, metrics.Handler(metrics.Default, cfg.Token))
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              cfg.Port,
+			Handler:           mux,
+			ReadHeaderTimeout: appConfig.Server.ReadHeaderTimeout,
+			WriteTimeout:      metricsWriteTimeout,
+			IdleTimeout:       appConfig.Server.IdleTimeout,
+			ErrorLog:          slog.NewLogLogger(logging.Component(logging.ComponentServer).Handler(), slog.LevelWarn),
+		},
+		shutdownTimeout: appConfig.Server.ShutdownTimeout,
+	}
+}
+
+// Addr 监听地址
+func (s *Server) Addr() string {
+	return s.httpServer.Addr
+}
+
+// TLSEnabled 是否启用 HTTPS
+func (s *Server) TLSEnabled() bool {
+	return s.certs != nil
+}
+
+// Run 启动服务并阻塞，直到 ctx 被取消（收到停止信号）或服务启动失败
+// ctx 取消后不再接受新连接，等待进行中的请求完成，最多等待 shutdownTimeout，超时后强制关闭剩余连接
+func (s *Server) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		var err error
+		if s.certs != nil {
+			err = s.httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = s.httpServer.ListenAndServe()
+		}
+		if !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	logging.Component(logging.ComponentServer).Info("收到停止信号，等待进行中的请求完成", "addr", s.httpServer.Addr, "timeout", s.shutdownTimeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
+		s.httpServer.Close()
+		return err
+	}
+	return <-errCh
+}
+
+// ReloadCertificate 重新加载 TLS 证书，加载失败时继续使用原证书
+func (s *Server) ReloadCertificate() error {
+	if s.certs == nil {
+		return nil
+	}
+	return s.certs.reload()
+}
+
+// certReloader 保存当前使用的证书，证书更新（如 Let's Encrypt 续期）后可不重启服务重新加载
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+// newCertReloader 加载证书，证书或私钥无效时返回错误
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// reload 从文件重新加载证书
+func (r *certReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.cert = &cert
+	r.mu.Unlock()
+	return nil
+}
+
+// GetCertificate 返回当前证书，用于 tls.Config.GetCertificate
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
@@ -0,0 +1,56 @@
+package apptest_test
+
+import (
+	"fmt"
+	"mvc-demo/apptest"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// 当天分配的导师当天生效，当天结束的分配当天起不再有效
+func TestAdvisees(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+	advisorUser, token, err := h.CreateUser("teacher@example.com", "secret123", apptest.RoleUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	advisor := &client{t: t, h: h, token: token}
+	universityID := admin.create("/api/admin/universities", map[string]interface{}{"name": "浙江大学"})
+	teacherID := admin.create("/api/admin/teachers", map[string]interface{}{
+		"user_id": advisorUser.ID, "name": "李老师", "university_id": universityID,
+	})
+	newStudent := func(email string) int64 {
+		return admin.create("/api/admin/students", map[string]interface{}{
+			"name": "周八", "email": email, "password": "secret123", "university_id": universityID,
+		})
+	}
+	currentID := newStudent("zhou1@example.com")
+	endedID := newStudent("zhou2@example.com")
+
+	// 今天分配的导师今天有效；昨天分配、今天结束的导师今天已无效
+	admin.ok(http.MethodPost, fmt.Sprintf("/api/admin/students/%d/advisor", currentID), map[string]interface{}{"teacher_id": teacherID}, nil)
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	admin.ok(http.MethodPost, fmt.Sprintf("/api/admin/students/%d/advisor", endedID), map[string]interface{}{"teacher_id": teacherID, "start_date": yesterday}, nil)
+	admin.ok(http.MethodPost, fmt.Sprintf("/api/admin/students/%d/advisor/end", endedID), map[string]interface{}{}, nil)
+
+	var students struct {
+		List []struct {
+			ID int64 `json:"id"`
+		} `json:"list"`
+		Total int64 `json:"total"`
+	}
+	for _, list := range []struct {
+		client *client
+		path   string
+	}{
+		{admin, fmt.Sprintf("/api/students?advisor_id=%d", teacherID)},
+		{advisor, "/api/advisees"},
+	} {
+		list.client.ok(http.MethodGet, list.path, nil, &students)
+		if students.Total != 1 || len(students.List) != 1 || students.List[0].ID != currentID {
+			t.Fatalf("GET %s 返回 %+v，期望只有学生 %d", list.path, students, currentID)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package apptest_test
+
+import (
+	"fmt"
+	"mvc-demo/apptest"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// client 以固定令牌发送请求，请求本身失败时终止测试
+type client struct {
+	t     *testing.T
+	h     *apptest.Harness
+	token string
+}
+
+// newHarness 创建测试用的应用实例，测试结束时自动清理
+func newHarness(t *testing.T, opts ...apptest.Option) *apptest.Harness {
+	t.Helper()
+	h, err := apptest.New(opts...)
+	if err != nil {
+		t.Fatalf("创建测试实例失败: %v", err)
+	}
+	t.Cleanup(h.Close)
+	return h
+}
+
+// newClient 创建指定角色的用户，并返回以其身份发送请求的客户端
+func newClient(t *testing.T, h *apptest.Harness, email string, role int32) *client {
+	t.Helper()
+	_, token, err := h.CreateUser(email, "secret123", role)
+	if err != nil {
+		t.Fatalf("创建用户失败: %v", err)
+	}
+	return &client{t: t, h: h, token: token}
+}
+
+// expect 发送请求并断言响应码
+func (c *client) expect(code int, method, path string, body interface{}) *apptest.Response {
+	c.t.Helper()
+	resp, err := c.h.Do(method, path, body, c.token)
+	if err != nil {
+		c.t.Fatalf("%s %s 请求失败: %v", method, path, err)
+	}
+	if resp.Status != http.StatusOK || resp.Code != code {
+		c.t.Fatalf("%s %s 期望响应码 %d，实际 HTTP %d，响应码 %d: %s", method, path, code, resp.Status, resp.Code, resp.Msg)
+	}
+	return resp
+}
+
+// ok 发送请求并断言成功，out 不为 nil 时解析响应数据
+func (c *client) ok(method, path string, body, out interface{}) {
+	c.t.Helper()
+	resp := c.expect(0, method, path, body)
+	if out != nil {
+		if err := resp.Decode(out); err != nil {
+			c.t.Fatalf("%s %s 解析响应失败: %v", method, path, err)
+		}
+	}
+}
+
+// create 发送创建请求并返回新记录的ID
+func (c *client) create(path string, body interface{}) int64 {
+	c.t.Helper()
+	var created struct {
+		ID int64 `json:"id"`
+	}
+	c.ok(http.MethodPost, path, body, &created)
+	if created.ID == 0 {
+		c.t.Fatalf("POST %s 未返回ID", path)
+	}
+	return created.ID
+}
+
+func TestLogin(t *testing.T) {
+	h := newHarness(t)
+	newClient(t, h, "user@example.com", apptest.RoleUser)
+	anonymous := &client{t: t, h: h}
+
+	anonymous.ok(http.MethodPost, "/api/auth/login", map[string]string{"email": "user@example.com", "password": "secret123"}, nil)
+	anonymous.expect(-2, http.MethodPost, "/api/auth/login", map[string]string{"email": "user@example.com", "password": "wrong-password"})
+}
+
+func TestAuthorization(t *testing.T) {
+	h := newHarness(t)
+	user := newClient(t, h, "user@example.com", apptest.RoleUser)
+	anonymous := &client{t: t, h: h}
+
+	anonymous.expect(-2, http.MethodGet, "/api/universities", nil)
+	user.ok(http.MethodGet, "/api/universities", nil, nil)
+	user.expect(-3, http.MethodPost, "/api/admin/universities", map[string]interface{}{"name": "北京大学"})
+}
+
+func TestUniversityCRUD(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+
+	id := admin.create("/api/admin/universities", map[string]interface{}{"name": "北京大学"})
+	path := fmt.Sprintf("/api/universities/%d", id)
+
+	var university struct {
+		Name string `json:"name"`
+	}
+	admin.ok(http.MethodGet, path, nil, &university)
+	if university.Name != "北京大学" {
+		t.Fatalf("大学名称为 %q", university.Name)
+	}
+
+	// 名称重复
+	if resp := admin.expect(-10, http.MethodPost, "/api/admin/universities", map[string]interface{}{"name": "北京大学"}); resp.Msg == "" {
+		t.Fatal("重复名称的错误信息为空")
+	}
+
+	admin.ok(http.MethodPut, fmt.Sprintf("/api/admin/universities/%d", id), map[string]interface{}{"name": "北京大学（本部）"}, nil)
+	admin.ok(http.MethodGet, path, nil, &university)
+	if university.Name != "北京大学（本部）" {
+		t.Fatalf("更新后大学名称为 %q", university.Name)
+	}
+
+	admin.ok(http.MethodDelete, fmt.Sprintf("/api/admin/universities/%d", id), nil, nil)
+	admin.expect(-4, http.MethodGet, path, nil)
+}
+
+func TestStudentListAndSearch(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+
+	universityID := admin.create("/api/admin/universities", map[string]interface{}{"name": "清华大学"})
+	names := []string{"张三", "李四", "王五"}
+	for i, name := range names {
+		admin.create("/api/admin/students", map[string]interface{}{
+			"name":          name,
+			"email":         fmt.Sprintf("student%d@example.com", i),
+			"password":      "secret123",
+			"university_id": universityID,
+		})
+	}
+
+	type studentList struct {
+		List []struct {
+			Name         string `json:"name"`
+			UniversityID *int64 `json:"university_id"`
+		} `json:"list"`
+		Total      *int64 `json:"total"`
+		NextCursor string `json:"next_cursor"`
+	}
+
+	var list studentList
+	admin.ok(http.MethodGet, fmt.Sprintf("/api/students?university_id=%d", universityID), nil, &list)
+	if len(list.List) != len(names) || list.Total == nil || *list.Total != int64(len(names)) {
+		t.Fatalf("学生列表返回 %d 条，total=%v", len(list.List), list.Total)
+	}
+
+	// 游标分页逐页读取全部学生
+	seen := 0
+	cursor := ""
+	for {
+		path := "/api/students?pagination=cursor&page_size=2"
+		if cursor != "" {
+			path += "&cursor=" + url.QueryEscape(cursor)
+		}
+		var page studentList
+		admin.ok(http.MethodGet, path, nil, &page)
+		seen += len(page.List)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if seen != len(names) {
+		t.Fatalf("游标分页共返回 %d 条，期望 %d", seen, len(names))
+	}
+
+	var result struct {
+		List []struct {
+			Name string `json:"name"`
+		} `json:"list"`
+		Total uint64 `json:"total"`
+	}
+	admin.ok(http.MethodGet, "/api/students/search?q="+url.QueryEscape("李四"), nil, &result)
+	if result.Total == 0 || result.List[0].Name != "李四" {
+		t.Fatalf("搜索结果不正确: %+v", result)
+	}
+}
@@ -0,0 +1,55 @@
+package apptest_test
+
+import (
+	"fmt"
+	"mvc-demo/apptest"
+	"net/http"
+	"testing"
+)
+
+// 岗位申请只能按 已申请 → 面试 → offer → 已接受/未通过 的流程变更，接受全职 offer 后登记就业去向
+func TestJobApplicationPipeline(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+
+	universityID := admin.create("/api/admin/universities", map[string]interface{}{"name": "同济大学"})
+	studentID := admin.create("/api/admin/students", map[string]interface{}{
+		"name": "冯三", "email": "feng@example.com", "password": "secret123", "university_id": universityID,
+	})
+	companyID := admin.create("/api/admin/companies", map[string]interface{}{"name": "示例科技有限公司"})
+	postingID := admin.create("/api/admin/postings", map[string]interface{}{"company_id": companyID, "title": "后端开发工程师", "type": "job"})
+
+	applicationID := admin.create("/api/admin/applications", map[string]interface{}{"posting_id": postingID, "student_id": studentID})
+	admin.expect(-10, http.MethodPost, "/api/admin/applications", map[string]interface{}{"posting_id": postingID, "student_id": studentID})
+
+	changeStatus := func(code int, status string) {
+		t.Helper()
+		admin.expect(code, http.MethodPost, fmt.Sprintf("/api/admin/applications/%d/status", applicationID), map[string]string{"status": status})
+	}
+	changeStatus(-10, "hired")    // 不存在的状态
+	changeStatus(-10, "accepted") // 未发 offer 不能接受
+	changeStatus(-10, "applied")  // 不能回到已申请
+	changeStatus(0, "interview")
+	changeStatus(-10, "interview")
+	changeStatus(0, "offer")
+	changeStatus(-10, "interview") // 不能回退
+	changeStatus(0, "accepted")
+	changeStatus(-10, "rejected") // 已接受为终态
+	changeStatus(-10, "offer")
+
+	admin.expect(-10, http.MethodDelete, fmt.Sprintf("/api/admin/applications/%d", applicationID), nil)
+
+	var records struct {
+		List []struct {
+			Outcome       string `json:"outcome"`
+			ApplicationID *int64 `json:"application_id"`
+		} `json:"list"`
+	}
+	admin.ok(http.MethodGet, fmt.Sprintf("/api/employment-records?student_id=%d", studentID), nil, &records)
+	if len(records.List) != 1 || records.List[0].Outcome != "employed" || records.List[0].ApplicationID == nil || *records.List[0].ApplicationID != applicationID {
+		t.Fatalf("接受 offer 后的毕业去向记录不正确: %+v", records.List)
+	}
+
+	// 每个学生只有一条毕业去向记录，已登记后不能再新建
+	admin.expect(-10, http.MethodPost, "/api/admin/employment-records", map[string]interface{}{"student_id": studentID, "outcome": "further_study"})
+}
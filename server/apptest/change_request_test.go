@@ -0,0 +1,60 @@
+package apptest_test
+
+import (
+	"fmt"
+	"mvc-demo/apptest"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestChangeRequestApprove(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+	user := newClient(t, h, "user@example.com", apptest.RoleUser)
+
+	studentID := admin.create("/api/admin/students", map[string]interface{}{
+		"name": "周八", "email": "zhou@example.com", "password": "secret123",
+	})
+	requestID := user.create("/api/change-requests", map[string]interface{}{
+		"student_id": studentID,
+		"changes":    map[string]interface{}{"name": "周捌"},
+	})
+
+	var request struct {
+		Status string `json:"status"`
+	}
+	admin.ok(http.MethodPost, fmt.Sprintf("/api/admin/change-requests/%d/approve", requestID), map[string]interface{}{}, &request)
+	if request.Status != "approved" {
+		t.Fatalf("审批后申请状态为 %q", request.Status)
+	}
+
+	var student struct {
+		Name string `json:"name"`
+	}
+	admin.ok(http.MethodGet, fmt.Sprintf("/api/students/%d", studentID), nil, &student)
+	if student.Name != "周捌" {
+		t.Fatalf("审批后学生姓名为 %q", student.Name)
+	}
+
+	// 审计日志与变更一起提交
+	var logs int64
+	if err := h.DB.Table("audit_logs").Where("action = ? AND entity_id = ?", "student.change_request.approve", studentID).Count(&logs).Error; err != nil {
+		t.Fatal(err)
+	}
+	if logs != 1 {
+		t.Fatalf("审批的审计日志有 %d 条，期望 1", logs)
+	}
+
+	// 事务提交后更新搜索索引
+	var result struct {
+		Total uint64 `json:"total"`
+	}
+	admin.ok(http.MethodGet, "/api/students/search?q="+url.QueryEscape("周捌"), nil, &result)
+	if result.Total != 1 {
+		t.Fatalf("按新姓名搜索到 %d 个学生，期望 1", result.Total)
+	}
+
+	// 已审批的申请不能再次审批
+	admin.expect(-10, http.MethodPost, fmt.Sprintf("/api/admin/change-requests/%d/approve", requestID), map[string]interface{}{})
+}
@@ -0,0 +1,57 @@
+package apptest_test
+
+import (
+	"fmt"
+	"mvc-demo/apptest"
+	"net/http"
+	"testing"
+)
+
+// 同一教学班同一天同一开始时间只能有一个课次，日期范围包含结束当天
+func TestClassSessions(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+
+	universityID := admin.create("/api/admin/universities", map[string]interface{}{"name": "武汉大学"})
+	courseID := admin.create("/api/admin/courses", map[string]interface{}{
+		"university_id": universityID, "code": "PH101", "title": "大学物理", "credits": 3,
+	})
+	sectionID := admin.create("/api/admin/sections", map[string]interface{}{
+		"course_id": courseID, "term": "2024-2025-2", "section_no": "01", "capacity": 30, "schedule": "1 08:00-09:40",
+	})
+
+	// 2025-03-03 和 2025-03-10 都是周一，结束日期当天也生成课次
+	generate := fmt.Sprintf("/api/sections/%d/sessions/generate", sectionID)
+	var generated []struct {
+		SessionDate string `json:"session_date"`
+	}
+	admin.ok(http.MethodPost, generate, map[string]string{"start_date": "2025-03-03", "end_date": "2025-03-10"}, &generated)
+	if len(generated) != 2 {
+		t.Fatalf("生成了 %d 个课次，期望 2", len(generated))
+	}
+	// 再次生成时已有的课次（包括结束当天）全部跳过
+	generated = nil
+	admin.ok(http.MethodPost, generate, map[string]string{"start_date": "2025-03-03", "end_date": "2025-03-10"}, &generated)
+	if len(generated) != 0 {
+		t.Fatalf("重复生成了 %d 个课次", len(generated))
+	}
+
+	var sessions struct {
+		List []struct {
+			ID int64 `json:"id"`
+		} `json:"list"`
+		Total int64 `json:"total"`
+	}
+	admin.ok(http.MethodGet, fmt.Sprintf("/api/sessions?section_id=%d&date_from=2025-03-10&date_to=2025-03-10", sectionID), nil, &sessions)
+	if sessions.Total != 1 {
+		t.Fatalf("按日期筛选到 %d 个课次，期望 1", sessions.Total)
+	}
+
+	// 与已有课次同一时段的课次按业务错误拒绝
+	slot := map[string]interface{}{"section_id": sectionID, "session_date": "2025-03-10", "start_time": "08:00", "end_time": "09:40"}
+	admin.expect(-10, http.MethodPost, "/api/sessions", slot)
+	slot["session_date"] = "2025-03-11"
+	otherID := admin.create("/api/sessions", slot)
+	slot["session_date"] = "2025-03-10"
+	admin.expect(-10, http.MethodPut, fmt.Sprintf("/api/sessions/%d", otherID), slot)
+}
@@ -0,0 +1,46 @@
+package apptest_test
+
+import (
+	"fmt"
+	"mvc-demo/apptest"
+	"net/http"
+	"testing"
+)
+
+// 教学班满员后拒绝选课，退课后空出的名额可以再选
+func TestEnrollmentCapacity(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+
+	universityID := admin.create("/api/admin/universities", map[string]interface{}{"name": "四川大学"})
+	courseID := admin.create("/api/admin/courses", map[string]interface{}{
+		"university_id": universityID, "code": "CH101", "title": "大学化学", "credits": 2,
+	})
+	sectionID := admin.create("/api/admin/sections", map[string]interface{}{
+		"course_id": courseID, "term": "2024-2025-1", "section_no": "01", "capacity": 1,
+	})
+	newStudent := func(email string) int64 {
+		return admin.create("/api/admin/students", map[string]interface{}{
+			"name": "郑十", "email": email, "password": "secret123", "university_id": universityID,
+		})
+	}
+	firstID := newStudent("zheng1@example.com")
+	secondID := newStudent("zheng2@example.com")
+
+	firstEnrollmentID := admin.create("/api/admin/enrollments", map[string]interface{}{"student_id": firstID, "section_id": sectionID})
+	resp := admin.expect(-10, http.MethodPost, "/api/admin/enrollments", map[string]interface{}{"student_id": secondID, "section_id": sectionID})
+	if resp.Msg != "教学班已满" {
+		t.Fatalf("满员时的错误信息为 %q", resp.Msg)
+	}
+
+	admin.ok(http.MethodPost, fmt.Sprintf("/api/admin/enrollments/%d/drop", firstEnrollmentID), nil, nil)
+	admin.create("/api/admin/enrollments", map[string]interface{}{"student_id": secondID, "section_id": sectionID})
+
+	var section struct {
+		EnrolledCount int64 `json:"enrolled_count"`
+	}
+	admin.ok(http.MethodGet, fmt.Sprintf("/api/sections/%d", sectionID), nil, &section)
+	if section.EnrolledCount != 1 {
+		t.Fatalf("教学班已选人数为 %d，期望 1", section.EnrolledCount)
+	}
+}
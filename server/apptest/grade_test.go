@@ -0,0 +1,71 @@
+package apptest_test
+
+import (
+	"mvc-demo/apptest"
+	"net/http"
+	"testing"
+)
+
+// 百分制按分数下限换算等级和绩点，等级制、五级制只接受评分标准中的等级
+func TestGradeScaleConversion(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+
+	universityID := admin.create("/api/admin/universities", map[string]interface{}{"name": "山东大学"})
+	studentID := admin.create("/api/admin/students", map[string]interface{}{
+		"name": "钱一", "email": "qian@example.com", "password": "secret123", "university_id": universityID,
+	})
+	newCourse := func(code string, scaleID *int64) int64 {
+		return admin.create("/api/admin/courses", map[string]interface{}{
+			"university_id": universityID, "code": code, "title": code, "credits": 2, "grading_scale_id": scaleID,
+		})
+	}
+	// 迁移中的默认评分标准：1 百分制（默认），3 五级制
+	fivePoint := int64(3)
+	percentageCourse := newCourse("PC101", nil)
+	fivePointCourse := newCourse("FP101", &fivePoint)
+
+	type grade struct {
+		Grade      string  `json:"grade"`
+		GradePoint float64 `json:"grade_point"`
+		Passed     bool    `json:"passed"`
+	}
+	for i, tc := range []struct {
+		score float64
+		want  grade
+	}{
+		{100, grade{"A", 4.0, true}},
+		{90, grade{"A", 4.0, true}},
+		{89.5, grade{"A-", 3.7, true}},
+		{75, grade{"B-", 2.7, true}},
+		{60, grade{"D", 1.0, true}},
+		{59.9, grade{"F", 0, false}},
+	} {
+		var got grade
+		admin.ok(http.MethodPost, "/api/admin/grades", map[string]interface{}{
+			"student_id": studentID, "course_id": percentageCourse, "term": "term-" + string(rune('a'+i)), "score": tc.score,
+		}, &got)
+		if got != tc.want {
+			t.Errorf("分数 %v 换算为 %+v，期望 %+v", tc.score, got, tc.want)
+		}
+	}
+
+	var got grade
+	admin.ok(http.MethodPost, "/api/admin/grades", map[string]interface{}{
+		"student_id": studentID, "course_id": fivePointCourse, "term": "2024-2025-1", "grade": "良好",
+	}, &got)
+	if got != (grade{"良好", 3.0, true}) {
+		t.Errorf("五级制等级“良好”换算为 %+v", got)
+	}
+
+	// 百分制需要分数，分数超出范围、等级不在评分标准中都按业务错误拒绝
+	admin.expect(-10, http.MethodPost, "/api/admin/grades", map[string]interface{}{
+		"student_id": studentID, "course_id": percentageCourse, "term": "2025-2026-1", "grade": "A",
+	})
+	admin.expect(-10, http.MethodPost, "/api/admin/grades", map[string]interface{}{
+		"student_id": studentID, "course_id": percentageCourse, "term": "2025-2026-1", "score": 101,
+	})
+	admin.expect(-10, http.MethodPost, "/api/admin/grades", map[string]interface{}{
+		"student_id": studentID, "course_id": fivePointCourse, "term": "2025-2026-1", "grade": "B",
+	})
+}
@@ -0,0 +1,215 @@
+// Package apptest 提供 HTTP 级别测试使用的应用实例：
+// 全部数据保存在临时目录中的 SQLite 数据库（表结构由正式迁移创建），
+// 路由与正式服务完全相同（routes.SetupRouter），不依赖 MySQL。
+//
+// 使用 WithMemoryRepositories 时用户、大学、学生改用 dao/memory 中的内存实现，
+// 其他模块中关联这三张表的 SQL 查询（如按导师筛选学生、出勤统计）访问的是 SQLite 中的空表，看不到内存中的数据。
+package apptest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mvc-demo/app"
+	"mvc-demo/config"
+	"mvc-demo/dao/memory"
+	"mvc-demo/dao/model"
+	"mvc-demo/db"
+	"mvc-demo/routes"
+	"mvc-demo/search"
+	"mvc-demo/utils"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 用户角色
+const (
+	RoleAdmin int32 = 1 // 管理员
+	RoleUser  int32 = 2 // 普通用户
+)
+
+// Harness 测试用的应用实例
+type Harness struct {
+	Router *gin.Engine
+	Deps   *app.Dependencies
+	DB     *gorm.DB      // 临时 SQLite 数据库
+	Store  *memory.Store // 用户、大学、学生的内存数据，未使用 WithMemoryRepositories 时为 nil
+
+	dir   string
+	index search.Index
+}
+
+// Option 测试实例的选项
+type Option func(*options)
+
+// options 创建测试实例时的选项
+type options struct {
+	configure []func(cfg *config.AppConfig)
+	memory    bool
+}
+
+// WithConfig 在当前配置的副本上修改测试实例的配置，如启用监控指标或链路追踪
+func WithConfig(fn func(cfg *config.AppConfig)) Option {
+	return func(o *options) {
+		o.configure = append(o.configure, fn)
+	}
+}
+
+// WithMemoryRepositories 用户、大学、学生使用 dao/memory 中的内存实现，其余数据仍保存在 SQLite 数据库
+func WithMemoryRepositories() Option {
+	return func(o *options) {
+		o.memory = true
+	}
+}
+
+// New 创建测试用的应用实例，使用完毕后调用 Close 清理临时文件
+func New(opts ...Option) (*Harness, error) {
+	gin.SetMode(gin.TestMode)
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	cfg := *config.GetConfig()
+	for _, fn := range o.configure {
+		fn(&cfg)
+	}
+
+	dir, err := os.MkdirTemp("", "apptest-")
+	if err != nil {
+		return nil, err
+	}
+	h := &Harness{dir: dir}
+
+	h.DB, err = gorm.Open(sqlite.Open(filepath.Join(dir, "test.db")+"?_pragma=busy_timeout(5000)"), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+	migrator, err := db.NewMigrator(h.DB)
+	if err == nil {
+		_, err = migrator.Up()
+	}
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+
+	h.index, err = search.OpenBleveIndex(filepath.Join(dir, "index"))
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+
+	repos := app.NewRepositories(h.DB)
+	if o.memory {
+		h.Store = memory.NewStore()
+		repos.Tx = memory.NewTxManager(h.Store, repos.Tx)
+		repos.Users = memory.NewUserRepository(h.Store)
+		repos.Universities = memory.NewUniversityRepository(h.Store)
+		repos.Students = memory.NewStudentRepository(h.Store)
+	}
+	h.Deps = app.NewDependencies(h.DB, repos, h.index, &cfg)
+	h.Router = routes.SetupRouter(h.Deps)
+	return h, nil
+}
+
+// Close 关闭索引和数据库并删除临时文件
+func (h *Harness) Close() {
+	if h.index != nil {
+		h.index.Close()
+	}
+	if h.DB != nil {
+		if sqlDB, err := h.DB.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
+	os.RemoveAll(h.dir)
+}
+
+// CreateUser 创建启用状态的用户，并返回其登录令牌
+func (h *Harness) CreateUser(email, password string, role int32) (*model.User, string, error) {
+	user := &model.User{
+		Email:    email,
+		Username: email,
+		Password: password,
+		Role:     role,
+		Status:   1,
+	}
+	if err := h.Deps.UserDAO.Create(context.Background(), user); err != nil {
+		return nil, "", err
+	}
+	token, err := utils.GenerateToken(user)
+	if err != nil {
+		return nil, "", err
+	}
+	return user, token, nil
+}
+
+// Response 接口响应，Data 保留原始 JSON，可通过 Decode 解析
+type Response struct {
+	Status int             `json:"-"`
+	Code   int             `json:"code"`
+	Msg    string          `json:"msg"`
+	Data   json.RawMessage `json:"data"`
+	Body   []byte          `json:"-"`
+}
+
+// Decode 把响应中的 data 解析到 out
+func (r *Response) Decode(out interface{}) error {
+	return json.Unmarshal(r.Data, out)
+}
+
+// Do 发送请求，body 不为 nil 时编码为 JSON，token 不为空时以 Bearer 令牌认证
+// 响应不是统一响应结构（如 PDF 导出）时只填充 Status 和 Body
+func (h *Harness) Do(method, path string, body interface{}, token string) (*Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	h.Router.ServeHTTP(rec, req)
+
+	resp := &Response{Status: rec.Code, Body: rec.Body.Bytes()}
+	if strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json") {
+		if err := json.Unmarshal(resp.Body, resp); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// Get 发送 GET 请求
+func (h *Harness) Get(path, token string) (*Response, error) {
+	return h.Do(http.MethodGet, path, nil, token)
+}
+
+// Post 发送 POST 请求
+func (h *Harness) Post(path string, body interface{}, token string) (*Response, error) {
+	return h.Do(http.MethodPost, path, body, token)
+}
@@ -0,0 +1,66 @@
+package apptest_test
+
+import (
+	"fmt"
+	"mvc-demo/apptest"
+	"mvc-demo/dao/model"
+	"net/http"
+	"testing"
+)
+
+// 用户、大学、学生使用内存实现时，接口的唯一性校验和软删除与数据库实现一致
+func TestMemoryRepositories(t *testing.T) {
+	h := newHarness(t, apptest.WithMemoryRepositories())
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+	anonymous := &client{t: t, h: h}
+
+	anonymous.ok(http.MethodPost, "/api/auth/login", map[string]string{"email": "admin@example.com", "password": "secret123"}, nil)
+
+	universityID := admin.create("/api/admin/universities", map[string]interface{}{"name": "北京大学", "code": "10001"})
+	admin.expect(-10, http.MethodPost, "/api/admin/universities", map[string]interface{}{"name": "北京大学"})
+	admin.expect(-10, http.MethodPost, "/api/admin/universities", map[string]interface{}{"name": "北大", "code": "10001"})
+
+	studentID := admin.create("/api/admin/students", map[string]interface{}{
+		"name":          "张三",
+		"email":         "student@example.com",
+		"password":      "secret123",
+		"university_id": universityID,
+	})
+	var student struct {
+		University *struct {
+			Name string `json:"name"`
+		} `json:"university"`
+	}
+	admin.ok(http.MethodGet, fmt.Sprintf("/api/students/%d", studentID), nil, &student)
+	if student.University == nil || student.University.Name != "北京大学" {
+		t.Fatalf("学生所属大学为 %+v", student.University)
+	}
+
+	admin.ok(http.MethodDelete, fmt.Sprintf("/api/admin/students/%d", studentID), nil, nil)
+	admin.expect(-4, http.MethodGet, fmt.Sprintf("/api/students/%d", studentID), nil)
+
+	admin.ok(http.MethodDelete, fmt.Sprintf("/api/admin/universities/%d", universityID), nil, nil)
+	admin.expect(-4, http.MethodGet, fmt.Sprintf("/api/universities/%d", universityID), nil)
+	// 已删除大学的名称仍被占用
+	admin.expect(-10, http.MethodPost, "/api/admin/universities", map[string]interface{}{"name": "北京大学"})
+
+	var list struct {
+		List  []interface{} `json:"list"`
+		Total *int64        `json:"total"`
+	}
+	admin.ok(http.MethodGet, "/api/universities", nil, &list)
+	if len(list.List) != 0 || list.Total == nil || *list.Total != 0 {
+		t.Fatalf("删除后大学列表返回 %d 条，total=%v", len(list.List), list.Total)
+	}
+
+	// 数据只保存在内存中，不写入 SQLite
+	for _, table := range []string{model.TableNameUser, model.TableNameUniversity, model.TableNameStudent} {
+		var count int64
+		if err := h.DB.Table(table).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Errorf("SQLite 表 %s 中有 %d 条记录", table, count)
+		}
+	}
+}
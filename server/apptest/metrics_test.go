@@ -0,0 +1,51 @@
+package apptest_test
+
+import (
+	"mvc-demo/apptest"
+	"mvc-demo/config"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// 监控指标默认关闭；启用并配置令牌后只有带令牌的抓取请求可以访问；配置独立端口时不在接口端口输出
+func TestMetricsEndpoint(t *testing.T) {
+	get := func(h *apptest.Harness, token string) *apptest.Response {
+		t.Helper()
+		resp, err := h.Get("/metrics", token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	if resp := get(newHarness(t), ""); resp.Status != http.StatusNotFound {
+		t.Fatalf("默认配置下 /metrics 返回 HTTP %d，期望 404", resp.Status)
+	}
+
+	protected := newHarness(t, apptest.WithConfig(func(cfg *config.AppConfig) {
+		cfg.Metrics.Enabled = true
+		cfg.Metrics.Token = "scrape-token"
+	}))
+	for _, token := range []string{"", "wrong-token"} {
+		if resp := get(protected, token); resp.Status != http.StatusUnauthorized {
+			t.Errorf("令牌为 %q 时 /metrics 返回 HTTP %d，期望 401", token, resp.Status)
+		}
+	}
+	// 先发送一个接口请求，指标中应有请求计数
+	if _, err := protected.Get("/api/transcripts/verify/ABCD", ""); err != nil {
+		t.Fatal(err)
+	}
+	resp := get(protected, "scrape-token")
+	if resp.Status != http.StatusOK || !strings.Contains(string(resp.Body), "http_requests_total") {
+		t.Fatalf("带令牌抓取返回 HTTP %d: %.200s", resp.Status, resp.Body)
+	}
+
+	separate := newHarness(t, apptest.WithConfig(func(cfg *config.AppConfig) {
+		cfg.Metrics.Enabled = true
+		cfg.Metrics.Port = ":9464"
+	}))
+	if resp := get(separate, ""); resp.Status != http.StatusNotFound {
+		t.Fatalf("配置独立端口时接口端口的 /metrics 返回 HTTP %d，期望 404", resp.Status)
+	}
+}
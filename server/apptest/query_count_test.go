@@ -0,0 +1,106 @@
+package apptest_test
+
+import (
+	"fmt"
+	"mvc-demo/apptest"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// queryCounter 通过 GORM 回调统计执行的查询语句数
+type queryCounter struct {
+	n atomic.Int64
+}
+
+// countQueries 在 db 上注册统计查询数的回调
+func countQueries(t *testing.T, db *gorm.DB) *queryCounter {
+	t.Helper()
+	counter := &queryCounter{}
+	count := func(*gorm.DB) { counter.n.Add(1) }
+	callbacks := db.Callback()
+	for name, err := range map[string]error{
+		"query": callbacks.Query().After("gorm:query").Register("apptest:count_query", count),
+		"row":   callbacks.Row().After("gorm:row").Register("apptest:count_row", count),
+		"raw":   callbacks.Raw().After("gorm:raw").Register("apptest:count_raw", count),
+	} {
+		if err != nil {
+			t.Fatalf("注册 %s 回调失败: %v", name, err)
+		}
+	}
+	return counter
+}
+
+// during 返回执行 fn 期间的查询数
+func (c *queryCounter) during(fn func()) int64 {
+	before := c.n.Load()
+	fn()
+	return c.n.Load() - before
+}
+
+// seedStudents 创建 n 个学生，每个学生属于不同的大学
+func seedStudents(admin *client, offset, n int) {
+	admin.t.Helper()
+	for i := offset; i < offset+n; i++ {
+		universityID := admin.create("/api/admin/universities", map[string]interface{}{"name": fmt.Sprintf("测试大学%d", i)})
+		admin.create("/api/admin/students", map[string]interface{}{
+			"name":          fmt.Sprintf("测试学生%d", i),
+			"email":         fmt.Sprintf("student%d@example.com", i),
+			"password":      "secret123",
+			"university_id": universityID,
+		})
+	}
+}
+
+// 学生列表、搜索和游标分页关联加载大学时整批只查询一次，查询数不随返回的行数增加
+func TestStudentQueriesDoNotGrowWithRows(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+	counter := countQueries(t, h.DB)
+
+	endpoints := map[string]string{
+		"列表":   "/api/students?page_size=100",
+		"游标分页": "/api/students?pagination=cursor&page_size=100",
+		"搜索":   "/api/students/search?page_size=100&q=" + url.QueryEscape("测试学生"),
+	}
+	measure := func(path string, rows int) int64 {
+		var page struct {
+			List []struct {
+				University *struct {
+					ID int64 `json:"id"`
+				} `json:"university"`
+			} `json:"list"`
+		}
+		queries := counter.during(func() {
+			admin.ok(http.MethodGet, path, nil, &page)
+		})
+		if len(page.List) != rows {
+			t.Fatalf("GET %s 返回 %d 条，期望 %d", path, len(page.List), rows)
+		}
+		for _, item := range page.List {
+			if item.University == nil {
+				t.Fatalf("GET %s 返回的学生未加载所属大学", path)
+			}
+		}
+		return queries
+	}
+
+	seedStudents(admin, 0, 2)
+	small := make(map[string]int64, len(endpoints))
+	for name, path := range endpoints {
+		small[name] = measure(path, 2)
+		if small[name] == 0 {
+			t.Fatalf("%s：未统计到查询，回调没有生效", name)
+		}
+	}
+
+	seedStudents(admin, 2, 18)
+	for name, path := range endpoints {
+		if large := measure(path, 20); large != small[name] {
+			t.Errorf("%s：2 条时执行 %d 次查询，20 条时执行 %d 次查询", name, small[name], large)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package apptest_test
+
+import (
+	"fmt"
+	"mvc-demo/apptest"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestStudentMerge(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+
+	universityID := admin.create("/api/admin/universities", map[string]interface{}{"name": "复旦大学"})
+	newStudent := func(email string) int64 {
+		return admin.create("/api/admin/students", map[string]interface{}{
+			"name":          "赵六",
+			"email":         email,
+			"password":      "secret123",
+			"university_id": universityID,
+		})
+	}
+	survivorID := newStudent("zhao1@example.com")
+	loserID := newStudent("zhao2@example.com")
+
+	courseID := admin.create("/api/admin/courses", map[string]interface{}{
+		"university_id": universityID, "code": "CS101", "title": "程序设计基础", "credits": 3,
+	})
+	sectionID := admin.create("/api/admin/sections", map[string]interface{}{
+		"course_id": courseID, "term": "2024-2025-1", "section_no": "01", "capacity": 30,
+	})
+	admin.create("/api/admin/enrollments", map[string]interface{}{"student_id": survivorID, "section_id": sectionID})
+	loserEnrollmentID := admin.create("/api/admin/enrollments", map[string]interface{}{"student_id": loserID, "section_id": sectionID})
+
+	merge := func(fields map[string]string) map[string]interface{} {
+		return map[string]interface{}{"survivor_id": survivorID, "loser_id": loserID, "fields": fields}
+	}
+
+	// 所属大学和状态不能通过合并修改
+	admin.expect(-1, http.MethodPost, "/api/admin/students/merge", merge(map[string]string{"university_id": "loser"}))
+	admin.expect(-1, http.MethodPost, "/api/admin/students/merge", merge(map[string]string{"status": "loser"}))
+
+	// 两人选了同一教学班，合并后会重复
+	admin.expect(-10, http.MethodPost, "/api/admin/students/merge", merge(nil))
+
+	// 退掉其中一条选课后仍然重复（同一教学班只能有一条选课记录），删除前不能合并
+	admin.ok(http.MethodPost, fmt.Sprintf("/api/admin/enrollments/%d/drop", loserEnrollmentID), nil, nil)
+	admin.expect(-10, http.MethodPost, "/api/admin/students/merge", merge(nil))
+
+	var section struct {
+		EnrolledCount int64 `json:"enrolled_count"`
+	}
+	admin.ok(http.MethodGet, fmt.Sprintf("/api/sections/%d", sectionID), nil, &section)
+	if section.EnrolledCount != 1 {
+		t.Fatalf("教学班已选人数为 %d，期望 1", section.EnrolledCount)
+	}
+}
+
+func TestStudentMergeMovesRelatedRecords(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+
+	universityID := admin.create("/api/admin/universities", map[string]interface{}{"name": "南京大学"})
+	survivorID := admin.create("/api/admin/students", map[string]interface{}{
+		"name": "孙七", "email": "sun1@example.com", "password": "secret123", "university_id": universityID,
+	})
+	loserID := admin.create("/api/admin/students", map[string]interface{}{
+		"name": "孙七", "email": "sun2@example.com", "password": "secret123", "university_id": universityID,
+		"phone": "13800000000",
+	})
+
+	courseID := admin.create("/api/admin/courses", map[string]interface{}{
+		"university_id": universityID, "code": "MA101", "title": "高等数学", "credits": 4,
+	})
+	sectionID := admin.create("/api/admin/sections", map[string]interface{}{
+		"course_id": courseID, "term": "2024-2025-1", "section_no": "01", "capacity": 30,
+	})
+	admin.create("/api/admin/enrollments", map[string]interface{}{"student_id": loserID, "section_id": sectionID})
+
+	var survivor struct {
+		Phone *string `json:"phone"`
+	}
+	admin.ok(http.MethodPost, "/api/admin/students/merge", map[string]interface{}{"survivor_id": survivorID, "loser_id": loserID}, &survivor)
+	if survivor.Phone == nil || *survivor.Phone != "13800000000" {
+		t.Fatalf("合并后电话为 %v，期望取被合并学生的电话", survivor.Phone)
+	}
+
+	var enrollments struct {
+		List []struct {
+			StudentID int64 `json:"student_id"`
+		} `json:"list"`
+	}
+	admin.ok(http.MethodGet, fmt.Sprintf("/api/enrollments?section_id=%d", sectionID), nil, &enrollments)
+	if len(enrollments.List) != 1 || enrollments.List[0].StudentID != survivorID {
+		t.Fatalf("合并后选课记录不正确: %+v", enrollments.List)
+	}
+	admin.expect(-4, http.MethodGet, fmt.Sprintf("/api/students/%d", loserID), nil)
+}
+
+// 每个学生同一时间只有一位导师，两人都有未结束的导师分配时不能合并
+func TestStudentMergeAdvisorConflict(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+
+	universityID := admin.create("/api/admin/universities", map[string]interface{}{"name": "中山大学"})
+	newTeacher := func(email, name string) int64 {
+		user, _, err := h.CreateUser(email, "secret123", apptest.RoleUser)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return admin.create("/api/admin/teachers", map[string]interface{}{"user_id": user.ID, "name": name, "university_id": universityID})
+	}
+	newStudent := func(email string) int64 {
+		return admin.create("/api/admin/students", map[string]interface{}{
+			"name": "吴九", "email": email, "password": "secret123", "university_id": universityID,
+		})
+	}
+	survivorID := newStudent("wu1@example.com")
+	loserID := newStudent("wu2@example.com")
+
+	startDate := time.Now().AddDate(0, 0, -7).Format("2006-01-02")
+	admin.ok(http.MethodPost, fmt.Sprintf("/api/admin/students/%d/advisor", survivorID),
+		map[string]interface{}{"teacher_id": newTeacher("chen@example.com", "陈老师"), "start_date": startDate}, nil)
+	admin.ok(http.MethodPost, fmt.Sprintf("/api/admin/students/%d/advisor", loserID),
+		map[string]interface{}{"teacher_id": newTeacher("lin@example.com", "林老师"), "start_date": startDate}, nil)
+
+	merge := map[string]interface{}{"survivor_id": survivorID, "loser_id": loserID}
+	admin.expect(-10, http.MethodPost, "/api/admin/students/merge", merge)
+
+	// 结束其中一条分配后可以合并，导师分配记录移到保留的学生
+	admin.ok(http.MethodPost, fmt.Sprintf("/api/admin/students/%d/advisor/end", loserID), map[string]interface{}{}, nil)
+	admin.ok(http.MethodPost, "/api/admin/students/merge", merge, nil)
+
+	var history []struct {
+		Current bool `json:"current"`
+	}
+	admin.ok(http.MethodGet, fmt.Sprintf("/api/students/%d/advisors", survivorID), nil, &history)
+	current := 0
+	for _, assignment := range history {
+		if assignment.Current {
+			current++
+		}
+	}
+	if len(history) != 2 || current != 1 {
+		t.Fatalf("合并后有 %d 条导师分配记录，其中 %d 条当前有效，期望 2 条且只有 1 条有效", len(history), current)
+	}
+}
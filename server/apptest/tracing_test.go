@@ -0,0 +1,88 @@
+package apptest_test
+
+import (
+	"context"
+	"mvc-demo/apptest"
+	"mvc-demo/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordSpans 把全局 TracerProvider 换成记录到内存的实现，测试结束后恢复
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		provider.Shutdown(context.Background())
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+// 请求头中的 traceparent 由 server span 继续，控制器 span 是 server span 的子 span，追踪ID写入响应头
+func TestTraceparentContinued(t *testing.T) {
+	recorder := recordSpans(t)
+	h := newHarness(t, apptest.WithConfig(func(cfg *config.AppConfig) {
+		cfg.Tracing.Exporter = "otlp"
+		cfg.Tracing.ServiceName = "apptest"
+	}))
+
+	const (
+		traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentSpanID = "00f067aa0ba902b7"
+	)
+	req := httptest.NewRequest(http.MethodGet, "/api/transcripts/verify/ABCD", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentSpanID+"-01")
+	rec := httptest.NewRecorder()
+	h.Router.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("X-Trace-ID"); got != traceID {
+		t.Fatalf("响应头 X-Trace-ID 为 %q，期望沿用上游的追踪ID %s", got, traceID)
+	}
+
+	var server, controller sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		switch span.Name() {
+		case "GET /api/transcripts/verify/:code":
+			server = span
+		case "TranscriptController.Verify":
+			controller = span
+		}
+	}
+	if server == nil || controller == nil {
+		t.Fatalf("缺少 server span 或控制器 span，已结束的 span: %v", spanNames(recorder.Ended()))
+	}
+	if server.SpanKind() != trace.SpanKindServer || server.SpanContext().TraceID().String() != traceID || server.Parent().SpanID().String() != parentSpanID || !server.Parent().IsRemote() {
+		t.Errorf("server span 没有继续上游的追踪: trace_id=%s parent=%s", server.SpanContext().TraceID(), server.Parent().SpanID())
+	}
+	if controller.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Errorf("控制器 span 的父 span 为 %s，期望 server span %s", controller.Parent().SpanID(), server.SpanContext().SpanID())
+	}
+
+	// 没有 traceparent 时新建追踪
+	rec = httptest.NewRecorder()
+	h.Router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/transcripts/verify/ABCD", nil))
+	if got := rec.Header().Get("X-Trace-ID"); got == "" || got == traceID {
+		t.Errorf("没有上游追踪时 X-Trace-ID 为 %q，期望新的追踪ID", got)
+	}
+}
+
+func spanNames(spans []sdktrace.ReadOnlySpan) []string {
+	names := make([]string, len(spans))
+	for i, span := range spans {
+		names[i] = span.Name()
+	}
+	return names
+}
@@ -0,0 +1,63 @@
+package apptest_test
+
+import (
+	"fmt"
+	"mvc-demo/apptest"
+	"net/http"
+	"testing"
+)
+
+// 重修的课程只以最后一次成绩计入累计绩点，学期绩点仍计入该学期的全部成绩
+func TestTranscriptRetake(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+
+	universityID := admin.create("/api/admin/universities", map[string]interface{}{"name": "厦门大学"})
+	studentID := admin.create("/api/admin/students", map[string]interface{}{
+		"name": "孙二", "email": "sun@example.com", "password": "secret123", "university_id": universityID,
+	})
+	mathID := admin.create("/api/admin/courses", map[string]interface{}{
+		"university_id": universityID, "code": "MA101", "title": "高等数学", "credits": 3,
+	})
+	englishID := admin.create("/api/admin/courses", map[string]interface{}{
+		"university_id": universityID, "code": "EN101", "title": "大学英语", "credits": 2,
+	})
+	record := func(courseID int64, term string, score float64) {
+		admin.create("/api/admin/grades", map[string]interface{}{"student_id": studentID, "course_id": courseID, "term": term, "score": score})
+	}
+	record(mathID, "2023-2024-1", 55)    // F 0.0，之后重修
+	record(englishID, "2023-2024-1", 80) // B 3.0
+	record(mathID, "2023-2024-2", 92)    // A 4.0
+
+	var transcript struct {
+		Terms []struct {
+			Term    string  `json:"term"`
+			GPA     float64 `json:"gpa"`
+			Courses []struct {
+				CourseID   int64 `json:"course_id"`
+				Superseded bool  `json:"superseded"`
+			} `json:"courses"`
+		} `json:"terms"`
+		AttemptedCredits float64 `json:"attempted_credits"`
+		EarnedCredits    float64 `json:"earned_credits"`
+		CumulativeGPA    float64 `json:"cumulative_gpa"`
+	}
+	admin.ok(http.MethodGet, fmt.Sprintf("/api/students/%d/transcript", studentID), nil, &transcript)
+
+	// 累计：(3×4.0 + 2×3.0) / 5 = 3.6，第一学期：(3×0 + 2×3.0) / 5 = 1.2
+	if transcript.CumulativeGPA != 3.6 || transcript.AttemptedCredits != 5 || transcript.EarnedCredits != 5 {
+		t.Fatalf("累计绩点 %v、计入学分 %v、已获得学分 %v，期望 3.6、5、5",
+			transcript.CumulativeGPA, transcript.AttemptedCredits, transcript.EarnedCredits)
+	}
+	if len(transcript.Terms) != 2 || transcript.Terms[0].GPA != 1.2 || transcript.Terms[1].GPA != 4 {
+		t.Fatalf("学期绩点不正确: %+v", transcript.Terms)
+	}
+	for _, course := range transcript.Terms[0].Courses {
+		if course.Superseded != (course.CourseID == mathID) {
+			t.Errorf("第一学期课程 %d 的 superseded 为 %v", course.CourseID, course.Superseded)
+		}
+	}
+	if transcript.Terms[1].Courses[0].Superseded {
+		t.Error("重修的成绩不应被标记为已取代")
+	}
+}
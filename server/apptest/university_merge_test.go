@@ -0,0 +1,102 @@
+package apptest_test
+
+import (
+	"fmt"
+	"mvc-demo/apptest"
+	"net/http"
+	"testing"
+)
+
+func TestUniversityMergeCourses(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+
+	targetID := admin.create("/api/admin/universities", map[string]interface{}{"name": "浙江大学"})
+	sourceID := admin.create("/api/admin/universities", map[string]interface{}{"name": "浙江 大学"})
+	newCourse := func(universityID int64, code string) int64 {
+		return admin.create("/api/admin/courses", map[string]interface{}{
+			"university_id": universityID, "code": code, "title": "课程" + code, "credits": 2,
+		})
+	}
+	newCourse(targetID, "CS101")
+	conflictID := newCourse(sourceID, "CS101")
+	movedID := newCourse(sourceID, "CS102")
+
+	type mergeResult struct {
+		Courses       int64    `json:"courses"`
+		ConflictCodes []string `json:"conflict_codes"`
+	}
+	request := func(dryRun bool) map[string]interface{} {
+		return map[string]interface{}{"source_id": sourceID, "target_id": targetID, "dry_run": dryRun}
+	}
+
+	// 预览列出代码相同的课程，执行合并被拒绝
+	var preview mergeResult
+	admin.ok(http.MethodPost, "/api/admin/universities/merge", request(true), &preview)
+	if preview.Courses != 2 || len(preview.ConflictCodes) != 1 || preview.ConflictCodes[0] != "CS101" {
+		t.Fatalf("预览结果不正确: %+v", preview)
+	}
+	admin.expect(-10, http.MethodPost, "/api/admin/universities/merge", request(false))
+
+	// 删除重复的课程后合并，其余课程移动到目标大学
+	admin.ok(http.MethodDelete, fmt.Sprintf("/api/admin/courses/%d", conflictID), nil, nil)
+	var result mergeResult
+	admin.ok(http.MethodPost, "/api/admin/universities/merge", request(false), &result)
+	if result.Courses != 1 || len(result.ConflictCodes) != 0 {
+		t.Fatalf("合并结果不正确: %+v", result)
+	}
+
+	var course struct {
+		UniversityID int64 `json:"university_id"`
+	}
+	admin.ok(http.MethodGet, fmt.Sprintf("/api/courses/%d", movedID), nil, &course)
+	if course.UniversityID != targetID {
+		t.Fatalf("合并后课程属于大学 %d，期望 %d", course.UniversityID, targetID)
+	}
+}
+
+func TestUniversityMergeTeachers(t *testing.T) {
+	h := newHarness(t)
+	admin := newClient(t, h, "admin@example.com", apptest.RoleAdmin)
+
+	targetID := admin.create("/api/admin/universities", map[string]interface{}{"name": "武汉大学"})
+	sourceID := admin.create("/api/admin/universities", map[string]interface{}{"name": "武大"})
+	targetCollegeID := admin.create("/api/admin/colleges", map[string]interface{}{"university_id": targetID, "name": "计算机学院"})
+	sourceCollegeID := admin.create("/api/admin/colleges", map[string]interface{}{"university_id": sourceID, "name": "计算机学院"})
+	movedCollegeID := admin.create("/api/admin/colleges", map[string]interface{}{"university_id": sourceID, "name": "数学学院"})
+
+	newTeacher := func(email string, collegeID int64) int64 {
+		user, _, err := h.CreateUser(email, "secret123", apptest.RoleUser)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return admin.create("/api/admin/teachers", map[string]interface{}{
+			"user_id": user.ID, "name": email, "university_id": sourceID, "college_id": collegeID,
+		})
+	}
+	mergedTeacherID := newTeacher("t1@example.com", sourceCollegeID)
+	movedTeacherID := newTeacher("t2@example.com", movedCollegeID)
+
+	var result struct {
+		Teachers int64 `json:"teachers"`
+	}
+	admin.ok(http.MethodPost, "/api/admin/universities/merge", map[string]interface{}{"source_id": sourceID, "target_id": targetID, "dry_run": true}, &result)
+	if result.Teachers != 2 {
+		t.Fatalf("预览的教师数为 %d，期望 2", result.Teachers)
+	}
+	admin.ok(http.MethodPost, "/api/admin/universities/merge", map[string]interface{}{"source_id": sourceID, "target_id": targetID}, &result)
+	if result.Teachers != 2 {
+		t.Fatalf("合并的教师数为 %d，期望 2", result.Teachers)
+	}
+
+	for teacherID, collegeID := range map[int64]int64{mergedTeacherID: targetCollegeID, movedTeacherID: movedCollegeID} {
+		var teacher struct {
+			UniversityID *int64 `json:"university_id"`
+			CollegeID    *int64 `json:"college_id"`
+		}
+		admin.ok(http.MethodGet, fmt.Sprintf("/api/teachers/%d", teacherID), nil, &teacher)
+		if teacher.UniversityID == nil || *teacher.UniversityID != targetID || teacher.CollegeID == nil || *teacher.CollegeID != collegeID {
+			t.Fatalf("教师 %d 合并后属于大学 %v 院系 %v，期望大学 %d 院系 %d", teacherID, teacher.UniversityID, teacher.CollegeID, targetID, collegeID)
+		}
+	}
+}
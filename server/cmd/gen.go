@@ -6,6 +6,7 @@ import (
 	"github.com/joho/godotenv"
 	"gorm.io/driver/mysql"
 	"gorm.io/gen"
+	"gorm.io/gen/field"
 	"gorm.io/gorm"
 
 	"mvc-demo/config"
@@ -53,8 +54,82 @@ func main() {
 	// 或者只生成指定表的模型
 	// 第一个参数是表名，第二个参数是模型名称
 	g.GenerateModel("users")
-	g.GenerateModel("universities")
-	g.GenerateModel("students")
+	// 大学关联别名（简称、曾用名等），用于按别名查找大学
+	aliases := g.GenerateModel("university_aliases")
+	university := g.GenerateModel("universities", gen.FieldRelate(field.HasMany, "Aliases", aliases, &field.RelateConfig{
+		RelateSlicePointer: true,
+		JSONTag:            "aliases",
+		GORMTag:            field.GormTag{"foreignKey": []string{"UniversityID"}},
+	}))
+	// 学生关联所属大学，查询时可通过 Preload("University") 一并加载
+	g.GenerateModel("students", gen.FieldRelate(field.BelongsTo, "University", university, &field.RelateConfig{
+		RelatePointer: true,
+		JSONTag:       "university",
+		GORMTag:       field.GormTag{"foreignKey": []string{"UniversityID"}},
+	}))
+	g.GenerateModel("student_search_index")
+	g.GenerateModel("colleges")
+	g.GenerateModel("majors")
+	g.GenerateModel("audit_logs")
+	g.GenerateModel("student_status_histories")
+	g.GenerateModel("student_change_requests")
+	g.GenerateModel("notifications")
+	// 课程、教学班、选课记录，选课记录可通过 Preload("Section.Course") 加载教学班和课程
+	course := g.GenerateModel("courses")
+	section := g.GenerateModel("course_sections", gen.FieldRelate(field.BelongsTo, "Course", course, &field.RelateConfig{
+		RelatePointer: true,
+		JSONTag:       "course",
+		GORMTag:       field.GormTag{"foreignKey": []string{"CourseID"}},
+	}))
+	g.GenerateModel("enrollments", gen.FieldRelate(field.BelongsTo, "Section", section, &field.RelateConfig{
+		RelatePointer: true,
+		JSONTag:       "section",
+		GORMTag:       field.GormTag{"foreignKey": []string{"SectionID"}},
+	}))
+	// 评分标准及其等级、课程成绩、已签发成绩单
+	scaleItems := g.GenerateModel("grading_scale_items")
+	g.GenerateModel("grading_scales", gen.FieldRelate(field.HasMany, "Items", scaleItems, &field.RelateConfig{
+		RelateSlicePointer: true,
+		JSONTag:            "items",
+		GORMTag:            field.GormTag{"foreignKey": []string{"ScaleID"}},
+	}))
+	g.GenerateModel("grades", gen.FieldRelate(field.BelongsTo, "Course", course, &field.RelateConfig{
+		RelatePointer: true,
+		JSONTag:       "course",
+		GORMTag:       field.GormTag{"foreignKey": []string{"CourseID"}},
+	}))
+	g.GenerateModel("transcripts")
+	// 课次、考勤记录，课次可通过 Preload("Section.Course") 加载教学班和课程
+	g.GenerateModel("class_sessions", gen.FieldRelate(field.BelongsTo, "Section", section, &field.RelateConfig{
+		RelatePointer: true,
+		JSONTag:       "section",
+		GORMTag:       field.GormTag{"foreignKey": []string{"SectionID"}},
+	}))
+	g.GenerateModel("attendance_records")
+	// 教师、导师分配记录，分配记录可通过 Preload("Teacher") 加载导师
+	teacher := g.GenerateModel("teachers")
+	g.GenerateModel("advisor_assignments", gen.FieldRelate(field.BelongsTo, "Teacher", teacher, &field.RelateConfig{
+		RelatePointer: true,
+		JSONTag:       "teacher",
+		GORMTag:       field.GormTag{"foreignKey": []string{"TeacherID"}},
+	}))
+	// 企业、招聘岗位、岗位申请、毕业去向，申请可通过 Preload("Posting.Company") 加载岗位和企业
+	company := g.GenerateModel("companies")
+	posting := g.GenerateModel("job_postings", gen.FieldRelate(field.BelongsTo, "Company", company, &field.RelateConfig{
+		RelatePointer: true,
+		JSONTag:       "company",
+		GORMTag:       field.GormTag{"foreignKey": []string{"CompanyID"}},
+	}))
+	g.GenerateModel("job_applications", gen.FieldRelate(field.BelongsTo, "Posting", posting, &field.RelateConfig{
+		RelatePointer: true,
+		JSONTag:       "posting",
+		GORMTag:       field.GormTag{"foreignKey": []string{"PostingID"}},
+	}))
+	g.GenerateModel("employment_records", gen.FieldRelate(field.BelongsTo, "Company", company, &field.RelateConfig{
+		RelatePointer: true,
+		JSONTag:       "company",
+		GORMTag:       field.GormTag{"foreignKey": []string{"CompanyID"}},
+	}))
 
 	// 生成代码
 	g.Execute()
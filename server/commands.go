@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"mvc-demo/app"
+	"mvc-demo/config"
+	"mvc-demo/dao"
+	"mvc-demo/db"
+	"mvc-demo/logging"
+	"mvc-demo/service"
+	"os"
+	"strings"
+)
+
+// commandUsage 子命令说明
+const commandUsage = `可用命令:
+  reindex                  重建学生搜索索引
+  match-majors [-apply]    把学生填写的专业名称与专业目录匹配，默认只输出报告
+  migrate up               执行全部未执行的数据库迁移
+  migrate down [-steps N]  回滚最近执行的 N 个迁移，默认 1 个
+  migrate status           查看迁移执行状态
+  migrate create <name>    在 db/migrations 下每种数据库的目录中创建新的迁移文件，需重新编译后生效
+  migrate sql [-driver D]  输出全部迁移合并成的建表脚本（含迁移记录），可挂载到数据库容器的初始化目录
+  config print [-format yaml|env]
+                           输出合并配置文件、环境变量和参数后的最终配置（隐藏密码等敏感信息）并校验
+除 migrate create 外，命令都可以带启动服务时的配置参数，如 -config config.yaml
+`
+
+// runCommand 执行命令行子命令
+func runCommand(name string, args []string) {
+	switch name {
+	case "reindex":
+		runReindex(args)
+	case "match-majors":
+		runMatchMajors(args)
+	case "migrate":
+		runMigrate(args)
+	case "config":
+		runConfig(args)
+	default:
+		fmt.Fprintf(os.Stderr, "未知命令: %s\n\n%s", name, commandUsage)
+		os.Exit(2)
+	}
+}
+
+// loadCommandConfig 解析子命令的参数和配置参数，加载配置并初始化日志，配置有误时退出
+// flags 中已定义子命令自己的参数，配置参数的含义与启动服务时相同
+func loadCommandConfig(flags *flag.FlagSet, args []string) *config.AppConfig {
+	loadConfig := config.RegisterFlags(flags)
+	flags.Parse(args)
+	if flags.NArg() > 0 {
+		log.Fatalf("未知的参数: %s", strings.Join(flags.Args(), " "))
+	}
+	appConfig, err := loadConfig()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	logging.Setup(appConfig.Log)
+	return appConfig
+}
+
+// runReindex 清空并重建学生搜索索引
+// 使用 bleve 引擎时索引目录会被运行中的服务锁定，需先停止服务，或调用 POST /api/admin/students/reindex
+func runReindex(args []string) {
+	appConfig := loadCommandConfig(flag.NewFlagSet("reindex", flag.ExitOnError), args)
+	db.InitDB(appConfig)
+
+	index := openSearchIndex(appConfig)
+	defer index.Close()
+
+	deps := app.NewDependencies(db.DB, app.NewRepositories(db.DB), index, appConfig)
+	searchLogger := logging.Component(logging.ComponentSearch)
+	count, err := deps.StudentSearchService.Reindex(context.Background())
+	if err != nil {
+		logging.Fatal(searchLogger, "重建搜索索引失败", "error", err)
+	}
+	searchLogger.Info("搜索索引重建完成", "students", count)
+}
+
+// runMatchMajors 匹配历史专业名称并输出未匹配的名称，便于补充专业目录后再次执行
+func runMatchMajors(args []string) {
+	flags := flag.NewFlagSet("match-majors", flag.ExitOnError)
+	apply := flags.Bool("apply", false, "为匹配成功的学生写入 major_id")
+	db.InitDB(loadCommandConfig(flags, args))
+	majorService := service.NewMajorService(dao.NewMajorDAO(db.DB), dao.NewCollegeDAO(db.DB), dao.NewStudentDAO(db.DB))
+
+	report, err := majorService.MatchLegacyMajors(context.Background(), *apply)
+	if err != nil {
+		log.Fatalf("匹配专业失败: %v", err)
+	}
+
+	if report.Applied {
+		fmt.Printf("已为 %d 名学生关联专业\n", report.Matched)
+	} else {
+		fmt.Printf("可关联专业的学生 %d 名（未写入，使用 -apply 写入）\n", report.Matched)
+	}
+	fmt.Printf("未匹配的专业名称 %d 个:\n", len(report.Unmatched))
+	for _, item := range report.Unmatched {
+		university := "-"
+		if item.UniversityID != nil {
+			university = fmt.Sprint(*item.UniversityID)
+		}
+		fmt.Printf("  大学ID=%s\t%s\t%d 名学生\t%s\n", university, item.Major, item.Count, item.Reason)
+	}
+}
+
+// runConfig 执行配置子命令
+func runConfig(args []string) {
+	if len(args) == 0 || args[0] != "print" {
+		fmt.Fprint(os.Stderr, commandUsage)
+		os.Exit(2)
+	}
+
+	// 配置参数与启动服务时相同，如 config print -format env -config config.yaml
+	flags := flag.NewFlagSet("config print", flag.ExitOnError)
+	format := flags.String("format", "yaml", "输出格式：yaml 或 env")
+	loadConfig := config.RegisterFlags(flags)
+	flags.Parse(args[1:])
+
+	// 配置有误时仍输出配置，便于定位
+	appConfig, err := loadConfig()
+	out, formatErr := appConfig.Format(*format)
+	if formatErr != nil {
+		log.Fatalf("%v", formatErr)
+	}
+	os.Stdout.Write(out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n%v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runMigrate 执行数据库迁移子命令
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, commandUsage)
+		os.Exit(2)
+	}
+
+	// create 只生成文件，不需要连接数据库
+	if args[0] == "create" {
+		flags := flag.NewFlagSet("migrate create", flag.ExitOnError)
+		dir := flags.String("dir", db.MigrationDir, "迁移文件目录")
+		flags.Parse(args[1:])
+		if flags.NArg() != 1 {
+			log.Fatal("用法: migrate create [-dir 目录] <name>")
+		}
+		paths, err := db.CreateMigrationFiles(*dir, flags.Arg(0))
+		for _, path := range paths {
+			fmt.Printf("已创建 %s\n", path)
+		}
+		if err != nil {
+			log.Fatalf("创建迁移文件失败: %v", err)
+		}
+		return
+	}
+
+	// sql 只输出内嵌的迁移文件，不需要连接数据库
+	if args[0] == "sql" {
+		flags := flag.NewFlagSet("migrate sql", flag.ExitOnError)
+		driver := flags.String("driver", "", "数据库类型：mysql、postgres 或 sqlite，默认使用配置中的数据库类型")
+		appConfig := loadCommandConfig(flags, args[1:])
+		if *driver == "" {
+			*driver = appConfig.DB.Driver
+		}
+		script, err := db.MigrationScript(*driver)
+		if err != nil {
+			log.Fatalf("生成建表脚本失败: %v", err)
+		}
+		fmt.Print(script)
+		return
+	}
+
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ExitOnError)
+	steps := flags.Int("steps", 1, "回滚的迁移个数，用于 migrate down")
+	db.InitDB(loadCommandConfig(flags, args[1:]))
+	migrator, err := db.NewMigrator(db.DB)
+	if err != nil {
+		log.Fatalf("加载迁移文件失败: %v", err)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			fmt.Printf("已执行 %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("执行迁移失败: %v", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("没有需要执行的迁移")
+		}
+	case "down":
+		reverted, err := migrator.Down(*steps)
+		for _, migration := range reverted {
+			fmt.Printf("已回滚 %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("回滚迁移失败: %v", err)
+		}
+		if len(reverted) == 0 {
+			fmt.Println("没有可以回滚的迁移")
+		}
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatalf("获取迁移状态失败: %v", err)
+		}
+		for _, status := range statuses {
+			appliedAt := "-"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%s\t%s\t%s\n", status.Version, status.Name, status.State, appliedAt)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "未知的 migrate 命令: %s\n\n%s", args[0], commandUsage)
+		os.Exit(2)
+	}
+}
@@ -1,90 +1,332 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 // AppConfig 应用配置
+// 每个配置项的 yaml 标签是配置文件中的键（嵌套结构用 . 连接即为命令行参数名，如 -server.port），env 标签是环境变量名
 type AppConfig struct {
-	ServerPort string
-	Mode       string
-	DB         DBConfig
+	Server     ServerConfig     `yaml:"server"`
+	DB         DBConfig         `yaml:"db"`
+	JWT        JWTConfig        `yaml:"jwt"`
+	Request    RequestConfig    `yaml:"request"`
+	Log        LogConfig        `yaml:"log"`
+	CORS       CORSConfig       `yaml:"cors"`
+	Metrics    MetricsConfig    `yaml:"metrics"`
+	Tracing    TracingConfig    `yaml:"tracing"`
+	Search     SearchConfig     `yaml:"search"`
+	Transcript TranscriptConfig `yaml:"transcript"`
+}
+
+// ServerConfig HTTP 服务配置
+type ServerConfig struct {
+	Port              string        `yaml:"port" env:"SERVER_PORT"`                               // 监听地址，如 :8080
+	Mode              string        `yaml:"mode" env:"GIN_MODE"`                                  // 运行模式：debug、release 或 test
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT"` // 读取请求头的超时时间
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT"`               // 读取整个请求（含请求体）的超时时间
+	// WriteTimeout 从读完请求头到写完响应的超时时间，0 表示按请求超时（RequestConfig）的最大值加上余量计算
+	WriteTimeout    time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`         // keep-alive 连接的空闲超时时间
+	MaxHeaderBytes  int           `yaml:"max_header_bytes" env:"SERVER_MAX_HEADER_BYTES"` // 请求头最大字节数
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"` // 收到停止信号后等待进行中的请求完成的最长时间
+	TLSCertFile     string        `yaml:"tls_cert_file" env:"SERVER_TLS_CERT_FILE"`       // TLS 证书文件，与 TLSKeyFile 都配置时启用 HTTPS
+	TLSKeyFile      string        `yaml:"tls_key_file" env:"SERVER_TLS_KEY_FILE"`         // TLS 私钥文件
+}
+
+// TLSEnabled 是否启用 HTTPS
+func (c ServerConfig) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
 }
 
 // DBConfig 数据库配置
 type DBConfig struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	Name     string
-	Charset  string
+	Driver   string `yaml:"driver" env:"DB_DRIVER"`   // 数据库类型：mysql、postgres 或 sqlite
+	Path     string `yaml:"path" env:"DB_PATH"`       // SQLite 数据库文件
+	SSLMode  string `yaml:"sslmode" env:"DB_SSLMODE"` // PostgreSQL SSL 模式
+	Host     string `yaml:"host" env:"DB_HOST"`
+	Port     string `yaml:"port" env:"DB_PORT"` // 为空时使用数据库类型的默认端口
+	User     string `yaml:"user" env:"DB_USER"` // 为空时 MySQL 使用 root，PostgreSQL 使用 postgres
+	Password string `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name     string `yaml:"name" env:"DB_NAME"`
+	Charset  string `yaml:"charset" env:"DB_CHARSET"`
+	// AutoMigrate 服务启动时自动执行未执行的迁移，多个实例同时启动时通过数据库锁保证只有一个执行
+	AutoMigrate bool `yaml:"auto_migrate" env:"DB_AUTO_MIGRATE"`
+	// 连接池
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`         // 最大空闲连接数
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`         // 最大连接数，0 表示不限制
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`   // 连接可复用的最长时间，0 表示不限制
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME"` // 连接空闲的最长时间，0 表示不限制
 }
 
-// 初始化环境变量
-func init() {
-	loadEnvFiles()
+// SearchConfig 搜索配置
+type SearchConfig struct {
+	Engine    string `yaml:"engine" env:"SEARCH_ENGINE"`         // 搜索引擎：bleve（内置嵌入式索引）或 mysql（FULLTEXT 索引）
+	IndexPath string `yaml:"index_path" env:"SEARCH_INDEX_PATH"` // bleve 索引目录
 }
 
-// loadEnvFiles 按顺序加载环境变量文件
-// 首先加载 .env 基础配置，然后加载 .env.local 覆盖配置（如果存在）
-func loadEnvFiles() {
-	// 获取当前工作目录
-	workDir, err := os.Getwd()
-	if err != nil {
-		log.Println("警告: 无法获取工作目录:", err)
-	}
+// TranscriptConfig 成绩单配置
+type TranscriptConfig struct {
+	FontPath string `yaml:"font_path" env:"TRANSCRIPT_FONT_PATH"` // 生成 PDF 使用的 TrueType 中文字体文件路径，未配置时不能导出 PDF
+}
 
-	// 基础配置文件路径
-	baseEnvPath := filepath.Join(workDir, ".env")
+// RequestConfig 请求处理配置
+type RequestConfig struct {
+	// Timeout 请求处理超时时间，超时后取消请求 ctx 中进行中的数据库查询，0 表示不限制
+	Timeout time.Duration `yaml:"timeout" env:"REQUEST_TIMEOUT"`
+	// RouteTimeouts 按路由前缀设置的超时时间，覆盖 Timeout，前缀最长的优先
+	// 环境变量和命令行参数的格式为 "路由前缀=时长"，多个用逗号分隔，如 "/api/admin/students/reindex=10m,/api/reports=2m"
+	RouteTimeouts map[string]time.Duration `yaml:"route_timeouts" env:"REQUEST_ROUTE_TIMEOUTS"`
+}
 
-	// 首先加载基础配置
-	err = godotenv.Load(baseEnvPath)
-	if err != nil {
-		log.Println("警告: 未找到.env文件或无法加载:", err)
-	} else {
-		log.Println("成功加载基础配置文件:", baseEnvPath)
+// MaxTimeout 所有路由中最长的超时时间，有路由不限制超时时返回 0
+func (c RequestConfig) MaxTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 0
+	}
+	max := c.Timeout
+	for _, t := range c.RouteTimeouts {
+		if t <= 0 {
+			return 0
+		}
+		if t > max {
+			max = t
+		}
 	}
+	return max
+}
 
-	// 加载本地覆盖配置
-	localEnvPath := filepath.Join(workDir, ".env.local")
-	if _, err := os.Stat(localEnvPath); err == nil {
-		// .env.local 文件存在，加载它以覆盖基础配置
-		err = godotenv.Overload(localEnvPath)
-		if err != nil {
-			log.Println("警告: 无法加载覆盖配置文件:", err)
-		} else {
-			log.Println("成功加载覆盖配置文件:", localEnvPath)
+// TimeoutFor 获取路由（gin 的路由模板，如 /api/students/:id）的超时时间
+func (c RequestConfig) TimeoutFor(route string) time.Duration {
+	timeout, matched := c.Timeout, ""
+	for prefix, t := range c.RouteTimeouts {
+		if len(prefix) <= len(matched) {
+			continue
+		}
+		if route == prefix || strings.HasPrefix(route, strings.TrimSuffix(prefix, "/")+"/") {
+			timeout, matched = t, prefix
 		}
 	}
+	return timeout
 }
 
-// GetConfig 获取应用配置
-func GetConfig() *AppConfig {
+// LogConfig 日志配置
+type LogConfig struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL"`   // 日志级别：debug、info、warn 或 error
+	Format string `yaml:"format" env:"LOG_FORMAT"` // 输出格式：text 或 json
+	// Levels 按组件设置日志级别，覆盖 Level，如 db=debug（输出全部 SQL）、http=warn（不输出访问日志）
+	// 环境变量和命令行参数的格式为 "组件=级别"，多个用逗号分隔
+	Levels             map[string]string `yaml:"levels" env:"LOG_LEVELS"`
+	SlowQueryThreshold time.Duration     `yaml:"slow_query_threshold" env:"LOG_SLOW_QUERY_THRESHOLD"` // 执行时间超过该值的 SQL 以 warn 级别记录，0 表示不记录慢查询
+	SQLParams          bool              `yaml:"sql_params" env:"LOG_SQL_PARAMS"`                     // SQL 日志是否包含参数值，默认只记录占位符，避免记录密码等敏感数据
+}
+
+// CORSConfig 跨域配置，AllowOrigins 为空时不处理跨域请求（前端与接口同源部署或通过代理访问）
+type CORSConfig struct {
+	AllowOrigins     []string      `yaml:"allow_origins" env:"CORS_ALLOW_ORIGINS"`         // 允许的来源，如 https://admin.example.com，* 表示任意来源
+	AllowMethods     []string      `yaml:"allow_methods" env:"CORS_ALLOW_METHODS"`         // 允许的请求方法
+	AllowHeaders     []string      `yaml:"allow_headers" env:"CORS_ALLOW_HEADERS"`         // 允许的请求头
+	ExposeHeaders    []string      `yaml:"expose_headers" env:"CORS_EXPOSE_HEADERS"`       // 允许前端读取的响应头
+	AllowCredentials bool          `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"` // 是否允许携带 Cookie，不能与 * 同时使用
+	MaxAge           time.Duration `yaml:"max_age" env:"CORS_MAX_AGE"`                     // 预检请求结果的缓存时间
+}
+
+// Enabled 是否处理跨域请求
+func (c CORSConfig) Enabled() bool {
+	return len(c.AllowOrigins) > 0
+}
+
+// MetricsConfig 监控指标配置，指标以 Prometheus 文本格式输出
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled" env:"METRICS_ENABLED"`           // 是否采集和输出监控指标
+	Path    string `yaml:"path" env:"METRICS_PATH"`                 // 指标路径
+	Port    string `yaml:"port" env:"METRICS_PORT"`                 // 独立的监听地址，如 :9090；为空时与接口共用端口
+	Token   string `yaml:"token" env:"METRICS_TOKEN" secret:"true"` // 访问令牌，配置后抓取时需带请求头 Authorization: Bearer <token>
+}
+
+// TracingConfig 链路追踪配置，环境变量沿用 OpenTelemetry 的标准变量名
+type TracingConfig struct {
+	Exporter     string `yaml:"exporter" env:"OTEL_TRACES_EXPORTER"`             // 导出方式：none（关闭）、otlp、console（标准输出）或 file
+	ServiceName  string `yaml:"service_name" env:"OTEL_SERVICE_NAME"`            // 服务名，即 span 的 service.name
+	OTLPEndpoint string `yaml:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"` // OTLP/HTTP 地址，span 发送到 {endpoint}/v1/traces
+	// OTLPHeaders 发送 OTLP 请求时附加的请求头（如认证令牌），环境变量格式为 "键=值"，多个用逗号分隔
+	OTLPHeaders map[string]string `yaml:"otlp_headers" env:"OTEL_EXPORTER_OTLP_HEADERS" secret:"true"`
+	File        string            `yaml:"file" env:"OTEL_TRACES_FILE"`                // file 导出方式写入的文件，每行一个 span（JSON）
+	SampleRatio float64           `yaml:"sample_ratio" env:"OTEL_TRACES_SAMPLER_ARG"` // 没有上游追踪上下文时的采样比例，0 到 1
+}
+
+// Enabled 是否启用链路追踪
+func (c TracingConfig) Enabled() bool {
+	return c.Exporter != "" && c.Exporter != "none"
+}
+
+// Default 返回默认配置
+func Default() *AppConfig {
 	return &AppConfig{
-		// 从环境变量中读取，如果不存在则使用默认值
-		ServerPort: getEnv("SERVER_PORT", ":8080"),
-		Mode:       getEnv("GIN_MODE", "debug"),
+		Server: ServerConfig{
+			Port:              ":8080",
+			Mode:              "debug",
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       60 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			MaxHeaderBytes:    1 << 20,
+			ShutdownTimeout:   30 * time.Second,
+		},
 		DB: DBConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "3306"),
-			User:     getEnv("DB_USER", "root"),
-			Password: getEnv("DB_PASSWORD", ""),
-			Name:     getEnv("DB_NAME", "student_management"),
-			Charset:  getEnv("DB_CHARSET", "utf8mb4"),
+			Driver:          "mysql",
+			Path:            "data/student_management.db",
+			SSLMode:         "disable",
+			Host:            "localhost",
+			Name:            "student_management",
+			Charset:         "utf8mb4",
+			MaxIdleConns:    10,
+			MaxOpenConns:    100,
+			ConnMaxLifetime: time.Hour,
+		},
+		JWT: JWTConfig{
+			SecretKey:     DefaultJWTSecretKey,
+			TokenExpiry:   24 * time.Hour,
+			RefreshExpiry: 168 * time.Hour,
+			Issuer:        "student-management-system",
+		},
+		Request: RequestConfig{
+			Timeout:       30 * time.Second,
+			RouteTimeouts: map[string]time.Duration{},
+		},
+		Log: LogConfig{
+			Level:              "info",
+			Format:             "text",
+			Levels:             map[string]string{},
+			SlowQueryThreshold: time.Second,
 		},
+		CORS: CORSConfig{
+			AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+			AllowHeaders:  []string{"Origin", "Content-Type", "Authorization", "X-Request-ID", "traceparent", "tracestate"},
+			ExposeHeaders: []string{"X-Request-ID", "X-Trace-ID", "Content-Disposition"},
+			MaxAge:        12 * time.Hour,
+		},
+		Metrics: MetricsConfig{
+			Path: "/metrics",
+		},
+		Tracing: TracingConfig{
+			Exporter:     "none",
+			ServiceName:  "student-management-server",
+			OTLPEndpoint: "http://localhost:4318",
+			OTLPHeaders:  map[string]string{},
+			File:         "data/traces.jsonl",
+			SampleRatio:  1,
+		},
+		Search: SearchConfig{
+			Engine:    "bleve",
+			IndexPath: "data/students.bleve",
+		},
+	}
+}
+
+// applyDriverDefaults 补充与数据库类型相关的默认值
+func (c *AppConfig) applyDriverDefaults() {
+	switch c.DB.Driver {
+	case "mysql":
+		if c.DB.Port == "" {
+			c.DB.Port = "3306"
+		}
+		if c.DB.User == "" {
+			c.DB.User = "root"
+		}
+	case "postgres":
+		if c.DB.Port == "" {
+			c.DB.Port = "5432"
+		}
+		if c.DB.User == "" {
+			c.DB.User = "postgres"
+		}
+	}
+}
+
+var (
+	currentMu sync.Mutex
+	current   *AppConfig
+)
+
+// Load 加载并校验配置，成功后作为 GetConfig 返回的配置
+// 优先级从低到高：默认值、配置文件（-config 参数或环境变量 CONFIG_FILE）、环境变量（含 .env、.env.local）、命令行参数
+// args 为命令行参数，如 []string{"-config", "config.yaml", "-server.port", ":9090"}；
+// 配置有误时返回 Errors，其中包含全部错误，此时返回的配置仅用于展示
+func Load(args []string) (*AppConfig, error) {
+	cfg, err := load(args)
+	if err != nil {
+		return cfg, err
 	}
+
+	currentMu.Lock()
+	current = cfg
+	currentMu.Unlock()
+	return cfg, nil
 }
 
-// getEnv 获取环境变量，如果不存在则返回默认值
-func getEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+// GetConfig 获取应用配置，配置只加载一次
+// 服务启动时由 main 调用 Load 加载；未调用 Load 时（如子命令、测试工具）首次调用时从配置文件和环境变量加载，配置有误时退出
+func GetConfig() *AppConfig {
+	currentMu.Lock()
+	defer currentMu.Unlock()
+
+	if current == nil {
+		cfg, err := load(nil)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		current = cfg
 	}
-	return value
+	return current
+}
+
+var (
+	envOnce  sync.Once
+	envFiles []string
+	envErrs  Errors
+)
+
+// loadEnvFiles 按顺序加载工作目录中的环境变量文件，进程中只加载一次，错误随配置错误一起返回
+// 首先加载 .env 基础配置，然后加载 .env.local 覆盖配置；文件不存在时跳过
+func loadEnvFiles() Errors {
+	envOnce.Do(func() {
+		workDir, err := os.Getwd()
+		if err != nil {
+			envErrs = Errors{fmt.Errorf("无法获取工作目录: %w", err)}
+			return
+		}
+
+		for _, file := range []struct {
+			name string
+			load func(filenames ...string) error
+		}{
+			{".env", godotenv.Load},           // 不覆盖已设置的环境变量
+			{".env.local", godotenv.Overload}, // 覆盖 .env 和已设置的环境变量
+		} {
+			path := filepath.Join(workDir, file.name)
+			if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			if err := file.load(path); err != nil {
+				envErrs = append(envErrs, fmt.Errorf("加载环境变量文件 %s 失败: %w", path, err))
+				continue
+			}
+			envFiles = append(envFiles, path)
+		}
+	})
+	return envErrs
+}
+
+// EnvFiles 返回已加载的环境变量文件，服务启动时输出到日志
+func EnvFiles() []string {
+	return envFiles
 }
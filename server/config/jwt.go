@@ -1,19 +1,20 @@
 package config
 
 import (
-	"fmt"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// JWTConfig JWT配置
+// DefaultJWTSecretKey 默认JWT密钥，仅用于开发，release 模式下不允许使用
+const DefaultJWTSecretKey = "your-secret-key-change-in-production"
+
+// JWTConfig JWT配置，时长只写数字时单位为小时（兼容原有的 JWT_TOKEN_EXPIRY=24 写法）
 type JWTConfig struct {
-	SecretKey     string
-	TokenExpiry   time.Duration
-	RefreshExpiry time.Duration
-	Issuer        string
+	SecretKey     string        `yaml:"secret_key" env:"JWT_SECRET_KEY" secret:"true"`
+	TokenExpiry   time.Duration `yaml:"token_expiry" env:"JWT_TOKEN_EXPIRY" unit:"h"`     // 访问令牌有效期
+	RefreshExpiry time.Duration `yaml:"refresh_expiry" env:"JWT_REFRESH_EXPIRY" unit:"h"` // 刷新令牌有效期
+	Issuer        string        `yaml:"issuer" env:"JWT_ISSUER"`
 }
 
 // JWT 声明结构体
@@ -26,27 +27,5 @@ type JWTClaims struct {
 
 // GetJWTConfig 获取JWT配置
 func GetJWTConfig() *JWTConfig {
-	return &JWTConfig{
-		// 从环境变量中读取，如果不存在则使用默认值
-		SecretKey:     getEnv("JWT_SECRET_KEY", "your-secret-key-change-in-production"),
-		TokenExpiry:   time.Duration(getEnvInt("JWT_TOKEN_EXPIRY", 24)) * time.Hour,    // 默认24小时
-		RefreshExpiry: time.Duration(getEnvInt("JWT_REFRESH_EXPIRY", 168)) * time.Hour, // 默认7天
-		Issuer:        getEnv("JWT_ISSUER", "student-management-system"),
-	}
-}
-
-// getEnvInt 获取整型环境变量，如果不存在则返回默认值
-func getEnvInt(key string, defaultValue int) int {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
-	}
-
-	intValue := 0
-	_, err := fmt.Sscanf(value, "%d", &intValue)
-	if err != nil {
-		return defaultValue
-	}
-
-	return intValue
+	return &GetConfig().JWT
 }
@@ -0,0 +1,434 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Errors 配置错误列表，加载时收集全部错误一次报告
+type Errors []error
+
+// Error 每行一个错误
+func (e Errors) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "配置有误（%d 项）:", len(e))
+	for _, err := range e {
+		b.WriteString("\n  - ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// redacted 敏感配置项输出时的替代值
+const redacted = "******"
+
+var (
+	durationType    = reflect.TypeOf(time.Duration(0))
+	stringSliceType = reflect.TypeOf([]string(nil))
+	durationMapType = reflect.TypeOf(map[string]time.Duration(nil))
+	stringMapType   = reflect.TypeOf(map[string]string(nil))
+)
+
+// field 一个配置项
+type field struct {
+	key    string        // 配置文件中的键和命令行参数名，如 server.port
+	env    string        // 环境变量名
+	secret bool          // 敏感信息，输出时隐藏
+	unit   time.Duration // 时长只写数字时的单位，0 表示必须带单位
+	value  reflect.Value
+}
+
+// fields 按定义顺序列出 cfg 的全部配置项
+func fields(cfg *AppConfig) []*field {
+	var result []*field
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			key := prefix + sf.Tag.Get("yaml")
+			if sf.Type.Kind() == reflect.Struct {
+				walk(v.Field(i), key+".")
+				continue
+			}
+			f := &field{key: key, env: sf.Tag.Get("env"), secret: sf.Tag.Get("secret") == "true", value: v.Field(i)}
+			if sf.Tag.Get("unit") == "h" {
+				f.unit = time.Hour
+			}
+			result = append(result, f)
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+	return result
+}
+
+// set 把字符串形式的值写入配置项
+func (f *field) set(raw string) error {
+	raw = strings.TrimSpace(raw)
+	switch f.value.Type() {
+	case durationType:
+		d, err := f.parseDuration(raw)
+		if err != nil {
+			return err
+		}
+		f.value.SetInt(int64(d))
+		return nil
+	case stringSliceType:
+		f.value.Set(reflect.ValueOf(splitList(raw)))
+		return nil
+	case durationMapType, stringMapType:
+		m := reflect.MakeMap(f.value.Type())
+		for _, item := range splitList(raw) {
+			key, value, ok := strings.Cut(item, "=")
+			if !ok {
+				return fmt.Errorf("%q 格式错误，应为 键=值", item)
+			}
+			v, err := f.parseMapValue(strings.TrimSpace(value))
+			if err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(strings.TrimSpace(key)), v)
+		}
+		f.value.Set(m)
+		return nil
+	}
+
+	switch f.value.Kind() {
+	case reflect.String:
+		f.value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q 不是有效的布尔值", raw)
+		}
+		f.value.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%q 不是有效的整数", raw)
+		}
+		f.value.SetInt(int64(n))
+	case reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%q 不是有效的数字", raw)
+		}
+		f.value.SetFloat(n)
+	default:
+		return fmt.Errorf("不支持的配置类型 %s", f.value.Type())
+	}
+	return nil
+}
+
+// parseMapValue 解析映射类型配置项中的值
+func (f *field) parseMapValue(raw string) (reflect.Value, error) {
+	if f.value.Type() == stringMapType {
+		return reflect.ValueOf(raw), nil
+	}
+	d, err := f.parseDuration(raw)
+	return reflect.ValueOf(d), err
+}
+
+// parseDuration 解析时长，如 30s、5m；配置了单位时允许只写数字
+func (f *field) parseDuration(raw string) (time.Duration, error) {
+	if f.unit > 0 {
+		if n, err := strconv.Atoi(raw); err == nil {
+			return time.Duration(n) * f.unit, nil
+		}
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%q 不是有效的时长（如 30s、5m、1h）", raw)
+	}
+	return d, nil
+}
+
+// setFileValue 写入配置文件中的值，列表和映射使用文件中的原生结构
+func (f *field) setFileValue(value interface{}) error {
+	switch v := value.(type) {
+	case []interface{}:
+		if f.value.Type() != stringSliceType {
+			return errors.New("不能是列表")
+		}
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			list = append(list, fmt.Sprint(item))
+		}
+		f.value.Set(reflect.ValueOf(list))
+		return nil
+	case map[string]interface{}:
+		if !isMapType(f.value.Type()) {
+			return errors.New("不能是映射")
+		}
+		m := reflect.MakeMap(f.value.Type())
+		for key, item := range v {
+			value, err := f.parseMapValue(fmt.Sprint(item))
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			m.SetMapIndex(reflect.ValueOf(key), value)
+		}
+		f.value.Set(m)
+		return nil
+	default:
+		return f.set(fmt.Sprint(v))
+	}
+}
+
+// String 配置项的字符串形式，与环境变量的格式一致
+func (f *field) String() string {
+	switch f.value.Type() {
+	case durationType:
+		return time.Duration(f.value.Int()).String()
+	case stringSliceType:
+		return strings.Join(f.value.Interface().([]string), ",")
+	case durationMapType, stringMapType:
+		keys := make([]string, 0, f.value.Len())
+		for _, key := range f.value.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+		items := make([]string, 0, len(keys))
+		for _, key := range keys {
+			items = append(items, fmt.Sprintf("%s=%v", key, f.value.MapIndex(reflect.ValueOf(key)).Interface()))
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(f.value.Interface())
+}
+
+// splitList 按逗号拆分列表，忽略空项
+func splitList(raw string) []string {
+	list := []string{}
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// flagValue 命令行参数，解析时只记录原始值，在配置文件和环境变量之后写入
+type flagValue struct {
+	field  *field
+	values map[string]string
+}
+
+func (v *flagValue) String() string {
+	if v.field == nil {
+		return ""
+	}
+	return v.field.String()
+}
+
+func (v *flagValue) Set(raw string) error {
+	v.values[v.field.key] = raw
+	return nil
+}
+
+// IsBoolFlag 布尔配置项可以只写参数名，如 -db.auto_migrate
+func (v *flagValue) IsBoolFlag() bool {
+	return v.field != nil && v.field.value.Kind() == reflect.Bool
+}
+
+// load 按优先级加载配置并校验，见 Load
+func load(args []string) (*AppConfig, error) {
+	flags := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ContinueOnError)
+	loadFlags := registerFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() > 0 {
+		return nil, fmt.Errorf("未知的参数: %s", strings.Join(flags.Args(), " "))
+	}
+	return loadFlags()
+}
+
+// RegisterFlags 在 flags 上注册与启动服务相同的配置参数（-config 和各配置项），用于子命令与自己的参数一起解析；
+// 解析参数后调用返回的函数加载并校验配置，结果与 Load 相同，成功后作为 GetConfig 返回的配置
+func RegisterFlags(flags *flag.FlagSet) func() (*AppConfig, error) {
+	loadFlags := registerFlags(flags)
+	return func() (*AppConfig, error) {
+		cfg, err := loadFlags()
+		if err != nil {
+			return cfg, err
+		}
+
+		currentMu.Lock()
+		current = cfg
+		currentMu.Unlock()
+		return cfg, nil
+	}
+}
+
+// registerFlags 在 flags 上注册配置参数，返回的函数按优先级加载配置并校验
+func registerFlags(flags *flag.FlagSet) func() (*AppConfig, error) {
+	cfg := Default()
+	all := fields(cfg)
+
+	// 先加载 .env，其中也可以设置 CONFIG_FILE
+	envFileErrs := loadEnvFiles()
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "配置文件（.yaml、.yml 或 .toml），也可通过环境变量 CONFIG_FILE 指定")
+	flagValues := make(map[string]string)
+	for _, f := range all {
+		flags.Var(&flagValue{field: f, values: flagValues}, f.key, "环境变量 "+f.env)
+	}
+	// 优先级从低到高：默认值、配置文件、环境变量（含 .env、.env.local）、命令行参数
+	return func() (*AppConfig, error) {
+		errs := append(Errors(nil), envFileErrs...)
+		if *configFile != "" {
+			errs = append(errs, loadFile(*configFile, all)...)
+		}
+		for _, f := range all {
+			if raw := os.Getenv(f.env); raw != "" {
+				if err := f.set(raw); err != nil {
+					errs = append(errs, fmt.Errorf("环境变量 %s: %w", f.env, err))
+				}
+			}
+		}
+		for _, f := range all {
+			if raw, ok := flagValues[f.key]; ok {
+				if err := f.set(raw); err != nil {
+					errs = append(errs, fmt.Errorf("参数 -%s: %w", f.key, err))
+				}
+			}
+		}
+
+		cfg.applyDriverDefaults()
+		errs = append(errs, cfg.validate()...)
+		if len(errs) > 0 {
+			return cfg, errs
+		}
+		return cfg, nil
+	}
+}
+
+// loadFile 读取 YAML 或 TOML 配置文件，按扩展名判断格式，文件中的键与配置项的 yaml 标签一致
+func loadFile(path string, all []*field) Errors {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Errors{fmt.Errorf("读取配置文件失败: %w", err)}
+	}
+
+	values := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &values)
+	case ".toml":
+		err = toml.Unmarshal(content, &values)
+	default:
+		return Errors{fmt.Errorf("不支持的配置文件格式 %s，可选 .yaml、.yml、.toml", path)}
+	}
+	if err != nil {
+		return Errors{fmt.Errorf("解析配置文件 %s 失败: %w", path, err)}
+	}
+
+	flat := make(map[string]interface{})
+	flatten(values, "", all, flat)
+
+	var errs Errors
+	known := make(map[string]bool, len(all))
+	for _, f := range all {
+		known[f.key] = true
+		value, ok := flat[f.key]
+		if !ok || value == nil {
+			continue
+		}
+		if err := f.setFileValue(value); err != nil {
+			errs = append(errs, fmt.Errorf("配置文件 %s: %w", f.key, err))
+		}
+	}
+	for _, key := range sortedKeys(flat) {
+		if !known[key] {
+			errs = append(errs, fmt.Errorf("配置文件中有未知的配置项 %s", key))
+		}
+	}
+	return errs
+}
+
+// flatten 把嵌套的配置展开为以 . 连接的键，映射类型的配置项（如 request.route_timeouts、log.levels）保持为映射
+func flatten(values map[string]interface{}, prefix string, all []*field, out map[string]interface{}) {
+	for key, value := range values {
+		key = prefix + key
+		nested, ok := value.(map[string]interface{})
+		if ok && !isMapField(key, all) {
+			flatten(nested, key+".", all, out)
+			continue
+		}
+		out[key] = value
+	}
+}
+
+// isMapField key 是否为映射类型的配置项
+func isMapField(key string, all []*field) bool {
+	for _, f := range all {
+		if f.key == key {
+			return isMapType(f.value.Type())
+		}
+	}
+	return false
+}
+
+// isMapType 是否为映射类型的配置项
+func isMapType(t reflect.Type) bool {
+	return t == durationMapType || t == stringMapType
+}
+
+// sortedKeys 按字母顺序返回映射的键
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Redacted 返回隐藏了密码、密钥等敏感信息的配置副本，用于输出
+// 敏感的映射类型配置项（如 OTLP 请求头）保留键，隐藏值
+func (c *AppConfig) Redacted() *AppConfig {
+	copied := *c
+	for _, f := range fields(&copied) {
+		if !f.secret {
+			continue
+		}
+		if f.value.Type() == stringMapType {
+			m := make(map[string]string, f.value.Len())
+			for _, key := range f.value.MapKeys() {
+				m[key.String()] = redacted
+			}
+			f.value.Set(reflect.ValueOf(m))
+		} else if f.value.String() != "" {
+			f.value.SetString(redacted)
+		}
+	}
+	return &copied
+}
+
+// Format 按格式输出配置：yaml（配置文件格式）或 env（环境变量格式），敏感信息已隐藏
+func (c *AppConfig) Format(format string) ([]byte, error) {
+	redactedConfig := c.Redacted()
+	switch format {
+	case "yaml":
+		return yaml.Marshal(redactedConfig)
+	case "env":
+		var b strings.Builder
+		for _, f := range fields(redactedConfig) {
+			fmt.Fprintf(&b, "%s=%s\n", f.env, f.String())
+		}
+		return []byte(b.String()), nil
+	default:
+		return nil, fmt.Errorf("不支持的输出格式 %s，可选 yaml、env", format)
+	}
+}
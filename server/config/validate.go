@@ -0,0 +1,201 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// 可选值
+var (
+	serverModes = []string{"debug", "release", "test"}
+	dbDrivers   = []string{"mysql", "postgres", "sqlite"}
+	logLevels   = []string{"debug", "info", "warn", "error"}
+	logFormats  = []string{"text", "json"}
+	engines     = []string{"bleve", "mysql"}
+	exporters   = []string{"none", "otlp", "console", "file"}
+)
+
+// validator 收集校验错误，错误信息中同时给出配置项和环境变量名
+type validator struct {
+	envs map[string]string
+	errs Errors
+}
+
+// errorf 记录配置项 key 的错误
+func (v *validator) errorf(key, format string, args ...interface{}) {
+	v.errs = append(v.errs, fmt.Errorf("%s（%s）: %s", key, v.envs[key], fmt.Sprintf(format, args...)))
+}
+
+// oneOf 值必须是可选值之一
+func (v *validator) oneOf(key, value string, options []string) {
+	for _, option := range options {
+		if value == option {
+			return
+		}
+	}
+	v.errorf(key, "不支持 %q，可选值: %s", value, strings.Join(options, "、"))
+}
+
+// required 值不能为空
+func (v *validator) required(key, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.errorf(key, "不能为空")
+	}
+}
+
+// nonNegative 时长不能为负数
+func (v *validator) nonNegative(key string, d time.Duration) {
+	if d < 0 {
+		v.errorf(key, "不能为负数")
+	}
+}
+
+// fileExists 配置了文件路径时文件必须存在
+func (v *validator) fileExists(key, path string) {
+	if path == "" {
+		return
+	}
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		v.errorf(key, "文件 %s 不存在", path)
+	}
+}
+
+// validate 校验配置，返回全部错误
+func (c *AppConfig) validate() Errors {
+	v := &validator{envs: make(map[string]string)}
+	for _, f := range fields(c) {
+		v.envs[f.key] = f.env
+	}
+
+	// HTTP 服务
+	v.required("server.port", c.Server.Port)
+	v.oneOf("server.mode", c.Server.Mode, serverModes)
+	v.nonNegative("server.read_header_timeout", c.Server.ReadHeaderTimeout)
+	v.nonNegative("server.read_timeout", c.Server.ReadTimeout)
+	v.nonNegative("server.write_timeout", c.Server.WriteTimeout)
+	v.nonNegative("server.idle_timeout", c.Server.IdleTimeout)
+	v.nonNegative("server.shutdown_timeout", c.Server.ShutdownTimeout)
+	if c.Server.MaxHeaderBytes <= 0 {
+		v.errorf("server.max_header_bytes", "必须大于 0")
+	}
+	if (c.Server.TLSCertFile == "") != (c.Server.TLSKeyFile == "") {
+		v.errorf("server.tls_key_file", "启用 HTTPS 需要同时配置证书和私钥")
+	}
+	v.fileExists("server.tls_cert_file", c.Server.TLSCertFile)
+	v.fileExists("server.tls_key_file", c.Server.TLSKeyFile)
+
+	// 数据库
+	v.oneOf("db.driver", c.DB.Driver, dbDrivers)
+	if c.DB.Driver == "sqlite" {
+		v.required("db.path", c.DB.Path)
+	} else {
+		v.required("db.host", c.DB.Host)
+		v.required("db.name", c.DB.Name)
+	}
+	if c.DB.MaxOpenConns < 0 {
+		v.errorf("db.max_open_conns", "不能为负数")
+	}
+	if c.DB.MaxIdleConns < 0 {
+		v.errorf("db.max_idle_conns", "不能为负数")
+	} else if c.DB.MaxOpenConns > 0 && c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		v.errorf("db.max_idle_conns", "不能大于最大连接数 %d", c.DB.MaxOpenConns)
+	}
+	v.nonNegative("db.conn_max_lifetime", c.DB.ConnMaxLifetime)
+	v.nonNegative("db.conn_max_idle_time", c.DB.ConnMaxIdleTime)
+
+	// JWT
+	v.required("jwt.secret_key", c.JWT.SecretKey)
+	if c.Server.Mode == "release" && c.JWT.SecretKey == DefaultJWTSecretKey {
+		v.errorf("jwt.secret_key", "release 模式下不能使用默认密钥")
+	}
+	if c.JWT.TokenExpiry <= 0 {
+		v.errorf("jwt.token_expiry", "必须大于 0")
+	}
+	if c.JWT.RefreshExpiry < c.JWT.TokenExpiry {
+		v.errorf("jwt.refresh_expiry", "不能小于访问令牌有效期 %s", c.JWT.TokenExpiry)
+	}
+	v.required("jwt.issuer", c.JWT.Issuer)
+
+	// 请求
+	v.nonNegative("request.timeout", c.Request.Timeout)
+	for route, timeout := range c.Request.RouteTimeouts {
+		if !strings.HasPrefix(route, "/") {
+			v.errorf("request.route_timeouts", "路由前缀 %q 必须以 / 开头", route)
+		}
+		if timeout < 0 {
+			v.errorf("request.route_timeouts", "路由 %s 的超时时间不能为负数", route)
+		}
+	}
+
+	// 日志
+	v.oneOf("log.level", c.Log.Level, logLevels)
+	v.oneOf("log.format", c.Log.Format, logFormats)
+	for component, level := range c.Log.Levels {
+		v.oneOf("log.levels", level, logLevels)
+		if component == "" {
+			v.errorf("log.levels", "组件名不能为空")
+		}
+	}
+	v.nonNegative("log.slow_query_threshold", c.Log.SlowQueryThreshold)
+
+	// 跨域
+	for _, origin := range c.CORS.AllowOrigins {
+		if origin == "*" {
+			if c.CORS.AllowCredentials {
+				v.errorf("cors.allow_origins", "允许携带 Cookie 时不能使用 *，请列出具体来源")
+			}
+		} else if !strings.HasPrefix(origin, "http://") && !strings.HasPrefix(origin, "https://") {
+			v.errorf("cors.allow_origins", "来源 %q 必须以 http:// 或 https:// 开头", origin)
+		}
+	}
+	v.nonNegative("cors.max_age", c.CORS.MaxAge)
+
+	// 监控指标
+	if c.Metrics.Enabled {
+		if !strings.HasPrefix(c.Metrics.Path, "/") {
+			v.errorf("metrics.path", "必须以 / 开头")
+		} else if c.Metrics.Port == "" && strings.HasPrefix(c.Metrics.Path, "/api/") {
+			v.errorf("metrics.path", "与接口共用端口时不能使用 /api/ 下的路径")
+		}
+		if c.Metrics.Port != "" && c.Metrics.Port == c.Server.Port {
+			v.errorf("metrics.port", "不能与服务端口 %s 相同，共用端口时留空", c.Server.Port)
+		}
+		// 与接口共用端口时指标对外公开，release 模式下要求访问令牌
+		if c.Server.Mode == "release" && c.Metrics.Port == "" && c.Metrics.Token == "" {
+			v.errorf("metrics.token", "release 模式下与接口共用端口时必须配置访问令牌，或通过 metrics.port 在独立端口输出")
+		}
+	}
+
+	// 链路追踪
+	v.oneOf("tracing.exporter", c.Tracing.Exporter, exporters)
+	if c.Tracing.Enabled() {
+		v.required("tracing.service_name", c.Tracing.ServiceName)
+		if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+			v.errorf("tracing.sample_ratio", "必须在 0 到 1 之间")
+		}
+	}
+	switch c.Tracing.Exporter {
+	case "otlp":
+		if !strings.HasPrefix(c.Tracing.OTLPEndpoint, "http://") && !strings.HasPrefix(c.Tracing.OTLPEndpoint, "https://") {
+			v.errorf("tracing.otlp_endpoint", "必须以 http:// 或 https:// 开头")
+		}
+	case "file":
+		v.required("tracing.file", c.Tracing.File)
+	}
+
+	// 搜索
+	v.oneOf("search.engine", c.Search.Engine, engines)
+	if c.Search.Engine == "bleve" {
+		v.required("search.index_path", c.Search.IndexPath)
+	}
+	if c.Search.Engine == "mysql" && c.DB.Driver != "mysql" {
+		v.errorf("search.engine", "mysql 搜索引擎需要使用 MySQL 数据库，当前数据库: %s", c.DB.Driver)
+	}
+
+	// 成绩单
+	v.fileExists("transcript.font_path", c.Transcript.FontPath)
+
+	return v.errs
+}
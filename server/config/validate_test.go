@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// release 模式下与接口共用端口输出监控指标时必须配置令牌
+func TestValidateMetricsToken(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		mode    string
+		enabled bool
+		port    string
+		token   string
+		wantErr bool
+	}{
+		{"关闭", "release", false, "", "", false},
+		{"debug 模式不要求令牌", "debug", true, "", "", false},
+		{"release 模式缺少令牌", "release", true, "", "", true},
+		{"release 模式配置了令牌", "release", true, "", "scrape-token", false},
+		{"release 模式使用独立端口", "release", true, ":9464", "", false},
+	} {
+		cfg := Default()
+		cfg.Server.Mode = tc.mode
+		cfg.Metrics.Enabled = tc.enabled
+		cfg.Metrics.Port = tc.port
+		cfg.Metrics.Token = tc.token
+
+		hasErr := false
+		for _, err := range cfg.validate() {
+			if strings.HasPrefix(err.Error(), "metrics.token") {
+				hasErr = true
+			}
+		}
+		if hasErr != tc.wantErr {
+			t.Errorf("%s: metrics.token 校验错误为 %v，期望 %v", tc.name, hasErr, tc.wantErr)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AdvisorController 导师分配控制器
+type AdvisorController struct {
+	advisorService *service.AdvisorService
+}
+
+// NewAdvisorController 创建导师分配控制器
+func NewAdvisorController(advisorService *service.AdvisorService) *AdvisorController {
+	return &AdvisorController{
+		advisorService: advisorService,
+	}
+}
+
+// Assign 为学生分配或更换导师，更换时上一位导师的分配在新导师的开始日期结束
+func (a *AdvisorController) Assign(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的学生ID")
+		return
+	}
+
+	var req dto.AdvisorAssignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	assignment, err := a.advisorService.Assign(c.Request.Context(), studentID, req.TeacherID, parseDateOrToday(req.StartDate), req.Reason, currentUserID(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "学生不存在")
+		case service.IsAdvisorError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "分配导师失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "分配成功", convertToAdvisorAssignmentResponse(assignment))
+}
+
+// End 结束学生当前的导师分配
+func (a *AdvisorController) End(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的学生ID")
+		return
+	}
+
+	var req dto.AdvisorEndRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	assignment, err := a.advisorService.End(c.Request.Context(), studentID, parseDateOrToday(req.EndDate), req.Reason, currentUserID(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "学生不存在")
+		case service.IsAdvisorError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "结束导师分配失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "已结束导师分配", convertToAdvisorAssignmentResponse(assignment))
+}
+
+// History 获取学生的导师分配记录，current 标记当天有效的分配
+func (a *AdvisorController) History(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的学生ID")
+		return
+	}
+
+	assignments, err := a.advisorService.GetHistory(c.Request.Context(), studentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "学生不存在")
+		} else {
+			utils.InternalError(c, "获取导师分配记录失败: "+err.Error())
+		}
+		return
+	}
+
+	responseList := []*dto.AdvisorAssignmentResponse{}
+	for _, assignment := range assignments {
+		responseList = append(responseList, convertToAdvisorAssignmentResponse(assignment))
+	}
+	utils.Success(c, responseList)
+}
+
+// parseDateOrToday 解析已通过 datetime=2006-01-02 校验的日期，为空时返回当天
+func parseDateOrToday(value string) time.Time {
+	if value == "" {
+		return time.Now()
+	}
+	date, _ := time.ParseInLocation("2006-01-02", value, time.Local)
+	return date
+}
+
+// 转换为导师分配记录响应DTO
+func convertToAdvisorAssignmentResponse(assignment *model.AdvisorAssignment) *dto.AdvisorAssignmentResponse {
+	response := &dto.AdvisorAssignmentResponse{
+		ID:        assignment.ID,
+		StudentID: assignment.StudentID,
+		TeacherID: assignment.TeacherID,
+		StartDate: assignment.StartDate.Format("2006-01-02"),
+		Current:   service.IsAssignmentActive(assignment, time.Now()),
+		Reason:    assignment.Reason,
+		EndReason: assignment.EndReason,
+		CreatedAt: assignment.CreatedAt,
+		UpdatedAt: assignment.UpdatedAt,
+		CreatedBy: assignment.CreatedBy,
+		UpdatedBy: assignment.UpdatedBy,
+	}
+	if assignment.EndDate != nil {
+		endDate := assignment.EndDate.Format("2006-01-02")
+		response.EndDate = &endDate
+	}
+	if assignment.Teacher != nil {
+		response.TeacherName = assignment.Teacher.Name
+	}
+	return response
+}
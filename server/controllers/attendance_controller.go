@@ -0,0 +1,398 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AttendanceController 课次与考勤控制器
+type AttendanceController struct {
+	attendanceService *service.AttendanceService
+}
+
+// NewAttendanceController 创建课次与考勤控制器
+func NewAttendanceController(attendanceService *service.AttendanceService) *AttendanceController {
+	return &AttendanceController{
+		attendanceService: attendanceService,
+	}
+}
+
+// CreateSession 创建课次
+func (a *AttendanceController) CreateSession(c *gin.Context) {
+	var req dto.SessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	session := &model.ClassSession{SectionID: req.SectionID}
+	if err := applySessionRequest(session, &req); err != nil {
+		utils.ParamError(c, err.Error())
+		return
+	}
+	uid := currentUserID(c)
+	session.CreatedBy = uid
+	session.UpdatedBy = uid
+
+	if err := a.attendanceService.CreateSession(c.Request.Context(), session); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.BusinessError(c, "教学班不存在")
+		case service.IsAttendanceError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "创建课次失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "创建成功", convertToSessionResponse(session))
+}
+
+// GenerateSessions 按教学班的上课时间为日期范围内的每次课生成课次
+func (a *AttendanceController) GenerateSessions(c *gin.Context) {
+	sectionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的教学班ID")
+		return
+	}
+
+	var req dto.GenerateSessionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+	from, _ := time.ParseInLocation("2006-01-02", req.StartDate, time.Local)
+	to, _ := time.ParseInLocation("2006-01-02", req.EndDate, time.Local)
+
+	sessions, err := a.attendanceService.GenerateSessions(c.Request.Context(), sectionID, from, to, currentUserID(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "教学班不存在")
+		case service.IsAttendanceError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "生成课次失败: "+err.Error())
+		}
+		return
+	}
+
+	responseList := []*dto.SessionResponse{}
+	for _, session := range sessions {
+		responseList = append(responseList, convertToSessionResponse(session))
+	}
+	utils.SuccessWithMsg(c, "已生成 "+strconv.Itoa(len(sessions))+" 个课次", responseList)
+}
+
+// GetSession 获取课次详情
+func (a *AttendanceController) GetSession(c *gin.Context) {
+	session, ok := a.findSession(c)
+	if !ok {
+		return
+	}
+	utils.Success(c, convertToSessionResponse(session))
+}
+
+// UpdateSession 更新课次的日期、时间和授课内容，不能修改所属教学班
+func (a *AttendanceController) UpdateSession(c *gin.Context) {
+	session, ok := a.findSession(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+	if req.SectionID != session.SectionID {
+		utils.BusinessError(c, "不能修改课次所属教学班")
+		return
+	}
+	if err := applySessionRequest(session, &req); err != nil {
+		utils.ParamError(c, err.Error())
+		return
+	}
+	session.UpdatedBy = currentUserID(c)
+
+	if err := a.attendanceService.UpdateSession(c.Request.Context(), session); err != nil {
+		if service.IsAttendanceError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "更新课次失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "更新成功", convertToSessionResponse(session))
+}
+
+// DeleteSession 删除课次及其考勤记录
+func (a *AttendanceController) DeleteSession(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的课次ID")
+		return
+	}
+
+	if err := a.attendanceService.DeleteSession(c.Request.Context(), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "课次不存在")
+		} else {
+			utils.InternalError(c, "删除课次失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "删除成功", nil)
+}
+
+// ListSessions 获取课次列表，支持按教学班、日期范围筛选
+func (a *AttendanceController) ListSessions(c *gin.Context) {
+	pageQuery := utils.GetPageQuery(c)
+
+	filters := make(map[string]interface{})
+	if sectionID := c.Query("section_id"); sectionID != "" {
+		id, err := strconv.ParseInt(sectionID, 10, 64)
+		if err != nil {
+			utils.ParamError(c, "无效的section_id")
+			return
+		}
+		filters["section_id"] = id
+	}
+	for _, key := range []string{"date_from", "date_to"} {
+		if value := c.Query(key); value != "" {
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				utils.ParamError(c, "无效的"+key+"，格式为 2006-01-02")
+				return
+			}
+			filters[key] = value
+		}
+	}
+
+	sessions, total, err := a.attendanceService.GetSessionList(c.Request.Context(), pageQuery.Page, pageQuery.PageSize, filters)
+	if err != nil {
+		utils.InternalError(c, "获取课次列表失败: "+err.Error())
+		return
+	}
+
+	responseList := []*dto.SessionResponse{}
+	for _, session := range sessions {
+		responseList = append(responseList, convertToSessionResponse(session))
+	}
+	utils.Success(c, &dto.SessionListResponse{
+		List:  responseList,
+		Total: total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	})
+}
+
+// GetAttendance 获取课次的点名册和考勤记录
+func (a *AttendanceController) GetAttendance(c *gin.Context) {
+	session, ok := a.findSession(c)
+	if !ok {
+		return
+	}
+
+	roster, err := a.attendanceService.GetRoster(c.Request.Context(), session.ID)
+	if err != nil {
+		utils.InternalError(c, "获取考勤记录失败: "+err.Error())
+		return
+	}
+
+	utils.Success(c, convertToSessionAttendanceResponse(session, roster))
+}
+
+// MarkAttendance 批量记录课次考勤，已有记录的学生会被更新
+func (a *AttendanceController) MarkAttendance(c *gin.Context) {
+	session, ok := a.findSession(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	marks := make([]*service.AttendanceMark, 0, len(req.Records))
+	for _, record := range req.Records {
+		marks = append(marks, &service.AttendanceMark{
+			StudentID: record.StudentID,
+			Status:    record.Status,
+			Remarks:   record.Remarks,
+		})
+	}
+
+	roster, err := a.attendanceService.MarkAttendance(c.Request.Context(), session.ID, marks, currentUserID(c))
+	if err != nil {
+		if service.IsAttendanceError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "记录考勤失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "考勤已记录", convertToSessionAttendanceResponse(session, roster))
+}
+
+// MarkAll 把课次全部已选学生记为同一考勤状态
+func (a *AttendanceController) MarkAll(c *gin.Context) {
+	session, ok := a.findSession(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AttendanceAllRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	roster, err := a.attendanceService.MarkAll(c.Request.Context(), session.ID, req.Status, req.OnlyUnmarked, currentUserID(c))
+	if err != nil {
+		if service.IsAttendanceError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "记录考勤失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "考勤已记录", convertToSessionAttendanceResponse(session, roster))
+}
+
+// StudentReport 获取学生的考勤统计，支持按学期筛选
+func (a *AttendanceController) StudentReport(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的学生ID")
+		return
+	}
+
+	report, err := a.attendanceService.GetStudentReport(c.Request.Context(), studentID, strings.TrimSpace(c.Query("term")))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "学生不存在")
+		} else {
+			utils.InternalError(c, "获取考勤统计失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.Success(c, report)
+}
+
+// SectionReport 获取教学班的考勤统计
+func (a *AttendanceController) SectionReport(c *gin.Context) {
+	sectionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的教学班ID")
+		return
+	}
+
+	report, err := a.attendanceService.GetSectionReport(c.Request.Context(), sectionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "教学班不存在")
+		} else {
+			utils.InternalError(c, "获取考勤统计失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.Success(c, report)
+}
+
+// findSession 按路径参数获取课次，失败时直接写入响应并返回 false
+func (a *AttendanceController) findSession(c *gin.Context) (*model.ClassSession, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的课次ID")
+		return nil, false
+	}
+
+	session, err := a.attendanceService.GetSessionByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "课次不存在")
+		} else {
+			utils.InternalError(c, "获取课次失败: "+err.Error())
+		}
+		return nil, false
+	}
+	return session, true
+}
+
+// applySessionRequest 把请求中的课次属性写入模型，所属教学班单独处理
+func applySessionRequest(session *model.ClassSession, req *dto.SessionRequest) error {
+	date, err := time.ParseInLocation("2006-01-02", req.SessionDate, time.Local)
+	if err != nil {
+		return errors.New("无效的上课日期，格式为 2006-01-02")
+	}
+	session.SessionDate = date
+	session.StartTime = strings.TrimSpace(req.StartTime)
+	session.EndTime = strings.TrimSpace(req.EndTime)
+	session.Topic = req.Topic
+	return nil
+}
+
+// 转换为课次响应DTO
+func convertToSessionResponse(session *model.ClassSession) *dto.SessionResponse {
+	response := &dto.SessionResponse{
+		ID:          session.ID,
+		SectionID:   session.SectionID,
+		SessionDate: session.SessionDate.Format("2006-01-02"),
+		StartTime:   session.StartTime,
+		EndTime:     session.EndTime,
+		Topic:       session.Topic,
+		CreatedAt:   session.CreatedAt,
+		UpdatedAt:   session.UpdatedAt,
+		CreatedBy:   session.CreatedBy,
+		UpdatedBy:   session.UpdatedBy,
+	}
+	if section := session.Section; section != nil {
+		response.Term = section.Term
+		response.SectionNo = section.SectionNo
+		if section.Course != nil {
+			response.CourseCode = section.Course.Code
+			response.CourseTitle = section.Course.Title
+		}
+	}
+	return response
+}
+
+// 转换为课次考勤响应DTO
+func convertToSessionAttendanceResponse(session *model.ClassSession, roster []*service.RosterEntry) *dto.SessionAttendanceResponse {
+	response := &dto.SessionAttendanceResponse{
+		Session: convertToSessionResponse(session),
+		Records: []*dto.AttendanceResponse{},
+	}
+	for _, entry := range roster {
+		item := &dto.AttendanceResponse{
+			StudentID:   entry.StudentID,
+			StudentName: entry.StudentName,
+		}
+		if record := entry.Record; record != nil {
+			item.Status = &record.Status
+			item.Remarks = record.Remarks
+			item.MarkedBy = record.MarkedBy
+			item.MarkedAt = record.MarkedAt
+		}
+		response.Records = append(response.Records, item)
+	}
+	return response
+}
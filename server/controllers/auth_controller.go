@@ -32,7 +32,7 @@ func (a *AuthController) Login(c *gin.Context) {
 	}
 
 	// 验证用户是否存在且密码正确
-	user, err := a.userService.Login(req.Email, req.Password)
+	user, err := a.userService.Login(c.Request.Context(), req.Email, req.Password)
 	if err != nil {
 		utils.Unauthorized(c, "邮箱或密码错误")
 		return
@@ -93,7 +93,7 @@ func (a *AuthController) Me(c *gin.Context) {
 	}
 
 	// 获取用户信息，需要将userID转换为int64类型
-	user, err := a.userService.GetUserByID(int64(userID.(uint)))
+	user, err := a.userService.GetUserByID(c.Request.Context(), int64(userID.(uint)))
 	if err != nil {
 		utils.InternalError(c, "获取用户信息失败")
 		return
@@ -0,0 +1,248 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ChangeRequestController 学生信息变更申请控制器
+type ChangeRequestController struct {
+	changeRequestService *service.StudentChangeRequestService
+}
+
+// NewChangeRequestController 创建学生信息变更申请控制器
+func NewChangeRequestController(changeRequestService *service.StudentChangeRequestService) *ChangeRequestController {
+	return &ChangeRequestController{
+		changeRequestService: changeRequestService,
+	}
+}
+
+// Create 提交学生信息变更申请，审批通过后生效
+func (r *ChangeRequestController) Create(c *gin.Context) {
+	var req dto.ChangeRequestCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.Unauthorized(c, "未登录")
+		return
+	}
+
+	change := &service.StudentChange{
+		Name:           req.Changes.Name,
+		Gender:         req.Changes.Gender,
+		Birthday:       req.Changes.Birthday,
+		Phone:          req.Changes.Phone,
+		UniversityID:   req.Changes.UniversityID,
+		Major:          req.Changes.Major,
+		MajorID:        req.Changes.MajorID,
+		Education:      req.Changes.Education,
+		GraduationYear: req.Changes.GraduationYear,
+		Remarks:        req.Changes.Remarks,
+		Avatar:         req.Changes.Avatar,
+	}
+	if req.Changes.Status != nil {
+		change.Status = &service.StudentStatusChange{
+			Status:        req.Changes.Status.Status,
+			EffectiveDate: req.Changes.Status.EffectiveDate,
+		}
+	}
+
+	request, err := r.changeRequestService.Submit(c.Request.Context(), req.StudentID, change, req.Reason, int64(userID.(uint)))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "学生不存在")
+		case service.IsChangeRequestError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "提交变更申请失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "提交变更申请成功，等待审批", convertToChangeRequestResponse(request))
+}
+
+// Get 获取变更申请详情，普通用户只能查看自己提交的申请
+func (r *ChangeRequestController) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的申请ID")
+		return
+	}
+
+	request, err := r.changeRequestService.GetChangeRequestByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "变更申请不存在")
+		} else {
+			utils.InternalError(c, "获取变更申请失败: "+err.Error())
+		}
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	if !isAdminRequest(c) && request.RequesterID != int64(userID.(uint)) {
+		utils.NotFound(c, "变更申请不存在")
+		return
+	}
+
+	utils.Success(c, convertToChangeRequestResponse(request))
+}
+
+// List 获取变更申请列表，支持按状态、学生、申请人、审批人筛选；普通用户只能查看自己提交的申请
+func (r *ChangeRequestController) List(c *gin.Context) {
+	pageQuery := utils.GetPageQuery(c)
+
+	filters := make(map[string]interface{})
+	if status := c.Query("status"); status != "" {
+		filters["status"] = status
+	}
+	for _, key := range []string{"student_id", "requester_id", "reviewer_id"} {
+		if value := c.Query(key); value != "" {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				utils.ParamError(c, "无效的"+key)
+				return
+			}
+			filters[key] = id
+		}
+	}
+	if !isAdminRequest(c) {
+		userID, _ := c.Get("user_id")
+		filters["requester_id"] = int64(userID.(uint))
+	}
+
+	requests, total, err := r.changeRequestService.GetChangeRequestList(c.Request.Context(), pageQuery.Page, pageQuery.PageSize, filters)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidChangeRequestStatus) {
+			utils.ParamError(c, err.Error())
+		} else {
+			utils.InternalError(c, "获取变更申请列表失败: "+err.Error())
+		}
+		return
+	}
+
+	responseList := []*dto.ChangeRequestResponse{}
+	for _, request := range requests {
+		responseList = append(responseList, convertToChangeRequestResponse(request))
+	}
+	utils.Success(c, &dto.ChangeRequestListResponse{
+		List:  responseList,
+		Total: total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	})
+}
+
+// Cancel 申请人撤回待审批的变更申请
+func (r *ChangeRequestController) Cancel(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的申请ID")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	request, err := r.changeRequestService.Cancel(c.Request.Context(), id, int64(userID.(uint)))
+	if err != nil {
+		r.handleReviewError(c, err, "撤回变更申请失败: ")
+		return
+	}
+
+	utils.SuccessWithMsg(c, "撤回变更申请成功", convertToChangeRequestResponse(request))
+}
+
+// Approve 审批通过变更申请并应用变更，审批人不能是申请人
+func (r *ChangeRequestController) Approve(c *gin.Context) {
+	r.review(c, true)
+}
+
+// Reject 驳回变更申请，需要填写审批意见
+func (r *ChangeRequestController) Reject(c *gin.Context) {
+	r.review(c, false)
+}
+
+// review 审批变更申请
+func (r *ChangeRequestController) review(c *gin.Context, approve bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的申请ID")
+		return
+	}
+
+	var req dto.ChangeRequestReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	reviewerID := int64(userID.(uint))
+
+	var request *model.StudentChangeRequest
+	if approve {
+		request, err = r.changeRequestService.Approve(c.Request.Context(), id, reviewerID, req.Comment)
+	} else {
+		request, err = r.changeRequestService.Reject(c.Request.Context(), id, reviewerID, req.Comment)
+	}
+	if err != nil {
+		r.handleReviewError(c, err, "审批变更申请失败: ")
+		return
+	}
+
+	msg := "变更申请已驳回"
+	if approve {
+		msg = "变更申请已通过，变更已生效"
+	}
+	utils.SuccessWithMsg(c, msg, convertToChangeRequestResponse(request))
+}
+
+// handleReviewError 处理审批、撤回的错误
+func (r *ChangeRequestController) handleReviewError(c *gin.Context, err error, prefix string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		utils.NotFound(c, "变更申请或学生不存在")
+	case errors.Is(err, service.ErrChangeRequestNotRequester):
+		utils.Forbidden(c, err.Error())
+	case service.IsChangeRequestError(err):
+		utils.BusinessError(c, err.Error())
+	default:
+		utils.InternalError(c, prefix+err.Error())
+	}
+}
+
+// isAdminRequest 当前用户是否为管理员(role=1)
+func isAdminRequest(c *gin.Context) bool {
+	role, exists := c.Get("role")
+	return exists && role.(int8) == 1
+}
+
+// convertToChangeRequestResponse 转换为变更申请响应DTO
+func convertToChangeRequestResponse(request *model.StudentChangeRequest) *dto.ChangeRequestResponse {
+	return &dto.ChangeRequestResponse{
+		ID:            request.ID,
+		StudentID:     request.StudentID,
+		Changes:       json.RawMessage(request.Changes),
+		Reason:        request.Reason,
+		Status:        request.Status,
+		RequesterID:   request.RequesterID,
+		ReviewerID:    request.ReviewerID,
+		ReviewComment: request.ReviewComment,
+		ReviewedAt:    request.ReviewedAt,
+		CreatedAt:     request.CreatedAt,
+		UpdatedAt:     request.UpdatedAt,
+	}
+}
@@ -0,0 +1,243 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CollegeController 院系控制器
+type CollegeController struct {
+	collegeService    *service.CollegeService
+	universityService *service.UniversityService
+}
+
+// NewCollegeController 创建院系控制器
+func NewCollegeController(collegeService *service.CollegeService, universityService *service.UniversityService) *CollegeController {
+	return &CollegeController{
+		collegeService:    collegeService,
+		universityService: universityService,
+	}
+}
+
+// Create 创建院系
+func (co *CollegeController) Create(c *gin.Context) {
+	var req dto.CollegeRequest
+
+	// 绑定请求参数
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	// 检查所属大学是否存在
+	if !co.checkUniversity(c, req.UniversityID) {
+		return
+	}
+
+	// 检查同一大学下院系名称是否已存在
+	exists, err := co.collegeService.CheckCollegeNameExists(c.Request.Context(), req.UniversityID, req.Name, 0)
+	if err != nil {
+		utils.InternalError(c, "检查院系名称失败: "+err.Error())
+		return
+	}
+	if exists {
+		utils.BusinessError(c, "该大学下已存在同名院系")
+		return
+	}
+
+	// 创建院系对象
+	college := &model.College{
+		UniversityID: req.UniversityID,
+		Name:         req.Name,
+		Code:         req.Code,
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("user_id")
+	if exists {
+		uid := int64(userID.(uint))
+		college.CreatedBy = &uid
+		college.UpdatedBy = &uid
+	}
+
+	// 创建院系
+	if err := co.collegeService.CreateCollege(c.Request.Context(), college); err != nil {
+		utils.InternalError(c, "创建院系失败: "+err.Error())
+		return
+	}
+
+	utils.SuccessWithMsg(c, "创建成功", convertToCollegeResponse(college))
+}
+
+// Get 获取院系详情
+func (co *CollegeController) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的院系ID")
+		return
+	}
+
+	college, err := co.collegeService.GetCollegeByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "院系不存在")
+		} else {
+			utils.InternalError(c, "获取院系失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.Success(c, convertToCollegeResponse(college))
+}
+
+// Update 更新院系
+func (co *CollegeController) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的院系ID")
+		return
+	}
+
+	// 检查院系是否存在
+	existingCollege, err := co.collegeService.GetCollegeByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "院系不存在")
+		} else {
+			utils.InternalError(c, "获取院系失败: "+err.Error())
+		}
+		return
+	}
+
+	// 绑定请求参数
+	var req dto.CollegeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	// 院系不能移动到其他大学，否则其下专业的归属会不一致
+	if req.UniversityID != existingCollege.UniversityID {
+		utils.BusinessError(c, "不能修改院系所属大学")
+		return
+	}
+
+	// 如果要修改名称，检查新名称是否与同一大学下的其他院系冲突
+	if req.Name != existingCollege.Name {
+		exists, err := co.collegeService.CheckCollegeNameExists(c.Request.Context(), existingCollege.UniversityID, req.Name, id)
+		if err != nil {
+			utils.InternalError(c, "检查院系名称失败: "+err.Error())
+			return
+		}
+		if exists {
+			utils.BusinessError(c, "该大学下已存在同名院系")
+			return
+		}
+	}
+
+	// 更新院系属性
+	existingCollege.Name = req.Name
+	existingCollege.Code = req.Code
+
+	// 获取当前用户ID，设置更新者
+	userID, exists := c.Get("user_id")
+	if exists {
+		uid := int64(userID.(uint))
+		existingCollege.UpdatedBy = &uid
+	}
+
+	// 更新院系
+	if err := co.collegeService.UpdateCollege(c.Request.Context(), existingCollege); err != nil {
+		utils.InternalError(c, "更新院系失败: "+err.Error())
+		return
+	}
+
+	utils.SuccessWithMsg(c, "更新成功", convertToCollegeResponse(existingCollege))
+}
+
+// Delete 删除院系
+func (co *CollegeController) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的院系ID")
+		return
+	}
+
+	// 检查院系是否存在
+	_, err = co.collegeService.GetCollegeByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "院系不存在")
+		} else {
+			utils.InternalError(c, "获取院系失败: "+err.Error())
+		}
+		return
+	}
+
+	// 删除院系
+	if err := co.collegeService.DeleteCollege(c.Request.Context(), id); err != nil {
+		if errors.Is(err, service.ErrCollegeHasMajors) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "删除院系失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "删除成功", nil)
+}
+
+// List 获取大学下的院系列表
+func (co *CollegeController) List(c *gin.Context) {
+	universityID, err := strconv.ParseInt(c.Query("university_id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "请指定有效的大学ID")
+		return
+	}
+
+	colleges, err := co.collegeService.GetCollegesByUniversity(c.Request.Context(), universityID)
+	if err != nil {
+		utils.InternalError(c, "获取院系列表失败: "+err.Error())
+		return
+	}
+
+	var responseList []*dto.CollegeResponse
+	for _, college := range colleges {
+		responseList = append(responseList, convertToCollegeResponse(college))
+	}
+	utils.Success(c, responseList)
+}
+
+// checkUniversity 检查大学是否存在，不存在时直接写入响应并返回 false
+func (co *CollegeController) checkUniversity(c *gin.Context, universityID int64) bool {
+	if _, err := co.universityService.GetUniversityByID(c.Request.Context(), universityID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.BusinessError(c, "所属大学不存在")
+		} else {
+			utils.InternalError(c, "获取大学失败: "+err.Error())
+		}
+		return false
+	}
+	return true
+}
+
+// 转换为院系响应DTO
+func convertToCollegeResponse(college *model.College) *dto.CollegeResponse {
+	return &dto.CollegeResponse{
+		ID:           college.ID,
+		UniversityID: college.UniversityID,
+		Name:         college.Name,
+		Code:         college.Code,
+		CreatedAt:    college.CreatedAt,
+		UpdatedAt:    college.UpdatedAt,
+		CreatedBy:    college.CreatedBy,
+		UpdatedBy:    college.UpdatedBy,
+	}
+}
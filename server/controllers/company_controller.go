@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CompanyController 企业控制器
+type CompanyController struct {
+	companyService *service.CompanyService
+}
+
+// NewCompanyController 创建企业控制器
+func NewCompanyController(companyService *service.CompanyService) *CompanyController {
+	return &CompanyController{
+		companyService: companyService,
+	}
+}
+
+// Create 创建企业
+func (co *CompanyController) Create(c *gin.Context) {
+	var req dto.CompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	company := &model.Company{}
+	applyCompanyRequest(company, &req)
+	uid := currentUserID(c)
+	company.CreatedBy = uid
+	company.UpdatedBy = uid
+
+	if err := co.companyService.CreateCompany(c.Request.Context(), company); err != nil {
+		if service.IsCompanyError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "创建企业失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "创建成功", convertToCompanyResponse(company))
+}
+
+// Get 获取企业详情
+func (co *CompanyController) Get(c *gin.Context) {
+	company, ok := co.findCompany(c)
+	if !ok {
+		return
+	}
+	utils.Success(c, convertToCompanyResponse(company))
+}
+
+// Update 更新企业
+func (co *CompanyController) Update(c *gin.Context) {
+	company, ok := co.findCompany(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+	applyCompanyRequest(company, &req)
+	company.UpdatedBy = currentUserID(c)
+
+	if err := co.companyService.UpdateCompany(c.Request.Context(), company); err != nil {
+		if service.IsCompanyError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "更新企业失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "更新成功", convertToCompanyResponse(company))
+}
+
+// Delete 删除企业，企业仍有招聘岗位或毕业去向记录时不能删除
+func (co *CompanyController) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的企业ID")
+		return
+	}
+
+	if err := co.companyService.DeleteCompany(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "企业不存在")
+		case service.IsCompanyError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "删除企业失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "删除成功", nil)
+}
+
+// List 获取企业列表，支持按名称、行业、城市筛选
+func (co *CompanyController) List(c *gin.Context) {
+	pageQuery := utils.GetPageQuery(c)
+
+	filters := make(map[string]interface{})
+	for _, key := range []string{"name", "industry", "city"} {
+		if value := strings.TrimSpace(c.Query(key)); value != "" {
+			filters[key] = value
+		}
+	}
+
+	companies, total, err := co.companyService.GetCompanyList(c.Request.Context(), pageQuery.Page, pageQuery.PageSize, filters)
+	if err != nil {
+		utils.InternalError(c, "获取企业列表失败: "+err.Error())
+		return
+	}
+
+	responseList := []*dto.CompanyResponse{}
+	for _, company := range companies {
+		responseList = append(responseList, convertToCompanyResponse(company))
+	}
+	utils.Success(c, &dto.CompanyListResponse{
+		List:  responseList,
+		Total: total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	})
+}
+
+// findCompany 按路径参数获取企业，失败时直接写入响应并返回 false
+func (co *CompanyController) findCompany(c *gin.Context) (*model.Company, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的企业ID")
+		return nil, false
+	}
+
+	company, err := co.companyService.GetCompanyByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "企业不存在")
+		} else {
+			utils.InternalError(c, "获取企业失败: "+err.Error())
+		}
+		return nil, false
+	}
+	return company, true
+}
+
+// applyCompanyRequest 把请求中的企业属性写入模型
+func applyCompanyRequest(company *model.Company, req *dto.CompanyRequest) {
+	company.Name = strings.TrimSpace(req.Name)
+	company.Industry = req.Industry
+	company.City = req.City
+	company.Website = req.Website
+	company.ContactName = req.ContactName
+	company.ContactEmail = req.ContactEmail
+	company.ContactPhone = req.ContactPhone
+	company.Description = req.Description
+}
+
+// 转换为企业响应DTO
+func convertToCompanyResponse(company *model.Company) *dto.CompanyResponse {
+	return &dto.CompanyResponse{
+		ID:           company.ID,
+		Name:         company.Name,
+		Industry:     company.Industry,
+		City:         company.City,
+		Website:      company.Website,
+		ContactName:  company.ContactName,
+		ContactEmail: company.ContactEmail,
+		ContactPhone: company.ContactPhone,
+		Description:  company.Description,
+		CreatedAt:    company.CreatedAt,
+		UpdatedAt:    company.UpdatedAt,
+		CreatedBy:    company.CreatedBy,
+		UpdatedBy:    company.UpdatedBy,
+	}
+}
@@ -0,0 +1,295 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CourseController 课程控制器
+type CourseController struct {
+	courseService       *service.CourseService
+	universityService   *service.UniversityService
+	gradingScaleService *service.GradingScaleService
+}
+
+// NewCourseController 创建课程控制器
+func NewCourseController(courseService *service.CourseService, universityService *service.UniversityService, gradingScaleService *service.GradingScaleService) *CourseController {
+	return &CourseController{
+		courseService:       courseService,
+		universityService:   universityService,
+		gradingScaleService: gradingScaleService,
+	}
+}
+
+// Create 创建课程
+func (co *CourseController) Create(c *gin.Context) {
+	var req dto.CourseRequest
+
+	// 绑定请求参数
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+	req.Code = strings.TrimSpace(req.Code)
+
+	// 检查所属大学是否存在
+	if !co.checkUniversity(c, req.UniversityID) {
+		return
+	}
+
+	// 检查评分标准是否存在
+	if !co.checkGradingScale(c, req.GradingScaleID) {
+		return
+	}
+
+	// 检查同一大学下课程代码是否已存在
+	exists, err := co.courseService.CheckCourseCodeExists(c.Request.Context(), req.UniversityID, req.Code, 0)
+	if err != nil {
+		utils.InternalError(c, "检查课程代码失败: "+err.Error())
+		return
+	}
+	if exists {
+		utils.BusinessError(c, "该大学下已存在相同代码的课程")
+		return
+	}
+
+	// 创建课程对象
+	course := &model.Course{
+		UniversityID:   req.UniversityID,
+		Code:           req.Code,
+		Title:          req.Title,
+		Credits:        req.Credits,
+		Description:    req.Description,
+		GradingScaleID: req.GradingScaleID,
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("user_id")
+	if exists {
+		uid := int64(userID.(uint))
+		course.CreatedBy = &uid
+		course.UpdatedBy = &uid
+	}
+
+	// 创建课程
+	if err := co.courseService.CreateCourse(c.Request.Context(), course); err != nil {
+		utils.InternalError(c, "创建课程失败: "+err.Error())
+		return
+	}
+
+	utils.SuccessWithMsg(c, "创建成功", convertToCourseResponse(course))
+}
+
+// Get 获取课程详情
+func (co *CourseController) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的课程ID")
+		return
+	}
+
+	course, err := co.courseService.GetCourseByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "课程不存在")
+		} else {
+			utils.InternalError(c, "获取课程失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.Success(c, convertToCourseResponse(course))
+}
+
+// Update 更新课程
+func (co *CourseController) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的课程ID")
+		return
+	}
+
+	// 检查课程是否存在
+	existingCourse, err := co.courseService.GetCourseByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "课程不存在")
+		} else {
+			utils.InternalError(c, "获取课程失败: "+err.Error())
+		}
+		return
+	}
+
+	// 绑定请求参数
+	var req dto.CourseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+	req.Code = strings.TrimSpace(req.Code)
+
+	// 课程不能移动到其他大学，否则已选课学生的归属会不一致
+	if req.UniversityID != existingCourse.UniversityID {
+		utils.BusinessError(c, "不能修改课程所属大学")
+		return
+	}
+
+	// 检查评分标准是否存在，已录入的成绩不受影响
+	if !co.checkGradingScale(c, req.GradingScaleID) {
+		return
+	}
+
+	// 如果要修改课程代码，检查新代码是否与同一大学下的其他课程冲突
+	if req.Code != existingCourse.Code {
+		exists, err := co.courseService.CheckCourseCodeExists(c.Request.Context(), existingCourse.UniversityID, req.Code, id)
+		if err != nil {
+			utils.InternalError(c, "检查课程代码失败: "+err.Error())
+			return
+		}
+		if exists {
+			utils.BusinessError(c, "该大学下已存在相同代码的课程")
+			return
+		}
+	}
+
+	// 更新课程属性
+	existingCourse.Code = req.Code
+	existingCourse.Title = req.Title
+	existingCourse.Credits = req.Credits
+	existingCourse.Description = req.Description
+	existingCourse.GradingScaleID = req.GradingScaleID
+
+	// 获取当前用户ID，设置更新者
+	userID, exists := c.Get("user_id")
+	if exists {
+		uid := int64(userID.(uint))
+		existingCourse.UpdatedBy = &uid
+	}
+
+	// 更新课程
+	if err := co.courseService.UpdateCourse(c.Request.Context(), existingCourse); err != nil {
+		utils.InternalError(c, "更新课程失败: "+err.Error())
+		return
+	}
+
+	utils.SuccessWithMsg(c, "更新成功", convertToCourseResponse(existingCourse))
+}
+
+// Delete 删除课程
+func (co *CourseController) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的课程ID")
+		return
+	}
+
+	// 检查课程是否存在
+	_, err = co.courseService.GetCourseByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "课程不存在")
+		} else {
+			utils.InternalError(c, "获取课程失败: "+err.Error())
+		}
+		return
+	}
+
+	// 删除课程
+	if err := co.courseService.DeleteCourse(c.Request.Context(), id); err != nil {
+		if errors.Is(err, service.ErrCourseHasSections) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "删除课程失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "删除成功", nil)
+}
+
+// List 获取课程列表，支持按大学、关键词（课程代码或名称）筛选
+func (co *CourseController) List(c *gin.Context) {
+	pageQuery := utils.GetPageQuery(c)
+
+	filters := make(map[string]interface{})
+	if universityID := c.Query("university_id"); universityID != "" {
+		uID, err := strconv.ParseInt(universityID, 10, 64)
+		if err == nil {
+			filters["university_id"] = uID
+		}
+	}
+	if keyword := strings.TrimSpace(c.Query("keyword")); keyword != "" {
+		filters["keyword"] = keyword
+	}
+
+	courses, total, err := co.courseService.GetCourseList(c.Request.Context(), pageQuery.Page, pageQuery.PageSize, filters)
+	if err != nil {
+		utils.InternalError(c, "获取课程列表失败: "+err.Error())
+		return
+	}
+
+	responseList := []*dto.CourseResponse{}
+	for _, course := range courses {
+		responseList = append(responseList, convertToCourseResponse(course))
+	}
+	utils.Success(c, &dto.CourseListResponse{
+		List:  responseList,
+		Total: total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	})
+}
+
+// checkUniversity 检查大学是否存在，不存在时直接写入响应并返回 false
+func (co *CourseController) checkUniversity(c *gin.Context, universityID int64) bool {
+	if _, err := co.universityService.GetUniversityByID(c.Request.Context(), universityID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.BusinessError(c, "所属大学不存在")
+		} else {
+			utils.InternalError(c, "获取大学失败: "+err.Error())
+		}
+		return false
+	}
+	return true
+}
+
+// checkGradingScale 检查评分标准是否存在，未指定时不检查，不存在时直接写入响应并返回 false
+func (co *CourseController) checkGradingScale(c *gin.Context, scaleID *int64) bool {
+	if scaleID == nil {
+		return true
+	}
+	if _, err := co.gradingScaleService.GetScaleByID(c.Request.Context(), *scaleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.BusinessError(c, "评分标准不存在")
+		} else {
+			utils.InternalError(c, "获取评分标准失败: "+err.Error())
+		}
+		return false
+	}
+	return true
+}
+
+// 转换为课程响应DTO
+func convertToCourseResponse(course *model.Course) *dto.CourseResponse {
+	return &dto.CourseResponse{
+		ID:             course.ID,
+		UniversityID:   course.UniversityID,
+		Code:           course.Code,
+		Title:          course.Title,
+		Credits:        course.Credits,
+		Description:    course.Description,
+		GradingScaleID: course.GradingScaleID,
+		CreatedAt:      course.CreatedAt,
+		UpdatedAt:      course.UpdatedAt,
+		CreatedBy:      course.CreatedBy,
+		UpdatedBy:      course.UpdatedBy,
+	}
+}
@@ -0,0 +1,254 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CourseSectionController 教学班控制器
+type CourseSectionController struct {
+	sectionService *service.CourseSectionService
+	courseService  *service.CourseService
+}
+
+// NewCourseSectionController 创建教学班控制器
+func NewCourseSectionController(sectionService *service.CourseSectionService, courseService *service.CourseService) *CourseSectionController {
+	return &CourseSectionController{
+		sectionService: sectionService,
+		courseService:  courseService,
+	}
+}
+
+// Create 创建教学班
+func (cs *CourseSectionController) Create(c *gin.Context) {
+	var req dto.SectionRequest
+
+	// 绑定请求参数
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	// 检查所属课程是否存在
+	if _, err := cs.courseService.GetCourseByID(c.Request.Context(), req.CourseID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.BusinessError(c, "所属课程不存在")
+		} else {
+			utils.InternalError(c, "获取课程失败: "+err.Error())
+		}
+		return
+	}
+
+	section := &model.CourseSection{CourseID: req.CourseID}
+	applySectionRequest(section, &req)
+
+	// 获取当前用户ID
+	userID, exists := c.Get("user_id")
+	if exists {
+		uid := int64(userID.(uint))
+		section.CreatedBy = &uid
+		section.UpdatedBy = &uid
+	}
+
+	// 创建教学班
+	if err := cs.sectionService.CreateSection(c.Request.Context(), section); err != nil {
+		if service.IsCourseSectionError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "创建教学班失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "创建成功", convertToSectionResponse(section, 0))
+}
+
+// Get 获取教学班详情，包含已选课人数
+func (cs *CourseSectionController) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的教学班ID")
+		return
+	}
+
+	section, err := cs.sectionService.GetSectionByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "教学班不存在")
+		} else {
+			utils.InternalError(c, "获取教学班失败: "+err.Error())
+		}
+		return
+	}
+
+	counts, err := cs.sectionService.CountEnrolled(c.Request.Context(), []*model.CourseSection{section})
+	if err != nil {
+		utils.InternalError(c, "统计选课人数失败: "+err.Error())
+		return
+	}
+
+	utils.Success(c, convertToSectionResponse(section, counts[section.ID]))
+}
+
+// Update 更新教学班
+func (cs *CourseSectionController) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的教学班ID")
+		return
+	}
+
+	// 检查教学班是否存在
+	existingSection, err := cs.sectionService.GetSectionByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "教学班不存在")
+		} else {
+			utils.InternalError(c, "获取教学班失败: "+err.Error())
+		}
+		return
+	}
+
+	// 绑定请求参数
+	var req dto.SectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	existingSection.CourseID = req.CourseID
+	applySectionRequest(existingSection, &req)
+
+	// 获取当前用户ID，设置更新者
+	userID, exists := c.Get("user_id")
+	if exists {
+		uid := int64(userID.(uint))
+		existingSection.UpdatedBy = &uid
+	}
+
+	// 更新教学班
+	if err := cs.sectionService.UpdateSection(c.Request.Context(), existingSection); err != nil {
+		if service.IsCourseSectionError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "更新教学班失败: "+err.Error())
+		}
+		return
+	}
+
+	counts, err := cs.sectionService.CountEnrolled(c.Request.Context(), []*model.CourseSection{existingSection})
+	if err != nil {
+		utils.InternalError(c, "统计选课人数失败: "+err.Error())
+		return
+	}
+
+	utils.SuccessWithMsg(c, "更新成功", convertToSectionResponse(existingSection, counts[existingSection.ID]))
+}
+
+// Delete 删除教学班
+func (cs *CourseSectionController) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的教学班ID")
+		return
+	}
+
+	// 检查教学班是否存在
+	_, err = cs.sectionService.GetSectionByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "教学班不存在")
+		} else {
+			utils.InternalError(c, "获取教学班失败: "+err.Error())
+		}
+		return
+	}
+
+	// 删除教学班
+	if err := cs.sectionService.DeleteSection(c.Request.Context(), id); err != nil {
+		if service.IsCourseSectionError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "删除教学班失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "删除成功", nil)
+}
+
+// List 获取教学班列表，支持按课程、学期筛选
+func (cs *CourseSectionController) List(c *gin.Context) {
+	pageQuery := utils.GetPageQuery(c)
+
+	filters := make(map[string]interface{})
+	if courseID := c.Query("course_id"); courseID != "" {
+		cID, err := strconv.ParseInt(courseID, 10, 64)
+		if err == nil {
+			filters["course_id"] = cID
+		}
+	}
+	if term := strings.TrimSpace(c.Query("term")); term != "" {
+		filters["term"] = term
+	}
+
+	sections, total, counts, err := cs.sectionService.GetSectionList(c.Request.Context(), pageQuery.Page, pageQuery.PageSize, filters)
+	if err != nil {
+		utils.InternalError(c, "获取教学班列表失败: "+err.Error())
+		return
+	}
+
+	responseList := []*dto.SectionResponse{}
+	for _, section := range sections {
+		responseList = append(responseList, convertToSectionResponse(section, counts[section.ID]))
+	}
+	utils.Success(c, &dto.SectionListResponse{
+		List:  responseList,
+		Total: total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	})
+}
+
+// applySectionRequest 把请求中的教学班属性写入模型，所属课程单独处理
+func applySectionRequest(section *model.CourseSection, req *dto.SectionRequest) {
+	section.Term = strings.TrimSpace(req.Term)
+	section.SectionNo = strings.TrimSpace(req.SectionNo)
+	section.Instructor = req.Instructor
+	section.Capacity = req.Capacity
+	section.Schedule = req.Schedule
+	section.Location = req.Location
+}
+
+// 转换为教学班响应DTO
+func convertToSectionResponse(section *model.CourseSection, enrolledCount int64) *dto.SectionResponse {
+	response := &dto.SectionResponse{
+		ID:            section.ID,
+		CourseID:      section.CourseID,
+		Term:          section.Term,
+		SectionNo:     section.SectionNo,
+		Instructor:    section.Instructor,
+		Capacity:      section.Capacity,
+		EnrolledCount: enrolledCount,
+		Schedule:      section.Schedule,
+		Location:      section.Location,
+		CreatedAt:     section.CreatedAt,
+		UpdatedAt:     section.UpdatedAt,
+		CreatedBy:     section.CreatedBy,
+		UpdatedBy:     section.UpdatedBy,
+	}
+	if section.Course != nil {
+		response.CourseCode = section.Course.Code
+		response.CourseTitle = section.Course.Title
+		response.Credits = section.Course.Credits
+	}
+	return response
+}
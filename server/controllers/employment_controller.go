@@ -0,0 +1,246 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// EmploymentController 毕业去向控制器
+type EmploymentController struct {
+	employmentService *service.EmploymentService
+}
+
+// NewEmploymentController 创建毕业去向控制器
+func NewEmploymentController(employmentService *service.EmploymentService) *EmploymentController {
+	return &EmploymentController{
+		employmentService: employmentService,
+	}
+}
+
+// Create 登记学生的毕业去向
+func (e *EmploymentController) Create(c *gin.Context) {
+	var req dto.EmploymentRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	record := &model.EmploymentRecord{StudentID: req.StudentID}
+	applyEmploymentRequest(record, &req)
+	uid := currentUserID(c)
+	record.CreatedBy = uid
+	record.UpdatedBy = uid
+
+	if err := e.employmentService.CreateRecord(c.Request.Context(), record); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "学生不存在")
+		case service.IsEmploymentError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "登记毕业去向失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "登记成功", convertToEmploymentRecordResponse(record))
+}
+
+// Get 获取毕业去向记录详情
+func (e *EmploymentController) Get(c *gin.Context) {
+	record, ok := e.findRecord(c)
+	if !ok {
+		return
+	}
+	utils.Success(c, convertToEmploymentRecordResponse(record))
+}
+
+// Update 更新毕业去向记录，不能修改所属学生
+func (e *EmploymentController) Update(c *gin.Context) {
+	record, ok := e.findRecord(c)
+	if !ok {
+		return
+	}
+
+	var req dto.EmploymentRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+	if req.StudentID != record.StudentID {
+		utils.BusinessError(c, "不能修改毕业去向记录所属学生")
+		return
+	}
+	applyEmploymentRequest(record, &req)
+	record.UpdatedBy = currentUserID(c)
+
+	if err := e.employmentService.UpdateRecord(c.Request.Context(), record); err != nil {
+		if service.IsEmploymentError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "更新毕业去向失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "更新成功", convertToEmploymentRecordResponse(record))
+}
+
+// Delete 删除毕业去向记录
+func (e *EmploymentController) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的记录ID")
+		return
+	}
+
+	if err := e.employmentService.DeleteRecord(c.Request.Context(), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "毕业去向记录不存在")
+		} else {
+			utils.InternalError(c, "删除毕业去向失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "删除成功", nil)
+}
+
+// List 获取毕业去向记录列表，支持按学生、去向、企业筛选
+func (e *EmploymentController) List(c *gin.Context) {
+	pageQuery := utils.GetPageQuery(c)
+
+	filters := make(map[string]interface{})
+	for _, key := range []string{"student_id", "company_id"} {
+		if value := c.Query(key); value != "" {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				utils.ParamError(c, "无效的"+key)
+				return
+			}
+			filters[key] = id
+		}
+	}
+	if outcome := c.Query("outcome"); outcome != "" {
+		filters["outcome"] = outcome
+	}
+
+	records, total, err := e.employmentService.GetRecordList(c.Request.Context(), pageQuery.Page, pageQuery.PageSize, filters)
+	if err != nil {
+		utils.InternalError(c, "获取毕业去向列表失败: "+err.Error())
+		return
+	}
+
+	responseList := []*dto.EmploymentRecordResponse{}
+	for _, record := range records {
+		responseList = append(responseList, convertToEmploymentRecordResponse(record))
+	}
+	utils.Success(c, &dto.EmploymentRecordListResponse{
+		List:  responseList,
+		Total: total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	})
+}
+
+// Report 毕业生就业率报表，group_by 为 university、major 或 graduation_year，默认按大学分组
+func (e *EmploymentController) Report(c *gin.Context) {
+	groupBy := c.DefaultQuery("group_by", "university")
+
+	filters := make(map[string]interface{})
+	for _, key := range []string{"university_id", "major_id", "graduation_year"} {
+		if value := c.Query(key); value != "" {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				utils.ParamError(c, "无效的"+key)
+				return
+			}
+			filters[key] = id
+		}
+	}
+
+	report, err := e.employmentService.GetRateReport(c.Request.Context(), groupBy, filters)
+	if err != nil {
+		if service.IsEmploymentError(err) {
+			utils.ParamError(c, err.Error())
+		} else {
+			utils.InternalError(c, "获取就业率报表失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.Success(c, report)
+}
+
+// findRecord 按路径参数获取毕业去向记录，失败时直接写入响应并返回 false
+func (e *EmploymentController) findRecord(c *gin.Context) (*model.EmploymentRecord, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的记录ID")
+		return nil, false
+	}
+
+	record, err := e.employmentService.GetRecordByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "毕业去向记录不存在")
+		} else {
+			utils.InternalError(c, "获取毕业去向失败: "+err.Error())
+		}
+		return nil, false
+	}
+	return record, true
+}
+
+// applyEmploymentRequest 把请求中的毕业去向属性写入模型，所属学生单独处理，开始日期已通过格式校验
+func applyEmploymentRequest(record *model.EmploymentRecord, req *dto.EmploymentRecordRequest) {
+	record.Outcome = req.Outcome
+	record.CompanyID = req.CompanyID
+	record.Organization = req.Organization
+	record.Position = req.Position
+	record.City = req.City
+	record.MonthlySalary = req.MonthlySalary
+	record.Remarks = req.Remarks
+	record.StartDate = nil
+	if req.StartDate != "" {
+		startDate, _ := time.ParseInLocation("2006-01-02", req.StartDate, time.Local)
+		record.StartDate = &startDate
+	}
+	record.Company = nil
+}
+
+// 转换为毕业去向响应DTO
+func convertToEmploymentRecordResponse(record *model.EmploymentRecord) *dto.EmploymentRecordResponse {
+	response := &dto.EmploymentRecordResponse{
+		ID:            record.ID,
+		StudentID:     record.StudentID,
+		Outcome:       record.Outcome,
+		CompanyID:     record.CompanyID,
+		Organization:  record.Organization,
+		Position:      record.Position,
+		City:          record.City,
+		MonthlySalary: record.MonthlySalary,
+		ApplicationID: record.ApplicationID,
+		Remarks:       record.Remarks,
+		CreatedAt:     record.CreatedAt,
+		UpdatedAt:     record.UpdatedAt,
+		CreatedBy:     record.CreatedBy,
+		UpdatedBy:     record.UpdatedBy,
+	}
+	if record.StartDate != nil {
+		startDate := record.StartDate.Format("2006-01-02")
+		response.StartDate = &startDate
+	}
+	if record.Company != nil {
+		response.CompanyName = &record.Company.Name
+	}
+	return response
+}
@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// EnrollmentController 选课控制器
+type EnrollmentController struct {
+	enrollmentService *service.EnrollmentService
+}
+
+// NewEnrollmentController 创建选课控制器
+func NewEnrollmentController(enrollmentService *service.EnrollmentService) *EnrollmentController {
+	return &EnrollmentController{
+		enrollmentService: enrollmentService,
+	}
+}
+
+// Create 为学生选课，检查容量和同学期的课程、时间冲突
+func (e *EnrollmentController) Create(c *gin.Context) {
+	var req dto.EnrollmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	enrollment, err := e.enrollmentService.Enroll(c.Request.Context(), req.StudentID, req.SectionID, currentUserID(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "学生或教学班不存在")
+		case service.IsEnrollmentError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "选课失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "选课成功", convertToEnrollmentResponse(enrollment))
+}
+
+// Get 获取选课记录详情
+func (e *EnrollmentController) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的选课记录ID")
+		return
+	}
+
+	enrollment, err := e.enrollmentService.GetEnrollmentByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "选课记录不存在")
+		} else {
+			utils.InternalError(c, "获取选课记录失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.Success(c, convertToEnrollmentResponse(enrollment))
+}
+
+// Drop 退课
+func (e *EnrollmentController) Drop(c *gin.Context) {
+	e.changeStatus(c, e.enrollmentService.Drop, "退课成功")
+}
+
+// Complete 标记选课记录为已修完
+func (e *EnrollmentController) Complete(c *gin.Context) {
+	e.changeStatus(c, e.enrollmentService.Complete, "已标记为修完")
+}
+
+// changeStatus 变更选课记录状态
+func (e *EnrollmentController) changeStatus(c *gin.Context, change func(ctx context.Context, id int64, actorID *int64) (*model.Enrollment, error), msg string) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的选课记录ID")
+		return
+	}
+
+	enrollment, err := change(c.Request.Context(), id, currentUserID(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "选课记录不存在")
+		case service.IsEnrollmentError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "变更选课状态失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, msg, convertToEnrollmentResponse(enrollment))
+}
+
+// List 获取选课记录列表，支持按学生、教学班、状态、学期筛选
+func (e *EnrollmentController) List(c *gin.Context) {
+	pageQuery := utils.GetPageQuery(c)
+
+	filters := make(map[string]interface{})
+	for _, key := range []string{"student_id", "section_id"} {
+		if value := c.Query(key); value != "" {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				utils.ParamError(c, "无效的"+key)
+				return
+			}
+			filters[key] = id
+		}
+	}
+	if status := c.Query("status"); status != "" {
+		filters["status"] = status
+	}
+	if term := strings.TrimSpace(c.Query("term")); term != "" {
+		filters["term"] = term
+	}
+
+	enrollments, total, err := e.enrollmentService.GetEnrollmentList(c.Request.Context(), pageQuery.Page, pageQuery.PageSize, filters)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidEnrollmentStatus) {
+			utils.ParamError(c, err.Error())
+		} else {
+			utils.InternalError(c, "获取选课记录失败: "+err.Error())
+		}
+		return
+	}
+
+	responseList := []*dto.EnrollmentResponse{}
+	for _, enrollment := range enrollments {
+		responseList = append(responseList, convertToEnrollmentResponse(enrollment))
+	}
+	utils.Success(c, &dto.EnrollmentListResponse{
+		List:  responseList,
+		Total: total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	})
+}
+
+// currentUserID 获取当前用户ID，未登录时返回 nil
+func currentUserID(c *gin.Context) *int64 {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return nil
+	}
+	uid := int64(userID.(uint))
+	return &uid
+}
+
+// 转换为选课记录响应DTO
+func convertToEnrollmentResponse(enrollment *model.Enrollment) *dto.EnrollmentResponse {
+	response := &dto.EnrollmentResponse{
+		ID:         enrollment.ID,
+		StudentID:  enrollment.StudentID,
+		SectionID:  enrollment.SectionID,
+		Status:     enrollment.Status,
+		EnrolledAt: enrollment.EnrolledAt,
+		DroppedAt:  enrollment.DroppedAt,
+		CreatedAt:  enrollment.CreatedAt,
+		UpdatedAt:  enrollment.UpdatedAt,
+	}
+	if section := enrollment.Section; section != nil {
+		response.Term = section.Term
+		response.SectionNo = section.SectionNo
+		response.CourseID = section.CourseID
+		if section.Course != nil {
+			response.CourseCode = section.Course.Code
+			response.CourseTitle = section.Course.Title
+			response.Credits = section.Course.Credits
+		}
+	}
+	return response
+}
@@ -0,0 +1,203 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GradeController 课程成绩控制器
+type GradeController struct {
+	gradeService *service.GradeService
+}
+
+// NewGradeController 创建课程成绩控制器
+func NewGradeController(gradeService *service.GradeService) *GradeController {
+	return &GradeController{
+		gradeService: gradeService,
+	}
+}
+
+// Create 录入成绩，按课程的评分标准换算等级和绩点
+func (g *GradeController) Create(c *gin.Context) {
+	var req dto.GradeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+	if req.EnrollmentID == nil && (req.StudentID == 0 || req.CourseID == 0) {
+		utils.ParamError(c, "需要指定选课记录，或者学生、课程和学期")
+		return
+	}
+
+	grade, err := g.gradeService.RecordGrade(c.Request.Context(), &service.GradeInput{
+		EnrollmentID: req.EnrollmentID,
+		StudentID:    req.StudentID,
+		CourseID:     req.CourseID,
+		Term:         req.Term,
+		Score:        req.Score,
+		Grade:        req.Grade,
+		Remarks:      req.Remarks,
+		ActorID:      currentUserID(c),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "学生、课程或选课记录不存在")
+		case service.IsGradeError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "录入成绩失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "录入成功", convertToGradeResponse(grade))
+}
+
+// Get 获取成绩详情
+func (g *GradeController) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的成绩ID")
+		return
+	}
+
+	grade, err := g.gradeService.GetGradeByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "成绩不存在")
+		} else {
+			utils.InternalError(c, "获取成绩失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.Success(c, convertToGradeResponse(grade))
+}
+
+// Update 更新成绩的分数或等级
+func (g *GradeController) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的成绩ID")
+		return
+	}
+
+	var req dto.GradeUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	grade, err := g.gradeService.UpdateGrade(c.Request.Context(), id, &service.GradeInput{
+		Score:   req.Score,
+		Grade:   req.Grade,
+		Remarks: req.Remarks,
+		ActorID: currentUserID(c),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "成绩不存在")
+		case service.IsGradeError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "更新成绩失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "更新成功", convertToGradeResponse(grade))
+}
+
+// Delete 删除成绩
+func (g *GradeController) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的成绩ID")
+		return
+	}
+
+	if err := g.gradeService.DeleteGrade(c.Request.Context(), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "成绩不存在")
+		} else {
+			utils.InternalError(c, "删除成绩失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "删除成功", nil)
+}
+
+// List 获取成绩列表，支持按学生、课程、学期筛选
+func (g *GradeController) List(c *gin.Context) {
+	pageQuery := utils.GetPageQuery(c)
+
+	filters := make(map[string]interface{})
+	for _, key := range []string{"student_id", "course_id"} {
+		if value := c.Query(key); value != "" {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				utils.ParamError(c, "无效的"+key)
+				return
+			}
+			filters[key] = id
+		}
+	}
+	if term := strings.TrimSpace(c.Query("term")); term != "" {
+		filters["term"] = term
+	}
+
+	grades, total, err := g.gradeService.GetGradeList(c.Request.Context(), pageQuery.Page, pageQuery.PageSize, filters)
+	if err != nil {
+		utils.InternalError(c, "获取成绩列表失败: "+err.Error())
+		return
+	}
+
+	responseList := []*dto.GradeResponse{}
+	for _, grade := range grades {
+		responseList = append(responseList, convertToGradeResponse(grade))
+	}
+	utils.Success(c, &dto.GradeListResponse{
+		List:  responseList,
+		Total: total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	})
+}
+
+// 转换为成绩响应DTO
+func convertToGradeResponse(grade *model.Grade) *dto.GradeResponse {
+	response := &dto.GradeResponse{
+		ID:           grade.ID,
+		StudentID:    grade.StudentID,
+		CourseID:     grade.CourseID,
+		EnrollmentID: grade.EnrollmentID,
+		Term:         grade.Term,
+		ScaleID:      grade.ScaleID,
+		Score:        grade.Score,
+		Grade:        grade.Grade,
+		GradePoint:   grade.GradePoint,
+		Credits:      grade.Credits,
+		Passed:       grade.Passed,
+		Remarks:      grade.Remarks,
+		CreatedAt:    grade.CreatedAt,
+		UpdatedAt:    grade.UpdatedAt,
+		CreatedBy:    grade.CreatedBy,
+		UpdatedBy:    grade.UpdatedBy,
+	}
+	if grade.Course != nil {
+		response.CourseCode = grade.Course.Code
+		response.CourseTitle = grade.Course.Title
+	}
+	return response
+}
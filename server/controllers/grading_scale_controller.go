@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GradingScaleController 评分标准控制器
+type GradingScaleController struct {
+	scaleService *service.GradingScaleService
+}
+
+// NewGradingScaleController 创建评分标准控制器
+func NewGradingScaleController(scaleService *service.GradingScaleService) *GradingScaleController {
+	return &GradingScaleController{
+		scaleService: scaleService,
+	}
+}
+
+// Create 创建评分标准
+func (g *GradingScaleController) Create(c *gin.Context) {
+	var req dto.GradingScaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	scale := &model.GradingScale{}
+	applyGradingScaleRequest(scale, &req)
+	if uid := currentUserID(c); uid != nil {
+		scale.CreatedBy = uid
+		scale.UpdatedBy = uid
+	}
+
+	if err := g.scaleService.CreateScale(c.Request.Context(), scale, gradingScaleItems(&req)); err != nil {
+		if service.IsGradingScaleError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "创建评分标准失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "创建成功", convertToGradingScaleResponse(scale))
+}
+
+// Get 获取评分标准详情
+func (g *GradingScaleController) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的评分标准ID")
+		return
+	}
+
+	scale, err := g.scaleService.GetScaleByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "评分标准不存在")
+		} else {
+			utils.InternalError(c, "获取评分标准失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.Success(c, convertToGradingScaleResponse(scale))
+}
+
+// Update 更新评分标准，等级整体替换
+func (g *GradingScaleController) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的评分标准ID")
+		return
+	}
+
+	// 检查评分标准是否存在
+	scale, err := g.scaleService.GetScaleByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "评分标准不存在")
+		} else {
+			utils.InternalError(c, "获取评分标准失败: "+err.Error())
+		}
+		return
+	}
+
+	var req dto.GradingScaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	// 取消默认标记会导致没有默认评分标准，只允许通过把其他评分标准设为默认来切换
+	if scale.IsDefault && !req.IsDefault {
+		utils.BusinessError(c, "请通过将其他评分标准设为默认来取消默认")
+		return
+	}
+
+	applyGradingScaleRequest(scale, &req)
+	scale.UpdatedBy = currentUserID(c)
+
+	if err := g.scaleService.UpdateScale(c.Request.Context(), scale, gradingScaleItems(&req)); err != nil {
+		if service.IsGradingScaleError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "更新评分标准失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "更新成功", convertToGradingScaleResponse(scale))
+}
+
+// Delete 删除评分标准
+func (g *GradingScaleController) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的评分标准ID")
+		return
+	}
+
+	if err := g.scaleService.DeleteScale(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "评分标准不存在")
+		case service.IsGradingScaleError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "删除评分标准失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "删除成功", nil)
+}
+
+// List 获取所有评分标准
+func (g *GradingScaleController) List(c *gin.Context) {
+	scales, err := g.scaleService.GetAllScales(c.Request.Context())
+	if err != nil {
+		utils.InternalError(c, "获取评分标准列表失败: "+err.Error())
+		return
+	}
+
+	responseList := []*dto.GradingScaleResponse{}
+	for _, scale := range scales {
+		responseList = append(responseList, convertToGradingScaleResponse(scale))
+	}
+	utils.Success(c, responseList)
+}
+
+// applyGradingScaleRequest 把请求中的评分标准属性写入模型，等级单独处理
+func applyGradingScaleRequest(scale *model.GradingScale, req *dto.GradingScaleRequest) {
+	scale.Name = strings.TrimSpace(req.Name)
+	scale.Type = req.Type
+	scale.IsDefault = req.IsDefault
+	scale.Description = req.Description
+}
+
+// gradingScaleItems 把请求中的等级转换为模型
+func gradingScaleItems(req *dto.GradingScaleRequest) []*model.GradingScaleItem {
+	items := make([]*model.GradingScaleItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, &model.GradingScaleItem{
+			Grade:      item.Grade,
+			MinScore:   item.MinScore,
+			GradePoint: item.GradePoint,
+			Passing:    item.Passing,
+		})
+	}
+	return items
+}
+
+// 转换为评分标准响应DTO
+func convertToGradingScaleResponse(scale *model.GradingScale) *dto.GradingScaleResponse {
+	response := &dto.GradingScaleResponse{
+		ID:          scale.ID,
+		Name:        scale.Name,
+		Type:        scale.Type,
+		IsDefault:   scale.IsDefault,
+		Description: scale.Description,
+		Items:       []*dto.GradingScaleItemResponse{},
+		CreatedAt:   scale.CreatedAt,
+		UpdatedAt:   scale.UpdatedAt,
+	}
+	for _, item := range scale.Items {
+		response.Items = append(response.Items, &dto.GradingScaleItemResponse{
+			ID:         item.ID,
+			Grade:      item.Grade,
+			MinScore:   item.MinScore,
+			GradePoint: item.GradePoint,
+			Passing:    item.Passing,
+		})
+	}
+	return response
+}
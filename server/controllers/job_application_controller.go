@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// JobApplicationController 岗位申请控制器
+type JobApplicationController struct {
+	applicationService *service.JobApplicationService
+}
+
+// NewJobApplicationController 创建岗位申请控制器
+func NewJobApplicationController(applicationService *service.JobApplicationService) *JobApplicationController {
+	return &JobApplicationController{
+		applicationService: applicationService,
+	}
+}
+
+// Create 登记学生的岗位申请
+func (j *JobApplicationController) Create(c *gin.Context) {
+	var req dto.JobApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	application, err := j.applicationService.Apply(c.Request.Context(), req.PostingID, req.StudentID, req.Remarks, currentUserID(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "学生或岗位不存在")
+		case service.IsJobApplicationError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "登记申请失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "申请成功", convertToJobApplicationResponse(application))
+}
+
+// Get 获取岗位申请详情
+func (j *JobApplicationController) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的申请ID")
+		return
+	}
+
+	application, err := j.applicationService.GetApplicationByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "申请不存在")
+		} else {
+			utils.InternalError(c, "获取申请失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.Success(c, convertToJobApplicationResponse(application))
+}
+
+// ChangeStatus 按申请流程变更状态：已投递 → 面试中 → 已发 offer → 已接受，任一阶段可以变更为未通过
+func (j *JobApplicationController) ChangeStatus(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的申请ID")
+		return
+	}
+
+	var req dto.ApplicationStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	application, err := j.applicationService.ChangeStatus(c.Request.Context(), id, req.Status, req.Remarks, currentUserID(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "申请不存在")
+		case service.IsJobApplicationError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "变更申请状态失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "状态已更新", convertToJobApplicationResponse(application))
+}
+
+// Delete 删除岗位申请，已接受的申请不能删除
+func (j *JobApplicationController) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的申请ID")
+		return
+	}
+
+	if err := j.applicationService.DeleteApplication(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "申请不存在")
+		case service.IsJobApplicationError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "删除申请失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "删除成功", nil)
+}
+
+// List 获取岗位申请列表，支持按岗位、学生、状态筛选
+func (j *JobApplicationController) List(c *gin.Context) {
+	pageQuery := utils.GetPageQuery(c)
+
+	filters := make(map[string]interface{})
+	for _, key := range []string{"posting_id", "student_id"} {
+		if value := c.Query(key); value != "" {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				utils.ParamError(c, "无效的"+key)
+				return
+			}
+			filters[key] = id
+		}
+	}
+	if status := c.Query("status"); status != "" {
+		filters["status"] = status
+	}
+
+	applications, total, err := j.applicationService.GetApplicationList(c.Request.Context(), pageQuery.Page, pageQuery.PageSize, filters)
+	if err != nil {
+		utils.InternalError(c, "获取申请列表失败: "+err.Error())
+		return
+	}
+
+	responseList := []*dto.JobApplicationResponse{}
+	for _, application := range applications {
+		responseList = append(responseList, convertToJobApplicationResponse(application))
+	}
+	utils.Success(c, &dto.JobApplicationListResponse{
+		List:  responseList,
+		Total: total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	})
+}
+
+// 转换为岗位申请响应DTO
+func convertToJobApplicationResponse(application *model.JobApplication) *dto.JobApplicationResponse {
+	response := &dto.JobApplicationResponse{
+		ID:              application.ID,
+		PostingID:       application.PostingID,
+		StudentID:       application.StudentID,
+		Status:          application.Status,
+		AppliedAt:       application.AppliedAt,
+		StatusChangedAt: application.StatusChangedAt,
+		Remarks:         application.Remarks,
+		CreatedAt:       application.CreatedAt,
+		UpdatedAt:       application.UpdatedAt,
+		CreatedBy:       application.CreatedBy,
+		UpdatedBy:       application.UpdatedBy,
+	}
+	if posting := application.Posting; posting != nil {
+		response.PostingTitle = posting.Title
+		response.PostingType = posting.Type
+		response.CompanyID = posting.CompanyID
+		if posting.Company != nil {
+			response.CompanyName = posting.Company.Name
+		}
+	}
+	return response
+}
@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// JobPostingController 招聘岗位控制器
+type JobPostingController struct {
+	postingService *service.JobPostingService
+}
+
+// NewJobPostingController 创建招聘岗位控制器
+func NewJobPostingController(postingService *service.JobPostingService) *JobPostingController {
+	return &JobPostingController{
+		postingService: postingService,
+	}
+}
+
+// Create 发布招聘岗位
+func (j *JobPostingController) Create(c *gin.Context) {
+	var req dto.JobPostingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	posting := &model.JobPosting{}
+	applyJobPostingRequest(posting, &req)
+	uid := currentUserID(c)
+	posting.CreatedBy = uid
+	posting.UpdatedBy = uid
+
+	if err := j.postingService.CreatePosting(c.Request.Context(), posting); err != nil {
+		if service.IsJobPostingError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "发布岗位失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "发布成功", convertToJobPostingResponse(posting))
+}
+
+// Get 获取招聘岗位详情
+func (j *JobPostingController) Get(c *gin.Context) {
+	posting, ok := j.findPosting(c)
+	if !ok {
+		return
+	}
+	utils.Success(c, convertToJobPostingResponse(posting))
+}
+
+// Update 更新招聘岗位，可以通过 status 关闭岗位
+func (j *JobPostingController) Update(c *gin.Context) {
+	posting, ok := j.findPosting(c)
+	if !ok {
+		return
+	}
+
+	var req dto.JobPostingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+	if req.Status == "" {
+		req.Status = posting.Status
+	}
+	applyJobPostingRequest(posting, &req)
+	posting.UpdatedBy = currentUserID(c)
+
+	if err := j.postingService.UpdatePosting(c.Request.Context(), posting); err != nil {
+		if service.IsJobPostingError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "更新岗位失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "更新成功", convertToJobPostingResponse(posting))
+}
+
+// Delete 删除招聘岗位，已有申请的岗位不能删除
+func (j *JobPostingController) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的岗位ID")
+		return
+	}
+
+	if err := j.postingService.DeletePosting(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "岗位不存在")
+		case service.IsJobPostingError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "删除岗位失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "删除成功", nil)
+}
+
+// List 获取招聘岗位列表，支持按岗位名称、企业、类型、状态筛选
+func (j *JobPostingController) List(c *gin.Context) {
+	pageQuery := utils.GetPageQuery(c)
+
+	filters := make(map[string]interface{})
+	if keyword := strings.TrimSpace(c.Query("keyword")); keyword != "" {
+		filters["keyword"] = keyword
+	}
+	if companyID := c.Query("company_id"); companyID != "" {
+		id, err := strconv.ParseInt(companyID, 10, 64)
+		if err != nil {
+			utils.ParamError(c, "无效的company_id")
+			return
+		}
+		filters["company_id"] = id
+	}
+	for _, key := range []string{"type", "status"} {
+		if value := c.Query(key); value != "" {
+			filters[key] = value
+		}
+	}
+
+	postings, total, err := j.postingService.GetPostingList(c.Request.Context(), pageQuery.Page, pageQuery.PageSize, filters)
+	if err != nil {
+		utils.InternalError(c, "获取岗位列表失败: "+err.Error())
+		return
+	}
+
+	responseList := []*dto.JobPostingResponse{}
+	for _, posting := range postings {
+		responseList = append(responseList, convertToJobPostingResponse(posting))
+	}
+	utils.Success(c, &dto.JobPostingListResponse{
+		List:  responseList,
+		Total: total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	})
+}
+
+// findPosting 按路径参数获取招聘岗位，失败时直接写入响应并返回 false
+func (j *JobPostingController) findPosting(c *gin.Context) (*model.JobPosting, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的岗位ID")
+		return nil, false
+	}
+
+	posting, err := j.postingService.GetPostingByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "岗位不存在")
+		} else {
+			utils.InternalError(c, "获取岗位失败: "+err.Error())
+		}
+		return nil, false
+	}
+	return posting, true
+}
+
+// applyJobPostingRequest 把请求中的岗位属性写入模型，截止日期已通过格式校验
+func applyJobPostingRequest(posting *model.JobPosting, req *dto.JobPostingRequest) {
+	posting.CompanyID = req.CompanyID
+	posting.Title = strings.TrimSpace(req.Title)
+	posting.Type = req.Type
+	posting.City = req.City
+	posting.Salary = req.Salary
+	posting.Description = req.Description
+	posting.Status = req.Status
+	posting.Deadline = nil
+	if req.Deadline != "" {
+		deadline, _ := time.ParseInLocation("2006-01-02", req.Deadline, time.Local)
+		posting.Deadline = &deadline
+	}
+}
+
+// 转换为招聘岗位响应DTO
+func convertToJobPostingResponse(posting *model.JobPosting) *dto.JobPostingResponse {
+	response := &dto.JobPostingResponse{
+		ID:          posting.ID,
+		CompanyID:   posting.CompanyID,
+		Title:       posting.Title,
+		Type:        posting.Type,
+		City:        posting.City,
+		Salary:      posting.Salary,
+		Description: posting.Description,
+		Status:      posting.Status,
+		Open:        service.IsPostingOpen(posting, time.Now()),
+		CreatedAt:   posting.CreatedAt,
+		UpdatedAt:   posting.UpdatedAt,
+		CreatedBy:   posting.CreatedBy,
+		UpdatedBy:   posting.UpdatedBy,
+	}
+	if posting.Deadline != nil {
+		deadline := posting.Deadline.Format("2006-01-02")
+		response.Deadline = &deadline
+	}
+	if posting.Company != nil {
+		response.CompanyName = posting.Company.Name
+	}
+	return response
+}
@@ -0,0 +1,296 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// MajorController 专业控制器
+type MajorController struct {
+	majorService      *service.MajorService
+	universityService *service.UniversityService
+}
+
+// NewMajorController 创建专业控制器
+func NewMajorController(majorService *service.MajorService, universityService *service.UniversityService) *MajorController {
+	return &MajorController{
+		majorService:      majorService,
+		universityService: universityService,
+	}
+}
+
+// Create 创建专业
+func (m *MajorController) Create(c *gin.Context) {
+	var req dto.MajorRequest
+
+	// 绑定请求参数
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	// 检查所属大学是否存在
+	if _, err := m.universityService.GetUniversityByID(c.Request.Context(), req.UniversityID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.BusinessError(c, "所属大学不存在")
+		} else {
+			utils.InternalError(c, "获取大学失败: "+err.Error())
+		}
+		return
+	}
+
+	// 检查同一大学下专业名称是否已存在
+	exists, err := m.majorService.CheckMajorNameExists(c.Request.Context(), req.UniversityID, req.Name, 0)
+	if err != nil {
+		utils.InternalError(c, "检查专业名称失败: "+err.Error())
+		return
+	}
+	if exists {
+		utils.BusinessError(c, "该大学下已存在同名专业")
+		return
+	}
+
+	// 创建专业对象
+	major := &model.Major{
+		UniversityID: req.UniversityID,
+		CollegeID:    req.CollegeID,
+		Name:         req.Name,
+		Code:         req.Code,
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("user_id")
+	if exists {
+		uid := int64(userID.(uint))
+		major.CreatedBy = &uid
+		major.UpdatedBy = &uid
+	}
+
+	// 创建专业
+	if err := m.majorService.CreateMajor(c.Request.Context(), major); err != nil {
+		m.handleCollegeError(c, err, "创建专业失败: ")
+		return
+	}
+
+	utils.SuccessWithMsg(c, "创建成功", convertToMajorResponse(major))
+}
+
+// Get 获取专业详情
+func (m *MajorController) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的专业ID")
+		return
+	}
+
+	major, err := m.majorService.GetMajorByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "专业不存在")
+		} else {
+			utils.InternalError(c, "获取专业失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.Success(c, convertToMajorResponse(major))
+}
+
+// Update 更新专业
+func (m *MajorController) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的专业ID")
+		return
+	}
+
+	// 检查专业是否存在
+	existingMajor, err := m.majorService.GetMajorByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "专业不存在")
+		} else {
+			utils.InternalError(c, "获取专业失败: "+err.Error())
+		}
+		return
+	}
+
+	// 绑定请求参数
+	var req dto.MajorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	// 专业不能移动到其他大学，否则已关联学生的归属会不一致
+	if req.UniversityID != existingMajor.UniversityID {
+		utils.BusinessError(c, "不能修改专业所属大学")
+		return
+	}
+
+	// 如果要修改名称，检查新名称是否与同一大学下的其他专业冲突
+	if req.Name != existingMajor.Name {
+		exists, err := m.majorService.CheckMajorNameExists(c.Request.Context(), existingMajor.UniversityID, req.Name, id)
+		if err != nil {
+			utils.InternalError(c, "检查专业名称失败: "+err.Error())
+			return
+		}
+		if exists {
+			utils.BusinessError(c, "该大学下已存在同名专业")
+			return
+		}
+	}
+
+	// 更新专业属性
+	existingMajor.CollegeID = req.CollegeID
+	existingMajor.Name = req.Name
+	existingMajor.Code = req.Code
+
+	// 获取当前用户ID，设置更新者
+	userID, exists := c.Get("user_id")
+	if exists {
+		uid := int64(userID.(uint))
+		existingMajor.UpdatedBy = &uid
+	}
+
+	// 更新专业
+	if err := m.majorService.UpdateMajor(c.Request.Context(), existingMajor); err != nil {
+		m.handleCollegeError(c, err, "更新专业失败: ")
+		return
+	}
+
+	utils.SuccessWithMsg(c, "更新成功", convertToMajorResponse(existingMajor))
+}
+
+// Delete 删除专业
+func (m *MajorController) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的专业ID")
+		return
+	}
+
+	// 检查专业是否存在
+	_, err = m.majorService.GetMajorByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "专业不存在")
+		} else {
+			utils.InternalError(c, "获取专业失败: "+err.Error())
+		}
+		return
+	}
+
+	// 删除专业
+	if err := m.majorService.DeleteMajor(c.Request.Context(), id); err != nil {
+		if errors.Is(err, service.ErrMajorInUse) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "删除专业失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "删除成功", nil)
+}
+
+// List 获取专业列表，须指定 university_id 或 college_id
+func (m *MajorController) List(c *gin.Context) {
+	filters := make(map[string]interface{})
+
+	if universityID := c.Query("university_id"); universityID != "" {
+		uID, err := strconv.ParseInt(universityID, 10, 64)
+		if err == nil {
+			filters["university_id"] = uID
+		}
+	}
+
+	if collegeID := c.Query("college_id"); collegeID != "" {
+		cID, err := strconv.ParseInt(collegeID, 10, 64)
+		if err == nil {
+			filters["college_id"] = cID
+		}
+	}
+
+	if len(filters) == 0 {
+		utils.ParamError(c, "请指定有效的大学ID或院系ID")
+		return
+	}
+
+	majors, err := m.majorService.GetMajorList(c.Request.Context(), filters)
+	if err != nil {
+		utils.InternalError(c, "获取专业列表失败: "+err.Error())
+		return
+	}
+
+	var responseList []*dto.MajorResponse
+	for _, major := range majors {
+		responseList = append(responseList, convertToMajorResponse(major))
+	}
+	utils.Success(c, responseList)
+}
+
+// Match 把学生自由填写的专业名称与专业目录匹配，默认只返回报告，apply=1 时写入匹配结果
+func (m *MajorController) Match(c *gin.Context) {
+	apply := c.Query("apply") == "1" || c.Query("apply") == "true"
+
+	report, err := m.majorService.MatchLegacyMajors(c.Request.Context(), apply)
+	if err != nil {
+		utils.InternalError(c, "匹配专业失败: "+err.Error())
+		return
+	}
+
+	utils.Success(c, convertToMajorMatchResponse(report))
+}
+
+// handleCollegeError 处理保存专业时的院系校验错误
+func (m *MajorController) handleCollegeError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		utils.BusinessError(c, "所属院系不存在")
+	case errors.Is(err, service.ErrCollegeUniversityMismatch):
+		utils.BusinessError(c, err.Error())
+	default:
+		utils.InternalError(c, msg+err.Error())
+	}
+}
+
+// 转换为专业响应DTO
+func convertToMajorResponse(major *model.Major) *dto.MajorResponse {
+	return &dto.MajorResponse{
+		ID:           major.ID,
+		UniversityID: major.UniversityID,
+		CollegeID:    major.CollegeID,
+		Name:         major.Name,
+		Code:         major.Code,
+		CreatedAt:    major.CreatedAt,
+		UpdatedAt:    major.UpdatedAt,
+		CreatedBy:    major.CreatedBy,
+		UpdatedBy:    major.UpdatedBy,
+	}
+}
+
+// 转换为专业匹配结果DTO
+func convertToMajorMatchResponse(report *service.MajorMatchReport) *dto.MajorMatchResponse {
+	response := &dto.MajorMatchResponse{
+		Matched:   report.Matched,
+		Applied:   report.Applied,
+		Unmatched: []*dto.UnmatchedMajorResponse{},
+	}
+	for _, item := range report.Unmatched {
+		response.Unmatched = append(response.Unmatched, &dto.UnmatchedMajorResponse{
+			UniversityID: item.UniversityID,
+			Major:        item.Major,
+			Count:        item.Count,
+			Reason:       item.Reason,
+		})
+	}
+	return response
+}
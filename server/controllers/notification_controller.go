@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationController 站内通知控制器，只能访问当前用户自己的通知
+type NotificationController struct {
+	notificationService *service.NotificationService
+}
+
+// NewNotificationController 创建站内通知控制器
+func NewNotificationController(notificationService *service.NotificationService) *NotificationController {
+	return &NotificationController{
+		notificationService: notificationService,
+	}
+}
+
+// List 获取当前用户的通知列表，unread=1 时只返回未读通知
+func (n *NotificationController) List(c *gin.Context) {
+	pageQuery := utils.GetPageQuery(c)
+	userID, _ := c.Get("user_id")
+	uid := int64(userID.(uint))
+
+	notifications, total, err := n.notificationService.GetNotificationList(c.Request.Context(), uid, c.Query("unread") == "1", pageQuery.Page, pageQuery.PageSize)
+	if err != nil {
+		utils.InternalError(c, "获取通知列表失败: "+err.Error())
+		return
+	}
+	unread, err := n.notificationService.CountUnread(c.Request.Context(), uid)
+	if err != nil {
+		utils.InternalError(c, "获取未读通知数失败: "+err.Error())
+		return
+	}
+
+	responseList := []*dto.NotificationResponse{}
+	for _, notification := range notifications {
+		responseList = append(responseList, &dto.NotificationResponse{
+			ID:         notification.ID,
+			Type:       notification.Type,
+			Title:      notification.Title,
+			Content:    notification.Content,
+			EntityType: notification.EntityType,
+			EntityID:   notification.EntityID,
+			ReadAt:     notification.ReadAt,
+			CreatedAt:  notification.CreatedAt,
+		})
+	}
+	utils.Success(c, &dto.NotificationListResponse{
+		List:   responseList,
+		Total:  total,
+		Unread: unread,
+		Page:   pageQuery.Page,
+		Size:   pageQuery.PageSize,
+	})
+}
+
+// Read 把一条通知标记为已读
+func (n *NotificationController) Read(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的通知ID")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	if err := n.notificationService.MarkRead(c.Request.Context(), int64(userID.(uint)), id); err != nil {
+		if errors.Is(err, service.ErrNotificationNotFound) {
+			utils.NotFound(c, err.Error())
+		} else {
+			utils.InternalError(c, "标记通知已读失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "已标记为已读", nil)
+}
+
+// ReadAll 把当前用户的所有未读通知标记为已读
+func (n *NotificationController) ReadAll(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	count, err := n.notificationService.MarkAllRead(c.Request.Context(), int64(userID.(uint)))
+	if err != nil {
+		utils.InternalError(c, "标记通知已读失败: "+err.Error())
+		return
+	}
+
+	utils.SuccessWithMsg(c, "已全部标记为已读", map[string]int64{"count": count})
+}
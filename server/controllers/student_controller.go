@@ -7,22 +7,37 @@ import (
 	"mvc-demo/service"
 	"mvc-demo/utils"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
+// studentFieldSpec 学生接口可通过 ?fields= 选择的字段和 ?include= 展开的关联
+var studentFieldSpec = func() *utils.FieldSpec {
+	spec := utils.NewFieldSpec("id", "name", "email", "gender", "birthday", "phone", "university_id", "major",
+		"major_id", "education", "graduation_year", "status", "remarks", "avatar", "last_login_time",
+		"created_at", "updated_at", "created_by", "updated_by")
+	spec.Includes["university"] = []string{"university_id"}
+	return spec
+}()
+
 // StudentController 学生控制器
 type StudentController struct {
-	studentService    *service.StudentService
-	universityService *service.UniversityService
+	studentService       *service.StudentService
+	studentSearchService *service.StudentSearchService
+	studentStatusService *service.StudentStatusService
+	teacherService       *service.TeacherService
 }
 
 // NewStudentController 创建学生控制器
-func NewStudentController(studentService *service.StudentService, universityService *service.UniversityService) *StudentController {
+func NewStudentController(studentService *service.StudentService, studentSearchService *service.StudentSearchService, studentStatusService *service.StudentStatusService, teacherService *service.TeacherService) *StudentController {
 	return &StudentController{
-		studentService:    studentService,
-		universityService: universityService,
+		studentService:       studentService,
+		studentSearchService: studentSearchService,
+		studentStatusService: studentStatusService,
+		teacherService:       teacherService,
 	}
 }
 
@@ -46,6 +61,7 @@ func (s *StudentController) Create(c *gin.Context) {
 		Phone:          req.Phone,
 		UniversityID:   req.UniversityID,
 		Major:          req.Major,
+		MajorID:        req.MajorID,
 		Education:      req.Education,
 		GraduationYear: req.GraduationYear,
 		Status:         req.Status,
@@ -61,13 +77,17 @@ func (s *StudentController) Create(c *gin.Context) {
 	}
 
 	// 创建学生
-	if err := s.studentService.CreateStudent(student); err != nil {
-		utils.InternalError(c, "创建学生失败: "+err.Error())
+	if err := s.studentService.CreateStudent(c.Request.Context(), student); err != nil {
+		if isStudentMajorError(err) || service.IsStudentStatusError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "创建学生失败: "+err.Error())
+		}
 		return
 	}
 
 	// 转换为响应DTO
-	response := s.convertToStudentResponse(student)
+	response := convertToStudentResponse(student)
 	utils.SuccessWithMsg(c, "创建学生成功", response)
 }
 
@@ -79,7 +99,14 @@ func (s *StudentController) Get(c *gin.Context) {
 		return
 	}
 
-	student, err := s.studentService.GetStudentByID(id)
+	// 解析字段选择
+	fs, err := utils.ParseFieldSet(c, studentFieldSpec)
+	if err != nil {
+		utils.ParamError(c, err.Error())
+		return
+	}
+
+	student, err := s.studentService.GetStudentByIDWithFields(c.Request.Context(), id, fs)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			utils.NotFound(c, "学生不存在")
@@ -90,8 +117,8 @@ func (s *StudentController) Get(c *gin.Context) {
 	}
 
 	// 转换为响应DTO
-	response := s.convertToStudentResponse(student)
-	utils.Success(c, response)
+	response := convertToStudentResponse(student)
+	utils.Success(c, fs.Apply(response))
 }
 
 // Update 更新学生
@@ -103,7 +130,7 @@ func (s *StudentController) Update(c *gin.Context) {
 	}
 
 	// 检查学生是否存在
-	existingStudent, err := s.studentService.GetStudentByID(id)
+	existingStudent, err := s.studentService.GetStudentByID(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			utils.NotFound(c, "学生不存在")
@@ -120,6 +147,12 @@ func (s *StudentController) Update(c *gin.Context) {
 		return
 	}
 
+	// 更换大学需要提交变更申请，经他人审批后生效
+	if (&service.StudentChange{UniversityID: req.UniversityID}).RequiresApproval(existingStudent) {
+		utils.BusinessError(c, service.ErrChangeApprovalRequired.Error())
+		return
+	}
+
 	// 更新学生属性
 	if req.Name != "" {
 		existingStudent.Name = req.Name
@@ -134,19 +167,28 @@ func (s *StudentController) Update(c *gin.Context) {
 		existingStudent.Phone = req.Phone
 	}
 	if req.UniversityID != nil {
+		// 更换大学且未同时指定专业时，原专业不再适用
+		if req.MajorID == nil && (existingStudent.UniversityID == nil || *existingStudent.UniversityID != *req.UniversityID) {
+			existingStudent.MajorID = nil
+		}
 		existingStudent.UniversityID = req.UniversityID
 	}
 	if req.Major != nil {
 		existingStudent.Major = req.Major
 	}
+	if req.MajorID != nil {
+		existingStudent.MajorID = req.MajorID
+	}
 	if req.Education != nil {
 		existingStudent.Education = req.Education
 	}
 	if req.GraduationYear != nil {
 		existingStudent.GraduationYear = req.GraduationYear
 	}
-	if req.Status != nil {
-		existingStudent.Status = req.Status
+	// 状态变更需要通过状态变更接口，以校验变更规则并记录历史
+	if req.Status != nil && (existingStudent.Status == nil || *req.Status != *existingStudent.Status) {
+		utils.BusinessError(c, service.ErrStudentStatusNotAllowed.Error())
+		return
 	}
 	if req.Remarks != nil {
 		existingStudent.Remarks = req.Remarks
@@ -163,13 +205,17 @@ func (s *StudentController) Update(c *gin.Context) {
 	}
 
 	// 更新学生
-	if err := s.studentService.UpdateStudent(existingStudent); err != nil {
-		utils.InternalError(c, "更新学生失败: "+err.Error())
+	if err := s.studentService.UpdateStudent(c.Request.Context(), existingStudent); err != nil {
+		if isStudentMajorError(err) || service.IsStudentStatusError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "更新学生失败: "+err.Error())
+		}
 		return
 	}
 
 	// 转换为响应DTO
-	response := s.convertToStudentResponse(existingStudent)
+	response := convertToStudentResponse(existingStudent)
 	utils.SuccessWithMsg(c, "更新学生成功", response)
 }
 
@@ -182,7 +228,7 @@ func (s *StudentController) Delete(c *gin.Context) {
 	}
 
 	// 检查学生是否存在
-	_, err = s.studentService.GetStudentByID(id)
+	_, err = s.studentService.GetStudentByID(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			utils.NotFound(c, "学生不存在")
@@ -193,7 +239,7 @@ func (s *StudentController) Delete(c *gin.Context) {
 	}
 
 	// 删除学生
-	if err := s.studentService.DeleteStudent(id); err != nil {
+	if err := s.studentService.DeleteStudent(c.Request.Context(), id); err != nil {
 		utils.InternalError(c, "删除学生失败: "+err.Error())
 		return
 	}
@@ -203,11 +249,40 @@ func (s *StudentController) Delete(c *gin.Context) {
 
 // List 获取学生列表
 func (s *StudentController) List(c *gin.Context) {
-	// 获取分页参数
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	filters, ok := parseStudentFilters(c)
+	if !ok {
+		return
+	}
+	s.listStudents(c, filters)
+}
 
-	// 获取筛选条件
+// MyAdvisees 获取当前用户作为导师正在指导的学生，筛选和分页参数与学生列表相同
+func (s *StudentController) MyAdvisees(c *gin.Context) {
+	uid := currentUserID(c)
+	if uid == nil {
+		utils.Unauthorized(c, "未登录")
+		return
+	}
+	teacher, err := s.teacherService.GetTeacherByUserID(c.Request.Context(), *uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.BusinessError(c, "当前用户没有教师档案")
+		} else {
+			utils.InternalError(c, "获取教师档案失败: "+err.Error())
+		}
+		return
+	}
+
+	filters, ok := parseStudentFilters(c)
+	if !ok {
+		return
+	}
+	filters["advisor_id"] = teacher.ID
+	s.listStudents(c, filters)
+}
+
+// parseStudentFilters 解析学生列表的筛选条件，参数无效时直接写入响应并返回 false
+func parseStudentFilters(c *gin.Context) (map[string]interface{}, bool) {
 	filters := make(map[string]interface{})
 
 	// 添加可筛选的字段
@@ -222,35 +297,240 @@ func (s *StudentController) List(c *gin.Context) {
 		}
 	}
 
+	if majorID := c.Query("major_id"); majorID != "" {
+		mID, err := strconv.ParseInt(majorID, 10, 64)
+		if err == nil {
+			filters["major_id"] = mID
+		}
+	}
+
 	if status := c.Query("status"); status != "" {
 		filters["status"] = status
 	}
 
+	// 当前由某位导师（教师ID）指导的学生
+	if advisorID := c.Query("advisor_id"); advisorID != "" {
+		aID, err := strconv.ParseInt(advisorID, 10, 64)
+		if err != nil {
+			utils.ParamError(c, "无效的advisor_id")
+			return nil, false
+		}
+		filters["advisor_id"] = aID
+	}
+
+	// 出勤率低于阈值（百分比）的学生，可配合 attendance_term 只统计某学期
+	if below := c.Query("attendance_below"); below != "" {
+		threshold, err := strconv.ParseFloat(below, 64)
+		if err != nil || threshold < 0 || threshold > 100 {
+			utils.ParamError(c, "无效的attendance_below，须为 0 到 100 之间的出勤率百分比")
+			return nil, false
+		}
+		filters["attendance_below"] = threshold / 100
+		if term := strings.TrimSpace(c.Query("attendance_term")); term != "" {
+			filters["attendance_term"] = term
+		}
+	}
+	return filters, true
+}
+
+// listStudents 按筛选条件分页查询学生并写入响应，支持偏移分页、游标分页和字段选择
+func (s *StudentController) listStudents(c *gin.Context, filters map[string]interface{}) {
+	// 获取分页参数
+	pageQuery := utils.GetPageQuery(c)
+
+	// 解析字段选择
+	fs, err := utils.ParseFieldSet(c, studentFieldSpec)
+	if err != nil {
+		utils.ParamError(c, err.Error())
+		return
+	}
+
+	// 游标分页
+	if pageQuery.UseCursor {
+		students, result, err := s.studentService.GetStudentListByCursor(c.Request.Context(), pageQuery, filters, fs)
+		if err != nil {
+			if errors.Is(err, utils.ErrInvalidCursor) || errors.Is(err, utils.ErrInvalidSort) {
+				utils.ParamError(c, err.Error())
+			} else {
+				utils.InternalError(c, "获取学生列表失败: "+err.Error())
+			}
+			return
+		}
+
+		utils.Success(c, fs.ApplyList(&dto.StudentListResponse{
+			List:       convertToStudentResponses(students),
+			Total:      result.Total,
+			Size:       pageQuery.PageSize,
+			NextCursor: result.NextCursor,
+		}))
+		return
+	}
+
 	// 获取学生列表
-	students, total, err := s.studentService.GetStudentList(page, pageSize, filters)
+	students, total, err := s.studentService.GetStudentList(c.Request.Context(), pageQuery.Page, pageQuery.PageSize, filters, fs)
 	if err != nil {
 		utils.InternalError(c, "获取学生列表失败: "+err.Error())
 		return
 	}
 
+	response := &dto.StudentListResponse{
+		List:  convertToStudentResponses(students),
+		Total: &total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	}
+
+	utils.Success(c, fs.ApplyList(response))
+}
+
+// ChangeStatus 变更学生状态（在读、休学、退学、毕业），按状态机校验并记录变更历史
+func (s *StudentController) ChangeStatus(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的学生ID")
+		return
+	}
+
+	// 绑定请求参数
+	var req dto.StudentStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+	// 退学需要提交变更申请，经他人审批后生效
+	if service.StatusChangeRequiresApproval(req.Status) {
+		utils.BusinessError(c, service.ErrChangeApprovalRequired.Error())
+		return
+	}
+	effectiveDate, err := time.ParseInLocation("2006-01-02", req.EffectiveDate, time.Local)
+	if err != nil {
+		utils.ParamError(c, "无效的生效日期")
+		return
+	}
+
+	transition := &service.StatusTransition{
+		Status:         req.Status,
+		EffectiveDate:  effectiveDate,
+		Reason:         req.Reason,
+		ApproverID:     req.ApproverID,
+		GraduationYear: req.GraduationYear,
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("user_id")
+	if exists {
+		uid := int64(userID.(uint))
+		transition.ActorID = &uid
+	}
+
+	student, err := s.studentStatusService.Transition(c.Request.Context(), id, transition)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "学生不存在")
+		case service.IsStudentStatusError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "变更学生状态失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "变更学生状态成功", convertToStudentResponse(student))
+}
+
+// StatusHistory 获取学生的状态变更记录
+func (s *StudentController) StatusHistory(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的学生ID")
+		return
+	}
+
+	histories, err := s.studentStatusService.GetStatusHistory(c.Request.Context(), id)
+	if err != nil {
+		utils.InternalError(c, "获取状态变更记录失败: "+err.Error())
+		return
+	}
+
+	responseList := []*dto.StudentStatusHistoryResponse{}
+	for _, history := range histories {
+		responseList = append(responseList, &dto.StudentStatusHistoryResponse{
+			ID:            history.ID,
+			StudentID:     history.StudentID,
+			FromStatus:    history.FromStatus,
+			ToStatus:      history.ToStatus,
+			EffectiveDate: history.EffectiveDate.Format("2006-01-02"),
+			Reason:        history.Reason,
+			ApproverID:    history.ApproverID,
+			CreatedAt:     history.CreatedAt,
+			CreatedBy:     history.CreatedBy,
+		})
+	}
+	utils.Success(c, responseList)
+}
+
+// Search 搜索学生（姓名、拼音、邮箱、电话、专业、备注），结果按相关度排序
+func (s *StudentController) Search(c *gin.Context) {
+	keyword := strings.TrimSpace(c.Query("q"))
+	if keyword == "" {
+		utils.ParamError(c, "搜索关键词不能为空")
+		return
+	}
+
+	pageQuery := utils.GetPageQuery(c)
+
+	hits, total, err := s.studentSearchService.Search(c.Request.Context(), keyword, pageQuery.Page, pageQuery.PageSize)
+	if err != nil {
+		utils.InternalError(c, "搜索学生失败: "+err.Error())
+		return
+	}
+
 	// 转换为响应DTO
-	var responseList []*dto.StudentResponse
-	for _, student := range students {
-		responseList = append(responseList, s.convertToStudentResponse(student))
+	var responseList []*dto.StudentSearchItem
+	for _, hit := range hits {
+		responseList = append(responseList, &dto.StudentSearchItem{
+			StudentResponse: convertToStudentResponse(hit.Student),
+			Score:           hit.Score,
+			Highlights:      hit.Highlights,
+		})
 	}
 
-	response := &dto.StudentListResponse{
+	utils.Success(c, &dto.StudentSearchResponse{
 		List:  responseList,
 		Total: total,
-		Page:  page,
-		Size:  pageSize,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	})
+}
+
+// Reindex 重建学生搜索索引
+func (s *StudentController) Reindex(c *gin.Context) {
+	count, err := s.studentSearchService.Reindex(c.Request.Context())
+	if err != nil {
+		utils.InternalError(c, "重建搜索索引失败: "+err.Error())
+		return
 	}
 
-	utils.Success(c, response)
+	utils.SuccessWithMsg(c, "重建搜索索引成功", gin.H{"count": count})
+}
+
+// isStudentMajorError 是否为学生关联专业的校验错误
+func isStudentMajorError(err error) bool {
+	return errors.Is(err, service.ErrMajorNotFound) || errors.Is(err, service.ErrMajorUniversityMismatch)
+}
+
+// 批量转换为学生响应DTO
+func convertToStudentResponses(students []*model.Student) []*dto.StudentResponse {
+	var responseList []*dto.StudentResponse
+	for _, student := range students {
+		responseList = append(responseList, convertToStudentResponse(student))
+	}
+	return responseList
 }
 
 // 转换为学生响应DTO
-func (s *StudentController) convertToStudentResponse(student *model.Student) *dto.StudentResponse {
+func convertToStudentResponse(student *model.Student) *dto.StudentResponse {
 	response := &dto.StudentResponse{
 		ID:             student.ID,
 		Name:           student.Name,
@@ -260,6 +540,7 @@ func (s *StudentController) convertToStudentResponse(student *model.Student) *dt
 		Phone:          student.Phone,
 		UniversityID:   student.UniversityID,
 		Major:          student.Major,
+		MajorID:        student.MajorID,
 		Education:      student.Education,
 		GraduationYear: student.GraduationYear,
 		Status:         student.Status,
@@ -272,19 +553,9 @@ func (s *StudentController) convertToStudentResponse(student *model.Student) *dt
 		UpdatedBy:      student.UpdatedBy,
 	}
 
-	// 如果有大学信息，添加大学响应
-	if student.UniversityID != nil && *student.UniversityID > 0 {
-		university, err := s.universityService.GetUniversityByID(*student.UniversityID)
-		if err == nil {
-			response.University = &dto.UniversityResponse{
-				ID:        university.ID,
-				Name:      university.Name,
-				CreatedAt: university.CreatedAt,
-				UpdatedAt: university.UpdatedAt,
-				CreatedBy: university.CreatedBy,
-				UpdatedBy: university.UpdatedBy,
-			}
-		}
+	// 所属大学由查询时预加载，不再逐条查询
+	if student.University != nil {
+		response.University = convertToUniversityResponse(student.University)
 	}
 
 	return response
@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// StudentDuplicateController 学生查重与合并控制器
+type StudentDuplicateController struct {
+	duplicateService *service.StudentDuplicateService
+	studentService   *service.StudentService
+}
+
+// NewStudentDuplicateController 创建学生查重与合并控制器
+func NewStudentDuplicateController(duplicateService *service.StudentDuplicateService, studentService *service.StudentService) *StudentDuplicateController {
+	return &StudentDuplicateController{
+		duplicateService: duplicateService,
+		studentService:   studentService,
+	}
+}
+
+// List 获取疑似重复的学生，按得分从高到低排序
+func (d *StudentDuplicateController) List(c *gin.Context) {
+	pageQuery := utils.GetPageQuery(c)
+
+	minScore := service.DefaultDuplicateMinScore
+	if value := c.Query("min_score"); value != "" {
+		score, err := strconv.Atoi(value)
+		if err != nil || score < 0 || score > 100 {
+			utils.ParamError(c, "min_score 须为 0-100 的整数")
+			return
+		}
+		minScore = score
+	}
+
+	pairs, err := d.duplicateService.FindDuplicates(c.Request.Context(), minScore)
+	if err != nil {
+		utils.InternalError(c, "查找重复学生失败: "+err.Error())
+		return
+	}
+
+	// 分页后只加载本页涉及的学生
+	total := int64(len(pairs))
+	start := pageQuery.Offset()
+	if start > len(pairs) {
+		start = len(pairs)
+	}
+	end := start + pageQuery.PageSize
+	if end > len(pairs) {
+		end = len(pairs)
+	}
+	pairs = pairs[start:end]
+
+	var ids []int64
+	for _, pair := range pairs {
+		ids = append(ids, pair.StudentIDs[0], pair.StudentIDs[1])
+	}
+	students, err := d.studentService.GetStudentsByIDs(c.Request.Context(), ids)
+	if err != nil {
+		utils.InternalError(c, "获取学生失败: "+err.Error())
+		return
+	}
+	byID := make(map[int64]*dto.StudentResponse, len(students))
+	for _, student := range students {
+		byID[student.ID] = convertToStudentResponse(student)
+	}
+
+	responseList := []*dto.StudentDuplicateResponse{}
+	for _, pair := range pairs {
+		a, b := byID[pair.StudentIDs[0]], byID[pair.StudentIDs[1]]
+		// 查重后被删除的学生忽略
+		if a == nil || b == nil {
+			continue
+		}
+		responseList = append(responseList, &dto.StudentDuplicateResponse{
+			Score:    pair.Score,
+			Reasons:  pair.Reasons,
+			Students: []*dto.StudentResponse{a, b},
+		})
+	}
+
+	utils.Success(c, &dto.StudentDuplicateListResponse{
+		List:  responseList,
+		Total: total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	})
+}
+
+// Merge 合并重复的学生
+func (d *StudentDuplicateController) Merge(c *gin.Context) {
+	var req dto.StudentMergeRequest
+
+	// 绑定请求参数
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	// 获取当前用户ID
+	var actorID *int64
+	userID, exists := c.Get("user_id")
+	if exists {
+		uid := int64(userID.(uint))
+		actorID = &uid
+	}
+
+	survivor, err := d.duplicateService.Merge(c.Request.Context(), req.SurvivorID, req.LoserID, req.Fields, actorID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnsupportedMergeField), errors.Is(err, service.ErrInvalidMergeFieldValue):
+			utils.ParamError(c, err.Error())
+		case errors.Is(err, service.ErrMergeSameStudent), errors.Is(err, service.ErrMergeReferenceConflict), isStudentMajorError(err), service.IsStudentStatusError(err):
+			utils.BusinessError(c, err.Error())
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "学生不存在")
+		default:
+			utils.InternalError(c, "合并学生失败: "+err.Error())
+		}
+		return
+	}
+
+	// 加载合并后学生所属的大学
+	if err := d.studentService.LoadUniversities(c.Request.Context(), []*model.Student{survivor}); err != nil {
+		utils.InternalError(c, "获取学生失败: "+err.Error())
+		return
+	}
+
+	utils.SuccessWithMsg(c, "合并成功", convertToStudentResponse(survivor))
+}
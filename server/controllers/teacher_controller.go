@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"errors"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// TeacherController 教师控制器
+type TeacherController struct {
+	teacherService *service.TeacherService
+}
+
+// NewTeacherController 创建教师控制器
+func NewTeacherController(teacherService *service.TeacherService) *TeacherController {
+	return &TeacherController{
+		teacherService: teacherService,
+	}
+}
+
+// Create 创建教师档案，教师档案关联一个登录用户
+func (t *TeacherController) Create(c *gin.Context) {
+	var req dto.TeacherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	teacher := &model.Teacher{}
+	applyTeacherRequest(teacher, &req)
+	uid := currentUserID(c)
+	teacher.CreatedBy = uid
+	teacher.UpdatedBy = uid
+
+	if err := t.teacherService.CreateTeacher(c.Request.Context(), teacher); err != nil {
+		if service.IsTeacherError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "创建教师失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "创建成功", convertToTeacherResponse(teacher))
+}
+
+// Get 获取教师详情
+func (t *TeacherController) Get(c *gin.Context) {
+	teacher, ok := t.findTeacher(c)
+	if !ok {
+		return
+	}
+	utils.Success(c, convertToTeacherResponse(teacher))
+}
+
+// Update 更新教师档案
+func (t *TeacherController) Update(c *gin.Context) {
+	teacher, ok := t.findTeacher(c)
+	if !ok {
+		return
+	}
+
+	var req dto.TeacherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+	if req.Status == "" {
+		req.Status = teacher.Status
+	}
+	applyTeacherRequest(teacher, &req)
+	teacher.UpdatedBy = currentUserID(c)
+
+	if err := t.teacherService.UpdateTeacher(c.Request.Context(), teacher); err != nil {
+		if service.IsTeacherError(err) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "更新教师失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "更新成功", convertToTeacherResponse(teacher))
+}
+
+// Delete 删除教师档案，有导师分配记录的教师不能删除
+func (t *TeacherController) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的教师ID")
+		return
+	}
+
+	if err := t.teacherService.DeleteTeacher(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "教师不存在")
+		case service.IsTeacherError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "删除教师失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "删除成功", nil)
+}
+
+// List 获取教师列表，支持按姓名、大学、院系、状态筛选
+func (t *TeacherController) List(c *gin.Context) {
+	pageQuery := utils.GetPageQuery(c)
+
+	filters := make(map[string]interface{})
+	if name := strings.TrimSpace(c.Query("name")); name != "" {
+		filters["name"] = name
+	}
+	for _, key := range []string{"university_id", "college_id"} {
+		if value := c.Query(key); value != "" {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				utils.ParamError(c, "无效的"+key)
+				return
+			}
+			filters[key] = id
+		}
+	}
+	if status := c.Query("status"); status != "" {
+		filters["status"] = status
+	}
+
+	teachers, total, err := t.teacherService.GetTeacherList(c.Request.Context(), pageQuery.Page, pageQuery.PageSize, filters)
+	if err != nil {
+		utils.InternalError(c, "获取教师列表失败: "+err.Error())
+		return
+	}
+
+	responseList := []*dto.TeacherResponse{}
+	for _, teacher := range teachers {
+		responseList = append(responseList, convertToTeacherResponse(teacher))
+	}
+	utils.Success(c, &dto.TeacherListResponse{
+		List:  responseList,
+		Total: total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	})
+}
+
+// findTeacher 按路径参数获取教师，失败时直接写入响应并返回 false
+func (t *TeacherController) findTeacher(c *gin.Context) (*model.Teacher, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的教师ID")
+		return nil, false
+	}
+
+	teacher, err := t.teacherService.GetTeacherByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "教师不存在")
+		} else {
+			utils.InternalError(c, "获取教师失败: "+err.Error())
+		}
+		return nil, false
+	}
+	return teacher, true
+}
+
+// applyTeacherRequest 把请求中的教师属性写入模型
+func applyTeacherRequest(teacher *model.Teacher, req *dto.TeacherRequest) {
+	teacher.UserID = req.UserID
+	teacher.EmployeeNo = req.EmployeeNo
+	teacher.Name = strings.TrimSpace(req.Name)
+	teacher.Title = req.Title
+	teacher.UniversityID = req.UniversityID
+	teacher.CollegeID = req.CollegeID
+	teacher.Email = req.Email
+	teacher.Phone = req.Phone
+	teacher.Status = req.Status
+}
+
+// 转换为教师响应DTO
+func convertToTeacherResponse(teacher *model.Teacher) *dto.TeacherResponse {
+	return &dto.TeacherResponse{
+		ID:           teacher.ID,
+		UserID:       teacher.UserID,
+		EmployeeNo:   teacher.EmployeeNo,
+		Name:         teacher.Name,
+		Title:        teacher.Title,
+		UniversityID: teacher.UniversityID,
+		CollegeID:    teacher.CollegeID,
+		Email:        teacher.Email,
+		Phone:        teacher.Phone,
+		Status:       teacher.Status,
+		CreatedAt:    teacher.CreatedAt,
+		UpdatedAt:    teacher.UpdatedAt,
+		CreatedBy:    teacher.CreatedBy,
+		UpdatedBy:    teacher.UpdatedBy,
+	}
+}
@@ -0,0 +1,184 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mvc-demo/dao/model"
+	"mvc-demo/models/dto"
+	"mvc-demo/service"
+	"mvc-demo/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// TranscriptController 成绩单控制器
+type TranscriptController struct {
+	transcriptService *service.TranscriptService
+}
+
+// NewTranscriptController 创建成绩单控制器
+func NewTranscriptController(transcriptService *service.TranscriptService) *TranscriptController {
+	return &TranscriptController{
+		transcriptService: transcriptService,
+	}
+}
+
+// Get 获取学生当前的成绩单，含学期绩点和累计绩点；format=pdf 时返回非正式的 PDF 预览
+func (t *TranscriptController) Get(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的学生ID")
+		return
+	}
+
+	transcript, err := t.transcriptService.GetTranscript(c.Request.Context(), studentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "学生不存在")
+		} else {
+			utils.InternalError(c, "生成成绩单失败: "+err.Error())
+		}
+		return
+	}
+
+	if c.Query("format") == "pdf" {
+		t.writePDF(c, transcript, fmt.Sprintf("transcript-%d-preview.pdf", studentID))
+		return
+	}
+	utils.Success(c, transcript)
+}
+
+// Issue 签发成绩单，保存快照并生成验证码，official 标记是否为正式成绩单
+func (t *TranscriptController) Issue(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的学生ID")
+		return
+	}
+
+	var req dto.IssueTranscriptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	record, _, err := t.transcriptService.Issue(c.Request.Context(), studentID, req.Official, currentUserID(c))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "学生不存在")
+		} else {
+			utils.InternalError(c, "签发成绩单失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessWithMsg(c, "签发成功", convertToIssuedTranscriptResponse(record, true))
+}
+
+// ListIssued 获取学生的已签发成绩单列表
+func (t *TranscriptController) ListIssued(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的学生ID")
+		return
+	}
+
+	records, err := t.transcriptService.GetIssuedList(c.Request.Context(), studentID)
+	if err != nil {
+		utils.InternalError(c, "获取已签发成绩单失败: "+err.Error())
+		return
+	}
+
+	responseList := []*dto.IssuedTranscriptResponse{}
+	for _, record := range records {
+		responseList = append(responseList, convertToIssuedTranscriptResponse(record, false))
+	}
+	utils.Success(c, responseList)
+}
+
+// GetIssued 获取已签发的成绩单；format=pdf 时返回带验证码的 PDF
+func (t *TranscriptController) GetIssued(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.ParamError(c, "无效的成绩单ID")
+		return
+	}
+
+	record, transcript, err := t.transcriptService.GetIssuedTranscript(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "成绩单不存在")
+		} else {
+			utils.InternalError(c, "获取成绩单失败: "+err.Error())
+		}
+		return
+	}
+
+	if c.Query("format") == "pdf" {
+		t.writePDF(c, transcript, fmt.Sprintf("transcript-%d-%s.pdf", record.StudentID, record.VerificationCode))
+		return
+	}
+	utils.Success(c, convertToIssuedTranscriptResponse(record, true))
+}
+
+// Verify 根据验证码核对成绩单，无需登录
+func (t *TranscriptController) Verify(c *gin.Context) {
+	record, transcript, err := t.transcriptService.Verify(c.Request.Context(), c.Param("code"))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, service.ErrInvalidVerificationCode):
+			utils.NotFound(c, "验证码无效，未找到对应的成绩单")
+		default:
+			utils.InternalError(c, "验证成绩单失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.Success(c, &dto.TranscriptVerifyResponse{
+		VerificationCode: service.FormatVerificationCode(record.VerificationCode),
+		Official:         record.Official,
+		StudentName:      transcript.StudentName,
+		UniversityName:   transcript.UniversityName,
+		CumulativeGPA:    record.CumulativeGpa,
+		EarnedCredits:    transcript.EarnedCredits,
+		IssuedAt:         record.IssuedAt,
+	})
+}
+
+// writePDF 渲染成绩单 PDF 并写入响应，渲染失败时返回 JSON 错误
+func (t *TranscriptController) writePDF(c *gin.Context, transcript *service.Transcript, filename string) {
+	var buf bytes.Buffer
+	if err := t.transcriptService.RenderPDF(c.Request.Context(), transcript, &buf); err != nil {
+		if errors.Is(err, service.ErrTranscriptFontNotConfigured) {
+			utils.BusinessError(c, err.Error())
+		} else {
+			utils.InternalError(c, "生成 PDF 失败: "+err.Error())
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, filename))
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// 转换为已签发成绩单响应DTO，withContent 为 true 时包含成绩单快照
+func convertToIssuedTranscriptResponse(record *model.Transcript, withContent bool) *dto.IssuedTranscriptResponse {
+	response := &dto.IssuedTranscriptResponse{
+		ID:               record.ID,
+		StudentID:        record.StudentID,
+		VerificationCode: service.FormatVerificationCode(record.VerificationCode),
+		Official:         record.Official,
+		CumulativeGPA:    record.CumulativeGpa,
+		IssuedBy:         record.IssuedBy,
+		IssuedAt:         record.IssuedAt,
+	}
+	if withContent && record.Content != "" {
+		response.Content = json.RawMessage(record.Content)
+	}
+	return response
+}
@@ -7,20 +7,31 @@ import (
 	"mvc-demo/service"
 	"mvc-demo/utils"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
+// universityFieldSpec 大学接口可通过 ?fields= 选择的字段和 ?include= 展开的关联
+var universityFieldSpec = func() *utils.FieldSpec {
+	spec := utils.NewFieldSpec("id", "name", "code", "short_name", "english_name", "province", "city",
+		"level_tags", "website", "logo", "created_at", "updated_at", "created_by", "updated_by")
+	spec.Includes["aliases"] = nil
+	return spec
+}()
+
 // UniversityController 大学控制器
 type UniversityController struct {
-	universityService *service.UniversityService
+	universityService      *service.UniversityService
+	universityMergeService *service.UniversityMergeService
 }
 
 // NewUniversityController 创建大学控制器
-func NewUniversityController(universityService *service.UniversityService) *UniversityController {
+func NewUniversityController(universityService *service.UniversityService, universityMergeService *service.UniversityMergeService) *UniversityController {
 	return &UniversityController{
-		universityService: universityService,
+		universityService:      universityService,
+		universityMergeService: universityMergeService,
 	}
 }
 
@@ -34,21 +45,11 @@ func (u *UniversityController) Create(c *gin.Context) {
 		return
 	}
 
-	// 检查大学名称是否已存在（包括已软删除的记录）
-	exists, err := u.universityService.CheckUniversityNameExistsWithDeleted(req.Name)
-	if err != nil {
-		utils.InternalError(c, "检查大学名称失败: "+err.Error())
-		return
-	}
-	if exists {
-		utils.BusinessError(c, "大学名称已存在，不能创建同名大学")
-		return
-	}
-
 	// 创建大学对象
 	university := &model.University{
 		Name: req.Name,
 	}
+	applyUniversityProfile(university, &req)
 
 	// 获取当前用户ID
 	userID, exists := c.Get("user_id")
@@ -58,22 +59,21 @@ func (u *UniversityController) Create(c *gin.Context) {
 		university.UpdatedBy = &uid
 	}
 
-	// 创建大学
-	if err := u.universityService.CreateUniversity(university); err != nil {
-		utils.InternalError(c, "创建大学失败: "+err.Error())
+	// 创建大学，名称、院校代码和别名的冲突检查在同一事务中完成
+	if err := u.universityService.CreateUniversity(c.Request.Context(), university, req.Aliases); err != nil {
+		switch {
+		case errors.Is(err, service.ErrUniversityNameExists):
+			utils.BusinessError(c, "大学名称已存在，不能创建同名大学")
+		case service.IsUniversityConflictError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "创建大学失败: "+err.Error())
+		}
 		return
 	}
 
 	// 转换为响应DTO
-	response := &dto.UniversityResponse{
-		ID:        university.ID,
-		Name:      university.Name,
-		CreatedAt: university.CreatedAt,
-		UpdatedAt: university.UpdatedAt,
-		CreatedBy: university.CreatedBy,
-		UpdatedBy: university.UpdatedBy,
-	}
-
+	response := convertToUniversityResponse(university)
 	utils.SuccessWithMsg(c, "创建成功", response)
 }
 
@@ -85,7 +85,14 @@ func (u *UniversityController) Get(c *gin.Context) {
 		return
 	}
 
-	university, err := u.universityService.GetUniversityByID(id)
+	// 解析字段选择
+	fs, err := utils.ParseFieldSet(c, universityFieldSpec)
+	if err != nil {
+		utils.ParamError(c, err.Error())
+		return
+	}
+
+	university, err := u.universityService.GetUniversityByIDWithFields(c.Request.Context(), id, fs)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			utils.NotFound(c, "大学不存在")
@@ -96,16 +103,8 @@ func (u *UniversityController) Get(c *gin.Context) {
 	}
 
 	// 转换为响应DTO
-	response := &dto.UniversityResponse{
-		ID:        university.ID,
-		Name:      university.Name,
-		CreatedAt: university.CreatedAt,
-		UpdatedAt: university.UpdatedAt,
-		CreatedBy: university.CreatedBy,
-		UpdatedBy: university.UpdatedBy,
-	}
-
-	utils.Success(c, response)
+	response := convertToUniversityResponse(university)
+	utils.Success(c, fs.Apply(response))
 }
 
 // Update 更新大学
@@ -117,7 +116,7 @@ func (u *UniversityController) Update(c *gin.Context) {
 	}
 
 	// 检查大学是否存在
-	existingUniversity, err := u.universityService.GetUniversityByID(id)
+	existingUniversity, err := u.universityService.GetUniversityByID(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			utils.NotFound(c, "大学不存在")
@@ -134,21 +133,9 @@ func (u *UniversityController) Update(c *gin.Context) {
 		return
 	}
 
-	// 如果要修改名称，检查新名称是否与其他大学（包括已删除的）冲突
-	if req.Name != existingUniversity.Name {
-		exists, err := u.universityService.CheckUniversityNameExistsExcludeID(req.Name, id)
-		if err != nil {
-			utils.InternalError(c, "检查大学名称失败: "+err.Error())
-			return
-		}
-		if exists {
-			utils.BusinessError(c, "大学名称已存在，不能修改为此名称")
-			return
-		}
-	}
-
 	// 更新大学属性
 	existingUniversity.Name = req.Name
+	applyUniversityProfile(existingUniversity, &req)
 
 	// 获取当前用户ID，设置更新者
 	userID, exists := c.Get("user_id")
@@ -157,22 +144,21 @@ func (u *UniversityController) Update(c *gin.Context) {
 		existingUniversity.UpdatedBy = &uid
 	}
 
-	// 更新大学
-	if err := u.universityService.UpdateUniversity(existingUniversity); err != nil {
-		utils.InternalError(c, "更新大学失败: "+err.Error())
+	// 更新大学，名称、院校代码和别名的冲突检查在同一事务中完成
+	if err := u.universityService.UpdateUniversity(c.Request.Context(), existingUniversity, req.Aliases); err != nil {
+		switch {
+		case errors.Is(err, service.ErrUniversityNameExists):
+			utils.BusinessError(c, "大学名称已存在，不能修改为此名称")
+		case service.IsUniversityConflictError(err):
+			utils.BusinessError(c, err.Error())
+		default:
+			utils.InternalError(c, "更新大学失败: "+err.Error())
+		}
 		return
 	}
 
 	// 转换为响应DTO
-	response := &dto.UniversityResponse{
-		ID:        existingUniversity.ID,
-		Name:      existingUniversity.Name,
-		CreatedAt: existingUniversity.CreatedAt,
-		UpdatedAt: existingUniversity.UpdatedAt,
-		CreatedBy: existingUniversity.CreatedBy,
-		UpdatedBy: existingUniversity.UpdatedBy,
-	}
-
+	response := convertToUniversityResponse(existingUniversity)
 	utils.SuccessWithMsg(c, "更新成功", response)
 }
 
@@ -185,7 +171,7 @@ func (u *UniversityController) Delete(c *gin.Context) {
 	}
 
 	// 检查大学是否存在
-	_, err = u.universityService.GetUniversityByID(id)
+	_, err = u.universityService.GetUniversityByID(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			utils.NotFound(c, "大学不存在")
@@ -196,7 +182,7 @@ func (u *UniversityController) Delete(c *gin.Context) {
 	}
 
 	// 删除大学
-	if err := u.universityService.DeleteUniversity(id); err != nil {
+	if err := u.universityService.DeleteUniversity(c.Request.Context(), id); err != nil {
 		utils.InternalError(c, "删除大学失败: "+err.Error())
 		return
 	}
@@ -207,60 +193,234 @@ func (u *UniversityController) Delete(c *gin.Context) {
 // List 获取大学列表
 func (u *UniversityController) List(c *gin.Context) {
 	// 获取分页参数
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	pageQuery := utils.GetPageQuery(c)
 
-	// 获取大学列表
-	universities, total, err := u.universityService.GetUniversityList(page, pageSize)
+	// 解析字段选择
+	fs, err := utils.ParseFieldSet(c, universityFieldSpec)
 	if err != nil {
-		utils.InternalError(c, "获取大学列表失败: "+err.Error())
+		utils.ParamError(c, err.Error())
 		return
 	}
 
-	// 转换为响应DTO
-	var responseList []*dto.UniversityResponse
-	for _, university := range universities {
-		responseList = append(responseList, &dto.UniversityResponse{
-			ID:        university.ID,
-			Name:      university.Name,
-			CreatedAt: university.CreatedAt,
-			UpdatedAt: university.UpdatedAt,
-			CreatedBy: university.CreatedBy,
-			UpdatedBy: university.UpdatedBy,
-		})
+	// 获取筛选条件
+	filters := make(map[string]interface{})
+
+	if keyword := strings.TrimSpace(c.Query("keyword")); keyword != "" {
+		filters["keyword"] = keyword
+	}
+
+	if province := c.Query("province"); province != "" {
+		filters["province"] = province
+	}
+
+	if levelTag := c.Query("level_tag"); levelTag != "" {
+		filters["level_tag"] = levelTag
+	}
+
+	// 游标分页
+	if pageQuery.UseCursor {
+		universities, result, err := u.universityService.GetUniversityListByCursor(c.Request.Context(), pageQuery, filters, fs)
+		if err != nil {
+			if errors.Is(err, utils.ErrInvalidCursor) || errors.Is(err, utils.ErrInvalidSort) {
+				utils.ParamError(c, err.Error())
+			} else {
+				utils.InternalError(c, "获取大学列表失败: "+err.Error())
+			}
+			return
+		}
+
+		utils.Success(c, fs.ApplyList(&dto.UniversityListResponse{
+			List:       convertToUniversityResponses(universities),
+			Total:      result.Total,
+			Size:       pageQuery.PageSize,
+			NextCursor: result.NextCursor,
+		}))
+		return
+	}
+
+	// 获取大学列表
+	universities, total, err := u.universityService.GetUniversityList(c.Request.Context(), pageQuery.Page, pageQuery.PageSize, filters, fs)
+	if err != nil {
+		utils.InternalError(c, "获取大学列表失败: "+err.Error())
+		return
 	}
 
 	response := &dto.UniversityListResponse{
-		List:  responseList,
-		Total: total,
-		Page:  page,
-		Size:  pageSize,
+		List:  convertToUniversityResponses(universities),
+		Total: &total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
 	}
 
-	utils.Success(c, response)
+	utils.Success(c, fs.ApplyList(response))
 }
 
 // All 获取所有大学（下拉列表使用）
 func (u *UniversityController) All(c *gin.Context) {
+	// 解析字段选择
+	fs, err := utils.ParseFieldSet(c, universityFieldSpec)
+	if err != nil {
+		utils.ParamError(c, err.Error())
+		return
+	}
+
 	// 获取所有大学
-	universities, err := u.universityService.GetAllUniversities()
+	universities, err := u.universityService.GetAllUniversities(c.Request.Context(), fs)
 	if err != nil {
 		utils.InternalError(c, "获取大学列表失败: "+err.Error())
 		return
 	}
 
-	// 转换为响应DTO
+	utils.Success(c, fs.Apply(convertToUniversityResponses(universities)))
+}
+
+// Resolve 按名称、别名或简称查找大学，用于导入数据时把“北大”等简称解析为大学
+func (u *UniversityController) Resolve(c *gin.Context) {
+	name := strings.TrimSpace(c.Query("name"))
+	if name == "" {
+		utils.ParamError(c, "大学名称不能为空")
+		return
+	}
+
+	university, err := u.universityService.GetUniversityByName(c.Request.Context(), name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.NotFound(c, "大学不存在")
+		} else {
+			utils.InternalError(c, "获取大学失败: "+err.Error())
+		}
+		return
+	}
+
+	utils.Success(c, convertToUniversityResponse(university))
+}
+
+// Merge 合并重复的大学，dry_run 为 true 时只预览受影响的数据
+func (u *UniversityController) Merge(c *gin.Context) {
+	var req dto.UniversityMergeRequest
+
+	// 绑定请求参数
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ParamError(c, "参数错误: "+err.Error())
+		return
+	}
+
+	// 获取当前用户ID
+	var actorID *int64
+	userID, exists := c.Get("user_id")
+	if exists {
+		uid := int64(userID.(uint))
+		actorID = &uid
+	}
+
+	result, err := u.universityMergeService.Merge(c.Request.Context(), req.SourceID, req.TargetID, actorID, req.DryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrMergeSameUniversity), errors.Is(err, service.ErrMergeCourseCodeConflict):
+			utils.BusinessError(c, err.Error())
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.NotFound(c, "大学不存在")
+		default:
+			utils.InternalError(c, "合并大学失败: "+err.Error())
+		}
+		return
+	}
+
+	response := &dto.UniversityMergeResponse{
+		Source:         convertToUniversityResponse(result.Source),
+		Target:         convertToUniversityResponse(result.Target),
+		DryRun:         result.DryRun,
+		Students:       result.Students,
+		Courses:        result.Courses,
+		Teachers:       result.Teachers,
+		ConflictCodes:  result.ConflictCodes,
+		CollegesMoved:  result.CollegesMoved,
+		CollegesMerged: result.CollegesMerged,
+		MajorsMoved:    result.MajorsMoved,
+		MajorsMerged:   result.MajorsMerged,
+		AddedAliases:   result.AddedAliases,
+		SkippedAliases: result.SkippedAliases,
+	}
+
+	if result.DryRun {
+		utils.Success(c, response)
+		return
+	}
+	utils.SuccessWithMsg(c, "合并成功", response)
+}
+
+// applyUniversityProfile 把请求中的大学资料写入模型，未传的字段保持不变
+func applyUniversityProfile(university *model.University, req *dto.UniversityRequest) {
+	if req.Code != nil {
+		university.Code = emptyToNil(req.Code)
+	}
+	if req.ShortName != nil {
+		university.ShortName = emptyToNil(req.ShortName)
+	}
+	if req.EnglishName != nil {
+		university.EnglishName = emptyToNil(req.EnglishName)
+	}
+	if req.Province != nil {
+		university.Province = emptyToNil(req.Province)
+	}
+	if req.City != nil {
+		university.City = emptyToNil(req.City)
+	}
+	if req.LevelTags != nil {
+		tags := strings.Join(req.LevelTags, ",")
+		university.LevelTags = emptyToNil(&tags)
+	}
+	if req.Website != nil {
+		university.Website = emptyToNil(req.Website)
+	}
+	if req.Logo != nil {
+		university.Logo = emptyToNil(req.Logo)
+	}
+}
+
+// emptyToNil 空字符串转换为 nil，用于清空可选字段（院校代码有唯一索引，不能保存空字符串）
+func emptyToNil(value *string) *string {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	return &trimmed
+}
+
+// 转换为大学响应DTO
+func convertToUniversityResponse(university *model.University) *dto.UniversityResponse {
+	response := &dto.UniversityResponse{
+		ID:          university.ID,
+		Name:        university.Name,
+		Code:        university.Code,
+		ShortName:   university.ShortName,
+		EnglishName: university.EnglishName,
+		Province:    university.Province,
+		City:        university.City,
+		LevelTags:   []string{},
+		Website:     university.Website,
+		Logo:        university.Logo,
+		CreatedAt:   university.CreatedAt,
+		UpdatedAt:   university.UpdatedAt,
+		CreatedBy:   university.CreatedBy,
+		UpdatedBy:   university.UpdatedBy,
+	}
+
+	if university.LevelTags != nil && *university.LevelTags != "" {
+		response.LevelTags = strings.Split(*university.LevelTags, ",")
+	}
+	for _, alias := range university.Aliases {
+		response.Aliases = append(response.Aliases, alias.Alias)
+	}
+
+	return response
+}
+
+// 批量转换为大学响应DTO
+func convertToUniversityResponses(universities []*model.University) []*dto.UniversityResponse {
 	var responseList []*dto.UniversityResponse
 	for _, university := range universities {
-		responseList = append(responseList, &dto.UniversityResponse{
-			ID:        university.ID,
-			Name:      university.Name,
-			CreatedAt: university.CreatedAt,
-			UpdatedAt: university.UpdatedAt,
-			CreatedBy: university.CreatedBy,
-			UpdatedBy: university.UpdatedBy,
-		})
+		responseList = append(responseList, convertToUniversityResponse(university))
 	}
-
-	utils.Success(c, responseList)
+	return responseList
 }
@@ -13,6 +13,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// userFieldSpec 用户接口可通过 ?fields= 选择的字段（不包含密码）
+var userFieldSpec = utils.NewFieldSpec("id", "email", "username", "role", "status", "last_login_time", "created_at", "updated_at")
+
 // UserController 用户控制器
 type UserController struct {
 	userService *service.UserService
@@ -44,7 +47,7 @@ func (u *UserController) Create(c *gin.Context) {
 	}
 
 	// 创建用户
-	if err := u.userService.CreateUser(user); err != nil {
+	if err := u.userService.CreateUser(c.Request.Context(), user); err != nil {
 		utils.InternalError(c, "创建用户失败: "+err.Error())
 		return
 	}
@@ -72,7 +75,14 @@ func (u *UserController) Get(c *gin.Context) {
 		return
 	}
 
-	user, err := u.userService.GetUserByID(id)
+	// 解析字段选择
+	fs, err := utils.ParseFieldSet(c, userFieldSpec)
+	if err != nil {
+		utils.ParamError(c, err.Error())
+		return
+	}
+
+	user, err := u.userService.GetUserByIDWithFields(c.Request.Context(), id, fs)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			utils.NotFound(c, "用户不存在")
@@ -94,7 +104,7 @@ func (u *UserController) Get(c *gin.Context) {
 		UpdatedAt:     user.UpdatedAt,
 	}
 
-	utils.Success(c, response)
+	utils.Success(c, fs.Apply(response))
 }
 
 // Update 更新用户
@@ -106,7 +116,7 @@ func (u *UserController) Update(c *gin.Context) {
 	}
 
 	// 检查用户是否存在
-	existingUser, err := u.userService.GetUserByID(id)
+	existingUser, err := u.userService.GetUserByID(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			utils.NotFound(c, "用户不存在")
@@ -145,7 +155,7 @@ func (u *UserController) Update(c *gin.Context) {
 	}
 
 	// 更新用户
-	if err := u.userService.UpdateUser(existingUser); err != nil {
+	if err := u.userService.UpdateUser(c.Request.Context(), existingUser); err != nil {
 		utils.InternalError(c, err.Error())
 		return
 	}
@@ -174,7 +184,7 @@ func (u *UserController) Delete(c *gin.Context) {
 	}
 
 	// 检查用户是否存在
-	_, err = u.userService.GetUserByID(id)
+	_, err = u.userService.GetUserByID(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			utils.NotFound(c, "用户不存在")
@@ -185,7 +195,7 @@ func (u *UserController) Delete(c *gin.Context) {
 	}
 
 	// 删除用户
-	if err := u.userService.DeleteUser(id); err != nil {
+	if err := u.userService.DeleteUser(c.Request.Context(), id); err != nil {
 		utils.InternalError(c, err.Error())
 		return
 	}
@@ -196,17 +206,55 @@ func (u *UserController) Delete(c *gin.Context) {
 // List 获取用户列表
 func (u *UserController) List(c *gin.Context) {
 	// 获取分页参数
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	pageQuery := utils.GetPageQuery(c)
+
+	// 解析字段选择
+	fs, err := utils.ParseFieldSet(c, userFieldSpec)
+	if err != nil {
+		utils.ParamError(c, err.Error())
+		return
+	}
+
+	// 游标分页
+	if pageQuery.UseCursor {
+		users, result, err := u.userService.GetUserListByCursor(c.Request.Context(), pageQuery, fs)
+		if err != nil {
+			if errors.Is(err, utils.ErrInvalidCursor) || errors.Is(err, utils.ErrInvalidSort) {
+				utils.ParamError(c, err.Error())
+			} else {
+				utils.InternalError(c, "获取用户列表失败: "+err.Error())
+			}
+			return
+		}
+
+		utils.Success(c, fs.ApplyList(&dto.UserListResponse{
+			List:       convertToUserResponses(users),
+			Total:      result.Total,
+			Size:       pageQuery.PageSize,
+			NextCursor: result.NextCursor,
+		}))
+		return
+	}
 
 	// 获取用户列表
-	users, total, err := u.userService.GetUserList(page, pageSize)
+	users, total, err := u.userService.GetUserList(c.Request.Context(), pageQuery.Page, pageQuery.PageSize, fs)
 	if err != nil {
 		utils.InternalError(c, "获取用户列表失败: "+err.Error())
 		return
 	}
 
-	// 转换为响应DTO
+	response := &dto.UserListResponse{
+		List:  convertToUserResponses(users),
+		Total: &total,
+		Page:  pageQuery.Page,
+		Size:  pageQuery.PageSize,
+	}
+
+	utils.Success(c, fs.ApplyList(response))
+}
+
+// 批量转换为用户响应DTO
+func convertToUserResponses(users []*model.User) []*dto.UserResponse {
 	var responseList []*dto.UserResponse
 	for _, user := range users {
 		responseList = append(responseList, &dto.UserResponse{
@@ -220,13 +268,5 @@ func (u *UserController) List(c *gin.Context) {
 			UpdatedAt:     user.UpdatedAt,
 		})
 	}
-
-	response := &dto.UserListResponse{
-		List:  responseList,
-		Total: total,
-		Page:  page,
-		Size:  pageSize,
-	}
-
-	utils.Success(c, response)
+	return responseList
 }
@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AdvisorAssignmentDAO 导师分配数据访问对象
+type AdvisorAssignmentDAO struct {
+	DB *gorm.DB
+}
+
+// NewAdvisorAssignmentDAO 创建导师分配DAO实例
+func NewAdvisorAssignmentDAO(db *gorm.DB) *AdvisorAssignmentDAO {
+	return &AdvisorAssignmentDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *AdvisorAssignmentDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 创建导师分配记录
+func (dao *AdvisorAssignmentDAO) Create(ctx context.Context, assignment *model.AdvisorAssignment) error {
+	return dao.db(ctx).Omit("Teacher").Create(assignment).Error
+}
+
+// Update 更新导师分配记录
+func (dao *AdvisorAssignmentDAO) Update(ctx context.Context, assignment *model.AdvisorAssignment) error {
+	return dao.db(ctx).Omit("Teacher").Save(assignment).Error
+}
+
+// GetLatestByStudent 获取学生开始日期最晚的一条导师分配记录，同时加载导师
+func (dao *AdvisorAssignmentDAO) GetLatestByStudent(ctx context.Context, studentID int64) (*model.AdvisorAssignment, error) {
+	var assignment model.AdvisorAssignment
+	err := dao.db(ctx).Preload("Teacher").Where("student_id = ?", studentID).Order("start_date DESC, id DESC").First(&assignment).Error
+	return &assignment, err
+}
+
+// GetByStudent 获取学生的全部导师分配记录，按开始日期倒序，同时加载导师
+func (dao *AdvisorAssignmentDAO) GetByStudent(ctx context.Context, studentID int64) ([]*model.AdvisorAssignment, error) {
+	var assignments []*model.AdvisorAssignment
+	err := dao.db(ctx).Preload("Teacher").Where("student_id = ?", studentID).Order("start_date DESC, id DESC").Find(&assignments).Error
+	return assignments, err
+}
+
+// CountByTeacher 统计教师的导师分配记录数（含历史记录）
+func (dao *AdvisorAssignmentDAO) CountByTeacher(ctx context.Context, teacherID int64) (int64, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.AdvisorAssignment{}).Where("teacher_id = ?", teacherID).Count(&count).Error
+	return count, err
+}
+
+// CountCurrentByTeacher 统计教师在某天及以后仍在指导的学生数，包括尚未生效的分配
+func (dao *AdvisorAssignmentDAO) CountCurrentByTeacher(ctx context.Context, teacherID int64, date time.Time) (int64, error) {
+	var count int64
+	db := dao.db(ctx)
+	err := db.Model(&model.AdvisorAssignment{}).
+		Where("teacher_id = ? AND (end_date IS NULL OR "+dateColumn(db, "end_date")+" > ?)", teacherID, dateValue(date)).
+		Count(&count).Error
+	return count, err
+}
+
+// adviseeQuery 某天由教师指导的学生ID子查询，分配记录在开始日期（含）到结束日期（不含）之间有效
+func adviseeQuery(db *gorm.DB, teacherID int64, date time.Time) *gorm.DB {
+	day := dateValue(date)
+	return db.Model(&model.AdvisorAssignment{}).Select("student_id").
+		Where("teacher_id = ? AND "+dateColumn(db, "start_date")+" <= ? AND (end_date IS NULL OR "+dateColumn(db, "end_date")+" > ?)", teacherID, day, day)
+}
@@ -0,0 +1,95 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+
+	"gorm.io/gorm"
+)
+
+// AttendanceSummary 学生在一个教学班的考勤统计
+type AttendanceSummary struct {
+	SectionID int64
+	StudentID int64
+	Present   int64
+	Absent    int64
+	Late      int64
+	Excused   int64
+}
+
+// attendanceCountColumns 按状态统计考勤次数的列
+const attendanceCountColumns = "SUM(CASE WHEN ar.status = 'present' THEN 1 ELSE 0 END) AS present, " +
+	"SUM(CASE WHEN ar.status = 'absent' THEN 1 ELSE 0 END) AS absent, " +
+	"SUM(CASE WHEN ar.status = 'late' THEN 1 ELSE 0 END) AS late, " +
+	"SUM(CASE WHEN ar.status = 'excused' THEN 1 ELSE 0 END) AS excused"
+
+// AttendanceDAO 考勤记录数据访问对象
+type AttendanceDAO struct {
+	DB *gorm.DB
+}
+
+// NewAttendanceDAO 创建考勤记录DAO实例
+func NewAttendanceDAO(db *gorm.DB) *AttendanceDAO {
+	return &AttendanceDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *AttendanceDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 创建考勤记录
+func (dao *AttendanceDAO) Create(ctx context.Context, record *model.AttendanceRecord) error {
+	return dao.db(ctx).Create(record).Error
+}
+
+// Update 更新考勤记录
+func (dao *AttendanceDAO) Update(ctx context.Context, record *model.AttendanceRecord) error {
+	return dao.db(ctx).Save(record).Error
+}
+
+// GetBySession 获取课次的全部考勤记录
+func (dao *AttendanceDAO) GetBySession(ctx context.Context, sessionID int64) ([]*model.AttendanceRecord, error) {
+	var records []*model.AttendanceRecord
+	err := dao.db(ctx).Where("session_id = ?", sessionID).Order("student_id").Find(&records).Error
+	return records, err
+}
+
+// DeleteBySession 删除课次的全部考勤记录
+func (dao *AttendanceDAO) DeleteBySession(ctx context.Context, sessionID int64) error {
+	return dao.db(ctx).Where("session_id = ?", sessionID).Delete(&model.AttendanceRecord{}).Error
+}
+
+// Summarize 按教学班和学生统计各状态的考勤次数，filters 支持 student_id、section_id、term
+func (dao *AttendanceDAO) Summarize(ctx context.Context, filters map[string]interface{}) ([]*AttendanceSummary, error) {
+	var rows []*AttendanceSummary
+	query := dao.db(ctx).Table("attendance_records AS ar").
+		Select("cs.section_id, ar.student_id, " + attendanceCountColumns).
+		Joins("JOIN class_sessions AS cs ON cs.id = ar.session_id")
+	for key, value := range filters {
+		switch key {
+		case "student_id":
+			query = query.Where("ar.student_id = ?", value)
+		case "section_id":
+			query = query.Where("cs.section_id = ?", value)
+		case "term":
+			query = query.Joins("JOIN course_sections AS s ON s.id = cs.section_id").Where("s.term = ?", value)
+		}
+	}
+	err := query.Group("cs.section_id, ar.student_id").Order("cs.section_id, ar.student_id").Scan(&rows).Error
+	return rows, err
+}
+
+// attendanceBelowQuery 出勤率低于 threshold（0-1）的学生ID子查询，term 不为空时只统计该学期
+// 出勤率 = (出勤 + 迟到) / (总次数 - 请假)，没有需要计入的考勤记录的学生不在结果中
+func attendanceBelowQuery(db *gorm.DB, threshold float64, term string) *gorm.DB {
+	query := db.Table("attendance_records AS ar").Select("ar.student_id")
+	if term != "" {
+		query = query.Joins("JOIN class_sessions AS cs ON cs.id = ar.session_id").
+			Joins("JOIN course_sections AS s ON s.id = cs.section_id").
+			Where("s.term = ?", term)
+	}
+	return query.Group("ar.student_id").
+		Having("SUM(CASE WHEN ar.status <> 'excused' THEN 1 ELSE 0 END) > 0").
+		Having("SUM(CASE WHEN ar.status IN ('present', 'late') THEN 1 ELSE 0 END) < ? * SUM(CASE WHEN ar.status <> 'excused' THEN 1 ELSE 0 END)", threshold)
+}
@@ -0,0 +1,28 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogDAO 审计日志数据访问对象
+type AuditLogDAO struct {
+	DB *gorm.DB
+}
+
+// NewAuditLogDAO 创建审计日志DAO实例
+func NewAuditLogDAO(db *gorm.DB) *AuditLogDAO {
+	return &AuditLogDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *AuditLogDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 写入审计日志
+func (dao *AuditLogDAO) Create(ctx context.Context, log *model.AuditLog) error {
+	return dao.db(ctx).Create(log).Error
+}
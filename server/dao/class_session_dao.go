@@ -0,0 +1,108 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ClassSessionDAO 课次数据访问对象
+type ClassSessionDAO struct {
+	DB *gorm.DB
+}
+
+// NewClassSessionDAO 创建课次DAO实例
+func NewClassSessionDAO(db *gorm.DB) *ClassSessionDAO {
+	return &ClassSessionDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *ClassSessionDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 创建课次
+func (dao *ClassSessionDAO) Create(ctx context.Context, session *model.ClassSession) error {
+	return dao.db(ctx).Omit("Section").Create(session).Error
+}
+
+// CreateBatch 批量创建课次
+func (dao *ClassSessionDAO) CreateBatch(ctx context.Context, sessions []*model.ClassSession) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+	return dao.db(ctx).Omit("Section").Create(&sessions).Error
+}
+
+// GetByID 根据ID获取课次，同时加载教学班和课程
+func (dao *ClassSessionDAO) GetByID(ctx context.Context, id int64) (*model.ClassSession, error) {
+	var session model.ClassSession
+	err := dao.db(ctx).Preload("Section.Course").First(&session, id).Error
+	return &session, err
+}
+
+// CheckExists 检查教学班同一天同一开始时间是否已有排除某ID外的课次，excludeID 为 0 时不排除
+func (dao *ClassSessionDAO) CheckExists(ctx context.Context, sectionID int64, date time.Time, startTime string, excludeID int64) (bool, error) {
+	var count int64
+	db := dao.db(ctx)
+	err := db.Model(&model.ClassSession{}).
+		Where("section_id = ? AND "+dateColumn(db, "session_date")+" = ? AND start_time = ? AND id != ?", sectionID, dateValue(date), startTime, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetBySectionBetween 获取教学班在日期范围内（含首尾）的课次
+func (dao *ClassSessionDAO) GetBySectionBetween(ctx context.Context, sectionID int64, from, to time.Time) ([]*model.ClassSession, error) {
+	var sessions []*model.ClassSession
+	db := dao.db(ctx)
+	err := db.Where("section_id = ? AND "+dateColumn(db, "session_date")+" BETWEEN ? AND ?", sectionID, dateValue(from), dateValue(to)).
+		Order("session_date, start_time").Find(&sessions).Error
+	return sessions, err
+}
+
+// Update 更新课次
+func (dao *ClassSessionDAO) Update(ctx context.Context, session *model.ClassSession) error {
+	return dao.db(ctx).Omit("Section").Save(session).Error
+}
+
+// Delete 删除课次
+func (dao *ClassSessionDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.ClassSession{}, id).Error
+}
+
+// CountBySection 统计教学班的课次数
+func (dao *ClassSessionDAO) CountBySection(ctx context.Context, sectionID int64) (int64, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.ClassSession{}).Where("section_id = ?", sectionID).Count(&count).Error
+	return count, err
+}
+
+// GetList 获取课次列表（支持按教学班、日期范围筛选），按上课时间排序，同时加载教学班和课程
+func (dao *ClassSessionDAO) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.ClassSession, int64, error) {
+	var sessions []*model.ClassSession
+	var total int64
+
+	query := dao.db(ctx).Model(&model.ClassSession{})
+	for key, value := range filters {
+		switch key {
+		case "date_from":
+			query = query.Where(dateColumn(query, "session_date")+" >= ?", value)
+		case "date_to":
+			query = query.Where(dateColumn(query, "session_date")+" <= ?", value)
+		default:
+			query = query.Where(key+" = ?", value)
+		}
+	}
+
+	// 查询总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取数据列表
+	offset := (page - 1) * pageSize
+	err := query.Preload("Section.Course").Order("session_date, start_time, id").Offset(offset).Limit(pageSize).Find(&sessions).Error
+	return sessions, total, err
+}
@@ -0,0 +1,66 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+
+	"gorm.io/gorm"
+)
+
+// CollegeDAO 院系数据访问对象
+type CollegeDAO struct {
+	DB *gorm.DB
+}
+
+// NewCollegeDAO 创建院系DAO实例
+func NewCollegeDAO(db *gorm.DB) *CollegeDAO {
+	return &CollegeDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *CollegeDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 创建院系
+func (dao *CollegeDAO) Create(ctx context.Context, college *model.College) error {
+	return dao.db(ctx).Create(college).Error
+}
+
+// GetByID 根据ID获取院系
+func (dao *CollegeDAO) GetByID(ctx context.Context, id int64) (*model.College, error) {
+	var college model.College
+	err := dao.db(ctx).First(&college, id).Error
+	return &college, err
+}
+
+// CheckNameExists 检查同一大学下排除某ID外是否存在同名院系，excludeID 为 0 时不排除
+func (dao *CollegeDAO) CheckNameExists(ctx context.Context, universityID int64, name string, excludeID int64) (bool, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.College{}).
+		Where("university_id = ? AND name = ? AND id != ?", universityID, name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Update 更新院系
+func (dao *CollegeDAO) Update(ctx context.Context, college *model.College) error {
+	return dao.db(ctx).Save(college).Error
+}
+
+// Delete 删除院系
+func (dao *CollegeDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.College{}, id).Error
+}
+
+// MoveToUniversity 把院系移动到另一所大学
+func (dao *CollegeDAO) MoveToUniversity(ctx context.Context, id, universityID int64) error {
+	return dao.db(ctx).Model(&model.College{}).Where("id = ?", id).Update("university_id", universityID).Error
+}
+
+// GetListByUniversity 获取大学下的所有院系
+func (dao *CollegeDAO) GetListByUniversity(ctx context.Context, universityID int64) ([]*model.College, error) {
+	var colleges []*model.College
+	err := dao.db(ctx).Where("university_id = ?", universityID).Order("id").Find(&colleges).Error
+	return colleges, err
+}
@@ -0,0 +1,77 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+
+	"gorm.io/gorm"
+)
+
+// CompanyDAO 企业数据访问对象
+type CompanyDAO struct {
+	DB *gorm.DB
+}
+
+// NewCompanyDAO 创建企业DAO实例
+func NewCompanyDAO(db *gorm.DB) *CompanyDAO {
+	return &CompanyDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *CompanyDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 创建企业
+func (dao *CompanyDAO) Create(ctx context.Context, company *model.Company) error {
+	return dao.db(ctx).Create(company).Error
+}
+
+// GetByID 根据ID获取企业
+func (dao *CompanyDAO) GetByID(ctx context.Context, id int64) (*model.Company, error) {
+	var company model.Company
+	err := dao.db(ctx).First(&company, id).Error
+	return &company, err
+}
+
+// CheckNameExists 检查排除某ID外是否存在同名企业，excludeID 为 0 时不排除
+func (dao *CompanyDAO) CheckNameExists(ctx context.Context, name string, excludeID int64) (bool, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.Company{}).Where("name = ? AND id != ?", name, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// Update 更新企业
+func (dao *CompanyDAO) Update(ctx context.Context, company *model.Company) error {
+	return dao.db(ctx).Save(company).Error
+}
+
+// Delete 删除企业
+func (dao *CompanyDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.Company{}, id).Error
+}
+
+// GetList 获取企业列表（支持按名称模糊查询，按行业、城市筛选）
+func (dao *CompanyDAO) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Company, int64, error) {
+	var companies []*model.Company
+	var total int64
+
+	query := dao.db(ctx).Model(&model.Company{})
+	for key, value := range filters {
+		if key == "name" {
+			query = query.Where("name "+likeOp(dao.db(ctx))+" ?", "%"+value.(string)+"%")
+		} else {
+			query = query.Where(key+" = ?", value)
+		}
+	}
+
+	// 查询总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取数据列表
+	offset := (page - 1) * pageSize
+	err := query.Order("id").Offset(offset).Limit(pageSize).Find(&companies).Error
+	return companies, total, err
+}
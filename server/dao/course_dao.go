@@ -0,0 +1,104 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+
+	"gorm.io/gorm"
+)
+
+// CourseDAO 课程数据访问对象
+type CourseDAO struct {
+	DB *gorm.DB
+}
+
+// NewCourseDAO 创建课程DAO实例
+func NewCourseDAO(db *gorm.DB) *CourseDAO {
+	return &CourseDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *CourseDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 创建课程
+func (dao *CourseDAO) Create(ctx context.Context, course *model.Course) error {
+	return dao.db(ctx).Create(course).Error
+}
+
+// GetByID 根据ID获取课程
+func (dao *CourseDAO) GetByID(ctx context.Context, id int64) (*model.Course, error) {
+	var course model.Course
+	err := dao.db(ctx).First(&course, id).Error
+	return &course, err
+}
+
+// CheckCodeExists 检查同一大学下排除某ID外是否存在相同代码的课程，excludeID 为 0 时不排除
+func (dao *CourseDAO) CheckCodeExists(ctx context.Context, universityID int64, code string, excludeID int64) (bool, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.Course{}).
+		Where("university_id = ? AND code = ? AND id != ?", universityID, code, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Update 更新课程
+func (dao *CourseDAO) Update(ctx context.Context, course *model.Course) error {
+	return dao.db(ctx).Save(course).Error
+}
+
+// Delete 删除课程
+func (dao *CourseDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.Course{}, id).Error
+}
+
+// GetList 获取课程列表（支持筛选），按课程代码排序
+func (dao *CourseDAO) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Course, int64, error) {
+	var courses []*model.Course
+	var total int64
+
+	query := dao.db(ctx).Model(&model.Course{})
+	for key, value := range filters {
+		switch key {
+		case "keyword":
+			like, op := "%"+value.(string)+"%", likeOp(dao.db(ctx))
+			query = query.Where("code "+op+" ? OR title "+op+" ?", like, like)
+		default:
+			query = query.Where(key+" = ?", value)
+		}
+	}
+
+	// 查询总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取数据列表
+	offset := (page - 1) * pageSize
+	err := query.Order("code, id").Offset(offset).Limit(pageSize).Find(&courses).Error
+	return courses, total, err
+}
+
+// CountByUniversity 统计某大学的课程数
+func (dao *CourseDAO) CountByUniversity(ctx context.Context, universityID int64) (int64, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.Course{}).Where("university_id = ?", universityID).Count(&count).Error
+	return count, err
+}
+
+// GetSharedCodes 获取两所大学都有的课程代码
+func (dao *CourseDAO) GetSharedCodes(ctx context.Context, universityID, otherUniversityID int64) ([]string, error) {
+	var codes []string
+	other := dao.db(ctx).Model(&model.Course{}).Select("code").Where("university_id = ?", otherUniversityID)
+	err := dao.db(ctx).Model(&model.Course{}).
+		Where("university_id = ? AND code IN (?)", universityID, other).
+		Order("code").Distinct().Pluck("code", &codes).Error
+	return codes, err
+}
+
+// ReassignUniversity 把某大学的所有课程改为属于另一所大学，返回受影响的课程数
+func (dao *CourseDAO) ReassignUniversity(ctx context.Context, fromID, toID int64) (int64, error) {
+	result := dao.db(ctx).Model(&model.Course{}).Where("university_id = ?", fromID).Update("university_id", toID)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,101 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CourseSectionDAO 教学班数据访问对象
+type CourseSectionDAO struct {
+	DB *gorm.DB
+}
+
+// NewCourseSectionDAO 创建教学班DAO实例
+func NewCourseSectionDAO(db *gorm.DB) *CourseSectionDAO {
+	return &CourseSectionDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *CourseSectionDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 创建教学班
+func (dao *CourseSectionDAO) Create(ctx context.Context, section *model.CourseSection) error {
+	// 关联的课程只读，不随教学班一起写入
+	return dao.db(ctx).Omit(clause.Associations).Create(section).Error
+}
+
+// GetByID 根据ID获取教学班，同时加载所属课程
+func (dao *CourseSectionDAO) GetByID(ctx context.Context, id int64) (*model.CourseSection, error) {
+	var section model.CourseSection
+	err := dao.db(ctx).Preload("Course").First(&section, id).Error
+	return &section, err
+}
+
+// GetByIDs 批量获取教学班，同时加载课程
+func (dao *CourseSectionDAO) GetByIDs(ctx context.Context, ids []int64) ([]*model.CourseSection, error) {
+	var sections []*model.CourseSection
+	if len(ids) == 0 {
+		return sections, nil
+	}
+	err := dao.db(ctx).Preload("Course").Where("id IN ?", ids).Find(&sections).Error
+	return sections, err
+}
+
+// GetByIDForUpdate 在事务中获取并锁定教学班，用于选课时的容量检查
+func (dao *CourseSectionDAO) GetByIDForUpdate(ctx context.Context, id int64) (*model.CourseSection, error) {
+	var section model.CourseSection
+	err := dao.db(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Course").First(&section, id).Error
+	return &section, err
+}
+
+// CheckSectionNoExists 检查同一课程同一学期下排除某ID外是否存在相同班号，excludeID 为 0 时不排除
+func (dao *CourseSectionDAO) CheckSectionNoExists(ctx context.Context, courseID int64, term, sectionNo string, excludeID int64) (bool, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.CourseSection{}).
+		Where("course_id = ? AND term = ? AND section_no = ? AND id != ?", courseID, term, sectionNo, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Update 更新教学班
+func (dao *CourseSectionDAO) Update(ctx context.Context, section *model.CourseSection) error {
+	return dao.db(ctx).Omit(clause.Associations).Save(section).Error
+}
+
+// Delete 删除教学班
+func (dao *CourseSectionDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.CourseSection{}, id).Error
+}
+
+// CountByCourse 统计课程下的教学班数
+func (dao *CourseSectionDAO) CountByCourse(ctx context.Context, courseID int64) (int64, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.CourseSection{}).Where("course_id = ?", courseID).Count(&count).Error
+	return count, err
+}
+
+// GetList 获取教学班列表（支持按课程、学期等筛选），同时加载所属课程
+func (dao *CourseSectionDAO) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.CourseSection, int64, error) {
+	var sections []*model.CourseSection
+	var total int64
+
+	query := dao.db(ctx).Model(&model.CourseSection{})
+	for key, value := range filters {
+		query = query.Where(key+" = ?", value)
+	}
+
+	// 查询总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取数据列表
+	offset := (page - 1) * pageSize
+	err := query.Preload("Course").Order("term DESC, course_id, section_no").Offset(offset).Limit(pageSize).Find(&sections).Error
+	return sections, total, err
+}
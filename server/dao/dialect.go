@@ -0,0 +1,31 @@
+package dao
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// likeOp 返回不区分大小写的模糊匹配运算符
+// MySQL 默认排序规则和 SQLite 的 LIKE 不区分大小写，PostgreSQL 的 LIKE 区分大小写，需要使用 ILIKE
+func likeOp(db *gorm.DB) string {
+	if db.Dialector.Name() == "postgres" {
+		return "ILIKE"
+	}
+	return "LIKE"
+}
+
+// dateColumn 返回按日期比较时使用的列表达式，与之比较的参数使用 dateValue 格式化
+// MySQL 和 PostgreSQL 的 DATE 列可以直接与 YYYY-MM-DD 比较；SQLite 驱动把 time.Time 存为
+// "2006-01-02 15:04:05-07:00" 格式的文本，需要截取日期部分后再比较，否则同一天的值无法相等，BETWEEN 会漏掉结束当天
+func dateColumn(db *gorm.DB, column string) string {
+	if db.Dialector.Name() == "sqlite" {
+		return "substr(" + column + ", 1, 10)"
+	}
+	return column
+}
+
+// dateValue 把日期格式化为 YYYY-MM-DD，与 dateColumn 配合使用
+func dateValue(date time.Time) string {
+	return date.Format("2006-01-02")
+}
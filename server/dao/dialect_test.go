@@ -0,0 +1,99 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+	"mvc-demo/db"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newMigratedDB 创建执行过全部迁移的 SQLite 数据库
+func newMigratedDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	conn, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := conn.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	migrator, err := db.NewMigrator(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+func day(value string) time.Time {
+	date, _ := time.ParseInLocation("2006-01-02", value, time.Local)
+	return date
+}
+
+// SQLite 把日期存为带时间和时区的文本，按日期比较的查询须与 MySQL、PostgreSQL 的 DATE 列结果一致
+func TestDateQueriesOnSQLite(t *testing.T) {
+	conn := newMigratedDB(t)
+	ctx := context.Background()
+
+	sessionDAO := NewClassSessionDAO(conn)
+	for _, date := range []string{"2025-03-03", "2025-03-10", "2025-03-17"} {
+		if err := sessionDAO.Create(ctx, &model.ClassSession{SectionID: 1, SessionDate: day(date), StartTime: "08:00", EndTime: "09:40"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	exists, err := sessionDAO.CheckExists(ctx, 1, day("2025-03-10"), "08:00", 0)
+	if err != nil || !exists {
+		t.Fatalf("CheckExists 返回 %v, %v，期望已存在", exists, err)
+	}
+	sessions, err := sessionDAO.GetBySectionBetween(ctx, 1, day("2025-03-03"), day("2025-03-10"))
+	if err != nil || len(sessions) != 2 {
+		t.Fatalf("GetBySectionBetween 返回 %d 个课次（%v），期望包含首尾两天的 2 个", len(sessions), err)
+	}
+	_, total, err := sessionDAO.GetList(ctx, 1, 10, map[string]interface{}{"date_from": "2025-03-10", "date_to": "2025-03-17"})
+	if err != nil || total != 2 {
+		t.Fatalf("GetList 按日期筛选到 %d 个课次（%v），期望 2", total, err)
+	}
+
+	// 分配在开始日期（含）到结束日期（不含）之间有效
+	assignmentDAO := NewAdvisorAssignmentDAO(conn)
+	endDate := day("2025-03-10")
+	for _, assignment := range []*model.AdvisorAssignment{
+		{StudentID: 1, TeacherID: 1, StartDate: day("2025-03-03"), EndDate: &endDate},
+		{StudentID: 2, TeacherID: 1, StartDate: day("2025-03-10")},
+	} {
+		if err := assignmentDAO.Create(ctx, assignment); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for date, want := range map[string][]int64{
+		"2025-03-02": nil,
+		"2025-03-03": {1},
+		"2025-03-09": {1},
+		"2025-03-10": {2},
+	} {
+		var ids []int64
+		if err := adviseeQuery(conn, 1, day(date)).Order("student_id").Scan(&ids).Error; err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != len(want) || (len(want) > 0 && ids[0] != want[0]) {
+			t.Errorf("%s 的指导学生为 %v，期望 %v", date, ids, want)
+		}
+	}
+	count, err := assignmentDAO.CountCurrentByTeacher(ctx, 1, day("2025-03-10"))
+	if err != nil || count != 1 {
+		t.Fatalf("CountCurrentByTeacher 返回 %d（%v），期望结束当天不再计入", count, err)
+	}
+}
@@ -0,0 +1,118 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+
+	"gorm.io/gorm"
+)
+
+// EmploymentRecordDAO 毕业去向数据访问对象
+type EmploymentRecordDAO struct {
+	DB *gorm.DB
+}
+
+// NewEmploymentRecordDAO 创建毕业去向DAO实例
+func NewEmploymentRecordDAO(db *gorm.DB) *EmploymentRecordDAO {
+	return &EmploymentRecordDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *EmploymentRecordDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// EmploymentRateRow 就业率报表的一行，按分组统计学生数和各去向人数
+type EmploymentRateRow struct {
+	GroupID      *int64 // 大学ID、专业ID或毕业年份，未填写时为空
+	Students     int64
+	Employed     int64
+	FurtherStudy int64
+	SelfEmployed int64
+	Unemployed   int64
+}
+
+// employmentGroupColumns 就业率报表可用的分组字段
+var employmentGroupColumns = map[string]string{
+	"university":      "s.university_id",
+	"major":           "s.major_id",
+	"graduation_year": "s.graduation_year",
+}
+
+// Create 创建毕业去向记录
+func (dao *EmploymentRecordDAO) Create(ctx context.Context, record *model.EmploymentRecord) error {
+	return dao.db(ctx).Omit("Company").Create(record).Error
+}
+
+// GetByID 根据ID获取毕业去向记录，同时加载企业
+func (dao *EmploymentRecordDAO) GetByID(ctx context.Context, id int64) (*model.EmploymentRecord, error) {
+	var record model.EmploymentRecord
+	err := dao.db(ctx).Preload("Company").First(&record, id).Error
+	return &record, err
+}
+
+// CheckStudentExists 检查学生是否已有排除某ID外的毕业去向记录，excludeID 为 0 时不排除
+func (dao *EmploymentRecordDAO) CheckStudentExists(ctx context.Context, studentID int64, excludeID int64) (bool, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.EmploymentRecord{}).Where("student_id = ? AND id != ?", studentID, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// Update 更新毕业去向记录
+func (dao *EmploymentRecordDAO) Update(ctx context.Context, record *model.EmploymentRecord) error {
+	return dao.db(ctx).Omit("Company").Save(record).Error
+}
+
+// Delete 删除毕业去向记录
+func (dao *EmploymentRecordDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.EmploymentRecord{}, id).Error
+}
+
+// CountByCompany 统计企业的毕业去向记录数
+func (dao *EmploymentRecordDAO) CountByCompany(ctx context.Context, companyID int64) (int64, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.EmploymentRecord{}).Where("company_id = ?", companyID).Count(&count).Error
+	return count, err
+}
+
+// GetList 获取毕业去向记录列表（支持按学生、去向、企业筛选），同时加载企业
+func (dao *EmploymentRecordDAO) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.EmploymentRecord, int64, error) {
+	var records []*model.EmploymentRecord
+	var total int64
+
+	query := dao.db(ctx).Model(&model.EmploymentRecord{})
+	for key, value := range filters {
+		query = query.Where(key+" = ?", value)
+	}
+
+	// 查询总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取数据列表
+	offset := (page - 1) * pageSize
+	err := query.Preload("Company").Order("id DESC").Offset(offset).Limit(pageSize).Find(&records).Error
+	return records, total, err
+}
+
+// RateReport 按 groupBy（university、major、graduation_year）分组统计学生的毕业去向
+// filters 为学生表的筛选条件，如 status、university_id、major_id、graduation_year；没有去向记录的学生只计入学生数
+func (dao *EmploymentRecordDAO) RateReport(ctx context.Context, groupBy string, filters map[string]interface{}) ([]*EmploymentRateRow, error) {
+	column := employmentGroupColumns[groupBy]
+	query := dao.db(ctx).Table("students AS s").
+		Select(column + " AS group_id, COUNT(DISTINCT s.id) AS students, " +
+			"COUNT(DISTINCT CASE WHEN er.outcome = 'employed' THEN s.id END) AS employed, " +
+			"COUNT(DISTINCT CASE WHEN er.outcome = 'further_study' THEN s.id END) AS further_study, " +
+			"COUNT(DISTINCT CASE WHEN er.outcome = 'self_employed' THEN s.id END) AS self_employed, " +
+			"COUNT(DISTINCT CASE WHEN er.outcome = 'unemployed' THEN s.id END) AS unemployed").
+		Joins("LEFT JOIN employment_records AS er ON er.student_id = s.id").
+		Where("s.deleted_at IS NULL")
+	for key, value := range filters {
+		query = query.Where("s."+key+" = ?", value)
+	}
+
+	var rows []*EmploymentRateRow
+	err := query.Group(column).Order(column).Scan(&rows).Error
+	return rows, err
+}
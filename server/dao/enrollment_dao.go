@@ -0,0 +1,142 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// EnrollmentDAO 选课记录数据访问对象
+type EnrollmentDAO struct {
+	DB *gorm.DB
+}
+
+// NewEnrollmentDAO 创建选课记录DAO实例
+func NewEnrollmentDAO(db *gorm.DB) *EnrollmentDAO {
+	return &EnrollmentDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *EnrollmentDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 创建选课记录
+func (dao *EnrollmentDAO) Create(ctx context.Context, enrollment *model.Enrollment) error {
+	return dao.db(ctx).Omit("Section").Create(enrollment).Error
+}
+
+// GetByID 根据ID获取选课记录，同时加载教学班和课程
+func (dao *EnrollmentDAO) GetByID(ctx context.Context, id int64) (*model.Enrollment, error) {
+	var enrollment model.Enrollment
+	err := dao.db(ctx).Preload("Section.Course").First(&enrollment, id).Error
+	return &enrollment, err
+}
+
+// GetByStudentAndSection 获取学生在教学班的最近一条选课记录，没有时返回 gorm.ErrRecordNotFound
+func (dao *EnrollmentDAO) GetByStudentAndSection(ctx context.Context, studentID, sectionID int64) (*model.Enrollment, error) {
+	var enrollment model.Enrollment
+	err := dao.db(ctx).Where("student_id = ? AND section_id = ?", studentID, sectionID).Order("id DESC").First(&enrollment).Error
+	return &enrollment, err
+}
+
+// GetByStudentAndTerm 获取学生某学期指定状态的选课记录，同时加载教学班和课程
+func (dao *EnrollmentDAO) GetByStudentAndTerm(ctx context.Context, studentID int64, term, status string) ([]*model.Enrollment, error) {
+	var enrollments []*model.Enrollment
+	sectionQuery := dao.db(ctx).Model(&model.CourseSection{}).Select("id").Where("term = ?", term)
+	err := dao.db(ctx).Preload("Section.Course").
+		Where("student_id = ? AND status = ? AND section_id IN (?)", studentID, status, sectionQuery).
+		Order("id").Find(&enrollments).Error
+	return enrollments, err
+}
+
+// GetBySection 获取教学班指定状态的选课记录，按学生ID排序
+func (dao *EnrollmentDAO) GetBySection(ctx context.Context, sectionID int64, statuses []string) ([]*model.Enrollment, error) {
+	var enrollments []*model.Enrollment
+	err := dao.db(ctx).Where("section_id = ? AND status IN ?", sectionID, statuses).Order("student_id").Find(&enrollments).Error
+	return enrollments, err
+}
+
+// CountBySection 统计教学班指定状态的选课人数，status 为空时统计全部选课记录
+func (dao *EnrollmentDAO) CountBySection(ctx context.Context, sectionID int64, status string) (int64, error) {
+	var count int64
+	query := dao.db(ctx).Model(&model.Enrollment{}).Where("section_id = ?", sectionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// CountBySections 批量统计教学班指定状态的选课人数，返回 教学班ID => 人数
+func (dao *EnrollmentDAO) CountBySections(ctx context.Context, sectionIDs []int64, status string) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(sectionIDs))
+	if len(sectionIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		SectionID int64
+		Count     int64
+	}
+	err := dao.db(ctx).Model(&model.Enrollment{}).
+		Select("section_id, COUNT(*) AS count").
+		Where("section_id IN ? AND status = ?", sectionIDs, status).
+		Group("section_id").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.SectionID] = row.Count
+	}
+	return counts, nil
+}
+
+// UpdateStatus 仅当选课记录仍为 fromStatus 时更新为 toStatus，返回值表示是否更新成功
+// 重新选课时更新选课时间并清空退课时间，退课时记录退课时间
+func (dao *EnrollmentDAO) UpdateStatus(ctx context.Context, id int64, fromStatus, toStatus string, updatedBy *int64) (bool, error) {
+	updates := map[string]interface{}{
+		"status":     toStatus,
+		"updated_by": updatedBy,
+	}
+	now := time.Now()
+	switch toStatus {
+	case "enrolled":
+		updates["enrolled_at"] = now
+		updates["dropped_at"] = nil
+	case "dropped":
+		updates["dropped_at"] = now
+	}
+
+	result := dao.db(ctx).Model(&model.Enrollment{}).Where("id = ? AND status = ?", id, fromStatus).Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetList 获取选课记录列表（支持按学生、教学班、状态、学期筛选），同时加载教学班和课程
+func (dao *EnrollmentDAO) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Enrollment, int64, error) {
+	var enrollments []*model.Enrollment
+	var total int64
+
+	query := dao.db(ctx).Model(&model.Enrollment{})
+	for key, value := range filters {
+		switch key {
+		case "term":
+			sectionQuery := dao.db(ctx).Model(&model.CourseSection{}).Select("id").Where("term = ?", value)
+			query = query.Where("section_id IN (?)", sectionQuery)
+		default:
+			query = query.Where(key+" = ?", value)
+		}
+	}
+
+	// 查询总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取数据列表
+	offset := (page - 1) * pageSize
+	err := query.Preload("Section.Course").Order("id DESC").Offset(offset).Limit(pageSize).Find(&enrollments).Error
+	return enrollments, total, err
+}
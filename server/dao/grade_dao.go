@@ -0,0 +1,84 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GradeDAO 课程成绩数据访问对象
+type GradeDAO struct {
+	DB *gorm.DB
+}
+
+// NewGradeDAO 创建课程成绩DAO实例
+func NewGradeDAO(db *gorm.DB) *GradeDAO {
+	return &GradeDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *GradeDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 录入成绩
+func (dao *GradeDAO) Create(ctx context.Context, grade *model.Grade) error {
+	// 关联的课程只读，不随成绩一起写入
+	return dao.db(ctx).Omit(clause.Associations).Create(grade).Error
+}
+
+// GetByID 根据ID获取成绩，同时加载课程
+func (dao *GradeDAO) GetByID(ctx context.Context, id int64) (*model.Grade, error) {
+	var grade model.Grade
+	err := dao.db(ctx).Preload("Course").First(&grade, id).Error
+	return &grade, err
+}
+
+// CheckExists 检查学生同一学期同一课程是否已有成绩
+func (dao *GradeDAO) CheckExists(ctx context.Context, studentID, courseID int64, term string) (bool, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.Grade{}).
+		Where("student_id = ? AND course_id = ? AND term = ?", studentID, courseID, term).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Update 更新成绩
+func (dao *GradeDAO) Update(ctx context.Context, grade *model.Grade) error {
+	return dao.db(ctx).Omit(clause.Associations).Save(grade).Error
+}
+
+// Delete 删除成绩
+func (dao *GradeDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.Grade{}, id).Error
+}
+
+// GetByStudent 获取学生的全部成绩，按学期、ID排序，同时加载课程
+func (dao *GradeDAO) GetByStudent(ctx context.Context, studentID int64) ([]*model.Grade, error) {
+	var grades []*model.Grade
+	err := dao.db(ctx).Preload("Course").Where("student_id = ?", studentID).Order("term, id").Find(&grades).Error
+	return grades, err
+}
+
+// GetList 获取成绩列表（支持按学生、课程、学期筛选），同时加载课程
+func (dao *GradeDAO) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Grade, int64, error) {
+	var grades []*model.Grade
+	var total int64
+
+	query := dao.db(ctx).Model(&model.Grade{})
+	for key, value := range filters {
+		query = query.Where(key+" = ?", value)
+	}
+
+	// 查询总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取数据列表
+	offset := (page - 1) * pageSize
+	err := query.Preload("Course").Order("term DESC, id DESC").Offset(offset).Limit(pageSize).Find(&grades).Error
+	return grades, total, err
+}
@@ -0,0 +1,110 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GradingScaleDAO 评分标准数据访问对象
+type GradingScaleDAO struct {
+	DB *gorm.DB
+}
+
+// NewGradingScaleDAO 创建评分标准DAO实例
+func NewGradingScaleDAO(db *gorm.DB) *GradingScaleDAO {
+	return &GradingScaleDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *GradingScaleDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 创建评分标准，等级通过 ReplaceItems 单独维护
+func (dao *GradingScaleDAO) Create(ctx context.Context, scale *model.GradingScale) error {
+	return dao.db(ctx).Omit(clause.Associations).Create(scale).Error
+}
+
+// GetByID 根据ID获取评分标准，同时加载等级
+func (dao *GradingScaleDAO) GetByID(ctx context.Context, id int64) (*model.GradingScale, error) {
+	var scale model.GradingScale
+	err := withScaleItems(dao.db(ctx)).First(&scale, id).Error
+	return &scale, err
+}
+
+// GetDefault 获取默认评分标准，同时加载等级
+func (dao *GradingScaleDAO) GetDefault(ctx context.Context) (*model.GradingScale, error) {
+	var scale model.GradingScale
+	err := withScaleItems(dao.db(ctx)).Where("is_default = ?", true).Order("id").First(&scale).Error
+	return &scale, err
+}
+
+// GetAll 获取所有评分标准，同时加载等级
+func (dao *GradingScaleDAO) GetAll(ctx context.Context) ([]*model.GradingScale, error) {
+	var scales []*model.GradingScale
+	err := withScaleItems(dao.db(ctx)).Order("id").Find(&scales).Error
+	return scales, err
+}
+
+// GetByIDs 批量获取评分标准，同时加载等级
+func (dao *GradingScaleDAO) GetByIDs(ctx context.Context, ids []int64) ([]*model.GradingScale, error) {
+	var scales []*model.GradingScale
+	if len(ids) == 0 {
+		return scales, nil
+	}
+	err := withScaleItems(dao.db(ctx)).Where("id IN ?", ids).Find(&scales).Error
+	return scales, err
+}
+
+// Update 更新评分标准，等级通过 ReplaceItems 单独维护
+func (dao *GradingScaleDAO) Update(ctx context.Context, scale *model.GradingScale) error {
+	return dao.db(ctx).Omit(clause.Associations).Save(scale).Error
+}
+
+// Delete 删除评分标准
+func (dao *GradingScaleDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.GradingScale{}, id).Error
+}
+
+// ReplaceItems 替换评分标准的全部等级
+func (dao *GradingScaleDAO) ReplaceItems(ctx context.Context, scaleID int64, items []*model.GradingScaleItem) error {
+	if err := dao.db(ctx).Where("scale_id = ?", scaleID).Delete(&model.GradingScaleItem{}).Error; err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	for _, item := range items {
+		item.ID = 0
+		item.ScaleID = scaleID
+	}
+	return dao.db(ctx).Create(items).Error
+}
+
+// ClearDefault 取消除指定评分标准外的默认标记
+func (dao *GradingScaleDAO) ClearDefault(ctx context.Context, exceptID int64) error {
+	return dao.db(ctx).Model(&model.GradingScale{}).Where("is_default = ? AND id != ?", true, exceptID).
+		Update("is_default", false).Error
+}
+
+// CountUsage 统计使用评分标准的课程数和成绩数
+func (dao *GradingScaleDAO) CountUsage(ctx context.Context, scaleID int64) (int64, error) {
+	var courses, grades int64
+	if err := dao.db(ctx).Model(&model.Course{}).Where("grading_scale_id = ?", scaleID).Count(&courses).Error; err != nil {
+		return 0, err
+	}
+	if err := dao.db(ctx).Model(&model.Grade{}).Where("scale_id = ?", scaleID).Count(&grades).Error; err != nil {
+		return 0, err
+	}
+	return courses + grades, nil
+}
+
+// withScaleItems 预加载评分标准的等级，按分数下限从高到低排列
+func withScaleItems(query *gorm.DB) *gorm.DB {
+	return query.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("min_score DESC, id")
+	})
+}
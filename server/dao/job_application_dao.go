@@ -0,0 +1,91 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// JobApplicationDAO 岗位申请数据访问对象
+type JobApplicationDAO struct {
+	DB *gorm.DB
+}
+
+// NewJobApplicationDAO 创建岗位申请DAO实例
+func NewJobApplicationDAO(db *gorm.DB) *JobApplicationDAO {
+	return &JobApplicationDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *JobApplicationDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 创建岗位申请
+func (dao *JobApplicationDAO) Create(ctx context.Context, application *model.JobApplication) error {
+	return dao.db(ctx).Omit("Posting").Create(application).Error
+}
+
+// GetByID 根据ID获取岗位申请，同时加载岗位和企业
+func (dao *JobApplicationDAO) GetByID(ctx context.Context, id int64) (*model.JobApplication, error) {
+	var application model.JobApplication
+	err := dao.db(ctx).Preload("Posting.Company").First(&application, id).Error
+	return &application, err
+}
+
+// CheckExists 检查学生是否已申请该岗位
+func (dao *JobApplicationDAO) CheckExists(ctx context.Context, postingID, studentID int64) (bool, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.JobApplication{}).Where("posting_id = ? AND student_id = ?", postingID, studentID).Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateStatus 仅当申请仍为 fromStatus 时更新为 toStatus，返回值表示是否更新成功，remarks 为 nil 时不修改备注
+func (dao *JobApplicationDAO) UpdateStatus(ctx context.Context, id int64, fromStatus, toStatus string, remarks *string, updatedBy *int64) (bool, error) {
+	updates := map[string]interface{}{
+		"status":            toStatus,
+		"status_changed_at": time.Now(),
+		"updated_by":        updatedBy,
+	}
+	if remarks != nil {
+		updates["remarks"] = *remarks
+	}
+
+	result := dao.db(ctx).Model(&model.JobApplication{}).Where("id = ? AND status = ?", id, fromStatus).Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Delete 删除岗位申请
+func (dao *JobApplicationDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.JobApplication{}, id).Error
+}
+
+// CountByPosting 统计岗位的申请数
+func (dao *JobApplicationDAO) CountByPosting(ctx context.Context, postingID int64) (int64, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.JobApplication{}).Where("posting_id = ?", postingID).Count(&count).Error
+	return count, err
+}
+
+// GetList 获取岗位申请列表（支持按岗位、学生、状态筛选），按投递时间倒序，同时加载岗位和企业
+func (dao *JobApplicationDAO) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.JobApplication, int64, error) {
+	var applications []*model.JobApplication
+	var total int64
+
+	query := dao.db(ctx).Model(&model.JobApplication{})
+	for key, value := range filters {
+		query = query.Where(key+" = ?", value)
+	}
+
+	// 查询总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取数据列表
+	offset := (page - 1) * pageSize
+	err := query.Preload("Posting.Company").Order("applied_at DESC, id DESC").Offset(offset).Limit(pageSize).Find(&applications).Error
+	return applications, total, err
+}
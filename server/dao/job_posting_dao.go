@@ -0,0 +1,77 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+
+	"gorm.io/gorm"
+)
+
+// JobPostingDAO 招聘岗位数据访问对象
+type JobPostingDAO struct {
+	DB *gorm.DB
+}
+
+// NewJobPostingDAO 创建招聘岗位DAO实例
+func NewJobPostingDAO(db *gorm.DB) *JobPostingDAO {
+	return &JobPostingDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *JobPostingDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 创建招聘岗位
+func (dao *JobPostingDAO) Create(ctx context.Context, posting *model.JobPosting) error {
+	return dao.db(ctx).Omit("Company").Create(posting).Error
+}
+
+// GetByID 根据ID获取招聘岗位，同时加载企业
+func (dao *JobPostingDAO) GetByID(ctx context.Context, id int64) (*model.JobPosting, error) {
+	var posting model.JobPosting
+	err := dao.db(ctx).Preload("Company").First(&posting, id).Error
+	return &posting, err
+}
+
+// Update 更新招聘岗位
+func (dao *JobPostingDAO) Update(ctx context.Context, posting *model.JobPosting) error {
+	return dao.db(ctx).Omit("Company").Save(posting).Error
+}
+
+// Delete 删除招聘岗位
+func (dao *JobPostingDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.JobPosting{}, id).Error
+}
+
+// CountByCompany 统计企业的招聘岗位数
+func (dao *JobPostingDAO) CountByCompany(ctx context.Context, companyID int64) (int64, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.JobPosting{}).Where("company_id = ?", companyID).Count(&count).Error
+	return count, err
+}
+
+// GetList 获取招聘岗位列表（支持按岗位名称模糊查询，按企业、类型、状态筛选），按发布时间倒序，同时加载企业
+func (dao *JobPostingDAO) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.JobPosting, int64, error) {
+	var postings []*model.JobPosting
+	var total int64
+
+	query := dao.db(ctx).Model(&model.JobPosting{})
+	for key, value := range filters {
+		if key == "keyword" {
+			query = query.Where("title "+likeOp(dao.db(ctx))+" ?", "%"+value.(string)+"%")
+		} else {
+			query = query.Where(key+" = ?", value)
+		}
+	}
+
+	// 查询总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取数据列表
+	offset := (page - 1) * pageSize
+	err := query.Preload("Company").Order("id DESC").Offset(offset).Limit(pageSize).Find(&postings).Error
+	return postings, total, err
+}
@@ -0,0 +1,95 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+
+	"gorm.io/gorm"
+)
+
+// MajorDAO 专业数据访问对象
+type MajorDAO struct {
+	DB *gorm.DB
+}
+
+// NewMajorDAO 创建专业DAO实例
+func NewMajorDAO(db *gorm.DB) *MajorDAO {
+	return &MajorDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *MajorDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 创建专业
+func (dao *MajorDAO) Create(ctx context.Context, major *model.Major) error {
+	return dao.db(ctx).Create(major).Error
+}
+
+// GetByID 根据ID获取专业
+func (dao *MajorDAO) GetByID(ctx context.Context, id int64) (*model.Major, error) {
+	var major model.Major
+	err := dao.db(ctx).First(&major, id).Error
+	return &major, err
+}
+
+// CheckNameExists 检查同一大学下排除某ID外是否存在同名专业，excludeID 为 0 时不排除
+func (dao *MajorDAO) CheckNameExists(ctx context.Context, universityID int64, name string, excludeID int64) (bool, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.Major{}).
+		Where("university_id = ? AND name = ? AND id != ?", universityID, name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Update 更新专业
+func (dao *MajorDAO) Update(ctx context.Context, major *model.Major) error {
+	return dao.db(ctx).Save(major).Error
+}
+
+// Delete 删除专业
+func (dao *MajorDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.Major{}, id).Error
+}
+
+// GetList 获取专业列表，filters 支持 university_id、college_id
+func (dao *MajorDAO) GetList(ctx context.Context, filters map[string]interface{}) ([]*model.Major, error) {
+	var majors []*model.Major
+	query := dao.db(ctx).Model(&model.Major{})
+	for key, value := range filters {
+		query = query.Where(key+" = ?", value)
+	}
+	err := query.Order("id").Find(&majors).Error
+	return majors, err
+}
+
+// GetByIDs 根据ID批量获取专业
+func (dao *MajorDAO) GetByIDs(ctx context.Context, ids []int64) ([]*model.Major, error) {
+	var majors []*model.Major
+	if len(ids) == 0 {
+		return majors, nil
+	}
+	err := dao.db(ctx).Where("id IN ?", ids).Find(&majors).Error
+	return majors, err
+}
+
+// GetAll 获取所有专业
+func (dao *MajorDAO) GetAll(ctx context.Context) ([]*model.Major, error) {
+	var majors []*model.Major
+	err := dao.db(ctx).Order("id").Find(&majors).Error
+	return majors, err
+}
+
+// MoveToUniversity 把专业移动到另一所大学及其院系
+func (dao *MajorDAO) MoveToUniversity(ctx context.Context, id, universityID int64, collegeID *int64) error {
+	return dao.db(ctx).Model(&model.Major{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"university_id": universityID, "college_id": collegeID}).Error
+}
+
+// CountByCollege 统计院系下的专业数
+func (dao *MajorDAO) CountByCollege(ctx context.Context, collegeID int64) (int64, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.Major{}).Where("college_id = ?", collegeID).Count(&count).Error
+	return count, err
+}
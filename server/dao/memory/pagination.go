@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"mvc-demo/utils"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// sortKind 排序字段类型，用于把游标中的排序值还原为可比较的值
+type sortKind int
+
+const (
+	sortInt sortKind = iota
+	sortString
+	sortTime
+)
+
+// cursorColumn 游标分页排序字段的类型，nullable 表示字段可以为 NULL
+type cursorColumn struct {
+	kind     sortKind
+	nullable bool
+}
+
+// cursorColumns 可用于游标分页的排序字段
+type cursorColumns map[string]cursorColumn
+
+// cursorPage 按游标分页参数排序并截取一页，语义与 dao 包的 applyCursor 一致：
+// value 返回记录在排序字段上的值（int64、string 或 time.Time，NULL 返回 nil），按排序字段和ID排序，NULL 排在所有值之前，
+// 传入游标时以游标中记录的排序字段和方向为准，从游标之后开始取
+func cursorPage[T any](items []T, page *utils.PageQuery, columns cursorColumns, value func(item T, sort string) interface{}, id func(item T) int64) ([]T, *utils.CursorResult, error) {
+	result := &utils.CursorResult{}
+	if page.WithTotal {
+		total := int64(len(items))
+		result.Total = &total
+	}
+
+	var token *utils.CursorToken
+	if page.Cursor != "" {
+		var err error
+		token, err = utils.DecodeCursor(page.Cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		page.Sort = token.Sort
+		page.Desc = token.Desc
+	}
+
+	column, ok := columns[page.Sort]
+	if !ok {
+		return nil, nil, utils.ErrInvalidSort
+	}
+
+	// 比较两条记录的先后，倒序时取反
+	less := func(av interface{}, aID int64, bv interface{}, bID int64) bool {
+		c := compareValue(av, bv)
+		if page.Sort == "id" || c == 0 {
+			c = compareValue(aID, bID)
+		}
+		if page.Desc {
+			return c > 0
+		}
+		return c < 0
+	}
+
+	sorted := append([]T{}, items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(value(sorted[i], page.Sort), id(sorted[i]), value(sorted[j], page.Sort), id(sorted[j]))
+	})
+
+	if token != nil {
+		var after interface{}
+		switch {
+		case page.Sort == "id":
+		case token.Null:
+			if !column.nullable {
+				return nil, nil, utils.ErrInvalidCursor
+			}
+		default:
+			var err error
+			after, err = parseValue(column.kind, token.Value)
+			if err != nil {
+				return nil, nil, utils.ErrInvalidCursor
+			}
+		}
+		start := sort.Search(len(sorted), func(i int) bool {
+			return less(after, token.ID, value(sorted[i], page.Sort), id(sorted[i]))
+		})
+		sorted = sorted[start:]
+	}
+
+	if len(sorted) > page.PageSize {
+		sorted = sorted[:page.PageSize]
+		last := sorted[len(sorted)-1]
+		token := &utils.CursorToken{
+			Sort: page.Sort,
+			Desc: page.Desc,
+			ID:   id(last),
+		}
+		if page.Sort != "id" {
+			if v := value(last, page.Sort); v != nil {
+				token.Value = formatValue(v)
+			} else {
+				token.Null = true
+			}
+		}
+		result.NextCursor = utils.EncodeCursor(token)
+	}
+
+	return sorted, result, nil
+}
+
+// compareValue 比较两个同类型的排序值，nil（NULL）小于所有值
+func compareValue(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	switch av := a.(type) {
+	case int64:
+		bv := b.(int64)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+	case string:
+		bv := b.(string)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+	case time.Time:
+		return av.Compare(b.(time.Time))
+	}
+	return 0
+}
+
+// parseValue 把游标中的排序值转换为对应类型
+func parseValue(kind sortKind, value string) (interface{}, error) {
+	switch kind {
+	case sortInt:
+		return strconv.ParseInt(value, 10, 64)
+	case sortTime:
+		return time.Parse(time.RFC3339Nano, value)
+	default:
+		return value, nil
+	}
+}
+
+// formatValue 把排序值编码为游标字符串，与 dao 包的编码方式一致
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case string:
+		return v
+	}
+	return ""
+}
+
+// timeValue 时间排序值，空值返回 nil
+func timeValue(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
@@ -0,0 +1,249 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mvc-demo/dao/model"
+	"mvc-demo/utils"
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// 邮箱、名称、院校代码重复时返回 gorm.ErrDuplicatedKey，已软删除的记录同样占用唯一值
+func TestUniqueConflicts(t *testing.T) {
+	store := NewStore()
+	ctx := context.Background()
+	users := NewUserRepository(store)
+	universities := NewUniversityRepository(store)
+	students := NewStudentRepository(store)
+
+	user := &model.User{Email: "a@example.com", Username: "a", Password: "secret", Role: 2, Status: 1}
+	if err := users.Create(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.Create(ctx, &model.User{Email: "a@example.com", Username: "b", Password: "secret"}); !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Errorf("重复邮箱创建用户: err = %v", err)
+	}
+	if err := users.Delete(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.Create(ctx, &model.User{Email: "a@example.com", Username: "c", Password: "secret"}); !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Errorf("使用已删除用户的邮箱创建用户: err = %v", err)
+	}
+
+	pku := &model.University{Name: "北京大学", Code: ptr("10001")}
+	thu := &model.University{Name: "清华大学", Code: ptr("10003")}
+	for _, university := range []*model.University{pku, thu} {
+		if err := universities.Create(ctx, university); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, university := range map[string]*model.University{
+		"重复名称": {Name: "北京大学"},
+		"重复代码": {Name: "北大", Code: ptr("10001")},
+	} {
+		if err := universities.Create(ctx, university); !errors.Is(err, gorm.ErrDuplicatedKey) {
+			t.Errorf("%s创建大学: err = %v", name, err)
+		}
+	}
+	renamed := *thu
+	renamed.Name = "北京大学"
+	if err := universities.Update(ctx, &renamed); !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Errorf("改为已有名称: err = %v", err)
+	}
+	if got, err := universities.GetByID(ctx, thu.ID); err != nil || got.Name != "清华大学" {
+		t.Errorf("更新失败后大学名称 = %q, err = %v", got.Name, err)
+	}
+	if err := universities.Delete(ctx, pku.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := universities.Create(ctx, &model.University{Name: "北京大学"}); !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Errorf("使用已删除大学的名称创建大学: err = %v", err)
+	}
+
+	if err := students.Create(ctx, &model.Student{Name: "张三", Email: "s@example.com", Password: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := students.Create(ctx, &model.Student{Name: "李四", Email: "s@example.com", Password: "secret"}); !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Errorf("重复邮箱创建学生: err = %v", err)
+	}
+}
+
+// 软删除的记录不出现在按ID、按唯一值的查询和列表中
+func TestSoftDeleteHidesRecords(t *testing.T) {
+	store := NewStore()
+	ctx := context.Background()
+	users := NewUserRepository(store)
+	universities := NewUniversityRepository(store)
+	students := NewStudentRepository(store)
+
+	user := &model.User{Email: "a@example.com", Username: "a", Password: "secret", Role: 2, Status: 1}
+	if err := users.Create(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := users.ValidateLogin(ctx, "a@example.com", "secret"); err != nil {
+		t.Fatalf("删除前登录: %v", err)
+	}
+	if err := users.Delete(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := users.GetByID(ctx, user.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetByID 已删除用户: err = %v", err)
+	}
+	if _, err := users.GetByEmail(ctx, "a@example.com"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetByEmail 已删除用户: err = %v", err)
+	}
+	if _, err := users.ValidateLogin(ctx, "a@example.com", "secret"); err == nil {
+		t.Error("已删除的用户仍可登录")
+	}
+	if list, total, err := users.GetList(ctx, 1, 10, nil); err != nil || total != 0 || len(list) != 0 {
+		t.Errorf("用户列表 len = %d, total = %d, err = %v", len(list), total, err)
+	}
+
+	university := &model.University{Name: "北京大学"}
+	if err := universities.Create(ctx, university); err != nil {
+		t.Fatal(err)
+	}
+	student := &model.Student{Name: "张三", Email: "s@example.com", Password: "secret", UniversityID: &university.ID}
+	if err := students.Create(ctx, student); err != nil {
+		t.Fatal(err)
+	}
+	if err := universities.Delete(ctx, university.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := universities.GetByID(ctx, university.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetByID 已删除大学: err = %v", err)
+	}
+	if _, err := universities.GetByName(ctx, "北京大学"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetByName 已删除大学: err = %v", err)
+	}
+	if exists, err := universities.CheckNameExistsWithDeleted(ctx, "北京大学"); err != nil || !exists {
+		t.Errorf("CheckNameExistsWithDeleted = %v, err = %v", exists, err)
+	}
+	if list, err := universities.GetAll(ctx, nil); err != nil || len(list) != 0 {
+		t.Errorf("GetAll len = %d, err = %v", len(list), err)
+	}
+	// 所属大学已删除时不附加大学
+	if got, err := students.GetByID(ctx, student.ID); err != nil || got.University != nil {
+		t.Errorf("学生所属大学 = %v, err = %v", got.University, err)
+	}
+
+	if err := students.Delete(ctx, student.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := students.GetByID(ctx, student.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetByID 已删除学生: err = %v", err)
+	}
+	if list, err := students.GetByIDs(ctx, []int64{student.ID}); err != nil || len(list) != 0 {
+		t.Errorf("GetByIDs len = %d, err = %v", len(list), err)
+	}
+	if list, total, err := students.GetList(ctx, 1, 10, nil, nil); err != nil || total != 0 || len(list) != 0 {
+		t.Errorf("学生列表 len = %d, total = %d, err = %v", len(list), total, err)
+	}
+}
+
+// fn 返回错误时恢复事务开始前的数据，嵌套事务失败只恢复到嵌套调用开始时
+func TestTxManagerRollback(t *testing.T) {
+	store := NewStore()
+	ctx := context.Background()
+	universities := NewUniversityRepository(store)
+	tx := NewTxManager(store, nil)
+	errRollback := errors.New("rollback")
+
+	err := tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := universities.Create(ctx, &model.University{Name: "北京大学"}); err != nil {
+			return err
+		}
+		err := tx.Transaction(ctx, func(ctx context.Context) error {
+			if err := universities.Create(ctx, &model.University{Name: "清华大学"}); err != nil {
+				return err
+			}
+			return errRollback
+		})
+		if !errors.Is(err, errRollback) {
+			t.Errorf("嵌套事务 err = %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := universities.GetByName(ctx, "北京大学"); err != nil {
+		t.Errorf("外层事务创建的大学: err = %v", err)
+	}
+	if _, err := universities.GetByName(ctx, "清华大学"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("回滚的嵌套事务创建的大学: err = %v", err)
+	}
+
+	err = tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := universities.Create(ctx, &model.University{Name: "复旦大学"}); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("err = %v", err)
+	}
+	if _, err := universities.GetByName(ctx, "复旦大学"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("回滚的事务创建的大学: err = %v", err)
+	}
+}
+
+// 游标分页与 dao 包一致：NULL 排在所有值之前，正序和倒序逐页读取都不漏、不重复
+func TestCursorNullSortValues(t *testing.T) {
+	store := NewStore()
+	ctx := context.Background()
+	users := NewUserRepository(store)
+
+	base := time.Date(2025, 3, 1, 8, 0, 0, 0, time.Local)
+	createdAt := []*time.Time{nil, ptr(base), nil, ptr(base.Add(time.Hour)), ptr(base), nil}
+	for i, value := range createdAt {
+		user := &model.User{Email: fmt.Sprintf("user%d@example.com", i), Username: fmt.Sprintf("user%d", i), Password: "x", Role: 2, Status: 1}
+		if err := users.Create(ctx, user); err != nil {
+			t.Fatal(err)
+		}
+		stored := store.users[user.ID]
+		stored.CreatedAt = value
+		store.users[user.ID] = stored
+	}
+
+	ascending := []int64{1, 3, 6, 2, 5, 4}
+	descending := []int64{4, 5, 2, 6, 3, 1}
+	for _, desc := range []bool{false, true} {
+		want := ascending
+		if desc {
+			want = descending
+		}
+		for _, pageSize := range []int{1, 2, 4} {
+			var got []int64
+			page := &utils.PageQuery{PageSize: pageSize, Sort: "created_at", Desc: desc}
+			for pages := 0; ; pages++ {
+				if pages > len(createdAt) {
+					t.Fatalf("desc=%v page_size=%d 分页没有结束", desc, pageSize)
+				}
+				list, result, err := users.GetListByCursor(ctx, page, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, user := range list {
+					got = append(got, user.ID)
+				}
+				if result.NextCursor == "" {
+					break
+				}
+				page = &utils.PageQuery{PageSize: pageSize, Cursor: result.NextCursor}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("desc=%v page_size=%d: got %v, want %v", desc, pageSize, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Package memory 提供用户、大学、学生仓储的内存实现，用于不依赖数据库的服务和接口测试。
+// 实现与对应DAO保持一致的语义：软删除的记录不出现在查询结果中，
+// 唯一约束（含已软删除的记录）冲突时返回 gorm.ErrDuplicatedKey，记录不存在时返回 gorm.ErrRecordNotFound。
+package memory
+
+import (
+	"fmt"
+	"mvc-demo/dao/model"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Store 内存数据，同一个 Store 上创建的仓储共享数据和事务
+type Store struct {
+	mu   sync.Mutex // 保护以下数据
+	txMu sync.Mutex // 串行执行最外层事务
+
+	users        map[int64]model.User
+	universities map[int64]model.University
+	aliases      map[int64]model.UniversityAlias
+	students     map[int64]model.Student
+	lastID       map[string]int64
+}
+
+// NewStore 创建空的内存数据
+func NewStore() *Store {
+	return &Store{
+		users:        make(map[int64]model.User),
+		universities: make(map[int64]model.University),
+		aliases:      make(map[int64]model.UniversityAlias),
+		students:     make(map[int64]model.Student),
+		lastID:       make(map[string]int64),
+	}
+}
+
+// snapshot 事务开始时的数据副本，用于回滚
+type snapshot struct {
+	users        map[int64]model.User
+	universities map[int64]model.University
+	aliases      map[int64]model.UniversityAlias
+	students     map[int64]model.Student
+	lastID       map[string]int64
+}
+
+// atomic 执行 fn，fn 返回错误或 panic 时恢复到执行前的数据
+// 执行期间，其他读取可以看到未提交的数据，测试中通常不会并发访问，暂不处理
+func (s *Store) atomic(fn func() error) (err error) {
+	s.mu.Lock()
+	saved := snapshot{
+		users:        copyMap(s.users),
+		universities: copyMap(s.universities),
+		aliases:      copyMap(s.aliases),
+		students:     copyMap(s.students),
+		lastID:       copyMap(s.lastID),
+	}
+	s.mu.Unlock()
+
+	rollback := func() {
+		s.mu.Lock()
+		s.users, s.universities, s.aliases, s.students, s.lastID =
+			saved.users, saved.universities, saved.aliases, saved.students, saved.lastID
+		s.mu.Unlock()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			rollback()
+			panic(r)
+		}
+	}()
+
+	if err = fn(); err != nil {
+		rollback()
+	}
+	return err
+}
+
+// nextID 生成某张表的自增ID，调用方需持有 s.mu
+func (s *Store) nextID(table string) int64 {
+	s.lastID[table]++
+	return s.lastID[table]
+}
+
+// copyMap 复制 map，值为结构体时即得到独立的副本
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	copied := make(map[K]V, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
+
+// sortedKeys 按ID升序返回 map 的键，保证遍历顺序与数据库按主键扫描一致
+func sortedKeys[V any](m map[int64]V) []int64 {
+	keys := make([]int64, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// now 当前时间，用于填充 created_at、updated_at 和 deleted_at
+func now() *time.Time {
+	t := time.Now()
+	return &t
+}
+
+// softDelete 软删除标记
+func softDelete() gorm.DeletedAt {
+	return gorm.DeletedAt{Time: time.Now(), Valid: true}
+}
+
+// offsetPage 偏移分页，page 从 1 开始
+func offsetPage[T any](items []T, page, pageSize int) []T {
+	start := (page - 1) * pageSize
+	if start < 0 {
+		start = 0
+	}
+	if start >= len(items) {
+		return []T{}
+	}
+	end := start + pageSize
+	if pageSize < 0 || end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+// inBatches 按批次回调，语义与 GORM 的 FindInBatches 一致
+func inBatches[T any](items []T, batchSize int, fn func(batch []T) error) error {
+	if batchSize <= 0 {
+		batchSize = len(items)
+	}
+	for start := 0; start < len(items); start += batchSize {
+		end := start + batchSize
+		if end > len(items) {
+			end = len(items)
+		}
+		if err := fn(items[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// containsFold 不区分大小写的子串匹配，对应 LIKE '%value%'（MySQL 默认排序规则不区分大小写）
+func containsFold(s *string, substr string) bool {
+	return s != nil && strings.Contains(strings.ToLower(*s), strings.ToLower(substr))
+}
+
+// equalValue 比较筛选条件，指针字段解引用后比较，兼容控制器传入的 int64、string 等类型
+func equalValue(field interface{}, value interface{}) bool {
+	switch f := field.(type) {
+	case *int64:
+		return f != nil && fmt.Sprint(*f) == fmt.Sprint(value)
+	case *string:
+		return f != nil && *f == fmt.Sprint(value)
+	default:
+		return fmt.Sprint(f) == fmt.Sprint(value)
+	}
+}
+
+// unsupportedFilter 内存实现不支持的筛选条件（通常依赖其他表的数据）
+func unsupportedFilter(key string) error {
+	return fmt.Errorf("memory: 不支持的筛选条件 %s", key)
+}
@@ -0,0 +1,456 @@
+package memory
+
+import (
+	"context"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/utils"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// StudentRepository 学生仓储的内存实现，所属大学从同一 Store 的大学数据中加载
+type StudentRepository struct {
+	store *Store
+}
+
+var _ dao.StudentRepository = (*StudentRepository)(nil)
+
+// NewStudentRepository 创建学生仓储
+func NewStudentRepository(store *Store) *StudentRepository {
+	return &StudentRepository{store: store}
+}
+
+// Create 创建学生，密码加密后保存，邮箱重复时返回 gorm.ErrDuplicatedKey
+func (r *StudentRepository) Create(ctx context.Context, student *model.Student) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(student.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.emailTaken(student.Email, 0) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	student.Password = string(hashedPassword)
+	student.ID = r.store.nextID(model.TableNameStudent)
+	student.CreatedAt, student.UpdatedAt = now(), now()
+	// 与数据库默认值一致
+	if student.Education == nil {
+		education := "本科"
+		student.Education = &education
+	}
+	if student.Status == nil {
+		status := "在读"
+		student.Status = &status
+	}
+	r.save(student)
+	return nil
+}
+
+// emailTaken 邮箱是否已被其他学生使用（包括已软删除的学生，与唯一索引一致），调用方需持有锁
+func (r *StudentRepository) emailTaken(email string, excludeID int64) bool {
+	for id, student := range r.store.students {
+		if id != excludeID && student.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+// save 保存学生，所属大学只读，不随学生一起保存，调用方需持有锁
+func (r *StudentRepository) save(student *model.Student) {
+	stored := *student
+	stored.University = nil
+	r.store.students[student.ID] = stored
+}
+
+// get 获取未删除的学生，withUniversity 为 true 时附加所属大学，调用方需持有锁
+func (r *StudentRepository) get(id int64, withUniversity bool) (*model.Student, error) {
+	student, ok := r.store.students[id]
+	if !ok || student.DeletedAt.Valid {
+		return &model.Student{}, gorm.ErrRecordNotFound
+	}
+	if withUniversity && student.UniversityID != nil {
+		if university, ok := r.store.universities[*student.UniversityID]; ok && !university.DeletedAt.Valid {
+			student.University = &university
+		}
+	}
+	return &student, nil
+}
+
+// list 按ID升序获取未删除的学生，调用方需持有锁
+func (r *StudentRepository) list(withUniversity bool) []*model.Student {
+	students := make([]*model.Student, 0, len(r.store.students))
+	for _, id := range sortedKeys(r.store.students) {
+		if student, err := r.get(id, withUniversity); err == nil {
+			students = append(students, student)
+		}
+	}
+	return students
+}
+
+// GetByID 根据ID获取学生（包含所属大学）
+func (r *StudentRepository) GetByID(ctx context.Context, id int64) (*model.Student, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.get(id, true)
+}
+
+// GetByIDWithFields 根据ID获取学生，按需附加所属大学
+func (r *StudentRepository) GetByIDWithFields(ctx context.Context, id int64, fs *utils.FieldSet) (*model.Student, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.get(id, fs.HasInclude("university"))
+}
+
+// GetByIDs 根据ID批量获取学生，按传入的ID顺序返回，不存在的ID会被忽略
+func (r *StudentRepository) GetByIDs(ctx context.Context, ids []int64) ([]*model.Student, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	students := make([]*model.Student, 0, len(ids))
+	for _, id := range ids {
+		if student, err := r.get(id, true); err == nil {
+			students = append(students, student)
+		}
+	}
+	return students, nil
+}
+
+// FindInBatches 分批遍历所有学生
+func (r *StudentRepository) FindInBatches(ctx context.Context, batchSize int, fn func(students []*model.Student) error) error {
+	r.store.mu.Lock()
+	students := r.list(false)
+	r.store.mu.Unlock()
+
+	return inBatches(students, batchSize, fn)
+}
+
+// GetByEmail 根据邮箱获取学生
+func (r *StudentRepository) GetByEmail(ctx context.Context, email string) (*model.Student, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, student := range r.list(false) {
+		if student.Email == email {
+			return student, nil
+		}
+	}
+	return &model.Student{}, gorm.ErrRecordNotFound
+}
+
+// Update 更新学生
+func (r *StudentRepository) Update(ctx context.Context, student *model.Student) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.emailTaken(student.Email, student.ID) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	student.UpdatedAt = now()
+	r.save(student)
+	return nil
+}
+
+// Delete 软删除学生
+func (r *StudentRepository) Delete(ctx context.Context, id int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.delete(id)
+	return nil
+}
+
+// delete 软删除学生，调用方需持有锁
+func (r *StudentRepository) delete(id int64) {
+	if student, err := r.get(id, false); err == nil {
+		student.DeletedAt = softDelete()
+		r.save(student)
+	}
+}
+
+// filter 按筛选条件过滤学生，调用方需持有锁
+// name 模糊匹配，其他条件精确匹配；attendance_below、advisor_id 依赖考勤和导师数据，内存实现不支持
+func (r *StudentRepository) filter(students []*model.Student, filters map[string]interface{}) ([]*model.Student, error) {
+	matched := make([]*model.Student, 0, len(students))
+	for _, student := range students {
+		ok := true
+		for key, value := range filters {
+			if value == nil || value == "" {
+				continue
+			}
+			switch key {
+			case "name":
+				ok = containsFold(&student.Name, value.(string))
+			case "university_id":
+				ok = equalValue(student.UniversityID, value)
+			case "major_id":
+				ok = equalValue(student.MajorID, value)
+			case "status":
+				ok = equalValue(student.Status, value)
+			case "education":
+				ok = equalValue(student.Education, value)
+			case "graduation_year":
+				ok = equalValue(student.GraduationYear, value)
+			case "attendance_term":
+				// 与 attendance_below 一起处理
+			default:
+				return nil, unsupportedFilter(key)
+			}
+			if !ok {
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, student)
+		}
+	}
+	return matched, nil
+}
+
+// GetList 获取学生列表（支持分页和筛选）
+func (r *StudentRepository) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.Student, int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	students, err := r.filter(r.list(fs.HasInclude("university")), filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	return offsetPage(students, page, pageSize), int64(len(students)), nil
+}
+
+// studentCursorColumns 学生列表可用于游标分页的排序字段
+var studentCursorColumns = cursorColumns{
+	"id":         {kind: sortInt},
+	"name":       {kind: sortString},
+	"created_at": {kind: sortTime, nullable: true},
+}
+
+// GetListByCursor 获取学生列表（游标分页，支持筛选）
+func (r *StudentRepository) GetListByCursor(ctx context.Context, page *utils.PageQuery, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.Student, *utils.CursorResult, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	students, err := r.filter(r.list(fs.HasInclude("university")), filters)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cursorPage(students, page, studentCursorColumns, func(student *model.Student, sort string) interface{} {
+		switch sort {
+		case "name":
+			return student.Name
+		case "created_at":
+			return timeValue(student.CreatedAt)
+		default:
+			return student.ID
+		}
+	}, func(student *model.Student) int64 { return student.ID })
+}
+
+// FindDuplicateCandidates 分批遍历所有学生
+func (r *StudentRepository) FindDuplicateCandidates(ctx context.Context, batchSize int, fn func(students []*model.Student) error) error {
+	return r.FindInBatches(ctx, batchSize, fn)
+}
+
+// FindReferenceConflicts 查找两个学生都有、合并后会重复的关联记录
+// 关联记录（选课、成绩等）不在内存实现中，不会有冲突
+func (r *StudentRepository) FindReferenceConflicts(ctx context.Context, fromID, toID int64) ([]dao.StudentReferenceConflict, error) {
+	return nil, nil
+}
+
+// ReassignRelated 把关联记录从一个学生移到另一个学生
+// 关联记录（选课、成绩等）不在内存实现中，无需处理
+func (r *StudentRepository) ReassignRelated(ctx context.Context, fromID, toID int64) error {
+	return nil
+}
+
+// MarkMerged 记录学生已合并到另一学生并软删除
+func (r *StudentRepository) MarkMerged(ctx context.Context, id, mergedIntoID int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if student, err := r.get(id, false); err == nil {
+		student.MergedIntoID = &mergedIntoID
+		r.save(student)
+	}
+	r.delete(id)
+	return nil
+}
+
+// UpdateStatus 当学生仍处于 fromStatus 时更新状态，返回是否更新成功
+func (r *StudentRepository) UpdateStatus(ctx context.Context, id int64, fromStatus *string, toStatus string, graduationYear *int64, updatedBy *int64) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	student, err := r.get(id, false)
+	if err != nil {
+		return false, nil
+	}
+	if (fromStatus == nil) != (student.Status == nil) || (fromStatus != nil && *fromStatus != *student.Status) {
+		return false, nil
+	}
+
+	student.Status = &toStatus
+	student.UpdatedBy = updatedBy
+	if graduationYear != nil {
+		student.GraduationYear = graduationYear
+	}
+	student.UpdatedAt = now()
+	r.save(student)
+	return true, nil
+}
+
+// update 修改满足条件的未删除学生，返回修改的学生数，调用方需持有锁
+func (r *StudentRepository) update(match func(student *model.Student) bool, apply func(student *model.Student)) int64 {
+	var affected int64
+	for _, student := range r.list(false) {
+		if match(student) {
+			apply(student)
+			student.UpdatedAt = now()
+			r.save(student)
+			affected++
+		}
+	}
+	return affected
+}
+
+// count 统计满足条件的未删除学生数，调用方需持有锁
+func (r *StudentRepository) count(match func(student *model.Student) bool) int64 {
+	var count int64
+	for _, student := range r.list(false) {
+		if match(student) {
+			count++
+		}
+	}
+	return count
+}
+
+// CountByUniversity 统计某大学的学生数
+func (r *StudentRepository) CountByUniversity(ctx context.Context, universityID int64) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.count(func(student *model.Student) bool {
+		return equalValue(student.UniversityID, universityID)
+	}), nil
+}
+
+// ReassignUniversity 把某大学的所有学生改为关联另一所大学，返回受影响的学生数
+func (r *StudentRepository) ReassignUniversity(ctx context.Context, fromID, toID int64) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.update(func(student *model.Student) bool {
+		return equalValue(student.UniversityID, fromID)
+	}, func(student *model.Student) {
+		student.UniversityID = &toID
+	}), nil
+}
+
+// ReassignMajor 把关联某专业的学生改为关联另一专业
+func (r *StudentRepository) ReassignMajor(ctx context.Context, fromID, toID int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.update(func(student *model.Student) bool {
+		return equalValue(student.MajorID, fromID)
+	}, func(student *model.Student) {
+		student.MajorID = &toID
+	})
+	return nil
+}
+
+// CountByMajor 统计关联某专业的学生数
+func (r *StudentRepository) CountByMajor(ctx context.Context, majorID int64) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.count(func(student *model.Student) bool {
+		return equalValue(student.MajorID, majorID)
+	}), nil
+}
+
+// FindUnlinkedMajors 分批遍历填写了专业名称但尚未关联专业目录的学生
+func (r *StudentRepository) FindUnlinkedMajors(ctx context.Context, batchSize int, fn func(students []*model.Student) error) error {
+	r.store.mu.Lock()
+	var students []*model.Student
+	for _, student := range r.list(false) {
+		if student.MajorID == nil && student.Major != nil && *student.Major != "" {
+			students = append(students, student)
+		}
+	}
+	r.store.mu.Unlock()
+
+	return inBatches(students, batchSize, fn)
+}
+
+// LinkMajor 为学生关联专业目录，不修改其他字段
+func (r *StudentRepository) LinkMajor(ctx context.Context, studentIDs []int64, majorID int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.update(func(student *model.Student) bool {
+		for _, id := range studentIDs {
+			if student.ID == id {
+				return true
+			}
+		}
+		return false
+	}, func(student *model.Student) {
+		student.MajorID = &majorID
+	})
+	return nil
+}
+
+// ValidateLogin 验证学生登录
+func (r *StudentRepository) ValidateLogin(ctx context.Context, email, password string) (*model.Student, error) {
+	student, err := r.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(student.Password), []byte(password)); err != nil {
+		return nil, err
+	}
+	return student, nil
+}
+
+// UpdateLastLoginTime 更新学生最后登录时间
+func (r *StudentRepository) UpdateLastLoginTime(ctx context.Context, studentID int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if student, err := r.get(studentID, false); err == nil {
+		student.LastLoginTime = now()
+		r.save(student)
+	}
+	return nil
+}
+
+// ResetPassword 重置学生密码
+func (r *StudentRepository) ResetPassword(ctx context.Context, email, newPassword string) error {
+	student, err := r.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	student.Password = string(hashedPassword)
+	r.save(student)
+	return nil
+}
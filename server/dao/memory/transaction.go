@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"mvc-demo/dao"
+)
+
+// txKey 内存事务在 context 中的标记
+type txKey struct{}
+
+// TxManager 内存仓储的事务管理器
+// fn 返回错误或 panic 时恢复 Store 中的数据；next 不为 nil 时同时在 next 的事务中执行 fn，
+// 使内存数据与数据库中的其余数据一起提交或回滚
+type TxManager struct {
+	store *Store
+	next  dao.Transactor
+}
+
+var _ dao.Transactor = (*TxManager)(nil)
+
+// NewTxManager 创建内存事务管理器，next 可以为 nil
+func NewTxManager(store *Store, next dao.Transactor) *TxManager {
+	return &TxManager{store: store, next: next}
+}
+
+// Transaction 在事务中执行 fn
+// 最外层事务之间串行执行；嵌套调用相当于保存点，fn 失败只恢复到嵌套调用开始时的数据
+func (m *TxManager) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txKey{}) == nil {
+		m.store.txMu.Lock()
+		defer m.store.txMu.Unlock()
+		ctx = context.WithValue(ctx, txKey{}, true)
+	}
+
+	if m.next == nil {
+		return m.store.atomic(func() error { return fn(ctx) })
+	}
+	// 外层恢复处理数据库提交失败，内层恢复处理每次重试前的数据
+	return m.store.atomic(func() error {
+		return m.next.Transaction(ctx, func(ctx context.Context) error {
+			return m.store.atomic(func() error { return fn(ctx) })
+		})
+	})
+}
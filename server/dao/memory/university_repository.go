@@ -0,0 +1,397 @@
+package memory
+
+import (
+	"context"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/utils"
+
+	"gorm.io/gorm"
+)
+
+// UniversityRepository 大学仓储的内存实现，别名单独保存，查询时按需附加
+type UniversityRepository struct {
+	store *Store
+}
+
+var _ dao.UniversityRepository = (*UniversityRepository)(nil)
+
+// NewUniversityRepository 创建大学仓储
+func NewUniversityRepository(store *Store) *UniversityRepository {
+	return &UniversityRepository{store: store}
+}
+
+// Create 创建大学，名称或院校代码重复时返回 gorm.ErrDuplicatedKey
+func (r *UniversityRepository) Create(ctx context.Context, university *model.University) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.conflicts(university) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	university.ID = r.store.nextID(model.TableNameUniversity)
+	university.CreatedAt, university.UpdatedAt = now(), now()
+	r.save(university)
+	return nil
+}
+
+// conflicts 名称或院校代码是否与其他大学重复（包括已软删除的大学，与唯一索引一致），调用方需持有锁
+func (r *UniversityRepository) conflicts(university *model.University) bool {
+	for id, existing := range r.store.universities {
+		if id == university.ID {
+			continue
+		}
+		if existing.Name == university.Name {
+			return true
+		}
+		if university.Code != nil && existing.Code != nil && *existing.Code == *university.Code {
+			return true
+		}
+	}
+	return false
+}
+
+// save 保存大学，别名不随大学一起保存，调用方需持有锁
+func (r *UniversityRepository) save(university *model.University) {
+	stored := *university
+	stored.Aliases = nil
+	r.store.universities[university.ID] = stored
+}
+
+// get 获取未删除的大学，withAliases 为 true 时附加别名，调用方需持有锁
+func (r *UniversityRepository) get(id int64, withAliases bool) (*model.University, error) {
+	university, ok := r.store.universities[id]
+	if !ok || university.DeletedAt.Valid {
+		return &model.University{}, gorm.ErrRecordNotFound
+	}
+	if withAliases {
+		university.Aliases = r.aliasesOf(id)
+	}
+	return &university, nil
+}
+
+// aliasesOf 获取大学的全部别名，调用方需持有锁
+func (r *UniversityRepository) aliasesOf(universityID int64) []*model.UniversityAlias {
+	aliases := make([]*model.UniversityAlias, 0)
+	for _, id := range sortedKeys(r.store.aliases) {
+		alias := r.store.aliases[id]
+		if alias.UniversityID == universityID {
+			aliases = append(aliases, &alias)
+		}
+	}
+	return aliases
+}
+
+// GetByID 根据ID获取大学（包含别名）
+func (r *UniversityRepository) GetByID(ctx context.Context, id int64) (*model.University, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.get(id, true)
+}
+
+// GetByIDWithFields 根据ID获取大学，按需附加别名
+func (r *UniversityRepository) GetByIDWithFields(ctx context.Context, id int64, fs *utils.FieldSet) (*model.University, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.get(id, fs.HasInclude("aliases"))
+}
+
+// GetByIDs 根据ID批量获取大学（不含别名）
+func (r *UniversityRepository) GetByIDs(ctx context.Context, ids []int64) ([]*model.University, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	universities := make([]*model.University, 0, len(ids))
+	for _, university := range r.list(false) {
+		for _, id := range ids {
+			if university.ID == id {
+				universities = append(universities, university)
+				break
+			}
+		}
+	}
+	return universities, nil
+}
+
+// GetByName 根据名称获取大学
+func (r *UniversityRepository) GetByName(ctx context.Context, name string) (*model.University, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, university := range r.list(false) {
+		if university.Name == name {
+			return university, nil
+		}
+	}
+	return &model.University{}, gorm.ErrRecordNotFound
+}
+
+// GetByAlias 根据别名或简称获取大学，别名优先
+func (r *UniversityRepository) GetByAlias(ctx context.Context, alias string) (*model.University, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	universities := r.list(false)
+	for _, university := range universities {
+		for _, a := range r.aliasesOf(university.ID) {
+			if a.Alias == alias {
+				return university, nil
+			}
+		}
+	}
+	for _, university := range universities {
+		if university.ShortName != nil && *university.ShortName == alias {
+			return university, nil
+		}
+	}
+	return &model.University{}, gorm.ErrRecordNotFound
+}
+
+// CheckCodeExistsExcludeID 检查排除某ID外是否存在相同院校代码的大学（包括已软删除的记录）
+func (r *UniversityRepository) CheckCodeExistsExcludeID(ctx context.Context, code string, excludeID int64) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, university := range r.store.universities {
+		if id != excludeID && university.Code != nil && *university.Code == code {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// FindAliasConflicts 查找已被其他大学用作别名或名称的别名
+func (r *UniversityRepository) FindAliasConflicts(ctx context.Context, aliases []string, excludeID int64) ([]string, error) {
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	wanted := make(map[string]bool, len(aliases))
+	for _, alias := range aliases {
+		wanted[alias] = true
+	}
+
+	var conflicts []string
+	for _, id := range sortedKeys(r.store.aliases) {
+		alias := r.store.aliases[id]
+		if alias.UniversityID != excludeID && wanted[alias.Alias] {
+			conflicts = append(conflicts, alias.Alias)
+		}
+	}
+	for _, university := range r.list(false) {
+		if university.ID != excludeID && wanted[university.Name] {
+			conflicts = append(conflicts, university.Name)
+		}
+	}
+	return conflicts, nil
+}
+
+// ReplaceAliases 用新的别名列表替换大学的全部别名
+func (r *UniversityRepository) ReplaceAliases(ctx context.Context, universityID int64, aliases []string, createdBy *int64) error {
+	r.store.mu.Lock()
+	for id, alias := range r.store.aliases {
+		if alias.UniversityID == universityID {
+			delete(r.store.aliases, id)
+		}
+	}
+	r.store.mu.Unlock()
+
+	return r.AddAliases(ctx, universityID, aliases, createdBy)
+}
+
+// AddAliases 为大学追加别名，别名已被使用时返回 gorm.ErrDuplicatedKey 且不添加任何别名
+func (r *UniversityRepository) AddAliases(ctx context.Context, universityID int64, aliases []string, createdBy *int64) error {
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	used := make(map[string]bool, len(r.store.aliases)+len(aliases))
+	for _, alias := range r.store.aliases {
+		used[alias.Alias] = true
+	}
+	for _, alias := range aliases {
+		if used[alias] {
+			return gorm.ErrDuplicatedKey
+		}
+		used[alias] = true
+	}
+
+	for _, alias := range aliases {
+		id := r.store.nextID(model.TableNameUniversityAlias)
+		r.store.aliases[id] = model.UniversityAlias{
+			ID:           id,
+			UniversityID: universityID,
+			Alias:        alias,
+			CreatedAt:    now(),
+			CreatedBy:    createdBy,
+		}
+	}
+	return nil
+}
+
+// GetAliasOwners 查询别名所属的大学，返回别名 => 大学ID，未使用的别名不出现在结果中
+func (r *UniversityRepository) GetAliasOwners(ctx context.Context, aliases []string) (map[string]int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	owners := make(map[string]int64)
+	for _, record := range r.store.aliases {
+		for _, alias := range aliases {
+			if record.Alias == alias {
+				owners[alias] = record.UniversityID
+			}
+		}
+	}
+	return owners, nil
+}
+
+// CheckNameExistsWithDeleted 检查大学名称是否存在（包括已软删除的记录）
+func (r *UniversityRepository) CheckNameExistsWithDeleted(ctx context.Context, name string) (bool, error) {
+	return r.CheckNameExistsExcludeID(ctx, name, 0)
+}
+
+// CheckNameExistsExcludeID 检查排除某ID外是否存在同名大学（包括已软删除的记录）
+func (r *UniversityRepository) CheckNameExistsExcludeID(ctx context.Context, name string, excludeID int64) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, university := range r.store.universities {
+		if id != excludeID && university.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Update 更新大学，别名通过 ReplaceAliases 单独维护
+func (r *UniversityRepository) Update(ctx context.Context, university *model.University) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.conflicts(university) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	university.UpdatedAt = now()
+	r.save(university)
+	return nil
+}
+
+// Delete 软删除大学
+func (r *UniversityRepository) Delete(ctx context.Context, id int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if university, err := r.get(id, false); err == nil {
+		university.DeletedAt = softDelete()
+		r.save(university)
+	}
+	return nil
+}
+
+// list 按ID升序获取未删除的大学，调用方需持有锁
+func (r *UniversityRepository) list(withAliases bool) []*model.University {
+	universities := make([]*model.University, 0, len(r.store.universities))
+	for _, id := range sortedKeys(r.store.universities) {
+		if university, err := r.get(id, withAliases); err == nil {
+			universities = append(universities, university)
+		}
+	}
+	return universities
+}
+
+// filter 按筛选条件过滤大学，筛选条件与 UniversityDAO 一致，调用方需持有锁
+// keyword 匹配名称、简称、英文名和别名；level_tag 匹配层次标签；province、city 精确匹配
+func (r *UniversityRepository) filter(universities []*model.University, filters map[string]interface{}) ([]*model.University, error) {
+	matched := make([]*model.University, 0, len(universities))
+	for _, university := range universities {
+		ok := true
+		for key, value := range filters {
+			switch key {
+			case "keyword":
+				keyword := value.(string)
+				ok = containsFold(&university.Name, keyword) || containsFold(university.ShortName, keyword) ||
+					containsFold(university.EnglishName, keyword) || r.aliasMatches(university.ID, keyword)
+			case "level_tag":
+				ok = containsFold(university.LevelTags, value.(string))
+			case "province":
+				ok = equalValue(university.Province, value)
+			case "city":
+				ok = equalValue(university.City, value)
+			default:
+				return nil, unsupportedFilter(key)
+			}
+			if !ok {
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, university)
+		}
+	}
+	return matched, nil
+}
+
+// aliasMatches 大学是否有包含关键字的别名，调用方需持有锁
+func (r *UniversityRepository) aliasMatches(universityID int64, keyword string) bool {
+	for _, alias := range r.aliasesOf(universityID) {
+		if containsFold(&alias.Alias, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetList 获取大学列表（支持分页和筛选）
+func (r *UniversityRepository) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.University, int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	universities, err := r.filter(r.list(fs.HasInclude("aliases")), filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	return offsetPage(universities, page, pageSize), int64(len(universities)), nil
+}
+
+// universityCursorColumns 大学列表可用于游标分页的排序字段
+var universityCursorColumns = cursorColumns{
+	"id":         {kind: sortInt},
+	"name":       {kind: sortString},
+	"created_at": {kind: sortTime, nullable: true},
+}
+
+// GetListByCursor 获取大学列表（游标分页，支持筛选）
+func (r *UniversityRepository) GetListByCursor(ctx context.Context, page *utils.PageQuery, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.University, *utils.CursorResult, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	universities, err := r.filter(r.list(fs.HasInclude("aliases")), filters)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cursorPage(universities, page, universityCursorColumns, func(university *model.University, sort string) interface{} {
+		switch sort {
+		case "name":
+			return university.Name
+		case "created_at":
+			return timeValue(university.CreatedAt)
+		default:
+			return university.ID
+		}
+	}, func(university *model.University) int64 { return university.ID })
+}
+
+// GetAll 获取所有大学（不分页）
+func (r *UniversityRepository) GetAll(ctx context.Context, fs *utils.FieldSet) ([]*model.University, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.list(fs.HasInclude("aliases")), nil
+}
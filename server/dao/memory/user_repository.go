@@ -0,0 +1,229 @@
+package memory
+
+import (
+	"context"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/utils"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// UserRepository 用户仓储的内存实现
+type UserRepository struct {
+	store *Store
+}
+
+var _ dao.UserRepository = (*UserRepository)(nil)
+
+// NewUserRepository 创建用户仓储
+func NewUserRepository(store *Store) *UserRepository {
+	return &UserRepository{store: store}
+}
+
+// Create 创建用户，密码加密后保存，邮箱重复时返回 gorm.ErrDuplicatedKey
+func (r *UserRepository) Create(ctx context.Context, user *model.User) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.emailTaken(user.Email, 0) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	user.Password = string(hashedPassword)
+	user.ID = r.store.nextID(model.TableNameUser)
+	user.CreatedAt, user.UpdatedAt = now(), now()
+	r.store.users[user.ID] = *user
+	return nil
+}
+
+// emailTaken 邮箱是否已被其他用户使用（包括已软删除的用户，与唯一索引一致），调用方需持有锁
+func (r *UserRepository) emailTaken(email string, excludeID int64) bool {
+	for id, user := range r.store.users {
+		if id != excludeID && user.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+// get 获取未删除的用户，调用方需持有锁
+func (r *UserRepository) get(id int64) (*model.User, error) {
+	user, ok := r.store.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return &model.User{}, gorm.ErrRecordNotFound
+	}
+	return &user, nil
+}
+
+// GetByID 根据ID获取用户
+func (r *UserRepository) GetByID(ctx context.Context, id int64) (*model.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.get(id)
+}
+
+// GetByIDWithFields 根据ID获取用户，内存实现总是返回全部字段，由响应裁剪
+func (r *UserRepository) GetByIDWithFields(ctx context.Context, id int64, fs *utils.FieldSet) (*model.User, error) {
+	return r.GetByID(ctx, id)
+}
+
+// GetByEmail 根据邮箱获取用户
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, id := range sortedKeys(r.store.users) {
+		user := r.store.users[id]
+		if !user.DeletedAt.Valid && user.Email == email {
+			return &user, nil
+		}
+	}
+	return &model.User{}, gorm.ErrRecordNotFound
+}
+
+// Update 更新用户，只更新 UserDAO.Update 更新的字段，密码为空时不修改
+func (r *UserRepository) Update(ctx context.Context, user *model.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, err := r.get(user.ID)
+	if err != nil {
+		// 与 GORM 一致，更新不存在的记录不报错
+		return nil
+	}
+	if r.emailTaken(user.Email, user.ID) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	stored.Email = user.Email
+	stored.Role = user.Role
+	stored.Status = user.Status
+	stored.LastLoginTime = user.LastLoginTime
+	stored.Username = user.Username
+	if len(user.Password) > 0 {
+		stored.Password = user.Password
+	}
+	stored.UpdatedAt = now()
+	r.store.users[user.ID] = *stored
+	return nil
+}
+
+// Delete 软删除用户
+func (r *UserRepository) Delete(ctx context.Context, id int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if user, err := r.get(id); err == nil {
+		user.DeletedAt = softDelete()
+		r.store.users[id] = *user
+	}
+	return nil
+}
+
+// list 按ID升序获取未删除的用户，调用方需持有锁
+func (r *UserRepository) list() []*model.User {
+	users := make([]*model.User, 0, len(r.store.users))
+	for _, id := range sortedKeys(r.store.users) {
+		user := r.store.users[id]
+		if !user.DeletedAt.Valid {
+			users = append(users, &user)
+		}
+	}
+	return users
+}
+
+// GetList 获取用户列表（支持分页）
+func (r *UserRepository) GetList(ctx context.Context, page, pageSize int, fs *utils.FieldSet) ([]*model.User, int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	users := r.list()
+	return offsetPage(users, page, pageSize), int64(len(users)), nil
+}
+
+// GetIDsByRole 获取指定角色的所有用户ID
+func (r *UserRepository) GetIDsByRole(ctx context.Context, role int32) ([]int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var ids []int64
+	for _, user := range r.list() {
+		if user.Role == role {
+			ids = append(ids, user.ID)
+		}
+	}
+	return ids, nil
+}
+
+// userCursorColumns 用户列表可用于游标分页的排序字段
+var userCursorColumns = cursorColumns{
+	"id":         {kind: sortInt},
+	"created_at": {kind: sortTime, nullable: true},
+}
+
+// GetListByCursor 获取用户列表（游标分页）
+func (r *UserRepository) GetListByCursor(ctx context.Context, page *utils.PageQuery, fs *utils.FieldSet) ([]*model.User, *utils.CursorResult, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return cursorPage(r.list(), page, userCursorColumns, func(user *model.User, sort string) interface{} {
+		if sort == "created_at" {
+			return timeValue(user.CreatedAt)
+		}
+		return user.ID
+	}, func(user *model.User) int64 { return user.ID })
+}
+
+// ValidateLogin 验证用户登录，用户停用时返回 gorm.ErrRecordNotFound
+func (r *UserRepository) ValidateLogin(ctx context.Context, email, password string) (*model.User, error) {
+	user, err := r.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return nil, err
+	}
+	if user.Status != 1 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return user, nil
+}
+
+// UpdateLastLoginTime 更新用户最后登录时间
+func (r *UserRepository) UpdateLastLoginTime(ctx context.Context, userID int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if user, err := r.get(userID); err == nil {
+		user.LastLoginTime = now()
+		r.store.users[userID] = *user
+	}
+	return nil
+}
+
+// ResetPassword 重置用户密码
+func (r *UserRepository) ResetPassword(ctx context.Context, email, newPassword string) error {
+	user, err := r.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	user.Password = string(hashedPassword)
+	user.UpdatedAt = now()
+	r.store.users[user.ID] = *user
+	return nil
+}
@@ -25,6 +25,7 @@ type Student struct {
 	ResumePath     *string        `gorm:"column:resume_path" json:"resume_path"`
 	UniversityID   *int64         `gorm:"column:university_id" json:"university_id"`
 	Major          *string        `gorm:"column:major" json:"major"`
+	MajorID        *int64         `gorm:"column:major_id" json:"major_id"`
 	Education      *string        `gorm:"column:education;default:本科" json:"education"`
 	GraduationYear *int64         `gorm:"column:graduation_year" json:"graduation_year"`
 	Status         *string        `gorm:"column:status;default:在读" json:"status"`
@@ -35,6 +36,8 @@ type Student struct {
 	CreatedBy      *int64         `gorm:"column:created_by" json:"created_by"`
 	UpdatedBy      *int64         `gorm:"column:updated_by" json:"updated_by"`
 	DeletedAt      gorm.DeletedAt `gorm:"column:deleted_at" json:"deleted_at"`
+	MergedIntoID   *int64         `gorm:"column:merged_into_id" json:"merged_into_id"`
+	University     *University    `gorm:"foreignKey:UniversityID" json:"university"`
 }
 
 // TableName Student's table name
@@ -14,13 +14,22 @@ const TableNameUniversity = "universities"
 
 // University 大学信息表
 type University struct {
-	ID        int64          `gorm:"column:id;primaryKey;autoIncrement:true;comment:大学ID" json:"id"` // 大学ID
-	Name      string         `gorm:"column:name;not null" json:"name"`
-	CreatedAt *time.Time     `gorm:"column:created_at" json:"created_at"`
-	UpdatedAt *time.Time     `gorm:"column:updated_at" json:"updated_at"`
-	CreatedBy *int64         `gorm:"column:created_by" json:"created_by"`
-	UpdatedBy *int64         `gorm:"column:updated_by" json:"updated_by"`
-	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at" json:"deleted_at"`
+	ID          int64              `gorm:"column:id;primaryKey;autoIncrement:true;comment:大学ID" json:"id"` // 大学ID
+	Name        string             `gorm:"column:name;not null" json:"name"`
+	Code        *string            `gorm:"column:code" json:"code"`
+	ShortName   *string            `gorm:"column:short_name" json:"short_name"`
+	EnglishName *string            `gorm:"column:english_name" json:"english_name"`
+	Province    *string            `gorm:"column:province" json:"province"`
+	City        *string            `gorm:"column:city" json:"city"`
+	LevelTags   *string            `gorm:"column:level_tags" json:"level_tags"`
+	Website     *string            `gorm:"column:website" json:"website"`
+	Logo        *string            `gorm:"column:logo" json:"logo"`
+	CreatedAt   *time.Time         `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   *time.Time         `gorm:"column:updated_at" json:"updated_at"`
+	CreatedBy   *int64             `gorm:"column:created_by" json:"created_by"`
+	UpdatedBy   *int64             `gorm:"column:updated_by" json:"updated_by"`
+	DeletedAt   gorm.DeletedAt     `gorm:"column:deleted_at" json:"deleted_at"`
+	Aliases     []*UniversityAlias `gorm:"foreignKey:UniversityID" json:"aliases"`
 }
 
 // TableName University's table name
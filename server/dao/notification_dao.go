@@ -0,0 +1,77 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationDAO 站内通知数据访问对象
+type NotificationDAO struct {
+	DB *gorm.DB
+}
+
+// NewNotificationDAO 创建站内通知DAO实例
+func NewNotificationDAO(db *gorm.DB) *NotificationDAO {
+	return &NotificationDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *NotificationDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// CreateBatch 批量写入通知
+func (dao *NotificationDAO) CreateBatch(ctx context.Context, notifications []*model.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return dao.db(ctx).Create(notifications).Error
+}
+
+// GetListByUser 获取用户的通知列表（按ID倒序），unreadOnly 为 true 时只返回未读通知
+func (dao *NotificationDAO) GetListByUser(ctx context.Context, userID int64, unreadOnly bool, page, pageSize int) ([]*model.Notification, int64, error) {
+	var notifications []*model.Notification
+	var total int64
+
+	query := dao.db(ctx).Model(&model.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	// 查询总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取数据列表
+	offset := (page - 1) * pageSize
+	err := query.Order("id DESC").Offset(offset).Limit(pageSize).Find(&notifications).Error
+	return notifications, total, err
+}
+
+// CountUnread 统计用户的未读通知数
+func (dao *NotificationDAO) CountUnread(ctx context.Context, userID int64) (int64, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// MarkRead 把用户的一条通知标记为已读，返回值表示通知是否存在
+func (dao *NotificationDAO) MarkRead(ctx context.Context, userID, id int64) (bool, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.Notification{}).Where("id = ? AND user_id = ?", id, userID).Count(&count).Error
+	if err != nil || count == 0 {
+		return false, err
+	}
+	err = dao.db(ctx).Model(&model.Notification{}).Where("id = ? AND read_at IS NULL", id).Update("read_at", time.Now()).Error
+	return err == nil, err
+}
+
+// MarkAllRead 把用户的所有未读通知标记为已读，返回标记的数量
+func (dao *NotificationDAO) MarkAllRead(ctx context.Context, userID int64) (int64, error) {
+	result := dao.db(ctx).Model(&model.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Update("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
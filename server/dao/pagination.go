@@ -0,0 +1,137 @@
+package dao
+
+import (
+	"mvc-demo/utils"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// sortKind 排序字段类型，用于把游标中的排序值还原为查询参数
+type sortKind int
+
+const (
+	sortInt sortKind = iota
+	sortString
+	sortTime
+)
+
+// cursorColumn 游标分页排序字段的类型，nullable 表示字段可以为 NULL
+type cursorColumn struct {
+	kind     sortKind
+	nullable bool
+}
+
+// cursorColumns 可用于游标分页的排序字段
+type cursorColumns map[string]cursorColumn
+
+// applyCursor 为查询追加游标条件和排序，并多取一条记录用于判断是否还有下一页
+// 传入游标时以游标中记录的排序字段和方向为准
+func applyCursor(query *gorm.DB, page *utils.PageQuery, columns cursorColumns) (*gorm.DB, error) {
+	var token *utils.CursorToken
+	if page.Cursor != "" {
+		var err error
+		token, err = utils.DecodeCursor(page.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		page.Sort = token.Sort
+		page.Desc = token.Desc
+	}
+
+	column, ok := columns[page.Sort]
+	if !ok {
+		return nil, utils.ErrInvalidSort
+	}
+
+	direction, op := "ASC", ">"
+	if page.Desc {
+		direction, op = "DESC", "<"
+	}
+	sort := page.Sort
+
+	if token != nil {
+		switch {
+		case sort == "id":
+			query = query.Where("id "+op+" ?", token.ID)
+		case token.Null:
+			if !column.nullable {
+				return nil, utils.ErrInvalidCursor
+			}
+			// NULL 排在所有值之前：正序时之后是其余 NULL 记录和全部非 NULL 记录，倒序时只剩其余 NULL 记录
+			if page.Desc {
+				query = query.Where(sort+" IS NULL AND id < ?", token.ID)
+			} else {
+				query = query.Where("(("+sort+" IS NULL AND id > ?) OR "+sort+" IS NOT NULL)", token.ID)
+			}
+		default:
+			value, err := parseSortValue(column.kind, token.Value)
+			if err != nil {
+				return nil, utils.ErrInvalidCursor
+			}
+			condition := sort + " " + op + " ? OR (" + sort + " = ? AND id " + op + " ?)"
+			if column.nullable && page.Desc {
+				condition += " OR " + sort + " IS NULL"
+			}
+			query = query.Where("("+condition+")", value, value, token.ID)
+		}
+	}
+
+	if sort != "id" {
+		// 各数据库 NULL 的默认排序位置不同（PostgreSQL 正序时排在最后），统一排在所有值之前
+		if column.nullable {
+			nullsFirst := "DESC"
+			if page.Desc {
+				nullsFirst = "ASC"
+			}
+			query = query.Order("CASE WHEN " + sort + " IS NULL THEN 1 ELSE 0 END " + nullsFirst)
+		}
+		query = query.Order(sort + " " + direction)
+	}
+	return query.Order("id " + direction).Limit(page.PageSize + 1), nil
+}
+
+// nextCursor 根据本页最后一条记录生成下一页游标，value 为 nil 表示该记录的排序值为 NULL
+func nextCursor(page *utils.PageQuery, value *string, id int64) string {
+	token := &utils.CursorToken{
+		Sort: page.Sort,
+		Desc: page.Desc,
+		ID:   id,
+	}
+	if value != nil {
+		token.Value = *value
+	} else if page.Sort != "id" {
+		token.Null = true
+	}
+	return utils.EncodeCursor(token)
+}
+
+// parseSortValue 把游标中的排序值转换为对应类型
+func parseSortValue(kind sortKind, value string) (interface{}, error) {
+	switch kind {
+	case sortInt:
+		return strconv.ParseInt(value, 10, 64)
+	case sortTime:
+		return time.Parse(time.RFC3339Nano, value)
+	default:
+		return value, nil
+	}
+}
+
+// formatSortTime 把时间排序值编码为游标字符串，时间为空时返回 nil
+func formatSortTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	value := t.Format(time.RFC3339Nano)
+	return &value
+}
+
+// selectColumns 按字段选择缩小查询的列，extra 为额外需要的列（如游标排序字段）
+func selectColumns(query *gorm.DB, fs *utils.FieldSet, extra ...string) *gorm.DB {
+	if columns := fs.SelectColumns(extra...); len(columns) > 0 {
+		return query.Select(columns)
+	}
+	return query
+}
@@ -0,0 +1,71 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"mvc-demo/dao/model"
+	"mvc-demo/utils"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// 按可为 NULL 的 created_at 游标分页时，正序和倒序逐页读取都不漏、不重复，NULL 排在所有值之前
+func TestCursorNullSortValues(t *testing.T) {
+	conn := newMigratedDB(t)
+	ctx := context.Background()
+
+	base := time.Date(2025, 3, 1, 8, 0, 0, 0, time.Local)
+	createdAt := []*time.Time{nil, ptr(base), nil, ptr(base.Add(time.Hour)), ptr(base), nil, ptr(base.Add(time.Hour)), ptr(base.Add(2 * time.Hour)), nil, ptr(base.Add(time.Hour))}
+	for i, value := range createdAt {
+		user := &model.User{Email: fmt.Sprintf("user%d@example.com", i), Username: fmt.Sprintf("user%d", i), Password: "x", Role: 2, Status: 1}
+		if err := conn.Create(user).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := conn.Model(user).UpdateColumn("created_at", value).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 期望顺序：NULL 按 id 排在最前，其余按 created_at、id 排序
+	ascending := []int64{1, 3, 6, 9, 2, 5, 4, 7, 10, 8}
+	descending := make([]int64, len(ascending))
+	for i, id := range ascending {
+		descending[len(ascending)-1-i] = id
+	}
+
+	userDAO := NewUserDAO(conn)
+	for _, desc := range []bool{false, true} {
+		want := ascending
+		if desc {
+			want = descending
+		}
+		for _, pageSize := range []int{1, 2, 3, 4} {
+			var got []int64
+			page := &utils.PageQuery{PageSize: pageSize, Sort: "created_at", Desc: desc}
+			for pages := 0; ; pages++ {
+				if pages > len(ascending) {
+					t.Fatalf("desc=%v page_size=%d 分页没有结束", desc, pageSize)
+				}
+				users, result, err := userDAO.GetListByCursor(ctx, page, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, user := range users {
+					got = append(got, user.ID)
+				}
+				if result.NextCursor == "" {
+					break
+				}
+				page = &utils.PageQuery{PageSize: pageSize, Cursor: result.NextCursor}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("desc=%v page_size=%d 逐页读取的顺序为 %v，期望 %v", desc, pageSize, got, want)
+			}
+		}
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
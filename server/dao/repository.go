@@ -0,0 +1,318 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+	"mvc-demo/utils"
+	"time"
+)
+
+// 数据访问接口：服务只依赖这些接口，由同名的 DAO 实现（如 StudentRepository 由 StudentDAO 实现），
+// 测试时可以替换其中任意一个。新增 DAO 方法时同步加入对应的接口。
+
+// UserRepository 用户数据访问接口，由 UserDAO 实现，测试时可替换为 dao/memory 中的内存实现
+type UserRepository interface {
+	Create(ctx context.Context, user *model.User) error
+	GetByID(ctx context.Context, id int64) (*model.User, error)
+	GetByIDWithFields(ctx context.Context, id int64, fs *utils.FieldSet) (*model.User, error)
+	GetByEmail(ctx context.Context, email string) (*model.User, error)
+	Update(ctx context.Context, user *model.User) error
+	Delete(ctx context.Context, id int64) error
+	GetList(ctx context.Context, page, pageSize int, fs *utils.FieldSet) ([]*model.User, int64, error)
+	GetIDsByRole(ctx context.Context, role int32) ([]int64, error)
+	GetListByCursor(ctx context.Context, page *utils.PageQuery, fs *utils.FieldSet) ([]*model.User, *utils.CursorResult, error)
+	ValidateLogin(ctx context.Context, email, password string) (*model.User, error)
+	UpdateLastLoginTime(ctx context.Context, userID int64) error
+	ResetPassword(ctx context.Context, email, newPassword string) error
+}
+
+// UniversityRepository 大学数据访问接口，由 UniversityDAO 实现，测试时可替换为 dao/memory 中的内存实现
+type UniversityRepository interface {
+	Create(ctx context.Context, university *model.University) error
+	GetByID(ctx context.Context, id int64) (*model.University, error)
+	GetByIDWithFields(ctx context.Context, id int64, fs *utils.FieldSet) (*model.University, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]*model.University, error)
+	GetByName(ctx context.Context, name string) (*model.University, error)
+	GetByAlias(ctx context.Context, alias string) (*model.University, error)
+	CheckCodeExistsExcludeID(ctx context.Context, code string, excludeID int64) (bool, error)
+	FindAliasConflicts(ctx context.Context, aliases []string, excludeID int64) ([]string, error)
+	ReplaceAliases(ctx context.Context, universityID int64, aliases []string, createdBy *int64) error
+	AddAliases(ctx context.Context, universityID int64, aliases []string, createdBy *int64) error
+	GetAliasOwners(ctx context.Context, aliases []string) (map[string]int64, error)
+	CheckNameExistsWithDeleted(ctx context.Context, name string) (bool, error)
+	CheckNameExistsExcludeID(ctx context.Context, name string, excludeID int64) (bool, error)
+	Update(ctx context.Context, university *model.University) error
+	Delete(ctx context.Context, id int64) error
+	GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.University, int64, error)
+	GetListByCursor(ctx context.Context, page *utils.PageQuery, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.University, *utils.CursorResult, error)
+	GetAll(ctx context.Context, fs *utils.FieldSet) ([]*model.University, error)
+}
+
+// StudentRepository 学生数据访问接口，由 StudentDAO 实现，测试时可替换为 dao/memory 中的内存实现
+type StudentRepository interface {
+	Create(ctx context.Context, student *model.Student) error
+	GetByID(ctx context.Context, id int64) (*model.Student, error)
+	GetByIDWithFields(ctx context.Context, id int64, fs *utils.FieldSet) (*model.Student, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]*model.Student, error)
+	FindInBatches(ctx context.Context, batchSize int, fn func(students []*model.Student) error) error
+	GetByEmail(ctx context.Context, email string) (*model.Student, error)
+	Update(ctx context.Context, student *model.Student) error
+	Delete(ctx context.Context, id int64) error
+	GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.Student, int64, error)
+	GetListByCursor(ctx context.Context, page *utils.PageQuery, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.Student, *utils.CursorResult, error)
+	FindDuplicateCandidates(ctx context.Context, batchSize int, fn func(students []*model.Student) error) error
+	FindReferenceConflicts(ctx context.Context, fromID, toID int64) ([]StudentReferenceConflict, error)
+	ReassignRelated(ctx context.Context, fromID, toID int64) error
+	MarkMerged(ctx context.Context, id, mergedIntoID int64) error
+	UpdateStatus(ctx context.Context, id int64, fromStatus *string, toStatus string, graduationYear *int64, updatedBy *int64) (bool, error)
+	CountByUniversity(ctx context.Context, universityID int64) (int64, error)
+	ReassignUniversity(ctx context.Context, fromID, toID int64) (int64, error)
+	ReassignMajor(ctx context.Context, fromID, toID int64) error
+	CountByMajor(ctx context.Context, majorID int64) (int64, error)
+	FindUnlinkedMajors(ctx context.Context, batchSize int, fn func(students []*model.Student) error) error
+	LinkMajor(ctx context.Context, studentIDs []int64, majorID int64) error
+	ValidateLogin(ctx context.Context, email, password string) (*model.Student, error)
+	UpdateLastLoginTime(ctx context.Context, studentID int64) error
+	ResetPassword(ctx context.Context, email, newPassword string) error
+}
+
+// AdvisorAssignmentRepository 导师分配数据访问接口，由 AdvisorAssignmentDAO 实现
+type AdvisorAssignmentRepository interface {
+	Create(ctx context.Context, assignment *model.AdvisorAssignment) error
+	Update(ctx context.Context, assignment *model.AdvisorAssignment) error
+	GetLatestByStudent(ctx context.Context, studentID int64) (*model.AdvisorAssignment, error)
+	GetByStudent(ctx context.Context, studentID int64) ([]*model.AdvisorAssignment, error)
+	CountByTeacher(ctx context.Context, teacherID int64) (int64, error)
+	CountCurrentByTeacher(ctx context.Context, teacherID int64, date time.Time) (int64, error)
+}
+
+// AttendanceRepository 考勤记录数据访问接口，由 AttendanceDAO 实现
+type AttendanceRepository interface {
+	Create(ctx context.Context, record *model.AttendanceRecord) error
+	Update(ctx context.Context, record *model.AttendanceRecord) error
+	GetBySession(ctx context.Context, sessionID int64) ([]*model.AttendanceRecord, error)
+	DeleteBySession(ctx context.Context, sessionID int64) error
+	Summarize(ctx context.Context, filters map[string]interface{}) ([]*AttendanceSummary, error)
+}
+
+// AuditLogRepository 审计日志数据访问接口，由 AuditLogDAO 实现
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *model.AuditLog) error
+}
+
+// ClassSessionRepository 课次数据访问接口，由 ClassSessionDAO 实现
+type ClassSessionRepository interface {
+	Create(ctx context.Context, session *model.ClassSession) error
+	CreateBatch(ctx context.Context, sessions []*model.ClassSession) error
+	GetByID(ctx context.Context, id int64) (*model.ClassSession, error)
+	CheckExists(ctx context.Context, sectionID int64, date time.Time, startTime string, excludeID int64) (bool, error)
+	GetBySectionBetween(ctx context.Context, sectionID int64, from, to time.Time) ([]*model.ClassSession, error)
+	Update(ctx context.Context, session *model.ClassSession) error
+	Delete(ctx context.Context, id int64) error
+	CountBySection(ctx context.Context, sectionID int64) (int64, error)
+	GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.ClassSession, int64, error)
+}
+
+// CollegeRepository 院系数据访问接口，由 CollegeDAO 实现
+type CollegeRepository interface {
+	Create(ctx context.Context, college *model.College) error
+	GetByID(ctx context.Context, id int64) (*model.College, error)
+	CheckNameExists(ctx context.Context, universityID int64, name string, excludeID int64) (bool, error)
+	Update(ctx context.Context, college *model.College) error
+	Delete(ctx context.Context, id int64) error
+	MoveToUniversity(ctx context.Context, id, universityID int64) error
+	GetListByUniversity(ctx context.Context, universityID int64) ([]*model.College, error)
+}
+
+// CompanyRepository 企业数据访问接口，由 CompanyDAO 实现
+type CompanyRepository interface {
+	Create(ctx context.Context, company *model.Company) error
+	GetByID(ctx context.Context, id int64) (*model.Company, error)
+	CheckNameExists(ctx context.Context, name string, excludeID int64) (bool, error)
+	Update(ctx context.Context, company *model.Company) error
+	Delete(ctx context.Context, id int64) error
+	GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Company, int64, error)
+}
+
+// CourseRepository 课程数据访问接口，由 CourseDAO 实现
+type CourseRepository interface {
+	Create(ctx context.Context, course *model.Course) error
+	GetByID(ctx context.Context, id int64) (*model.Course, error)
+	CheckCodeExists(ctx context.Context, universityID int64, code string, excludeID int64) (bool, error)
+	Update(ctx context.Context, course *model.Course) error
+	Delete(ctx context.Context, id int64) error
+	GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Course, int64, error)
+	CountByUniversity(ctx context.Context, universityID int64) (int64, error)
+	GetSharedCodes(ctx context.Context, universityID, otherUniversityID int64) ([]string, error)
+	ReassignUniversity(ctx context.Context, fromID, toID int64) (int64, error)
+}
+
+// CourseSectionRepository 教学班数据访问接口，由 CourseSectionDAO 实现
+type CourseSectionRepository interface {
+	Create(ctx context.Context, section *model.CourseSection) error
+	GetByID(ctx context.Context, id int64) (*model.CourseSection, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]*model.CourseSection, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (*model.CourseSection, error)
+	CheckSectionNoExists(ctx context.Context, courseID int64, term, sectionNo string, excludeID int64) (bool, error)
+	Update(ctx context.Context, section *model.CourseSection) error
+	Delete(ctx context.Context, id int64) error
+	CountByCourse(ctx context.Context, courseID int64) (int64, error)
+	GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.CourseSection, int64, error)
+}
+
+// EmploymentRecordRepository 毕业去向数据访问接口，由 EmploymentRecordDAO 实现
+type EmploymentRecordRepository interface {
+	Create(ctx context.Context, record *model.EmploymentRecord) error
+	GetByID(ctx context.Context, id int64) (*model.EmploymentRecord, error)
+	CheckStudentExists(ctx context.Context, studentID int64, excludeID int64) (bool, error)
+	Update(ctx context.Context, record *model.EmploymentRecord) error
+	Delete(ctx context.Context, id int64) error
+	CountByCompany(ctx context.Context, companyID int64) (int64, error)
+	GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.EmploymentRecord, int64, error)
+	RateReport(ctx context.Context, groupBy string, filters map[string]interface{}) ([]*EmploymentRateRow, error)
+}
+
+// EnrollmentRepository 选课记录数据访问接口，由 EnrollmentDAO 实现
+type EnrollmentRepository interface {
+	Create(ctx context.Context, enrollment *model.Enrollment) error
+	GetByID(ctx context.Context, id int64) (*model.Enrollment, error)
+	GetByStudentAndSection(ctx context.Context, studentID, sectionID int64) (*model.Enrollment, error)
+	GetByStudentAndTerm(ctx context.Context, studentID int64, term, status string) ([]*model.Enrollment, error)
+	GetBySection(ctx context.Context, sectionID int64, statuses []string) ([]*model.Enrollment, error)
+	CountBySection(ctx context.Context, sectionID int64, status string) (int64, error)
+	CountBySections(ctx context.Context, sectionIDs []int64, status string) (map[int64]int64, error)
+	UpdateStatus(ctx context.Context, id int64, fromStatus, toStatus string, updatedBy *int64) (bool, error)
+	GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Enrollment, int64, error)
+}
+
+// GradeRepository 课程成绩数据访问接口，由 GradeDAO 实现
+type GradeRepository interface {
+	Create(ctx context.Context, grade *model.Grade) error
+	GetByID(ctx context.Context, id int64) (*model.Grade, error)
+	CheckExists(ctx context.Context, studentID, courseID int64, term string) (bool, error)
+	Update(ctx context.Context, grade *model.Grade) error
+	Delete(ctx context.Context, id int64) error
+	GetByStudent(ctx context.Context, studentID int64) ([]*model.Grade, error)
+	GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Grade, int64, error)
+}
+
+// GradingScaleRepository 评分标准数据访问接口，由 GradingScaleDAO 实现
+type GradingScaleRepository interface {
+	Create(ctx context.Context, scale *model.GradingScale) error
+	GetByID(ctx context.Context, id int64) (*model.GradingScale, error)
+	GetDefault(ctx context.Context) (*model.GradingScale, error)
+	GetAll(ctx context.Context) ([]*model.GradingScale, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]*model.GradingScale, error)
+	Update(ctx context.Context, scale *model.GradingScale) error
+	Delete(ctx context.Context, id int64) error
+	ReplaceItems(ctx context.Context, scaleID int64, items []*model.GradingScaleItem) error
+	ClearDefault(ctx context.Context, exceptID int64) error
+	CountUsage(ctx context.Context, scaleID int64) (int64, error)
+}
+
+// JobApplicationRepository 岗位申请数据访问接口，由 JobApplicationDAO 实现
+type JobApplicationRepository interface {
+	Create(ctx context.Context, application *model.JobApplication) error
+	GetByID(ctx context.Context, id int64) (*model.JobApplication, error)
+	CheckExists(ctx context.Context, postingID, studentID int64) (bool, error)
+	UpdateStatus(ctx context.Context, id int64, fromStatus, toStatus string, remarks *string, updatedBy *int64) (bool, error)
+	Delete(ctx context.Context, id int64) error
+	CountByPosting(ctx context.Context, postingID int64) (int64, error)
+	GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.JobApplication, int64, error)
+}
+
+// JobPostingRepository 招聘岗位数据访问接口，由 JobPostingDAO 实现
+type JobPostingRepository interface {
+	Create(ctx context.Context, posting *model.JobPosting) error
+	GetByID(ctx context.Context, id int64) (*model.JobPosting, error)
+	Update(ctx context.Context, posting *model.JobPosting) error
+	Delete(ctx context.Context, id int64) error
+	CountByCompany(ctx context.Context, companyID int64) (int64, error)
+	GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.JobPosting, int64, error)
+}
+
+// MajorRepository 专业数据访问接口，由 MajorDAO 实现
+type MajorRepository interface {
+	Create(ctx context.Context, major *model.Major) error
+	GetByID(ctx context.Context, id int64) (*model.Major, error)
+	CheckNameExists(ctx context.Context, universityID int64, name string, excludeID int64) (bool, error)
+	Update(ctx context.Context, major *model.Major) error
+	Delete(ctx context.Context, id int64) error
+	GetList(ctx context.Context, filters map[string]interface{}) ([]*model.Major, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]*model.Major, error)
+	GetAll(ctx context.Context) ([]*model.Major, error)
+	MoveToUniversity(ctx context.Context, id, universityID int64, collegeID *int64) error
+	CountByCollege(ctx context.Context, collegeID int64) (int64, error)
+}
+
+// NotificationRepository 站内通知数据访问接口，由 NotificationDAO 实现
+type NotificationRepository interface {
+	CreateBatch(ctx context.Context, notifications []*model.Notification) error
+	GetListByUser(ctx context.Context, userID int64, unreadOnly bool, page, pageSize int) ([]*model.Notification, int64, error)
+	CountUnread(ctx context.Context, userID int64) (int64, error)
+	MarkRead(ctx context.Context, userID, id int64) (bool, error)
+	MarkAllRead(ctx context.Context, userID int64) (int64, error)
+}
+
+// StudentChangeRequestRepository 学生信息变更申请数据访问接口，由 StudentChangeRequestDAO 实现
+type StudentChangeRequestRepository interface {
+	Create(ctx context.Context, request *model.StudentChangeRequest) error
+	GetByID(ctx context.Context, id int64) (*model.StudentChangeRequest, error)
+	GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.StudentChangeRequest, int64, error)
+	UpdateStatus(ctx context.Context, id int64, fromStatus, toStatus string, reviewerID *int64, comment *string) (bool, error)
+}
+
+// StudentStatusHistoryRepository 学生状态变更记录数据访问接口，由 StudentStatusHistoryDAO 实现
+type StudentStatusHistoryRepository interface {
+	Create(ctx context.Context, history *model.StudentStatusHistory) error
+	GetListByStudent(ctx context.Context, studentID int64) ([]*model.StudentStatusHistory, error)
+}
+
+// TeacherRepository 教师数据访问接口，由 TeacherDAO 实现
+type TeacherRepository interface {
+	Create(ctx context.Context, teacher *model.Teacher) error
+	GetByID(ctx context.Context, id int64) (*model.Teacher, error)
+	GetByUserID(ctx context.Context, userID int64) (*model.Teacher, error)
+	CheckUserExists(ctx context.Context, userID int64, excludeID int64) (bool, error)
+	CheckEmployeeNoExists(ctx context.Context, employeeNo string, excludeID int64) (bool, error)
+	Update(ctx context.Context, teacher *model.Teacher) error
+	Delete(ctx context.Context, id int64) error
+	GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Teacher, int64, error)
+	CountByUniversity(ctx context.Context, universityID int64) (int64, error)
+	ReassignUniversity(ctx context.Context, fromID, toID int64) (int64, error)
+	ReassignCollege(ctx context.Context, fromID, toID int64) error
+}
+
+// TranscriptRepository 已签发成绩单数据访问接口，由 TranscriptDAO 实现
+type TranscriptRepository interface {
+	Create(ctx context.Context, transcript *model.Transcript) error
+	GetByID(ctx context.Context, id int64) (*model.Transcript, error)
+	GetByCode(ctx context.Context, code string) (*model.Transcript, error)
+	GetListByStudent(ctx context.Context, studentID int64) ([]*model.Transcript, error)
+}
+
+var (
+	_ UserRepository                 = (*UserDAO)(nil)
+	_ UniversityRepository           = (*UniversityDAO)(nil)
+	_ StudentRepository              = (*StudentDAO)(nil)
+	_ AdvisorAssignmentRepository    = (*AdvisorAssignmentDAO)(nil)
+	_ AttendanceRepository           = (*AttendanceDAO)(nil)
+	_ AuditLogRepository             = (*AuditLogDAO)(nil)
+	_ ClassSessionRepository         = (*ClassSessionDAO)(nil)
+	_ CollegeRepository              = (*CollegeDAO)(nil)
+	_ CompanyRepository              = (*CompanyDAO)(nil)
+	_ CourseRepository               = (*CourseDAO)(nil)
+	_ CourseSectionRepository        = (*CourseSectionDAO)(nil)
+	_ EmploymentRecordRepository     = (*EmploymentRecordDAO)(nil)
+	_ EnrollmentRepository           = (*EnrollmentDAO)(nil)
+	_ GradeRepository                = (*GradeDAO)(nil)
+	_ GradingScaleRepository         = (*GradingScaleDAO)(nil)
+	_ JobApplicationRepository       = (*JobApplicationDAO)(nil)
+	_ JobPostingRepository           = (*JobPostingDAO)(nil)
+	_ MajorRepository                = (*MajorDAO)(nil)
+	_ NotificationRepository         = (*NotificationDAO)(nil)
+	_ StudentChangeRequestRepository = (*StudentChangeRequestDAO)(nil)
+	_ StudentStatusHistoryRepository = (*StudentStatusHistoryDAO)(nil)
+	_ TeacherRepository              = (*TeacherDAO)(nil)
+	_ TranscriptRepository           = (*TranscriptDAO)(nil)
+)
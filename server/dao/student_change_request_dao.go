@@ -0,0 +1,80 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StudentChangeRequestDAO 学生信息变更申请数据访问对象
+type StudentChangeRequestDAO struct {
+	DB *gorm.DB
+}
+
+// NewStudentChangeRequestDAO 创建学生信息变更申请DAO实例
+func NewStudentChangeRequestDAO(db *gorm.DB) *StudentChangeRequestDAO {
+	return &StudentChangeRequestDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *StudentChangeRequestDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 创建变更申请
+func (dao *StudentChangeRequestDAO) Create(ctx context.Context, request *model.StudentChangeRequest) error {
+	return dao.db(ctx).Create(request).Error
+}
+
+// GetByID 根据ID获取变更申请
+func (dao *StudentChangeRequestDAO) GetByID(ctx context.Context, id int64) (*model.StudentChangeRequest, error) {
+	var request model.StudentChangeRequest
+	err := dao.db(ctx).First(&request, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// GetList 获取变更申请列表（按ID倒序），filters 为字段等值条件
+func (dao *StudentChangeRequestDAO) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.StudentChangeRequest, int64, error) {
+	var requests []*model.StudentChangeRequest
+	var total int64
+
+	query := dao.db(ctx).Model(&model.StudentChangeRequest{})
+	for key, value := range filters {
+		query = query.Where(key+" = ?", value)
+	}
+
+	// 查询总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取数据列表
+	offset := (page - 1) * pageSize
+	err := query.Order("id DESC").Offset(offset).Limit(pageSize).Find(&requests).Error
+	return requests, total, err
+}
+
+// UpdateStatus 仅当申请仍为 fromStatus 时更新为 toStatus，并记录审批人和审批意见；
+// 返回值表示是否更新成功，为 false 说明申请已被其他操作处理
+// toStatus 为待审批时清空审批信息
+func (dao *StudentChangeRequestDAO) UpdateStatus(ctx context.Context, id int64, fromStatus, toStatus string, reviewerID *int64, comment *string) (bool, error) {
+	var reviewedAt *time.Time
+	if reviewerID != nil {
+		now := time.Now()
+		reviewedAt = &now
+	}
+	result := dao.db(ctx).Model(&model.StudentChangeRequest{}).
+		Where("id = ? AND status = ?", id, fromStatus).
+		Updates(map[string]interface{}{
+			"status":         toStatus,
+			"reviewer_id":    reviewerID,
+			"review_comment": comment,
+			"reviewed_at":    reviewedAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
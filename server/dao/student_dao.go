@@ -1,11 +1,16 @@
 package dao
 
 import (
+	"context"
+	"fmt"
 	"mvc-demo/dao/model"
+	"mvc-demo/utils"
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // StudentDAO 学生数据访问对象
@@ -18,8 +23,58 @@ func NewStudentDAO(db *gorm.DB) *StudentDAO {
 	return &StudentDAO{DB: db}
 }
 
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *StudentDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// studentReference 引用学生ID的关联表字段，合并重复学生时改为引用保留的学生
+type studentReference struct {
+	Table  string
+	Column string
+}
+
+// studentReferences 所有引用学生的关联记录，新增引用 students.id 的表时需要在这里登记
+var studentReferences = []studentReference{
+	{Table: "student_status_histories", Column: "student_id"},
+	{Table: "student_change_requests", Column: "student_id"},
+	{Table: "enrollments", Column: "student_id"},
+	{Table: "grades", Column: "student_id"},
+	{Table: "transcripts", Column: "student_id"},
+	{Table: "attendance_records", Column: "student_id"},
+	{Table: "advisor_assignments", Column: "student_id"},
+	{Table: "job_applications", Column: "student_id"},
+	{Table: "employment_records", Column: "student_id"},
+}
+
+// studentUniqueReference 每个学生在 Columns 上最多只有一条的关联记录（由服务层保证），Columns 为空表示每个学生只有一条
+// OpenColumn 不为空时只比较该列为 NULL 的记录，如每个学生同一时间只有一条未结束的导师分配
+type studentUniqueReference struct {
+	Table      string
+	Name       string
+	Columns    []string
+	OpenColumn string
+}
+
+// studentUniqueReferences 合并学生时两个学生都有会产生重复的关联记录
+var studentUniqueReferences = []studentUniqueReference{
+	{Table: "enrollments", Name: "选课记录", Columns: []string{"section_id"}},
+	{Table: "grades", Name: "课程成绩", Columns: []string{"course_id", "term"}},
+	{Table: "attendance_records", Name: "考勤记录", Columns: []string{"session_id"}},
+	{Table: "job_applications", Name: "岗位申请", Columns: []string{"posting_id"}},
+	{Table: "employment_records", Name: "毕业去向"},
+	{Table: "advisor_assignments", Name: "当前导师分配", OpenColumn: "end_date"},
+}
+
+// StudentReferenceConflict 两个学生重复的关联记录
+type StudentReferenceConflict struct {
+	Table string // 关联表
+	Name  string // 关联记录名称，如 选课记录
+	Count int64  // 重复的记录数
+}
+
 // Create 创建学生
-func (dao *StudentDAO) Create(student *model.Student) error {
+func (dao *StudentDAO) Create(ctx context.Context, student *model.Student) error {
 	// 密码加密
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(student.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -27,69 +82,314 @@ func (dao *StudentDAO) Create(student *model.Student) error {
 	}
 	student.Password = string(hashedPassword)
 
-	return dao.DB.Create(student).Error
+	// 关联的大学只读，不随学生一起写入
+	return dao.db(ctx).Omit(clause.Associations).Create(student).Error
 }
 
 // GetByID 根据ID获取学生
-func (dao *StudentDAO) GetByID(id int64) (*model.Student, error) {
+func (dao *StudentDAO) GetByID(ctx context.Context, id int64) (*model.Student, error) {
+	var student model.Student
+	err := dao.db(ctx).Preload("University").First(&student, id).Error
+	return &student, err
+}
+
+// GetByIDWithFields 根据ID获取学生，只查询选择的字段，按需加载所属大学
+func (dao *StudentDAO) GetByIDWithFields(ctx context.Context, id int64, fs *utils.FieldSet) (*model.Student, error) {
 	var student model.Student
-	err := dao.DB.Preload("University").First(&student, id).Error
+	query := selectColumns(dao.db(ctx), fs)
+	if fs.HasInclude("university") {
+		query = query.Preload("University")
+	}
+	err := query.First(&student, id).Error
 	return &student, err
 }
 
+// GetByIDs 根据ID批量获取学生，按传入的ID顺序返回，不存在的ID会被忽略
+func (dao *StudentDAO) GetByIDs(ctx context.Context, ids []int64) ([]*model.Student, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var students []*model.Student
+	if err := dao.db(ctx).Preload("University").Where("id IN ?", ids).Find(&students).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*model.Student, len(students))
+	for _, student := range students {
+		byID[student.ID] = student
+	}
+
+	ordered := make([]*model.Student, 0, len(students))
+	for _, id := range ids {
+		if student, ok := byID[id]; ok {
+			ordered = append(ordered, student)
+		}
+	}
+	return ordered, nil
+}
+
+// FindInBatches 分批遍历所有学生
+func (dao *StudentDAO) FindInBatches(ctx context.Context, batchSize int, fn func(students []*model.Student) error) error {
+	var students []*model.Student
+	return dao.db(ctx).FindInBatches(&students, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(students)
+	}).Error
+}
+
 // GetByEmail 根据邮箱获取学生
-func (dao *StudentDAO) GetByEmail(email string) (*model.Student, error) {
+func (dao *StudentDAO) GetByEmail(ctx context.Context, email string) (*model.Student, error) {
 	var student model.Student
-	err := dao.DB.Where("email = ?", email).First(&student).Error
+	err := dao.db(ctx).Where("email = ?", email).First(&student).Error
 	return &student, err
 }
 
 // Update 更新学生
-func (dao *StudentDAO) Update(student *model.Student) error {
-	return dao.DB.Save(student).Error
+func (dao *StudentDAO) Update(ctx context.Context, student *model.Student) error {
+	// 关联的大学只读，不随学生一起写入
+	return dao.db(ctx).Omit(clause.Associations).Save(student).Error
 }
 
 // Delete 删除学生
-func (dao *StudentDAO) Delete(id int64) error {
-	return dao.DB.Delete(&model.Student{}, id).Error
+func (dao *StudentDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.Student{}, id).Error
 }
 
-// GetList 获取学生列表（支持分页和筛选）
-func (dao *StudentDAO) GetList(page, pageSize int, filters map[string]interface{}) ([]*model.Student, int64, error) {
+// GetList 获取学生列表（支持分页和筛选），fs 为 nil 时查询全部字段
+func (dao *StudentDAO) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.Student, int64, error) {
 	var students []*model.Student
 	var total int64
 
 	// 构建查询
-	query := dao.DB.Model(&model.Student{})
+	query := applyStudentFilters(dao.db(ctx).Model(&model.Student{}), filters)
+
+	// 查询总数
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
 
-	// 添加筛选条件
+	// 获取数据列表
+	query = selectColumns(query, fs)
+	if fs.HasInclude("university") {
+		query = query.Preload("University")
+	}
+	offset := (page - 1) * pageSize
+	err = query.Offset(offset).Limit(pageSize).Find(&students).Error
+	return students, total, err
+}
+
+// studentCursorColumns 学生列表可用于游标分页的排序字段
+var studentCursorColumns = cursorColumns{
+	"id":         {kind: sortInt},
+	"name":       {kind: sortString},
+	"created_at": {kind: sortTime, nullable: true},
+}
+
+// GetListByCursor 获取学生列表（游标分页，支持筛选），fs 为 nil 时查询全部字段
+func (dao *StudentDAO) GetListByCursor(ctx context.Context, page *utils.PageQuery, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.Student, *utils.CursorResult, error) {
+	var students []*model.Student
+	result := &utils.CursorResult{}
+
+	// 构建查询
+	query := applyStudentFilters(dao.db(ctx).Model(&model.Student{}), filters)
+
+	// 按需查询总数
+	if page.WithTotal {
+		var total int64
+		if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, nil, err
+		}
+		result.Total = &total
+	}
+
+	query, err := applyCursor(query, page, studentCursorColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 获取数据列表
+	query = selectColumns(query, fs, page.Sort)
+	if fs.HasInclude("university") {
+		query = query.Preload("University")
+	}
+	if err := query.Find(&students).Error; err != nil {
+		return nil, nil, err
+	}
+
+	// 多取的一条用于判断是否还有下一页
+	if len(students) > page.PageSize {
+		students = students[:page.PageSize]
+		last := students[len(students)-1]
+		result.NextCursor = nextCursor(page, studentSortValue(last, page.Sort), last.ID)
+	}
+
+	return students, result, nil
+}
+
+// studentSortValue 获取学生记录在排序字段上的值，值为 NULL 或按 id 排序时返回 nil
+func studentSortValue(student *model.Student, sort string) *string {
+	switch sort {
+	case "name":
+		return &student.Name
+	case "created_at":
+		return formatSortTime(student.CreatedAt)
+	default:
+		return nil
+	}
+}
+
+// applyStudentFilters 为学生查询添加筛选条件
+// attendance_below 为出勤率阈值（0-1），可配合 attendance_term 只统计某学期的考勤
+// advisor_id 为导师（教师）ID，只保留当天由该导师指导的学生
+func applyStudentFilters(query *gorm.DB, filters map[string]interface{}) *gorm.DB {
 	for key, value := range filters {
 		if value != nil && value != "" {
-			// 支持模糊查询的字段
-			if key == "name" {
-				query = query.Where(key+" LIKE ?", "%"+value.(string)+"%")
-			} else {
+			switch key {
+			case "name":
+				// 支持模糊查询的字段
+				query = query.Where(key+" "+likeOp(query)+" ?", "%"+value.(string)+"%")
+			case "attendance_below":
+				term, _ := filters["attendance_term"].(string)
+				subQuery := attendanceBelowQuery(query.Session(&gorm.Session{NewDB: true}), value.(float64), term)
+				query = query.Where("id IN (?)", subQuery)
+			case "attendance_term":
+				// 与 attendance_below 一起处理
+			case "advisor_id":
+				subQuery := adviseeQuery(query.Session(&gorm.Session{NewDB: true}), value.(int64), time.Now())
+				query = query.Where("id IN (?)", subQuery)
+			default:
 				query = query.Where(key+" = ?", value)
 			}
 		}
 	}
+	return query
+}
 
-	// 查询总数
-	err := query.Count(&total).Error
+// FindDuplicateCandidates 分批遍历所有学生，只查询查重所需的列
+func (dao *StudentDAO) FindDuplicateCandidates(ctx context.Context, batchSize int, fn func(students []*model.Student) error) error {
+	var students []*model.Student
+	return dao.db(ctx).Select("id", "name", "phone", "birthday", "university_id").
+		FindInBatches(&students, batchSize, func(tx *gorm.DB, batch int) error {
+			return fn(students)
+		}).Error
+}
+
+// FindReferenceConflicts 查找两个学生都有、合并后会重复的关联记录，如选了同一教学班、同一课程同一学期都有成绩
+func (dao *StudentDAO) FindReferenceConflicts(ctx context.Context, fromID, toID int64) ([]StudentReferenceConflict, error) {
+	var conflicts []StudentReferenceConflict
+	for _, ref := range studentUniqueReferences {
+		on := "1 = 1"
+		if len(ref.Columns) > 0 {
+			conditions := make([]string, len(ref.Columns))
+			for i, column := range ref.Columns {
+				conditions[i] = fmt.Sprintf("a.%s = b.%s", column, column)
+			}
+			on = strings.Join(conditions, " AND ")
+		}
+
+		var count int64
+		query := dao.db(ctx).Table(ref.Table+" AS a").
+			Joins(fmt.Sprintf("JOIN %s AS b ON %s", ref.Table, on)).
+			Where("a.student_id = ? AND b.student_id = ?", fromID, toID)
+		if ref.OpenColumn != "" {
+			query = query.Where(fmt.Sprintf("a.%s IS NULL AND b.%s IS NULL", ref.OpenColumn, ref.OpenColumn))
+		}
+		err := query.Count(&count).Error
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			conflicts = append(conflicts, StudentReferenceConflict{Table: ref.Table, Name: ref.Name, Count: count})
+		}
+	}
+	return conflicts, nil
+}
+
+// ReassignRelated 把关联记录从一个学生移到另一个学生，调用前应通过 FindReferenceConflicts 确认不会产生重复记录
+func (dao *StudentDAO) ReassignRelated(ctx context.Context, fromID, toID int64) error {
+	for _, ref := range studentReferences {
+		err := dao.db(ctx).Table(ref.Table).Where(ref.Column+" = ?", fromID).Update(ref.Column, toID).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarkMerged 记录学生已合并到另一学生并软删除
+func (dao *StudentDAO) MarkMerged(ctx context.Context, id, mergedIntoID int64) error {
+	err := dao.db(ctx).Model(&model.Student{}).Where("id = ?", id).Update("merged_into_id", mergedIntoID).Error
 	if err != nil {
-		return nil, 0, err
+		return err
+	}
+	return dao.Delete(ctx, id)
+}
+
+// UpdateStatus 当学生仍处于 fromStatus 时更新状态，返回是否更新成功，用于避免并发变更覆盖
+func (dao *StudentDAO) UpdateStatus(ctx context.Context, id int64, fromStatus *string, toStatus string, graduationYear *int64, updatedBy *int64) (bool, error) {
+	updates := map[string]interface{}{"status": toStatus, "updated_by": updatedBy}
+	if graduationYear != nil {
+		updates["graduation_year"] = *graduationYear
 	}
 
-	// 获取数据列表
-	offset := (page - 1) * pageSize
-	err = query.Preload("University").Offset(offset).Limit(pageSize).Find(&students).Error
-	return students, total, err
+	query := dao.db(ctx).Model(&model.Student{}).Where("id = ?", id)
+	if fromStatus == nil {
+		query = query.Where("status IS NULL")
+	} else {
+		query = query.Where("status = ?", *fromStatus)
+	}
+	result := query.Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+// CountByUniversity 统计某大学的学生数
+func (dao *StudentDAO) CountByUniversity(ctx context.Context, universityID int64) (int64, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.Student{}).Where("university_id = ?", universityID).Count(&count).Error
+	return count, err
+}
+
+// ReassignUniversity 把某大学的所有学生改为关联另一所大学，返回受影响的学生数
+func (dao *StudentDAO) ReassignUniversity(ctx context.Context, fromID, toID int64) (int64, error) {
+	result := dao.db(ctx).Model(&model.Student{}).Where("university_id = ?", fromID).Update("university_id", toID)
+	return result.RowsAffected, result.Error
+}
+
+// ReassignMajor 把关联某专业的学生改为关联另一专业
+func (dao *StudentDAO) ReassignMajor(ctx context.Context, fromID, toID int64) error {
+	return dao.db(ctx).Model(&model.Student{}).Where("major_id = ?", fromID).Update("major_id", toID).Error
+}
+
+// CountByMajor 统计关联某专业的学生数
+func (dao *StudentDAO) CountByMajor(ctx context.Context, majorID int64) (int64, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.Student{}).Where("major_id = ?", majorID).Count(&count).Error
+	return count, err
+}
+
+// FindUnlinkedMajors 分批遍历填写了专业名称但尚未关联专业目录的学生，只查询匹配所需的列
+func (dao *StudentDAO) FindUnlinkedMajors(ctx context.Context, batchSize int, fn func(students []*model.Student) error) error {
+	var students []*model.Student
+	return dao.db(ctx).Select("id", "university_id", "major").
+		Where("major_id IS NULL AND major IS NOT NULL AND major != ''").
+		FindInBatches(&students, batchSize, func(tx *gorm.DB, batch int) error {
+			return fn(students)
+		}).Error
+}
+
+// LinkMajor 为学生关联专业目录，不修改其他字段
+func (dao *StudentDAO) LinkMajor(ctx context.Context, studentIDs []int64, majorID int64) error {
+	if len(studentIDs) == 0 {
+		return nil
+	}
+	return dao.db(ctx).Model(&model.Student{}).Where("id IN ?", studentIDs).Update("major_id", majorID).Error
 }
 
 // ValidateLogin 验证学生登录
-func (dao *StudentDAO) ValidateLogin(email, password string) (*model.Student, error) {
+func (dao *StudentDAO) ValidateLogin(ctx context.Context, email, password string) (*model.Student, error) {
 	// 查找学生
-	student, err := dao.GetByEmail(email)
+	student, err := dao.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
@@ -104,14 +404,14 @@ func (dao *StudentDAO) ValidateLogin(email, password string) (*model.Student, er
 }
 
 // UpdateLastLoginTime 更新学生最后登录时间
-func (dao *StudentDAO) UpdateLastLoginTime(studentID int64) error {
+func (dao *StudentDAO) UpdateLastLoginTime(ctx context.Context, studentID int64) error {
 	now := time.Now()
-	return dao.DB.Model(&model.Student{}).Where("id = ?", studentID).Update("last_login_time", &now).Error
+	return dao.db(ctx).Model(&model.Student{}).Where("id = ?", studentID).Update("last_login_time", &now).Error
 }
 
 // ResetPassword 重置学生密码
-func (dao *StudentDAO) ResetPassword(email, newPassword string) error {
-	student, err := dao.GetByEmail(email)
+func (dao *StudentDAO) ResetPassword(ctx context.Context, email, newPassword string) error {
+	student, err := dao.GetByEmail(ctx, email)
 	if err != nil {
 		return err
 	}
@@ -122,5 +422,5 @@ func (dao *StudentDAO) ResetPassword(email, newPassword string) error {
 	}
 
 	student.Password = string(hashedPassword)
-	return dao.DB.Save(student).Error
+	return dao.db(ctx).Save(student).Error
 }
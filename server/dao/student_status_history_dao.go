@@ -0,0 +1,35 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+
+	"gorm.io/gorm"
+)
+
+// StudentStatusHistoryDAO 学生状态变更记录数据访问对象
+type StudentStatusHistoryDAO struct {
+	DB *gorm.DB
+}
+
+// NewStudentStatusHistoryDAO 创建学生状态变更记录DAO实例
+func NewStudentStatusHistoryDAO(db *gorm.DB) *StudentStatusHistoryDAO {
+	return &StudentStatusHistoryDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *StudentStatusHistoryDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 写入状态变更记录
+func (dao *StudentStatusHistoryDAO) Create(ctx context.Context, history *model.StudentStatusHistory) error {
+	return dao.db(ctx).Create(history).Error
+}
+
+// GetListByStudent 获取学生的状态变更记录，按时间倒序
+func (dao *StudentStatusHistoryDAO) GetListByStudent(ctx context.Context, studentID int64) ([]*model.StudentStatusHistory, error) {
+	var histories []*model.StudentStatusHistory
+	err := dao.db(ctx).Where("student_id = ?", studentID).Order("id DESC").Find(&histories).Error
+	return histories, err
+}
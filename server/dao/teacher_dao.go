@@ -0,0 +1,109 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+
+	"gorm.io/gorm"
+)
+
+// TeacherDAO 教师数据访问对象
+type TeacherDAO struct {
+	DB *gorm.DB
+}
+
+// NewTeacherDAO 创建教师DAO实例
+func NewTeacherDAO(db *gorm.DB) *TeacherDAO {
+	return &TeacherDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *TeacherDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 创建教师
+func (dao *TeacherDAO) Create(ctx context.Context, teacher *model.Teacher) error {
+	return dao.db(ctx).Create(teacher).Error
+}
+
+// GetByID 根据ID获取教师
+func (dao *TeacherDAO) GetByID(ctx context.Context, id int64) (*model.Teacher, error) {
+	var teacher model.Teacher
+	err := dao.db(ctx).First(&teacher, id).Error
+	return &teacher, err
+}
+
+// GetByUserID 根据用户ID获取教师档案
+func (dao *TeacherDAO) GetByUserID(ctx context.Context, userID int64) (*model.Teacher, error) {
+	var teacher model.Teacher
+	err := dao.db(ctx).Where("user_id = ?", userID).First(&teacher).Error
+	return &teacher, err
+}
+
+// CheckUserExists 检查用户是否已关联排除某ID外的教师档案，excludeID 为 0 时不排除
+func (dao *TeacherDAO) CheckUserExists(ctx context.Context, userID int64, excludeID int64) (bool, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.Teacher{}).Where("user_id = ? AND id != ?", userID, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// CheckEmployeeNoExists 检查排除某ID外是否存在相同工号的教师，excludeID 为 0 时不排除
+func (dao *TeacherDAO) CheckEmployeeNoExists(ctx context.Context, employeeNo string, excludeID int64) (bool, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.Teacher{}).Where("employee_no = ? AND id != ?", employeeNo, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// Update 更新教师
+func (dao *TeacherDAO) Update(ctx context.Context, teacher *model.Teacher) error {
+	return dao.db(ctx).Save(teacher).Error
+}
+
+// Delete 删除教师
+func (dao *TeacherDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.Teacher{}, id).Error
+}
+
+// GetList 获取教师列表（支持按姓名模糊查询，按大学、院系、状态筛选）
+func (dao *TeacherDAO) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Teacher, int64, error) {
+	var teachers []*model.Teacher
+	var total int64
+
+	query := dao.db(ctx).Model(&model.Teacher{})
+	for key, value := range filters {
+		if key == "name" {
+			query = query.Where("name "+likeOp(dao.db(ctx))+" ?", "%"+value.(string)+"%")
+		} else {
+			query = query.Where(key+" = ?", value)
+		}
+	}
+
+	// 查询总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取数据列表
+	offset := (page - 1) * pageSize
+	err := query.Order("id").Offset(offset).Limit(pageSize).Find(&teachers).Error
+	return teachers, total, err
+}
+
+// CountByUniversity 统计某大学的教师数
+func (dao *TeacherDAO) CountByUniversity(ctx context.Context, universityID int64) (int64, error) {
+	var count int64
+	err := dao.db(ctx).Model(&model.Teacher{}).Where("university_id = ?", universityID).Count(&count).Error
+	return count, err
+}
+
+// ReassignUniversity 把某大学的所有教师改为属于另一所大学，返回受影响的教师数
+func (dao *TeacherDAO) ReassignUniversity(ctx context.Context, fromID, toID int64) (int64, error) {
+	result := dao.db(ctx).Model(&model.Teacher{}).Where("university_id = ?", fromID).Update("university_id", toID)
+	return result.RowsAffected, result.Error
+}
+
+// ReassignCollege 把某院系的所有教师改为属于另一院系
+func (dao *TeacherDAO) ReassignCollege(ctx context.Context, fromID, toID int64) error {
+	return dao.db(ctx).Model(&model.Teacher{}).Where("college_id = ?", fromID).Update("college_id", toID).Error
+}
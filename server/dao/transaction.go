@@ -0,0 +1,157 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// 事务重试默认值
+const (
+	DefaultTxMaxRetries   = 3                     // 死锁等可重试错误的最多重试次数
+	DefaultTxRetryBackoff = 50 * time.Millisecond // 重试等待时间基数，第 n 次重试等待 n 倍基数加随机抖动
+)
+
+// Transactor 在一个事务中执行 fn，fn 中通过 ctx 访问事务
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// txKey 事务连接在 context 中的键
+type txKey struct{}
+
+// afterCommitKey 事务提交后回调在 context 中的键
+type afterCommitKey struct{}
+
+// afterCommitHooks 事务（或保存点）中登记的提交后回调
+type afterCommitHooks struct {
+	mu  sync.Mutex
+	fns []func(ctx context.Context)
+}
+
+func (h *afterCommitHooks) add(fns ...func(ctx context.Context)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fns = append(h.fns, fns...)
+}
+
+// withTx 返回带事务连接和提交后回调列表的 ctx
+func withTx(ctx context.Context, tx *gorm.DB, hooks *afterCommitHooks) context.Context {
+	return context.WithValue(context.WithValue(ctx, txKey{}, tx), afterCommitKey{}, hooks)
+}
+
+// TxManager 基于 GORM 的事务管理器
+// 事务连接保存在 ctx 中，DAO 方法通过参数 ctx 自动使用事务连接，无需在服务之间传递 txDAO
+type TxManager struct {
+	DB           *gorm.DB
+	MaxRetries   int           // 最外层事务遇到死锁、锁等待超时时的最多重试次数，0 表示不重试
+	RetryBackoff time.Duration // 重试等待时间基数
+}
+
+var _ Transactor = (*TxManager)(nil)
+
+// NewTxManager 创建事务管理器
+func NewTxManager(db *gorm.DB) *TxManager {
+	return &TxManager{
+		DB:           db,
+		MaxRetries:   DefaultTxMaxRetries,
+		RetryBackoff: DefaultTxRetryBackoff,
+	}
+}
+
+// Transaction 在一个数据库事务中执行 fn，fn 返回错误或 panic 时回滚
+// ctx 中已有事务时（嵌套调用）在外层事务中创建保存点，fn 失败只回滚到保存点，由外层决定是否提交；
+// 最外层事务遇到死锁、锁等待超时等可重试错误时整体重试，因此 fn 需要可以重复执行（不要在 fn 中发送通知等）；
+// 事务外的副作用（如更新搜索索引）通过 AfterCommit 登记，最外层事务提交后才执行
+func (m *TxManager) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		// 保存点中登记的回调在保存点成功后并入外层事务，回滚时丢弃
+		hooks := &afterCommitHooks{}
+		err := tx.Transaction(func(tx *gorm.DB) error {
+			return fn(withTx(ctx, tx, hooks))
+		})
+		if parent, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks); ok && err == nil {
+			parent.add(hooks.fns...)
+		}
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		hooks := &afterCommitHooks{}
+		err := m.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(withTx(ctx, tx, hooks))
+		})
+		if err == nil {
+			for _, hook := range hooks.fns {
+				hook(ctx)
+			}
+			return nil
+		}
+		if attempt >= m.MaxRetries || !IsRetryableError(err) {
+			return err
+		}
+
+		backoff := m.RetryBackoff * time.Duration(attempt+1)
+		if backoff > 0 {
+			backoff += time.Duration(rand.Int63n(int64(backoff)))
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// InTransaction ctx 中是否有进行中的事务
+func InTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return ok
+}
+
+// AfterCommit 登记在 ctx 中的事务提交后执行的 fn，事务回滚时不执行；ctx 中没有事务时立即执行
+// fn 接收的 ctx 不带事务连接，可以正常访问数据库
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks); ok {
+		hooks.add(fn)
+		return
+	}
+	fn(ctx)
+}
+
+// conn 返回 ctx 中的事务连接，没有事务时返回 db，两者都绑定 ctx
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// IsRetryableError 是否为重试整个事务可能成功的错误：死锁、锁等待超时、序列化失败、SQLite 数据库被锁定
+func IsRetryableError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		// 1213 死锁，1205 锁等待超时
+		return mysqlErr.Number == 1213 || mysqlErr.Number == 1205
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// 40P01 死锁，40001 序列化失败
+		return pgErr.Code == "40P01" || pgErr.Code == "40001"
+	}
+
+	var sqliteErr interface{ Code() int }
+	if errors.As(err, &sqliteErr) {
+		// SQLITE_BUSY、SQLITE_LOCKED 及其扩展错误码
+		code := sqliteErr.Code() & 0xff
+		return code == 5 || code == 6
+	}
+	return false
+}
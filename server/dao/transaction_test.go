@@ -0,0 +1,211 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newTestTxManager(t *testing.T) *TxManager {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return NewTxManager(db)
+}
+
+func TestAfterCommit(t *testing.T) {
+	m := newTestTxManager(t)
+	ctx := context.Background()
+	errRollback := errors.New("rollback")
+
+	var ran []string
+	hook := func(name string) func(ctx context.Context) {
+		return func(ctx context.Context) {
+			if InTransaction(ctx) {
+				t.Errorf("%s: 回调的 ctx 中仍带有事务", name)
+			}
+			ran = append(ran, name)
+		}
+	}
+
+	// 没有事务时立即执行
+	AfterCommit(ctx, hook("direct"))
+
+	err := m.Transaction(ctx, func(ctx context.Context) error {
+		AfterCommit(ctx, hook("outer"))
+		// 成功的保存点中登记的回调随外层事务提交
+		if err := m.Transaction(ctx, func(ctx context.Context) error {
+			AfterCommit(ctx, hook("savepoint"))
+			return nil
+		}); err != nil {
+			return err
+		}
+		// 回滚的保存点中登记的回调被丢弃
+		_ = m.Transaction(ctx, func(ctx context.Context) error {
+			AfterCommit(ctx, hook("rolled-back-savepoint"))
+			return errRollback
+		})
+		if len(ran) != 1 {
+			t.Errorf("提交前执行了回调: %v", ran)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 回滚的事务不执行回调
+	err = m.Transaction(ctx, func(ctx context.Context) error {
+		AfterCommit(ctx, hook("rolled-back"))
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("期望回滚错误，实际 %v", err)
+	}
+
+	want := []string{"direct", "outer", "savepoint"}
+	if len(ran) != len(want) {
+		t.Fatalf("执行的回调为 %v，期望 %v", ran, want)
+	}
+	for i := range want {
+		if ran[i] != want[i] {
+			t.Fatalf("执行的回调为 %v，期望 %v", ran, want)
+		}
+	}
+}
+
+// countItems 统计 items 表中名称为 names 的记录数
+func countItems(t *testing.T, db *gorm.DB, names ...string) int64 {
+	t.Helper()
+	var count int64
+	if err := db.Table("items").Where("name IN ?", names).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func newItemsTxManager(t *testing.T) *TxManager {
+	t.Helper()
+	m := newTestTxManager(t)
+	if err := m.DB.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT NOT NULL)").Error; err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func insertItem(ctx context.Context, m *TxManager, name string) error {
+	return conn(ctx, m.DB).Exec("INSERT INTO items (name) VALUES (?)", name).Error
+}
+
+// 嵌套事务失败只回滚到保存点，外层事务继续执行并提交；外层回滚时已成功的保存点一并回滚
+func TestNestedRollbackToSavepoint(t *testing.T) {
+	m := newItemsTxManager(t)
+	ctx := context.Background()
+	errRollback := errors.New("rollback")
+
+	err := m.Transaction(ctx, func(ctx context.Context) error {
+		if err := insertItem(ctx, m, "outer-before"); err != nil {
+			return err
+		}
+		err := m.Transaction(ctx, func(ctx context.Context) error {
+			if err := insertItem(ctx, m, "nested"); err != nil {
+				return err
+			}
+			return errRollback
+		})
+		if !errors.Is(err, errRollback) {
+			t.Errorf("嵌套事务返回 %v，期望回滚错误", err)
+		}
+		return insertItem(ctx, m, "outer-after")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if countItems(t, m.DB, "outer-before", "outer-after") != 2 || countItems(t, m.DB, "nested") != 0 {
+		t.Fatal("嵌套事务回滚后外层事务的写入应保留，嵌套事务的写入应回滚")
+	}
+
+	err = m.Transaction(ctx, func(ctx context.Context) error {
+		if err := m.Transaction(ctx, func(ctx context.Context) error {
+			return insertItem(ctx, m, "committed-savepoint")
+		}); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("期望回滚错误，实际 %v", err)
+	}
+	if countItems(t, m.DB, "committed-savepoint") != 0 {
+		t.Fatal("外层事务回滚时保存点中的写入应一并回滚")
+	}
+}
+
+// 最外层事务遇到死锁时整体重试，之前尝试中的写入已回滚；嵌套事务不单独重试，不可重试的错误直接返回
+func TestTransactionRetry(t *testing.T) {
+	m := newItemsTxManager(t)
+	m.RetryBackoff = 0
+	ctx := context.Background()
+	deadlock := &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+
+	attempts, committed := 0, 0
+	err := m.Transaction(ctx, func(ctx context.Context) error {
+		attempts++
+		// 失败的尝试中登记的提交后回调随回滚丢弃
+		AfterCommit(ctx, func(ctx context.Context) { committed++ })
+		if err := insertItem(ctx, m, "retried"); err != nil {
+			return err
+		}
+		// 嵌套事务中的死锁向外传递，由最外层事务重试
+		return m.Transaction(ctx, func(ctx context.Context) error {
+			if attempts < 3 {
+				return deadlock
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 {
+		t.Fatalf("执行了 %d 次，期望前两次死锁后第三次成功", attempts)
+	}
+	if count := countItems(t, m.DB, "retried"); count != 1 || committed != 1 {
+		t.Fatalf("重试后有 %d 条记录，提交后回调执行了 %d 次，失败的尝试应已回滚", count, committed)
+	}
+
+	// 超过最多重试次数后返回最后一次的错误
+	attempts = 0
+	err = m.Transaction(ctx, func(ctx context.Context) error {
+		attempts++
+		return deadlock
+	})
+	if !errors.Is(err, deadlock) || attempts != m.MaxRetries+1 {
+		t.Fatalf("执行了 %d 次并返回 %v，期望执行 %d 次后返回死锁错误", attempts, err, m.MaxRetries+1)
+	}
+
+	attempts = 0
+	errBusiness := errors.New("business")
+	err = m.Transaction(ctx, func(ctx context.Context) error {
+		attempts++
+		return errBusiness
+	})
+	if !errors.Is(err, errBusiness) || attempts != 1 {
+		t.Fatalf("不可重试的错误执行了 %d 次", attempts)
+	}
+}
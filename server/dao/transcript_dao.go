@@ -0,0 +1,49 @@
+package dao
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+
+	"gorm.io/gorm"
+)
+
+// TranscriptDAO 已签发成绩单数据访问对象
+type TranscriptDAO struct {
+	DB *gorm.DB
+}
+
+// NewTranscriptDAO 创建已签发成绩单DAO实例
+func NewTranscriptDAO(db *gorm.DB) *TranscriptDAO {
+	return &TranscriptDAO{DB: db}
+}
+
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *TranscriptDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
+// Create 保存签发的成绩单
+func (dao *TranscriptDAO) Create(ctx context.Context, transcript *model.Transcript) error {
+	return dao.db(ctx).Create(transcript).Error
+}
+
+// GetByID 根据ID获取已签发成绩单
+func (dao *TranscriptDAO) GetByID(ctx context.Context, id int64) (*model.Transcript, error) {
+	var transcript model.Transcript
+	err := dao.db(ctx).First(&transcript, id).Error
+	return &transcript, err
+}
+
+// GetByCode 根据验证码获取已签发成绩单
+func (dao *TranscriptDAO) GetByCode(ctx context.Context, code string) (*model.Transcript, error) {
+	var transcript model.Transcript
+	err := dao.db(ctx).Where("verification_code = ?", code).First(&transcript).Error
+	return &transcript, err
+}
+
+// GetListByStudent 获取学生的已签发成绩单（不含内容），按签发时间倒序
+func (dao *TranscriptDAO) GetListByStudent(ctx context.Context, studentID int64) ([]*model.Transcript, error) {
+	var transcripts []*model.Transcript
+	err := dao.db(ctx).Omit("content").Where("student_id = ?", studentID).Order("id DESC").Find(&transcripts).Error
+	return transcripts, err
+}
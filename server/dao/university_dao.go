@@ -1,9 +1,13 @@
 package dao
 
 import (
+	"context"
+	"errors"
 	"mvc-demo/dao/model"
+	"mvc-demo/utils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // UniversityDAO 大学数据访问对象
@@ -16,70 +20,261 @@ func NewUniversityDAO(db *gorm.DB) *UniversityDAO {
 	return &UniversityDAO{DB: db}
 }
 
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *UniversityDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
 // Create 创建大学
-func (dao *UniversityDAO) Create(university *model.University) error {
-	return dao.DB.Create(university).Error
+func (dao *UniversityDAO) Create(ctx context.Context, university *model.University) error {
+	// 别名通过 ReplaceAliases 单独维护
+	return dao.db(ctx).Omit(clause.Associations).Create(university).Error
 }
 
-// GetByID 根据ID获取大学
-func (dao *UniversityDAO) GetByID(id int64) (*model.University, error) {
+// GetByID 根据ID获取大学（包含别名）
+func (dao *UniversityDAO) GetByID(ctx context.Context, id int64) (*model.University, error) {
 	var university model.University
-	err := dao.DB.First(&university, id).Error
+	err := dao.db(ctx).Preload("Aliases").First(&university, id).Error
 	return &university, err
 }
 
+// GetByIDWithFields 根据ID获取大学，只查询选择的字段，按需加载别名
+func (dao *UniversityDAO) GetByIDWithFields(ctx context.Context, id int64, fs *utils.FieldSet) (*model.University, error) {
+	var university model.University
+	err := withAliases(selectColumns(dao.db(ctx), fs), fs).First(&university, id).Error
+	return &university, err
+}
+
+// GetByIDs 根据ID批量获取大学
+func (dao *UniversityDAO) GetByIDs(ctx context.Context, ids []int64) ([]*model.University, error) {
+	var universities []*model.University
+	if len(ids) == 0 {
+		return universities, nil
+	}
+	err := dao.db(ctx).Where("id IN ?", ids).Find(&universities).Error
+	return universities, err
+}
+
 // GetByName 根据名称获取大学
-func (dao *UniversityDAO) GetByName(name string) (*model.University, error) {
+func (dao *UniversityDAO) GetByName(ctx context.Context, name string) (*model.University, error) {
+	var university model.University
+	err := dao.db(ctx).Where("name = ?", name).First(&university).Error
+	return &university, err
+}
+
+// GetByAlias 根据别名或简称获取大学，别名优先
+func (dao *UniversityDAO) GetByAlias(ctx context.Context, alias string) (*model.University, error) {
 	var university model.University
-	err := dao.DB.Where("name = ?", name).First(&university).Error
+	aliasQuery := dao.db(ctx).Model(&model.UniversityAlias{}).Select("university_id").Where("alias = ?", alias)
+	err := dao.db(ctx).Where("id IN (?)", aliasQuery).First(&university).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = dao.db(ctx).Where("short_name = ?", alias).First(&university).Error
+	}
 	return &university, err
 }
 
+// CheckCodeExistsExcludeID 检查排除某ID外是否存在相同院校代码的大学（包括已软删除的记录），excludeID 为 0 时不排除
+func (dao *UniversityDAO) CheckCodeExistsExcludeID(ctx context.Context, code string, excludeID int64) (bool, error) {
+	var count int64
+	err := dao.db(ctx).Unscoped().Model(&model.University{}).
+		Where("code = ? AND id != ?", code, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+// FindAliasConflicts 查找已被其他大学用作别名或名称的别名
+func (dao *UniversityDAO) FindAliasConflicts(ctx context.Context, aliases []string, excludeID int64) ([]string, error) {
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+
+	var usedAsAlias []string
+	err := dao.db(ctx).Model(&model.UniversityAlias{}).
+		Where("alias IN ? AND university_id != ?", aliases, excludeID).
+		Pluck("alias", &usedAsAlias).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var usedAsName []string
+	err = dao.db(ctx).Model(&model.University{}).
+		Where("name IN ? AND id != ?", aliases, excludeID).
+		Pluck("name", &usedAsName).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return append(usedAsAlias, usedAsName...), nil
+}
+
+// ReplaceAliases 用新的别名列表替换大学的全部别名
+func (dao *UniversityDAO) ReplaceAliases(ctx context.Context, universityID int64, aliases []string, createdBy *int64) error {
+	if err := dao.db(ctx).Where("university_id = ?", universityID).Delete(&model.UniversityAlias{}).Error; err != nil {
+		return err
+	}
+	return dao.AddAliases(ctx, universityID, aliases, createdBy)
+}
+
+// AddAliases 为大学追加别名
+func (dao *UniversityDAO) AddAliases(ctx context.Context, universityID int64, aliases []string, createdBy *int64) error {
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	records := make([]*model.UniversityAlias, 0, len(aliases))
+	for _, alias := range aliases {
+		records = append(records, &model.UniversityAlias{
+			UniversityID: universityID,
+			Alias:        alias,
+			CreatedBy:    createdBy,
+		})
+	}
+	return dao.db(ctx).Create(&records).Error
+}
+
+// GetAliasOwners 查询别名所属的大学，返回别名 => 大学ID，未使用的别名不出现在结果中
+func (dao *UniversityDAO) GetAliasOwners(ctx context.Context, aliases []string) (map[string]int64, error) {
+	owners := make(map[string]int64)
+	if len(aliases) == 0 {
+		return owners, nil
+	}
+
+	var records []*model.UniversityAlias
+	if err := dao.db(ctx).Where("alias IN ?", aliases).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		owners[record.Alias] = record.UniversityID
+	}
+	return owners, nil
+}
+
 // CheckNameExistsWithDeleted 检查大学名称是否存在（包括已软删除的记录）
-func (dao *UniversityDAO) CheckNameExistsWithDeleted(name string) (bool, error) {
+func (dao *UniversityDAO) CheckNameExistsWithDeleted(ctx context.Context, name string) (bool, error) {
 	var count int64
-	err := dao.DB.Unscoped().Model(&model.University{}).Where("name = ?", name).Count(&count).Error
+	err := dao.db(ctx).Unscoped().Model(&model.University{}).Where("name = ?", name).Count(&count).Error
 	return count > 0, err
 }
 
 // CheckNameExistsExcludeID 检查排除某ID外是否存在同名大学（包括已软删除的记录）
-func (dao *UniversityDAO) CheckNameExistsExcludeID(name string, excludeID int64) (bool, error) {
+func (dao *UniversityDAO) CheckNameExistsExcludeID(ctx context.Context, name string, excludeID int64) (bool, error) {
 	var count int64
-	err := dao.DB.Unscoped().Model(&model.University{}).
+	err := dao.db(ctx).Unscoped().Model(&model.University{}).
 		Where("name = ? AND id != ?", name, excludeID).Count(&count).Error
 	return count > 0, err
 }
 
 // Update 更新大学
-func (dao *UniversityDAO) Update(university *model.University) error {
-	return dao.DB.Save(university).Error
+func (dao *UniversityDAO) Update(ctx context.Context, university *model.University) error {
+	// 别名通过 ReplaceAliases 单独维护
+	return dao.db(ctx).Omit(clause.Associations).Save(university).Error
 }
 
 // Delete 删除大学
-func (dao *UniversityDAO) Delete(id int64) error {
-	return dao.DB.Delete(&model.University{}, id).Error
+func (dao *UniversityDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.University{}, id).Error
 }
 
-// GetList 获取大学列表（支持分页）
-func (dao *UniversityDAO) GetList(page, pageSize int) ([]*model.University, int64, error) {
+// GetList 获取大学列表（支持分页和筛选），fs 为 nil 时查询全部字段
+func (dao *UniversityDAO) GetList(ctx context.Context, page, pageSize int, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.University, int64, error) {
 	var universities []*model.University
 	var total int64
 
+	// 构建查询
+	query := dao.applyFilters(ctx, dao.db(ctx).Model(&model.University{}), filters)
+
 	// 查询总数
-	err := dao.DB.Model(&model.University{}).Count(&total).Error
+	err := query.Count(&total).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// 获取数据列表
 	offset := (page - 1) * pageSize
-	err = dao.DB.Offset(offset).Limit(pageSize).Find(&universities).Error
+	err = withAliases(selectColumns(query, fs), fs).Offset(offset).Limit(pageSize).Find(&universities).Error
 	return universities, total, err
 }
 
-// GetAll 获取所有大学（不分页）
-func (dao *UniversityDAO) GetAll() ([]*model.University, error) {
+// universityCursorColumns 大学列表可用于游标分页的排序字段
+var universityCursorColumns = cursorColumns{
+	"id":         {kind: sortInt},
+	"name":       {kind: sortString},
+	"created_at": {kind: sortTime, nullable: true},
+}
+
+// GetListByCursor 获取大学列表（游标分页，支持筛选），fs 为 nil 时查询全部字段
+func (dao *UniversityDAO) GetListByCursor(ctx context.Context, page *utils.PageQuery, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.University, *utils.CursorResult, error) {
+	var universities []*model.University
+	result := &utils.CursorResult{}
+
+	// 构建查询
+	query := dao.applyFilters(ctx, dao.db(ctx).Model(&model.University{}), filters)
+
+	// 按需查询总数
+	if page.WithTotal {
+		var total int64
+		if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, nil, err
+		}
+		result.Total = &total
+	}
+
+	query, err := applyCursor(query, page, universityCursorColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 获取数据列表
+	if err := withAliases(selectColumns(query, fs, page.Sort), fs).Find(&universities).Error; err != nil {
+		return nil, nil, err
+	}
+
+	// 多取的一条用于判断是否还有下一页
+	if len(universities) > page.PageSize {
+		universities = universities[:page.PageSize]
+		last := universities[len(universities)-1]
+		var value *string
+		switch page.Sort {
+		case "name":
+			value = &last.Name
+		case "created_at":
+			value = formatSortTime(last.CreatedAt)
+		}
+		result.NextCursor = nextCursor(page, value, last.ID)
+	}
+
+	return universities, result, nil
+}
+
+// GetAll 获取所有大学（不分页），fs 为 nil 时查询全部字段
+func (dao *UniversityDAO) GetAll(ctx context.Context, fs *utils.FieldSet) ([]*model.University, error) {
 	var universities []*model.University
-	err := dao.DB.Find(&universities).Error
+	err := withAliases(selectColumns(dao.db(ctx), fs), fs).Find(&universities).Error
 	return universities, err
 }
+
+// applyFilters 为大学查询添加筛选条件
+// keyword 模糊匹配名称、简称、英文名和别名；level_tag 匹配层次标签（标签之间互不包含，可直接按子串匹配）
+func (dao *UniversityDAO) applyFilters(ctx context.Context, query *gorm.DB, filters map[string]interface{}) *gorm.DB {
+	for key, value := range filters {
+		switch key {
+		case "keyword":
+			like, op := "%"+value.(string)+"%", likeOp(dao.db(ctx))
+			aliasQuery := dao.db(ctx).Model(&model.UniversityAlias{}).Select("university_id").Where("alias "+op+" ?", like)
+			query = query.Where("name "+op+" ? OR short_name "+op+" ? OR english_name "+op+" ? OR id IN (?)",
+				like, like, like, aliasQuery)
+		case "level_tag":
+			query = query.Where("level_tags "+likeOp(dao.db(ctx))+" ?", "%"+value.(string)+"%")
+		default:
+			query = query.Where(key+" = ?", value)
+		}
+	}
+	return query
+}
+
+// withAliases 按需预加载大学别名
+func withAliases(query *gorm.DB, fs *utils.FieldSet) *gorm.DB {
+	if fs.HasInclude("aliases") {
+		return query.Preload("Aliases")
+	}
+	return query
+}
@@ -1,13 +1,15 @@
 package dao
 
 import (
-	"log"
+	"context"
+	"mvc-demo/logging"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 
 	"mvc-demo/dao/model"
+	"mvc-demo/utils"
 )
 
 // UserDAO 用户数据访问对象
@@ -20,35 +22,46 @@ func NewUserDAO(db *gorm.DB) *UserDAO {
 	return &UserDAO{DB: db}
 }
 
+// db 返回绑定 ctx 的数据库连接，ctx 中有事务时使用事务连接
+func (dao *UserDAO) db(ctx context.Context) *gorm.DB {
+	return conn(ctx, dao.DB)
+}
+
 // Create 创建用户
-func (dao *UserDAO) Create(user *model.User) error {
+func (dao *UserDAO) Create(ctx context.Context, user *model.User) error {
 	// 密码加密
-	log.Println("创建用户密码：", user.Password)
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return err
 	}
 	user.Password = string(hashedPassword)
 
-	return dao.DB.Create(user).Error
+	return dao.db(ctx).Create(user).Error
 }
 
 // GetByID 根据ID获取用户
-func (dao *UserDAO) GetByID(id int64) (*model.User, error) {
+func (dao *UserDAO) GetByID(ctx context.Context, id int64) (*model.User, error) {
+	var user model.User
+	err := dao.db(ctx).First(&user, id).Error
+	return &user, err
+}
+
+// GetByIDWithFields 根据ID获取用户，只查询选择的字段
+func (dao *UserDAO) GetByIDWithFields(ctx context.Context, id int64, fs *utils.FieldSet) (*model.User, error) {
 	var user model.User
-	err := dao.DB.First(&user, id).Error
+	err := selectColumns(dao.db(ctx), fs).First(&user, id).Error
 	return &user, err
 }
 
 // GetByEmail 根据邮箱获取用户
-func (dao *UserDAO) GetByEmail(email string) (*model.User, error) {
+func (dao *UserDAO) GetByEmail(ctx context.Context, email string) (*model.User, error) {
 	var user model.User
-	err := dao.DB.Where("email = ?", email).First(&user).Error
+	err := dao.db(ctx).Where("email = ?", email).First(&user).Error
 	return &user, err
 }
 
 // Update 更新用户
-func (dao *UserDAO) Update(user *model.User) error {
+func (dao *UserDAO) Update(ctx context.Context, user *model.User) error {
 	// 准备待更新的字段
 	updates := map[string]interface{}{
 		"email":           user.Email,
@@ -64,50 +77,101 @@ func (dao *UserDAO) Update(user *model.User) error {
 	}
 
 	// 使用Updates而不是Save，只更新指定字段
-	return dao.DB.Model(user).Updates(updates).Error
+	return dao.db(ctx).Model(user).Updates(updates).Error
 }
 
 // Delete 删除用户
-func (dao *UserDAO) Delete(id int64) error {
-	return dao.DB.Delete(&model.User{}, id).Error
+func (dao *UserDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db(ctx).Delete(&model.User{}, id).Error
 }
 
-// GetList 获取用户列表（支持分页）
-func (dao *UserDAO) GetList(page, pageSize int) ([]*model.User, int64, error) {
+// GetList 获取用户列表（支持分页），fs 为 nil 时查询全部字段
+func (dao *UserDAO) GetList(ctx context.Context, page, pageSize int, fs *utils.FieldSet) ([]*model.User, int64, error) {
 	var users []*model.User
 	var total int64
 
 	// 查询总数
-	err := dao.DB.Model(&model.User{}).Count(&total).Error
+	err := dao.db(ctx).Model(&model.User{}).Count(&total).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// 获取数据列表
 	offset := (page - 1) * pageSize
-	err = dao.DB.Offset(offset).Limit(pageSize).Find(&users).Error
+	err = selectColumns(dao.db(ctx), fs).Offset(offset).Limit(pageSize).Find(&users).Error
 	return users, total, err
 }
 
+// GetIDsByRole 获取指定角色的所有用户ID
+func (dao *UserDAO) GetIDsByRole(ctx context.Context, role int32) ([]int64, error) {
+	var ids []int64
+	err := dao.db(ctx).Model(&model.User{}).Where("role = ?", role).Order("id").Pluck("id", &ids).Error
+	return ids, err
+}
+
+// userCursorColumns 用户列表可用于游标分页的排序字段
+var userCursorColumns = cursorColumns{
+	"id":         {kind: sortInt},
+	"created_at": {kind: sortTime, nullable: true},
+}
+
+// GetListByCursor 获取用户列表（游标分页），fs 为 nil 时查询全部字段
+func (dao *UserDAO) GetListByCursor(ctx context.Context, page *utils.PageQuery, fs *utils.FieldSet) ([]*model.User, *utils.CursorResult, error) {
+	var users []*model.User
+	result := &utils.CursorResult{}
+
+	// 按需查询总数
+	if page.WithTotal {
+		var total int64
+		if err := dao.db(ctx).Model(&model.User{}).Count(&total).Error; err != nil {
+			return nil, nil, err
+		}
+		result.Total = &total
+	}
+
+	query, err := applyCursor(dao.db(ctx).Model(&model.User{}), page, userCursorColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 获取数据列表
+	if err := selectColumns(query, fs, page.Sort).Find(&users).Error; err != nil {
+		return nil, nil, err
+	}
+
+	// 多取的一条用于判断是否还有下一页
+	if len(users) > page.PageSize {
+		users = users[:page.PageSize]
+		last := users[len(users)-1]
+		var value *string
+		if page.Sort == "created_at" {
+			value = formatSortTime(last.CreatedAt)
+		}
+		result.NextCursor = nextCursor(page, value, last.ID)
+	}
+
+	return users, result, nil
+}
+
 // ValidateLogin 验证用户登录
-func (dao *UserDAO) ValidateLogin(email, password string) (*model.User, error) {
+func (dao *UserDAO) ValidateLogin(ctx context.Context, email, password string) (*model.User, error) {
 	// 查找用户
-	user, err := dao.GetByEmail(email)
+	user, err := dao.GetByEmail(ctx, email)
 	if err != nil {
-		log.Println("查找用户错误：", err)
+		logging.FromContext(ctx).Debug("登录失败：查找用户出错", "error", err)
 		return nil, err
 	}
 
 	// 验证密码
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		log.Println("密码错误：", err)
+		logging.FromContext(ctx).Debug("登录失败：密码错误", "user_id", user.ID)
 		return nil, err
 	}
 
 	// 验证用户状态
 	if user.Status != 1 {
-		log.Println("用户状态不可用")
+		logging.FromContext(ctx).Debug("登录失败：用户状态不可用", "user_id", user.ID)
 		return nil, gorm.ErrRecordNotFound
 	}
 
@@ -115,14 +179,14 @@ func (dao *UserDAO) ValidateLogin(email, password string) (*model.User, error) {
 }
 
 // UpdateLastLoginTime 更新用户最后登录时间
-func (dao *UserDAO) UpdateLastLoginTime(userID int64) error {
+func (dao *UserDAO) UpdateLastLoginTime(ctx context.Context, userID int64) error {
 	now := time.Now()
-	return dao.DB.Model(&model.User{}).Where("id = ?", userID).Update("last_login_time", &now).Error
+	return dao.db(ctx).Model(&model.User{}).Where("id = ?", userID).Update("last_login_time", &now).Error
 }
 
 // ResetPassword 重置用户密码
-func (dao *UserDAO) ResetPassword(email, newPassword string) error {
-	user, err := dao.GetByEmail(email)
+func (dao *UserDAO) ResetPassword(ctx context.Context, email, newPassword string) error {
+	user, err := dao.GetByEmail(ctx, email)
 	if err != nil {
 		return err
 	}
@@ -133,5 +197,5 @@ func (dao *UserDAO) ResetPassword(email, newPassword string) error {
 	}
 
 	user.Password = string(hashedPassword)
-	return dao.DB.Save(user).Error
+	return dao.db(ctx).Save(user).Error
 }
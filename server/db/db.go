@@ -2,88 +2,114 @@ package db
 
 import (
 	"fmt"
-	"log"
+	"mvc-demo/config"
+	"mvc-demo/logging"
 	"os"
-	"time"
+	"path/filepath"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
+// 数据库类型，与 gorm 方言名称一致
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// Drivers 支持的数据库类型
+var Drivers = []string{DriverMySQL, DriverPostgres, DriverSQLite}
+
 // DB 全局数据库连接
 var DB *gorm.DB
 
-// InitDB 初始化数据库连接
-func InitDB() {
-
-	fmt.Println("test env", os.Getenv("DB_HOST"))
-	// 获取数据库配置信息
-	dbUser := getEnv("DB_USER", "root")
-	dbPassword := getEnv("DB_PASSWORD", "")
-	dbHost := getEnv("DB_HOST", "localhost")
-	dbPort := getEnv("DB_PORT", "3306")
-	dbName := getEnv("DB_NAME", "mvc_demo")
-	dbCharset := getEnv("DB_CHARSET", "utf8mb4")
-
-	// 调试信息：打印环境变量
-	log.Printf("数据库配置信息: HOST=%s, PORT=%s, USER=%s, DB=%s",
-		dbHost, dbPort, dbUser, dbName)
-	log.Printf("原始环境变量: DB_HOST=%s", os.Getenv("DB_HOST"))
-
-	// 构建DSN
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=%s&parseTime=True&loc=Local",
-		dbUser, dbPassword, dbHost, dbPort, dbName, dbCharset)
-
-	// 打印构建的DSN (隐藏密码)
-	dsnLog := fmt.Sprintf("%s:***@tcp(%s:%s)/%s?charset=%s&parseTime=True&loc=Local",
-		dbUser, dbHost, dbPort, dbName, dbCharset)
-	log.Printf("数据库连接DSN: %s", dsnLog)
-
-	// 配置日志记录器
-	newLogger := logger.New(
-		log.New(os.Stdout, "\r\n", log.LstdFlags),
-		logger.Config{
-			SlowThreshold:             time.Second,
-			LogLevel:                  logger.Info,
-			IgnoreRecordNotFoundError: true,
-			Colorful:                  true,
-		},
-	)
-
-	// 连接数据库
-	var err error
-	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: newLogger,
+// InitDB 初始化数据库连接，DB.Driver 选择数据库类型：mysql（默认）、postgres 或 sqlite
+func InitDB(appConfig *config.AppConfig) {
+	dbLogger := logging.Component(logging.ComponentDB)
+	dialector, dsnLog, err := openDialector(appConfig.DB)
+	if err != nil {
+		logging.Fatal(dbLogger, "数据库配置错误", "error", err)
+	}
+	dbLogger.Info("连接数据库", "driver", appConfig.DB.Driver, "dsn", dsnLog)
+
+	// 连接数据库，SQL 日志写入 db 组件；TranslateError 把各数据库的唯一约束冲突统一转换为 gorm.ErrDuplicatedKey
+	DB, err = gorm.Open(dialector, &gorm.Config{
+		Logger:         NewLogger(appConfig.Log),
+		TranslateError: true,
 	})
 
 	if err != nil {
-		log.Fatalf("数据库连接失败: %v", err)
+		logging.Fatal(dbLogger, "数据库连接失败", "error", err)
+	}
+
+	// 启用链路追踪时为每条 SQL 创建 span
+	if appConfig.Tracing.Enabled() {
+		if err := DB.Use(NewTracingPlugin(appConfig.DB.Name)); err != nil {
+			logging.Fatal(dbLogger, "注册链路追踪插件失败", "error", err)
+		}
 	}
 
 	// 设置连接池
 	sqlDB, err := DB.DB()
 	if err != nil {
-		log.Fatalf("获取DB实例失败: %v", err)
+		logging.Fatal(dbLogger, "获取DB实例失败", "error", err)
 	}
 
 	// 设置空闲连接池中的最大连接数
-	sqlDB.SetMaxIdleConns(10)
+	sqlDB.SetMaxIdleConns(appConfig.DB.MaxIdleConns)
 
 	// 设置打开数据库连接的最大数量
-	sqlDB.SetMaxOpenConns(100)
+	sqlDB.SetMaxOpenConns(appConfig.DB.MaxOpenConns)
+
+	// 设置连接可复用和空闲的最长时间
+	sqlDB.SetConnMaxLifetime(appConfig.DB.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(appConfig.DB.ConnMaxIdleTime)
 
-	// 设置连接可复用的最大时间
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	dbLogger.Info("数据库连接成功")
+}
 
-	log.Println("数据库连接成功")
+// Close 关闭数据库连接池，等待正在使用的连接归还后关闭
+func Close() error {
+	if DB == nil {
+		return nil
+	}
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
 }
 
-// 获取环境变量，如果不存在则返回默认值
-func getEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+// openDialector 根据数据库配置构建 gorm 方言，同时返回隐藏密码后的 DSN 用于日志
+func openDialector(cfg config.DBConfig) (gorm.Dialector, string, error) {
+	switch cfg.Driver {
+	case DriverMySQL:
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=%s&parseTime=True&loc=Local",
+			cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name, cfg.Charset)
+		dsnLog := fmt.Sprintf("%s:***@tcp(%s:%s)/%s?charset=%s&parseTime=True&loc=Local",
+			cfg.User, cfg.Host, cfg.Port, cfg.Name, cfg.Charset)
+		return mysql.Open(dsn), dsnLog, nil
+
+	case DriverPostgres:
+		dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=Local",
+			cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode)
+		dsnLog := fmt.Sprintf("host=%s port=%s user=%s password=*** dbname=%s sslmode=%s",
+			cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.SSLMode)
+		return postgres.Open(dsn), dsnLog, nil
+
+	case DriverSQLite:
+		path := cfg.Path
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, "", err
+		}
+		// 写入冲突时等待而不是立即返回 database is locked，WAL 模式下读写互不阻塞
+		dsn := path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+		return sqlite.Open(dsn), dsn, nil
+
+	default:
+		return nil, "", fmt.Errorf("不支持的数据库类型: %s，可选值: mysql、postgres、sqlite", cfg.Driver)
 	}
-	return value
 }
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"fmt"
+	"mvc-demo/logging"
+
+	"gorm.io/gorm"
+)
+
+// legacyUpgrade 迁移子系统之前 upgrade.sql 中的一项结构变更
+// 早期数据库由 db.sql 创建（docker-compose 挂载到 /docker-entrypoint-initdb.d），之后的变更需手动执行 upgrade.sql，
+// 这类数据库没有迁移记录，各表停留在不同的阶段；版本 1 的建表语句使用 IF NOT EXISTS，不会补齐已存在的表缺少的列
+type legacyUpgrade struct {
+	Table     string // 变更的表
+	Column    string // 变更新增的列，表存在但缺少该列时执行
+	Statement string
+}
+
+// mysqlLegacyUpgrades 与原 upgrade.sql 的变更一一对应，按原顺序执行
+var mysqlLegacyUpgrades = []legacyUpgrade{
+	{
+		// 学生关联专业目录（院系/专业）
+		Table:  "students",
+		Column: "major_id",
+		Statement: "ALTER TABLE `students` " +
+			"MODIFY COLUMN `major` VARCHAR(100) DEFAULT NULL COMMENT '专业名称（自由填写，历史数据保留）', " +
+			"ADD COLUMN `major_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '专业ID（关联majors表）' AFTER `major`, " +
+			"ADD KEY `idx_major_id` (`major_id`)",
+	},
+	{
+		// 大学资料（院校代码、简称、英文名、所在地、层次标签、官网、Logo）
+		Table:  "universities",
+		Column: "code",
+		Statement: "ALTER TABLE `universities` " +
+			"ADD COLUMN `code` VARCHAR(20) DEFAULT NULL COMMENT '院校代码（如教育部院校标识码）' AFTER `name`, " +
+			"ADD COLUMN `short_name` VARCHAR(50) DEFAULT NULL COMMENT '简称' AFTER `code`, " +
+			"ADD COLUMN `english_name` VARCHAR(200) DEFAULT NULL COMMENT '英文名称' AFTER `short_name`, " +
+			"ADD COLUMN `province` VARCHAR(50) DEFAULT NULL COMMENT '所在省份' AFTER `english_name`, " +
+			"ADD COLUMN `city` VARCHAR(50) DEFAULT NULL COMMENT '所在城市' AFTER `province`, " +
+			"ADD COLUMN `level_tags` VARCHAR(100) DEFAULT NULL COMMENT '层次标签，逗号分隔(985,211,双一流)' AFTER `city`, " +
+			"ADD COLUMN `website` VARCHAR(255) DEFAULT NULL COMMENT '官网地址' AFTER `level_tags`, " +
+			"ADD COLUMN `logo` VARCHAR(255) DEFAULT NULL COMMENT '校徽/Logo URL' AFTER `website`, " +
+			"ADD UNIQUE KEY `idx_code` (`code`), " +
+			"ADD KEY `idx_province` (`province`)",
+	},
+	{
+		// 学生重复记录合并
+		Table:  "students",
+		Column: "merged_into_id",
+		Statement: "ALTER TABLE `students` " +
+			"ADD COLUMN `merged_into_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '合并后保留的学生ID（重复记录合并后软删除）' AFTER `updated_by`, " +
+			"ADD KEY `idx_phone` (`phone`)",
+	},
+	{
+		// 课程指定评分标准
+		Table:  "courses",
+		Column: "grading_scale_id",
+		Statement: "ALTER TABLE `courses` " +
+			"ADD COLUMN `grading_scale_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '评分标准ID（关联grading_scales表），为空时使用默认评分标准' AFTER `description`",
+	},
+}
+
+// upgradeLegacy 没有任何迁移记录时，为早期数据库中已存在的表补齐版本 1 之前的结构变更
+// 全新的数据库中这些表都不存在，由版本 1 创建，不会执行任何变更
+func (m *Migrator) upgradeLegacy(conn *gorm.DB) error {
+	for _, upgrade := range m.dialect.legacyUpgrades {
+		if !conn.Migrator().HasTable(upgrade.Table) || conn.Migrator().HasColumn(upgrade.Table, upgrade.Column) {
+			continue
+		}
+		if err := conn.Exec(upgrade.Statement).Error; err != nil {
+			return fmt.Errorf("升级早期数据库的 %s.%s 失败: %w", upgrade.Table, upgrade.Column, err)
+		}
+		logging.Component(logging.ComponentMigrate).Info("已升级早期数据库结构", "table", upgrade.Table, "column", upgrade.Column)
+	}
+	return nil
+}
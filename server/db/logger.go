@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mvc-demo/config"
+	"mvc-demo/logging"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// gormLogger 把 GORM 日志写入 slog（db 组件），SQL 日志带上 ctx 中的请求ID等字段
+// 执行出错的 SQL 记为 error，慢查询记为 warn，其余 SQL 记为 debug（LOG_LEVELS=db=debug 时输出）
+type gormLogger struct {
+	level         logger.LogLevel
+	slowThreshold time.Duration
+	sqlParams     bool
+}
+
+var (
+	_ logger.Interface  = (*gormLogger)(nil)
+	_ gorm.ParamsFilter = (*gormLogger)(nil)
+)
+
+// NewLogger 按日志配置创建 GORM 日志记录器
+func NewLogger(cfg config.LogConfig) logger.Interface {
+	return &gormLogger{level: logger.Info, slowThreshold: cfg.SlowQueryThreshold, sqlParams: cfg.SQLParams}
+}
+
+// LogMode 设置 GORM 的日志级别，Silent 时不输出任何日志
+func (l *gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+// logger 返回 ctx 中的请求日志记录器并标记为 db 组件
+func (l *gormLogger) logger(ctx context.Context) *slog.Logger {
+	return logging.FromContext(ctx).With(logging.ComponentKey, logging.ComponentDB)
+}
+
+func (l *gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Info {
+		l.logger(ctx).InfoContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Warn {
+		l.logger(ctx).WarnContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Error {
+		l.logger(ctx).ErrorContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+// Trace 记录一条 SQL 的执行结果
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	level, msg := slog.LevelDebug, "SQL"
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= logger.Error:
+		level, msg = slog.LevelError, "SQL执行失败"
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= logger.Warn:
+		level, msg = slog.LevelWarn, "慢查询"
+	}
+
+	log := l.logger(ctx)
+	if !log.Enabled(ctx, level) {
+		return
+	}
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Float64("elapsed_ms", float64(elapsed.Microseconds())/1000),
+	}
+	if level == slog.LevelError {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	log.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// ParamsFilter 未开启 LOG_SQL_PARAMS 时 SQL 日志中只保留占位符，不记录参数值
+func (l *gormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.sqlParams {
+		return sql, params
+	}
+	return sql, nil
+}
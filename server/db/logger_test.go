@@ -0,0 +1,124 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mvc-demo/config"
+	"mvc-demo/logging"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openLoggedDB 打开使用 GORM 日志桥接的 SQLite 数据库，日志以 JSON 格式写入返回的缓冲区
+func openLoggedDB(t *testing.T, cfg config.LogConfig) (*gorm.DB, *bytes.Buffer) {
+	t.Helper()
+	var buf bytes.Buffer
+	cfg.Format = "json"
+	logging.SetupWithWriter(cfg, &buf)
+	t.Cleanup(func() { logging.Setup(config.LogConfig{Level: "info"}) })
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{Logger: NewLogger(cfg)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	if err := db.Exec("CREATE TABLE accounts (id INTEGER PRIMARY KEY, email TEXT, password TEXT)").Error; err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	return db, &buf
+}
+
+// logRecords 解析缓冲区中的每一行日志
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var result []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("无法解析日志 %q: %v", line, err)
+		}
+		result = append(result, record)
+	}
+	return result
+}
+
+// SQL 日志写入 db 组件并带上请求日志记录器的字段，默认不记录参数值
+func TestGormLoggerBridge(t *testing.T) {
+	db, buf := openLoggedDB(t, config.LogConfig{Level: "info", Levels: map[string]string{logging.ComponentDB: "debug"}})
+	ctx := logging.With(context.Background(), "request_id", "req-1")
+
+	if err := db.WithContext(ctx).Exec("INSERT INTO accounts (email, password) VALUES (?, ?)", "user@example.com", "secret123").Error; err != nil {
+		t.Fatal(err)
+	}
+	logs := logRecords(t, buf)
+	if len(logs) != 1 {
+		t.Fatalf("期望 1 条 SQL 日志，实际 %d 条: %s", len(logs), buf)
+	}
+	record := logs[0]
+	if record["level"] != "DEBUG" || record["component"] != logging.ComponentDB || record["request_id"] != "req-1" {
+		t.Errorf("SQL 日志的级别、组件或请求ID不正确: %v", record)
+	}
+	if sql, _ := record["sql"].(string); !strings.Contains(sql, "?") || strings.Contains(buf.String(), "secret123") {
+		t.Errorf("未开启 sql_params 时 SQL 日志不应包含参数值: %v", record)
+	}
+
+	// 执行出错的 SQL 记为 error
+	buf.Reset()
+	if err := db.WithContext(ctx).Exec("SELECT * FROM missing_table").Error; err == nil {
+		t.Fatal("查询不存在的表应返回错误")
+	}
+	logs = logRecords(t, buf)
+	if len(logs) != 1 || logs[0]["level"] != "ERROR" || logs[0]["msg"] != "SQL执行失败" || logs[0]["error"] == nil {
+		t.Errorf("执行失败的 SQL 日志不正确: %v", logs)
+	}
+}
+
+// db 组件为 info 时只记录慢查询和失败的 SQL，开启 sql_params 后记录参数值
+func TestGormLoggerLevels(t *testing.T) {
+	db, buf := openLoggedDB(t, config.LogConfig{Level: "info", SQLParams: true})
+	if err := db.Exec("INSERT INTO accounts (email) VALUES (?)", "user@example.com").Error; err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("info 级别不应输出普通 SQL: %s", buf)
+	}
+
+	slow := NewLogger(config.LogConfig{SlowQueryThreshold: time.Millisecond, SQLParams: true})
+	slow.Trace(context.Background(), time.Now().Add(-time.Second), func() (string, int64) { return "SELECT 0", 1 }, nil)
+	slow.Trace(context.Background(), time.Now(), func() (string, int64) { return "SELECT 1", 0 }, gorm.ErrRecordNotFound)
+	slow.Trace(context.Background(), time.Now(), func() (string, int64) { return "SELECT 2", 0 }, errors.New("boom"))
+
+	logs := logRecords(t, buf)
+	if len(logs) != 2 || logs[0]["msg"] != "慢查询" || logs[1]["msg"] != "SQL执行失败" {
+		t.Fatalf("期望一条慢查询和一条执行失败日志（记录不存在不算失败）: %s", buf)
+	}
+
+	if _, params := slow.(gorm.ParamsFilter).ParamsFilter(context.Background(), "SELECT ?", "value"); len(params) != 1 {
+		t.Error("开启 sql_params 后应保留参数值")
+	}
+	if _, params := NewLogger(config.LogConfig{}).(gorm.ParamsFilter).ParamsFilter(context.Background(), "SELECT ?", "value"); params != nil {
+		t.Error("未开启 sql_params 时不应保留参数值")
+	}
+
+	buf.Reset()
+	slow.LogMode(logger.Silent).Trace(context.Background(), time.Now(), func() (string, int64) { return "SELECT 3", 0 }, errors.New("boom"))
+	if buf.Len() != 0 {
+		t.Errorf("Silent 模式不应输出日志: %s", buf)
+	}
+}
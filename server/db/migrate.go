@@ -0,0 +1,595 @@
+package db
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mvc-demo/logging"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 迁移文件嵌入到程序中，每种数据库一个目录，如 migrations/mysql
+// 文件名格式为 <版本号>_<名称>.up.sql / <版本号>_<名称>.down.sql
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+// MigrationDir 迁移文件在源码中的目录，migrate create 默认在此创建文件
+const MigrationDir = "db/migrations"
+
+// migrationLockName 迁移锁名称，多个实例同时启动时只有一个执行迁移
+const migrationLockName = "schema_migrations"
+
+// migrationLockKey PostgreSQL 咨询锁的键
+const migrationLockKey int64 = 20250601
+
+// migrationLockTimeout 等待迁移锁的秒数
+const migrationLockTimeout = 60
+
+var (
+	migrationFilePattern      = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+	migrationNameInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+var (
+	ErrMigrationDirty    = errors.New("存在执行失败的迁移，请手动修复数据库后删除 schema_migrations 中对应的记录")
+	ErrChecksumMismatch  = errors.New("已执行的迁移文件被修改，校验和不一致")
+	ErrNoDownMigration   = errors.New("迁移没有回滚脚本")
+	ErrUnknownMigration  = errors.New("数据库中的迁移版本不在当前程序中，请使用对应版本的程序回滚")
+	ErrMigrationLockBusy = errors.New("等待迁移锁超时，可能有其他实例正在执行迁移")
+)
+
+// Migration 一个版本的迁移
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // 升级脚本的 SHA-256
+}
+
+// SchemaMigration 已执行的迁移记录
+type SchemaMigration struct {
+	Version   int64     `gorm:"column:version;primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"column:name"`
+	Checksum  string    `gorm:"column:checksum"`
+	Dirty     bool      `gorm:"column:dirty"` // 开始执行时写入 true，执行成功后改为 false
+	AppliedAt time.Time `gorm:"column:applied_at"`
+}
+
+// TableName SchemaMigration's table name
+func (*SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationStatus 迁移状态
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	State     string // pending、applied、dirty、modified（校验和不一致）、unknown（程序中没有该版本）
+	AppliedAt *time.Time
+}
+
+// 迁移状态
+const (
+	MigrationPending  = "pending"
+	MigrationApplied  = "applied"
+	MigrationDirty    = "dirty"
+	MigrationModified = "modified"
+	MigrationUnknown  = "unknown"
+)
+
+// migrationDialect 不同数据库执行迁移的差异
+type migrationDialect struct {
+	createTable    string                    // 迁移记录表的建表语句
+	lock           func(conn *gorm.DB) error // 获取迁移锁，为空表示不需要加锁
+	unlock         func(conn *gorm.DB)       // 释放迁移锁
+	transactional  bool                      // DDL 可以在事务中回滚，迁移失败时不会留下 dirty 记录
+	legacyUpgrades []legacyUpgrade           // 迁移子系统之前建立的数据库需要补齐的结构变更
+}
+
+// migrationDialects 支持的数据库，键为 gorm 方言名称，与迁移文件目录名一致
+var migrationDialects = map[string]*migrationDialect{
+	DriverMySQL: {
+		createTable: "CREATE TABLE IF NOT EXISTS `schema_migrations` (" +
+			"`version` BIGINT NOT NULL COMMENT '迁移版本号'," +
+			"`name` VARCHAR(255) NOT NULL COMMENT '迁移名称'," +
+			"`checksum` CHAR(64) NOT NULL COMMENT '升级脚本的SHA-256'," +
+			"`dirty` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否执行中或执行失败'," +
+			"`applied_at` DATETIME NOT NULL COMMENT '执行时间'," +
+			"PRIMARY KEY (`version`)" +
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='数据库迁移记录表'",
+		lock: func(conn *gorm.DB) error {
+			var locked *int
+			if err := conn.Raw("SELECT GET_LOCK(?, ?)", migrationLockName, migrationLockTimeout).Scan(&locked).Error; err != nil {
+				return err
+			}
+			if locked == nil || *locked != 1 {
+				return ErrMigrationLockBusy
+			}
+			return nil
+		},
+		unlock: func(conn *gorm.DB) {
+			conn.Exec("SELECT RELEASE_LOCK(?)", migrationLockName)
+		},
+		legacyUpgrades: mysqlLegacyUpgrades,
+	},
+	DriverPostgres: {
+		createTable: portableMigrationTable,
+		lock: func(conn *gorm.DB) error {
+			// pg_advisory_lock 会一直等待，改为轮询以便超时退出
+			for i := 0; i < migrationLockTimeout; i++ {
+				var locked bool
+				if err := conn.Raw("SELECT pg_try_advisory_lock(?)", migrationLockKey).Scan(&locked).Error; err != nil {
+					return err
+				}
+				if locked {
+					return nil
+				}
+				time.Sleep(time.Second)
+			}
+			return ErrMigrationLockBusy
+		},
+		unlock: func(conn *gorm.DB) {
+			conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey)
+		},
+		transactional: true,
+	},
+	// SQLite 为单文件数据库，写入时由 SQLite 自身加锁，不需要额外的迁移锁
+	DriverSQLite: {
+		createTable:   portableMigrationTable,
+		transactional: true,
+	},
+}
+
+// portableMigrationTable PostgreSQL 和 SQLite 通用的迁移记录表建表语句
+const portableMigrationTable = "CREATE TABLE IF NOT EXISTS schema_migrations (" +
+	"version BIGINT NOT NULL PRIMARY KEY," +
+	"name VARCHAR(255) NOT NULL," +
+	"checksum CHAR(64) NOT NULL," +
+	"dirty BOOLEAN NOT NULL DEFAULT FALSE," +
+	"applied_at TIMESTAMP NOT NULL)"
+
+// Migrator 数据库迁移执行器
+type Migrator struct {
+	db         *gorm.DB
+	dialect    *migrationDialect
+	migrations []*Migration
+}
+
+// NewMigrator 按数据库类型使用程序内嵌的迁移文件创建迁移执行器
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	name := db.Dialector.Name()
+	dialect, ok := migrationDialects[name]
+	if !ok {
+		return nil, fmt.Errorf("不支持的数据库类型: %s", name)
+	}
+	migrations, err := embeddedMigrations(name)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+// embeddedMigrations 读取程序内嵌的某种数据库的迁移文件
+func embeddedMigrations(driver string) ([]*Migration, error) {
+	sub, err := fs.Sub(migrationFiles, "migrations/"+driver)
+	if err != nil {
+		return nil, err
+	}
+	return LoadMigrations(sub)
+}
+
+// MigrationScript 生成依次执行全部升级脚本并写入迁移记录的 SQL 脚本，不需要连接数据库
+// 用于由数据库容器初始化时执行建表（如挂载到 MySQL 镜像的 /docker-entrypoint-initdb.d），
+// 脚本写入了迁移记录，服务启动时不会重复执行这些迁移
+func MigrationScript(driver string) (string, error) {
+	dialect, ok := migrationDialects[driver]
+	if !ok {
+		return "", fmt.Errorf("不支持的数据库类型: %s", driver)
+	}
+	migrations, err := embeddedMigrations(driver)
+	if err != nil {
+		return "", err
+	}
+
+	var script strings.Builder
+	fmt.Fprintf(&script, "-- 由 migrate sql 根据 %s 的迁移文件生成，请勿手动修改\n\n", driver)
+	fmt.Fprintf(&script, "%s;\n", dialect.createTable)
+	for _, migration := range migrations {
+		fmt.Fprintf(&script, "\n-- %04d_%s\n", migration.Version, migration.Name)
+		for _, statement := range SplitStatements(migration.Up) {
+			fmt.Fprintf(&script, "%s;\n", statement)
+		}
+		fmt.Fprintf(&script, "INSERT INTO schema_migrations (version, name, checksum, dirty, applied_at) VALUES (%d, '%s', '%s', FALSE, CURRENT_TIMESTAMP);\n",
+			migration.Version, migration.Name, migration.Checksum)
+	}
+	return script.String(), nil
+}
+
+// LoadMigrations 读取目录中的迁移文件，按版本号升序返回
+func LoadMigrations(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("迁移文件名格式错误: %s", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("迁移版本 %d 重复: %s 和 %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("迁移 %d_%s 缺少升级脚本", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up 执行全部未执行的迁移，返回本次执行的迁移
+func (m *Migrator) Up() ([]*Migration, error) {
+	var applied []*Migration
+	err := m.withLock(func(conn *gorm.DB) error {
+		records, err := m.appliedRecords(conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(records); err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			if err := m.upgradeLegacy(conn); err != nil {
+				return err
+			}
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := records[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(conn, migration); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down 按版本号倒序回滚最近执行的 steps 个迁移，返回本次回滚的迁移
+func (m *Migrator) Down(steps int) ([]*Migration, error) {
+	var reverted []*Migration
+	err := m.withLock(func(conn *gorm.DB) error {
+		records, err := m.appliedRecords(conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(records); err != nil {
+			return err
+		}
+
+		versions := make([]int64, 0, len(records))
+		for version := range records {
+			versions = append(versions, version)
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+		for i := 0; i < steps && i < len(versions); i++ {
+			migration := m.find(versions[i])
+			if migration == nil {
+				return fmt.Errorf("%w: %d", ErrUnknownMigration, versions[i])
+			}
+			if err := m.revert(conn, migration); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status 获取每个迁移的执行状态，数据库中有但程序中没有的版本标记为 unknown
+func (m *Migrator) Status() ([]*MigrationStatus, error) {
+	records := make(map[int64]*SchemaMigration)
+	if m.db.Migrator().HasTable(&SchemaMigration{}) {
+		var err error
+		if records, err = m.appliedRecords(m.db); err != nil {
+			return nil, err
+		}
+	}
+
+	var statuses []*MigrationStatus
+	for _, migration := range m.migrations {
+		status := &MigrationStatus{Version: migration.Version, Name: migration.Name, State: MigrationPending}
+		if record, ok := records[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+			switch {
+			case record.Dirty:
+				status.State = MigrationDirty
+			case record.Checksum != migration.Checksum:
+				status.State = MigrationModified
+			default:
+				status.State = MigrationApplied
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	for version, record := range records {
+		if m.find(version) == nil {
+			appliedAt := record.AppliedAt
+			statuses = append(statuses, &MigrationStatus{Version: version, Name: record.Name, State: MigrationUnknown, AppliedAt: &appliedAt})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// apply 执行一个迁移的升级脚本
+// 支持事务性 DDL 的数据库在一个事务中执行脚本并写入记录；
+// MySQL 的 DDL 会隐式提交，无法放在事务中回滚，执行前写入 dirty 记录，全部语句成功后清除，执行失败时需人工处理
+func (m *Migrator) apply(conn *gorm.DB, migration *Migration) error {
+	record := &SchemaMigration{
+		Version:   migration.Version,
+		Name:      migration.Name,
+		Checksum:  migration.Checksum,
+		AppliedAt: time.Now(),
+	}
+	if m.dialect.transactional {
+		return conn.Transaction(func(tx *gorm.DB) error {
+			if err := execStatements(tx, migration.Up); err != nil {
+				return fmt.Errorf("执行迁移 %d_%s 失败: %w", migration.Version, migration.Name, err)
+			}
+			return tx.Create(record).Error
+		})
+	}
+
+	record.Dirty = true
+	if err := conn.Create(record).Error; err != nil {
+		return err
+	}
+	if err := execStatements(conn, migration.Up); err != nil {
+		return fmt.Errorf("执行迁移 %d_%s 失败: %w", migration.Version, migration.Name, err)
+	}
+	return conn.Model(record).Update("dirty", false).Error
+}
+
+// revert 执行一个迁移的回滚脚本，成功后删除迁移记录
+func (m *Migrator) revert(conn *gorm.DB, migration *Migration) error {
+	if strings.TrimSpace(migration.Down) == "" {
+		return fmt.Errorf("%w: %d_%s", ErrNoDownMigration, migration.Version, migration.Name)
+	}
+	record := &SchemaMigration{Version: migration.Version}
+	if m.dialect.transactional {
+		return conn.Transaction(func(tx *gorm.DB) error {
+			if err := execStatements(tx, migration.Down); err != nil {
+				return fmt.Errorf("回滚迁移 %d_%s 失败: %w", migration.Version, migration.Name, err)
+			}
+			return tx.Delete(record).Error
+		})
+	}
+
+	if err := conn.Model(record).Update("dirty", true).Error; err != nil {
+		return err
+	}
+	if err := execStatements(conn, migration.Down); err != nil {
+		return fmt.Errorf("回滚迁移 %d_%s 失败: %w", migration.Version, migration.Name, err)
+	}
+	return conn.Delete(record).Error
+}
+
+// verify 检查已执行的迁移没有 dirty 记录，且迁移文件未被修改
+func (m *Migrator) verify(records map[int64]*SchemaMigration) error {
+	for version, record := range records {
+		if record.Dirty {
+			return fmt.Errorf("%w: %d_%s", ErrMigrationDirty, version, record.Name)
+		}
+		if migration := m.find(version); migration != nil && migration.Checksum != record.Checksum {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, version, migration.Name)
+		}
+	}
+	return nil
+}
+
+// withLock 在同一个数据库连接上获取迁移锁后执行 fn，锁随连接会话持有
+func (m *Migrator) withLock(fn func(conn *gorm.DB) error) error {
+	return m.db.Connection(func(conn *gorm.DB) error {
+		if m.dialect.lock != nil {
+			if err := m.dialect.lock(conn); err != nil {
+				return err
+			}
+			defer m.dialect.unlock(conn)
+		}
+
+		if err := m.ensureTable(conn); err != nil {
+			return err
+		}
+		return fn(conn)
+	})
+}
+
+// ensureTable 创建迁移记录表
+func (m *Migrator) ensureTable(conn *gorm.DB) error {
+	return conn.Exec(m.dialect.createTable).Error
+}
+
+// appliedRecords 获取已执行的迁移记录，按版本号索引
+func (m *Migrator) appliedRecords(conn *gorm.DB) (map[int64]*SchemaMigration, error) {
+	var records []*SchemaMigration
+	if err := conn.Order("version").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[int64]*SchemaMigration, len(records))
+	for _, record := range records {
+		result[record.Version] = record
+	}
+	return result, nil
+}
+
+// find 根据版本号查找迁移
+func (m *Migrator) find(version int64) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+	return nil
+}
+
+// execStatements 逐条执行脚本中的 SQL 语句
+func execStatements(conn *gorm.DB, script string) error {
+	for _, statement := range SplitStatements(script) {
+		if err := conn.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SplitStatements 按分号拆分 SQL 脚本，忽略注释以及引号内的分号，不支持 PostgreSQL 的 $$ 引用
+func SplitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	var quote byte
+
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); statement != "" {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(script); i++ {
+		ch := script[i]
+		if quote != 0 {
+			current.WriteByte(ch)
+			if ch == '\\' && quote != '`' && i+1 < len(script) {
+				i++
+				current.WriteByte(script[i])
+			} else if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch {
+		case ch == '\'' || ch == '"' || ch == '`':
+			quote = ch
+			current.WriteByte(ch)
+		case ch == '-' && isLineComment(script[i:]):
+			// 行注释，跳到行尾
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+			current.WriteByte('\n')
+		case ch == '/' && strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end + 3
+			}
+			current.WriteByte(' ')
+		case ch == ';':
+			flush()
+		default:
+			current.WriteByte(ch)
+		}
+	}
+	flush()
+	return statements
+}
+
+// isLineComment 判断是否为 "-- " 开头的行注释，MySQL 要求 -- 后面跟空白字符
+func isLineComment(s string) bool {
+	return strings.HasPrefix(s, "--") && (len(s) == 2 || s[2] == ' ' || s[2] == '\t' || s[2] == '\n' || s[2] == '\r')
+}
+
+// CreateMigrationFiles 在 dir 下每种数据库的目录中创建下一个版本的空迁移文件，返回创建的文件路径
+// 各数据库使用相同的版本号，取所有目录中最大的版本号加一
+func CreateMigrationFiles(dir, name string) ([]string, error) {
+	name = strings.Trim(migrationNameInvalidChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, errors.New("迁移名称只能包含字母、数字和下划线")
+	}
+
+	var version int64 = 1
+	for _, driver := range Drivers {
+		migrations, err := LoadMigrations(os.DirFS(filepath.Join(dir, driver)))
+		if err != nil {
+			return nil, err
+		}
+		if len(migrations) > 0 && migrations[len(migrations)-1].Version >= version {
+			version = migrations[len(migrations)-1].Version + 1
+		}
+	}
+
+	var paths []string
+	for _, driver := range Drivers {
+		base := filepath.Join(dir, driver, fmt.Sprintf("%04d_%s", version, name))
+		if err := os.WriteFile(base+".up.sql", []byte(fmt.Sprintf("-- %s\n\n", name)), 0644); err != nil {
+			return paths, err
+		}
+		if err := os.WriteFile(base+".down.sql", []byte(fmt.Sprintf("-- 回滚 %s\n\n", name)), 0644); err != nil {
+			return paths, err
+		}
+		paths = append(paths, base+".up.sql", base+".down.sql")
+	}
+	return paths, nil
+}
+
+// MigrateUp 执行全部未执行的迁移，用于服务启动时自动迁移
+func MigrateUp(db *gorm.DB) error {
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up()
+	for _, migration := range applied {
+		logging.Component(logging.ComponentMigrate).Info("已执行迁移", "version", migration.Version, "name", migration.Name)
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// 没有迁移记录的早期数据库先补齐已存在的表缺少的列，不存在的表留给迁移创建
+func TestUpgradeLegacy(t *testing.T) {
+	db := newTestDB(t)
+	if err := db.Exec("CREATE TABLE students (id INTEGER PRIMARY KEY, name VARCHAR(50))").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	dialect := *migrationDialects[DriverSQLite]
+	dialect.legacyUpgrades = []legacyUpgrade{
+		{Table: "students", Column: "phone", Statement: "ALTER TABLE students ADD COLUMN phone VARCHAR(20)"},
+		{Table: "courses", Column: "grading_scale_id", Statement: "ALTER TABLE courses ADD COLUMN grading_scale_id BIGINT"},
+	}
+	migrator := &Migrator{db: db, dialect: &dialect, migrations: []*Migration{{
+		Version:  1,
+		Name:     "initial_schema",
+		Up:       "CREATE TABLE IF NOT EXISTS courses (id INTEGER PRIMARY KEY, grading_scale_id BIGINT)",
+		Checksum: "test",
+	}}}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+	if !db.Migrator().HasColumn("students", "phone") {
+		t.Fatal("早期数据库的 students 表没有补齐 phone 列")
+	}
+	if !db.Migrator().HasColumn("courses", "grading_scale_id") {
+		t.Fatal("版本 1 没有创建 courses 表")
+	}
+
+	// 已有迁移记录后不再检查早期结构
+	dialect.legacyUpgrades = []legacyUpgrade{{Table: "students", Column: "avatar", Statement: "ALTER TABLE students ADD COLUMN avatar VARCHAR(255)"}}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+	if db.Migrator().HasColumn("students", "avatar") {
+		t.Fatal("已有迁移记录的数据库不应执行早期结构变更")
+	}
+}
+
+// 执行 migrate sql 生成的脚本后，迁移执行器认为全部迁移都已执行
+func TestMigrationScript(t *testing.T) {
+	db := newTestDB(t)
+	script, err := MigrationScript(DriverSQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := execStatements(db, script); err != nil {
+		t.Fatal(err)
+	}
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	applied, err := migrator.Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 0 {
+		t.Fatalf("执行脚本后仍有 %d 个迁移未执行", len(applied))
+	}
+	statuses, err := migrator.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range statuses {
+		if status.State != MigrationApplied {
+			t.Fatalf("迁移 %04d_%s 的状态为 %s", status.Version, status.Name, status.State)
+		}
+	}
+}
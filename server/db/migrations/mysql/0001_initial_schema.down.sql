@@ -0,0 +1,28 @@
+-- 回滚初始表结构，会删除全部业务数据
+
+DROP TABLE IF EXISTS `employment_records`;
+DROP TABLE IF EXISTS `job_applications`;
+DROP TABLE IF EXISTS `job_postings`;
+DROP TABLE IF EXISTS `companies`;
+DROP TABLE IF EXISTS `advisor_assignments`;
+DROP TABLE IF EXISTS `teachers`;
+DROP TABLE IF EXISTS `attendance_records`;
+DROP TABLE IF EXISTS `class_sessions`;
+DROP TABLE IF EXISTS `transcripts`;
+DROP TABLE IF EXISTS `grades`;
+DROP TABLE IF EXISTS `grading_scale_items`;
+DROP TABLE IF EXISTS `grading_scales`;
+DROP TABLE IF EXISTS `enrollments`;
+DROP TABLE IF EXISTS `course_sections`;
+DROP TABLE IF EXISTS `courses`;
+DROP TABLE IF EXISTS `notifications`;
+DROP TABLE IF EXISTS `student_change_requests`;
+DROP TABLE IF EXISTS `student_status_histories`;
+DROP TABLE IF EXISTS `audit_logs`;
+DROP TABLE IF EXISTS `majors`;
+DROP TABLE IF EXISTS `colleges`;
+DROP TABLE IF EXISTS `student_search_index`;
+DROP TABLE IF EXISTS `students`;
+DROP TABLE IF EXISTS `university_aliases`;
+DROP TABLE IF EXISTS `universities`;
+DROP TABLE IF EXISTS `users`;
@@ -0,0 +1,508 @@
+-- 初始表结构：用户、大学、学生、院系专业、课程成绩、考勤、导师、就业等全部业务表，以及默认评分标准
+-- 由原 db/db.sql 和 db/upgrade.sql 合并而来，全部使用 IF NOT EXISTS，已有数据库执行时不会修改已存在的表
+
+-- 用户表（用于鉴权）
+CREATE TABLE IF NOT EXISTS `users` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '用户ID',
+  `email` VARCHAR(100) NOT NULL COMMENT '邮箱(登录账号)',
+  `username` VARCHAR(100) NOT NULL COMMENT '姓名',
+  `password` VARCHAR(255) NOT NULL COMMENT '密码(加密存储)',
+  `role` TINYINT NOT NULL DEFAULT 2 COMMENT '角色(1:管理员,2:普通用户)',
+  `last_login_time` DATETIME DEFAULT NULL COMMENT '上次登录时间',
+  `status` TINYINT NOT NULL DEFAULT 1 COMMENT '状态(0:禁用,1:启用)',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` DATETIME DEFAULT NULL COMMENT '删除时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_email` (`email`),
+  KEY `idx_role` (`role`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户表';
+
+-- 大学表
+CREATE TABLE IF NOT EXISTS `universities` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '大学ID',
+  `name` VARCHAR(100) NOT NULL COMMENT '大学名称',
+  `code` VARCHAR(20) DEFAULT NULL COMMENT '院校代码（如教育部院校标识码）',
+  `short_name` VARCHAR(50) DEFAULT NULL COMMENT '简称',
+  `english_name` VARCHAR(200) DEFAULT NULL COMMENT '英文名称',
+  `province` VARCHAR(50) DEFAULT NULL COMMENT '所在省份',
+  `city` VARCHAR(50) DEFAULT NULL COMMENT '所在城市',
+  `level_tags` VARCHAR(100) DEFAULT NULL COMMENT '层次标签，逗号分隔(985,211,双一流)',
+  `website` VARCHAR(255) DEFAULT NULL COMMENT '官网地址',
+  `logo` VARCHAR(255) DEFAULT NULL COMMENT '校徽/Logo URL',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` DATETIME DEFAULT NULL COMMENT '删除时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_name` (`name`),
+  UNIQUE KEY `idx_code` (`code`),
+  KEY `idx_province` (`province`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='大学信息表';
+
+-- 大学别名表（简称、曾用名等，用于按别名查找大学）
+CREATE TABLE IF NOT EXISTS `university_aliases` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '别名ID',
+  `university_id` BIGINT UNSIGNED NOT NULL COMMENT '大学ID（关联universities表）',
+  `alias` VARCHAR(100) NOT NULL COMMENT '别名',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_alias` (`alias`),
+  KEY `idx_university_id` (`university_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='大学别名表';
+
+-- 学生表
+CREATE TABLE IF NOT EXISTS `students` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '学生ID（主键）',
+  `name` VARCHAR(50) NOT NULL COMMENT '学生姓名',
+  `password` VARCHAR(255) NOT NULL COMMENT '登录密码(加密存储)',
+  `email` VARCHAR(100) NOT NULL COMMENT '电子邮箱(登录账号，唯一标识)',
+  `last_login_time` DATETIME DEFAULT NULL COMMENT '上次登录时间',
+  `gender` TINYINT DEFAULT 1 COMMENT '性别(1:男,2:女,3:其他)',
+  `birthday` DATE DEFAULT NULL COMMENT '出生日期',
+  `phone` VARCHAR(20) DEFAULT NULL COMMENT '联系电话',
+  `resume_path` VARCHAR(255) DEFAULT NULL COMMENT '简历文件相对路径',
+  `university_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '大学ID（关联universities表）',
+  `major` VARCHAR(100) DEFAULT NULL COMMENT '专业名称（自由填写，历史数据保留）',
+  `major_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '专业ID（关联majors表）',
+  `education` ENUM('专科', '本科', '硕士', '博士') DEFAULT '本科' COMMENT '学历',
+  `graduation_year` YEAR DEFAULT NULL COMMENT '毕业年份',
+  `status` ENUM('在读', '休学', '退学', '毕业') DEFAULT '在读' COMMENT '学生状态',
+  `remarks` TEXT DEFAULT NULL COMMENT '备注信息',
+  `avatar` VARCHAR(255) DEFAULT NULL COMMENT '头像URL',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` DATETIME DEFAULT NULL COMMENT '删除时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  `merged_into_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '合并后保留的学生ID（重复记录合并后软删除）',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_email` (`email`),
+  KEY `idx_name` (`name`),
+  KEY `idx_phone` (`phone`),
+  KEY `idx_university_id` (`university_id`),
+  KEY `idx_major_id` (`major_id`),
+  KEY `idx_graduation_year` (`graduation_year`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='学生信息表';
+
+-- 学生全文检索表（SEARCH_ENGINE=mysql 时使用）
+CREATE TABLE IF NOT EXISTS `student_search_index` (
+  `student_id` BIGINT UNSIGNED NOT NULL COMMENT '学生ID',
+  `name` VARCHAR(50) NOT NULL COMMENT '学生姓名',
+  `name_pinyin` VARCHAR(255) DEFAULT NULL COMMENT '姓名拼音(全拼和首字母)',
+  `email` VARCHAR(100) NOT NULL COMMENT '电子邮箱',
+  `phone` VARCHAR(20) DEFAULT NULL COMMENT '联系电话',
+  `major` VARCHAR(100) DEFAULT NULL COMMENT '专业名称（自由填写，历史数据保留）',
+  `major_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '专业ID（关联majors表）',
+  `remarks` TEXT DEFAULT NULL COMMENT '备注信息',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`student_id`),
+  FULLTEXT KEY `ft_student_search` (`name`, `name_pinyin`, `email`, `phone`, `major`, `remarks`) WITH PARSER ngram
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='学生全文检索表';
+
+-- 院系表
+CREATE TABLE IF NOT EXISTS `colleges` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '院系ID',
+  `university_id` BIGINT UNSIGNED NOT NULL COMMENT '大学ID（关联universities表）',
+  `name` VARCHAR(100) NOT NULL COMMENT '院系名称',
+  `code` VARCHAR(50) DEFAULT NULL COMMENT '院系代码',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` DATETIME DEFAULT NULL COMMENT '删除时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`),
+  KEY `idx_university_name` (`university_id`, `name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='院系表';
+
+-- 专业表
+CREATE TABLE IF NOT EXISTS `majors` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '专业ID',
+  `university_id` BIGINT UNSIGNED NOT NULL COMMENT '大学ID（关联universities表）',
+  `college_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '院系ID（关联colleges表）',
+  `name` VARCHAR(100) NOT NULL COMMENT '专业名称',
+  `code` VARCHAR(50) DEFAULT NULL COMMENT '专业代码',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` DATETIME DEFAULT NULL COMMENT '删除时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`),
+  KEY `idx_university_name` (`university_id`, `name`),
+  KEY `idx_college_id` (`college_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='专业表';
+
+-- 操作审计日志表
+CREATE TABLE IF NOT EXISTS `audit_logs` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '日志ID',
+  `actor_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '操作人ID',
+  `action` VARCHAR(50) NOT NULL COMMENT '操作类型，如 university.merge',
+  `entity_type` VARCHAR(50) NOT NULL COMMENT '操作对象类型',
+  `entity_id` BIGINT UNSIGNED NOT NULL COMMENT '操作对象ID',
+  `detail` TEXT DEFAULT NULL COMMENT '操作详情(JSON)',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_entity` (`entity_type`, `entity_id`),
+  KEY `idx_actor_id` (`actor_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='操作审计日志表';
+
+-- 学生状态变更记录表
+CREATE TABLE IF NOT EXISTS `student_status_histories` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '记录ID',
+  `student_id` BIGINT UNSIGNED NOT NULL COMMENT '学生ID（关联students表）',
+  `from_status` VARCHAR(10) DEFAULT NULL COMMENT '变更前状态',
+  `to_status` VARCHAR(10) NOT NULL COMMENT '变更后状态',
+  `effective_date` DATE NOT NULL COMMENT '生效日期',
+  `reason` VARCHAR(500) DEFAULT NULL COMMENT '变更原因',
+  `approver_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '审批人ID（关联users表）',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '操作人ID',
+  PRIMARY KEY (`id`),
+  KEY `idx_student_id` (`student_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='学生状态变更记录表';
+
+-- 学生信息变更申请表（退学、更换大学等敏感变更需要他人审批）
+CREATE TABLE IF NOT EXISTS `student_change_requests` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '申请ID',
+  `student_id` BIGINT UNSIGNED NOT NULL COMMENT '学生ID（关联students表）',
+  `changes` TEXT NOT NULL COMMENT '变更内容(JSON)',
+  `reason` VARCHAR(500) DEFAULT NULL COMMENT '申请原因',
+  `status` VARCHAR(20) NOT NULL DEFAULT 'pending' COMMENT '申请状态：pending-待审批，approved-已通过，rejected-已驳回，cancelled-已撤回',
+  `requester_id` BIGINT UNSIGNED NOT NULL COMMENT '申请人ID（关联users表）',
+  `reviewer_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '审批人ID（关联users表）',
+  `review_comment` VARCHAR(500) DEFAULT NULL COMMENT '审批意见',
+  `reviewed_at` DATETIME DEFAULT NULL COMMENT '审批时间',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_status` (`status`),
+  KEY `idx_student_id` (`student_id`),
+  KEY `idx_requester_id` (`requester_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='学生信息变更申请表';
+
+-- 站内通知表
+CREATE TABLE IF NOT EXISTS `notifications` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '通知ID',
+  `user_id` BIGINT UNSIGNED NOT NULL COMMENT '接收人ID（关联users表）',
+  `type` VARCHAR(50) NOT NULL COMMENT '通知类型，如 change_request.submitted',
+  `title` VARCHAR(200) NOT NULL COMMENT '标题',
+  `content` VARCHAR(1000) DEFAULT NULL COMMENT '内容',
+  `entity_type` VARCHAR(50) DEFAULT NULL COMMENT '关联对象类型',
+  `entity_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '关联对象ID',
+  `read_at` DATETIME DEFAULT NULL COMMENT '已读时间，为空表示未读',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_user_read` (`user_id`, `read_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='站内通知表';
+
+-- 课程表
+CREATE TABLE IF NOT EXISTS `courses` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '课程ID',
+  `university_id` BIGINT UNSIGNED NOT NULL COMMENT '所属大学ID（关联universities表）',
+  `code` VARCHAR(50) NOT NULL COMMENT '课程代码，同一大学内唯一',
+  `title` VARCHAR(200) NOT NULL COMMENT '课程名称',
+  `credits` DECIMAL(4,1) NOT NULL COMMENT '学分',
+  `description` VARCHAR(1000) DEFAULT NULL COMMENT '课程简介',
+  `grading_scale_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '评分标准ID（关联grading_scales表），为空时使用默认评分标准',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` DATETIME DEFAULT NULL COMMENT '删除时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`),
+  KEY `idx_university_code` (`university_id`, `code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='课程表';
+
+-- 教学班表（课程在某个学期的开课）
+CREATE TABLE IF NOT EXISTS `course_sections` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '教学班ID',
+  `course_id` BIGINT UNSIGNED NOT NULL COMMENT '课程ID（关联courses表）',
+  `term` VARCHAR(20) NOT NULL COMMENT '学期，如 2024-2025-1',
+  `section_no` VARCHAR(20) NOT NULL COMMENT '班号，同一课程同一学期内唯一',
+  `instructor` VARCHAR(50) DEFAULT NULL COMMENT '任课教师',
+  `capacity` INT NOT NULL COMMENT '容量（最多选课人数）',
+  `schedule` VARCHAR(200) DEFAULT NULL COMMENT '上课时间，如 1 08:00-09:40,3 10:00-11:40（星期 开始-结束）',
+  `location` VARCHAR(100) DEFAULT NULL COMMENT '上课地点',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` DATETIME DEFAULT NULL COMMENT '删除时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`),
+  KEY `idx_course_term` (`course_id`, `term`),
+  KEY `idx_term` (`term`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='教学班表';
+
+-- 选课记录表
+CREATE TABLE IF NOT EXISTS `enrollments` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '选课记录ID',
+  `student_id` BIGINT UNSIGNED NOT NULL COMMENT '学生ID（关联students表）',
+  `section_id` BIGINT UNSIGNED NOT NULL COMMENT '教学班ID（关联course_sections表）',
+  `status` VARCHAR(20) NOT NULL DEFAULT 'enrolled' COMMENT '状态：enrolled-已选，dropped-已退，completed-已修完',
+  `enrolled_at` DATETIME DEFAULT NULL COMMENT '选课时间',
+  `dropped_at` DATETIME DEFAULT NULL COMMENT '退课时间',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`),
+  KEY `idx_student_section` (`student_id`, `section_id`),
+  KEY `idx_section_status` (`section_id`, `status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='选课记录表';
+
+-- 评分标准表
+CREATE TABLE IF NOT EXISTS `grading_scales` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '评分标准ID',
+  `name` VARCHAR(50) NOT NULL COMMENT '名称',
+  `type` VARCHAR(20) NOT NULL COMMENT '类型：percentage-百分制，letter-等级制，five_point-五级制',
+  `is_default` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否为默认评分标准（课程未指定时使用）',
+  `description` VARCHAR(500) DEFAULT NULL COMMENT '说明',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` DATETIME DEFAULT NULL COMMENT '删除时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='评分标准表';
+
+-- 评分标准等级表
+CREATE TABLE IF NOT EXISTS `grading_scale_items` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '等级ID',
+  `scale_id` BIGINT UNSIGNED NOT NULL COMMENT '评分标准ID（关联grading_scales表）',
+  `grade` VARCHAR(10) NOT NULL COMMENT '等级，如 A、B+、优秀',
+  `min_score` DECIMAL(5,2) DEFAULT NULL COMMENT '百分制的分数下限（含），其他类型为空',
+  `grade_point` DECIMAL(3,2) NOT NULL COMMENT '绩点',
+  `passing` TINYINT(1) NOT NULL DEFAULT 1 COMMENT '是否及格',
+  PRIMARY KEY (`id`),
+  KEY `idx_scale_id` (`scale_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='评分标准等级表';
+
+-- 课程成绩表
+CREATE TABLE IF NOT EXISTS `grades` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '成绩ID',
+  `student_id` BIGINT UNSIGNED NOT NULL COMMENT '学生ID（关联students表）',
+  `course_id` BIGINT UNSIGNED NOT NULL COMMENT '课程ID（关联courses表）',
+  `enrollment_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '选课记录ID（关联enrollments表），补录的成绩为空',
+  `term` VARCHAR(20) NOT NULL COMMENT '学期',
+  `scale_id` BIGINT UNSIGNED NOT NULL COMMENT '评分标准ID（关联grading_scales表）',
+  `score` DECIMAL(5,2) DEFAULT NULL COMMENT '百分制分数，等级制为空',
+  `grade` VARCHAR(10) NOT NULL COMMENT '等级',
+  `grade_point` DECIMAL(3,2) NOT NULL COMMENT '绩点',
+  `credits` DECIMAL(4,1) NOT NULL COMMENT '学分（录入时课程的学分）',
+  `passed` TINYINT(1) NOT NULL COMMENT '是否及格',
+  `remarks` VARCHAR(500) DEFAULT NULL COMMENT '备注',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '录入人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`),
+  KEY `idx_student_term` (`student_id`, `term`),
+  KEY `idx_course_id` (`course_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='课程成绩表';
+
+-- 已签发成绩单表（保存签发时的成绩单内容，可通过验证码核验）
+CREATE TABLE IF NOT EXISTS `transcripts` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '成绩单ID',
+  `student_id` BIGINT UNSIGNED NOT NULL COMMENT '学生ID（关联students表）',
+  `verification_code` VARCHAR(32) NOT NULL COMMENT '验证码',
+  `official` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否为正式成绩单',
+  `content` MEDIUMTEXT NOT NULL COMMENT '成绩单内容(JSON)',
+  `cumulative_gpa` DECIMAL(4,2) NOT NULL COMMENT '累计GPA',
+  `issued_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '签发人ID',
+  `issued_at` DATETIME DEFAULT NULL COMMENT '签发时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_verification_code` (`verification_code`),
+  KEY `idx_student_id` (`student_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='已签发成绩单表';
+
+-- 课次表（教学班的每一次上课）
+CREATE TABLE IF NOT EXISTS `class_sessions` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '课次ID',
+  `section_id` BIGINT UNSIGNED NOT NULL COMMENT '教学班ID（关联course_sections表）',
+  `session_date` DATE NOT NULL COMMENT '上课日期',
+  `start_time` CHAR(5) NOT NULL COMMENT '开始时间，如 08:00',
+  `end_time` CHAR(5) NOT NULL COMMENT '结束时间，如 09:40',
+  `topic` VARCHAR(200) DEFAULT NULL COMMENT '授课内容',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_section_date_start` (`section_id`, `session_date`, `start_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='课次表';
+
+-- 考勤记录表（同一课次同一学生只有一条，由服务层保证，便于合并学生时迁移）
+CREATE TABLE IF NOT EXISTS `attendance_records` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '考勤记录ID',
+  `session_id` BIGINT UNSIGNED NOT NULL COMMENT '课次ID（关联class_sessions表）',
+  `student_id` BIGINT UNSIGNED NOT NULL COMMENT '学生ID（关联students表）',
+  `status` VARCHAR(20) NOT NULL COMMENT '状态：present-出勤，absent-缺勤，late-迟到，excused-请假',
+  `remarks` VARCHAR(255) DEFAULT NULL COMMENT '备注',
+  `marked_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '记录人ID',
+  `marked_at` DATETIME DEFAULT NULL COMMENT '记录时间',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_session_student` (`session_id`, `student_id`),
+  KEY `idx_student_id` (`student_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='考勤记录表';
+
+-- 教师表（教师档案关联登录用户）
+CREATE TABLE IF NOT EXISTS `teachers` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '教师ID',
+  `user_id` BIGINT UNSIGNED NOT NULL COMMENT '用户ID（关联users表）',
+  `employee_no` VARCHAR(50) DEFAULT NULL COMMENT '工号',
+  `name` VARCHAR(50) NOT NULL COMMENT '姓名',
+  `title` VARCHAR(50) DEFAULT NULL COMMENT '职称，如 教授、副教授、讲师',
+  `university_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '所属大学ID（关联universities表）',
+  `college_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '所属院系ID（关联colleges表）',
+  `email` VARCHAR(100) DEFAULT NULL COMMENT '联系邮箱',
+  `phone` VARCHAR(20) DEFAULT NULL COMMENT '联系电话',
+  `status` VARCHAR(10) NOT NULL DEFAULT '在职' COMMENT '状态：在职、离职',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_user_id` (`user_id`),
+  UNIQUE KEY `idx_employee_no` (`employee_no`),
+  KEY `idx_college_id` (`college_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='教师表';
+
+-- 导师分配表（每次分配一条记录，结束日期为空表示仍在指导，历史记录保留）
+CREATE TABLE IF NOT EXISTS `advisor_assignments` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '分配记录ID',
+  `student_id` BIGINT UNSIGNED NOT NULL COMMENT '学生ID（关联students表）',
+  `teacher_id` BIGINT UNSIGNED NOT NULL COMMENT '导师ID（关联teachers表）',
+  `start_date` DATE NOT NULL COMMENT '开始日期（含）',
+  `end_date` DATE DEFAULT NULL COMMENT '结束日期（不含），为空表示仍在指导',
+  `reason` VARCHAR(500) DEFAULT NULL COMMENT '分配原因',
+  `end_reason` VARCHAR(500) DEFAULT NULL COMMENT '结束原因',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '分配人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`),
+  KEY `idx_student_start` (`student_id`, `start_date`),
+  KEY `idx_teacher_dates` (`teacher_id`, `start_date`, `end_date`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='导师分配表';
+
+-- 企业表
+CREATE TABLE IF NOT EXISTS `companies` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '企业ID',
+  `name` VARCHAR(100) NOT NULL COMMENT '企业名称',
+  `industry` VARCHAR(50) DEFAULT NULL COMMENT '所属行业',
+  `city` VARCHAR(50) DEFAULT NULL COMMENT '所在城市',
+  `website` VARCHAR(255) DEFAULT NULL COMMENT '官网地址',
+  `contact_name` VARCHAR(50) DEFAULT NULL COMMENT '联系人',
+  `contact_email` VARCHAR(100) DEFAULT NULL COMMENT '联系人邮箱',
+  `contact_phone` VARCHAR(20) DEFAULT NULL COMMENT '联系人电话',
+  `description` TEXT DEFAULT NULL COMMENT '企业简介',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='企业表';
+
+-- 招聘岗位表（全职岗位和实习岗位）
+CREATE TABLE IF NOT EXISTS `job_postings` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '岗位ID',
+  `company_id` BIGINT UNSIGNED NOT NULL COMMENT '企业ID（关联companies表）',
+  `title` VARCHAR(100) NOT NULL COMMENT '岗位名称',
+  `type` VARCHAR(20) NOT NULL COMMENT '岗位类型：job-全职，internship-实习',
+  `city` VARCHAR(50) DEFAULT NULL COMMENT '工作城市',
+  `salary` VARCHAR(50) DEFAULT NULL COMMENT '薪资范围，如 10k-15k',
+  `description` TEXT DEFAULT NULL COMMENT '岗位描述',
+  `status` VARCHAR(20) NOT NULL DEFAULT 'open' COMMENT '状态：open-招聘中，closed-已关闭',
+  `deadline` DATE DEFAULT NULL COMMENT '截止日期（含），为空表示不限',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`),
+  KEY `idx_company_id` (`company_id`),
+  KEY `idx_type_status` (`type`, `status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='招聘岗位表';
+
+-- 岗位申请表（同一学生同一岗位只有一条，由服务层保证，便于合并学生时迁移）
+CREATE TABLE IF NOT EXISTS `job_applications` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '申请ID',
+  `posting_id` BIGINT UNSIGNED NOT NULL COMMENT '岗位ID（关联job_postings表）',
+  `student_id` BIGINT UNSIGNED NOT NULL COMMENT '学生ID（关联students表）',
+  `status` VARCHAR(20) NOT NULL DEFAULT 'applied' COMMENT '状态：applied-已投递，interview-面试中，offer-已发offer，accepted-已接受，rejected-未通过',
+  `applied_at` DATETIME NOT NULL COMMENT '投递时间',
+  `status_changed_at` DATETIME DEFAULT NULL COMMENT '最近一次状态变更时间',
+  `remarks` VARCHAR(500) DEFAULT NULL COMMENT '备注',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`),
+  KEY `idx_posting_status` (`posting_id`, `status`),
+  KEY `idx_student_id` (`student_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='岗位申请表';
+
+-- 毕业去向表（每名学生一条，由服务层保证）
+CREATE TABLE IF NOT EXISTS `employment_records` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '记录ID',
+  `student_id` BIGINT UNSIGNED NOT NULL COMMENT '学生ID（关联students表）',
+  `outcome` VARCHAR(20) NOT NULL COMMENT '去向：employed-就业，further_study-升学，self_employed-自主创业，unemployed-待就业',
+  `company_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '就业企业ID（关联companies表）',
+  `organization` VARCHAR(100) DEFAULT NULL COMMENT '单位或升学院校名称，未登记企业时填写',
+  `position` VARCHAR(100) DEFAULT NULL COMMENT '职位或专业',
+  `city` VARCHAR(50) DEFAULT NULL COMMENT '所在城市',
+  `monthly_salary` DECIMAL(10,2) DEFAULT NULL COMMENT '月薪（元）',
+  `start_date` DATE DEFAULT NULL COMMENT '入职或入学日期',
+  `application_id` BIGINT UNSIGNED DEFAULT NULL COMMENT '来源岗位申请ID（关联job_applications表）',
+  `remarks` VARCHAR(500) DEFAULT NULL COMMENT '备注',
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `created_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '创建人ID',
+  `updated_by` BIGINT UNSIGNED DEFAULT NULL COMMENT '最后修改人ID',
+  PRIMARY KEY (`id`),
+  KEY `idx_student_id` (`student_id`),
+  KEY `idx_company_id` (`company_id`),
+  KEY `idx_outcome` (`outcome`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='毕业去向表';
+
+-- 默认评分标准：百分制（4分制绩点）、等级制、五级制
+INSERT INTO `grading_scales` (`id`, `name`, `type`, `is_default`, `description`)
+SELECT * FROM (
+  SELECT 1 AS id, '百分制' AS name, 'percentage' AS type, 1 AS is_default, '按分数换算等级和绩点（4分制）' AS description UNION ALL
+  SELECT 2, '等级制', 'letter', 0, '直接录入 A-F 等级' UNION ALL
+  SELECT 3, '五级制', 'five_point', 0, '优秀、良好、中等、及格、不及格'
+) AS scales
+WHERE NOT EXISTS (SELECT 1 FROM `grading_scales`);
+INSERT INTO `grading_scale_items` (`scale_id`, `grade`, `min_score`, `grade_point`, `passing`)
+SELECT * FROM (
+  SELECT 1 AS scale_id, 'A' AS grade, 90 AS min_score, 4.0 AS grade_point, 1 AS passing UNION ALL
+  SELECT 1, 'A-', 85, 3.7, 1 UNION ALL
+  SELECT 1, 'B+', 82, 3.3, 1 UNION ALL
+  SELECT 1, 'B', 78, 3.0, 1 UNION ALL
+  SELECT 1, 'B-', 75, 2.7, 1 UNION ALL
+  SELECT 1, 'C+', 72, 2.3, 1 UNION ALL
+  SELECT 1, 'C', 68, 2.0, 1 UNION ALL
+  SELECT 1, 'C-', 64, 1.5, 1 UNION ALL
+  SELECT 1, 'D', 60, 1.0, 1 UNION ALL
+  SELECT 1, 'F', 0, 0, 0 UNION ALL
+  SELECT 2, 'A', NULL, 4.0, 1 UNION ALL
+  SELECT 2, 'A-', NULL, 3.7, 1 UNION ALL
+  SELECT 2, 'B+', NULL, 3.3, 1 UNION ALL
+  SELECT 2, 'B', NULL, 3.0, 1 UNION ALL
+  SELECT 2, 'B-', NULL, 2.7, 1 UNION ALL
+  SELECT 2, 'C+', NULL, 2.3, 1 UNION ALL
+  SELECT 2, 'C', NULL, 2.0, 1 UNION ALL
+  SELECT 2, 'C-', NULL, 1.5, 1 UNION ALL
+  SELECT 2, 'D', NULL, 1.0, 1 UNION ALL
+  SELECT 2, 'F', NULL, 0, 0 UNION ALL
+  SELECT 3, '优秀', NULL, 4.0, 1 UNION ALL
+  SELECT 3, '良好', NULL, 3.0, 1 UNION ALL
+  SELECT 3, '中等', NULL, 2.0, 1 UNION ALL
+  SELECT 3, '及格', NULL, 1.0, 1 UNION ALL
+  SELECT 3, '不及格', NULL, 0, 0
+) AS items
+WHERE NOT EXISTS (SELECT 1 FROM `grading_scale_items`);
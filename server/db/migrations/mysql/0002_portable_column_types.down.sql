@@ -0,0 +1,5 @@
+-- 恢复 ENUM 和 YEAR 类型，已有数据必须在枚举范围内，毕业年份须在 1901-2155 之间
+ALTER TABLE `students`
+  MODIFY COLUMN `education` ENUM('专科', '本科', '硕士', '博士') DEFAULT '本科' COMMENT '学历',
+  MODIFY COLUMN `graduation_year` YEAR DEFAULT NULL COMMENT '毕业年份',
+  MODIFY COLUMN `status` ENUM('在读', '休学', '退学', '毕业') DEFAULT '在读' COMMENT '学生状态';
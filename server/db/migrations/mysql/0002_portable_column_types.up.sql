@@ -0,0 +1,6 @@
+-- 学历、学生状态和毕业年份改为各数据库通用的类型，取值由服务层校验
+-- 与 PostgreSQL、SQLite 的初始表结构保持一致
+ALTER TABLE `students`
+  MODIFY COLUMN `education` VARCHAR(10) DEFAULT '本科' COMMENT '学历(专科,本科,硕士,博士)',
+  MODIFY COLUMN `graduation_year` SMALLINT DEFAULT NULL COMMENT '毕业年份',
+  MODIFY COLUMN `status` VARCHAR(10) DEFAULT '在读' COMMENT '学生状态(在读,休学,退学,毕业)';
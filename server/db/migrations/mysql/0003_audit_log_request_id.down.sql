@@ -0,0 +1,4 @@
+-- 回滚 audit_log_request_id
+ALTER TABLE `audit_logs`
+  DROP KEY `idx_request_id`,
+  DROP COLUMN `request_id`;
@@ -0,0 +1,4 @@
+-- 审计日志记录产生该操作的请求ID，便于与请求日志关联
+ALTER TABLE `audit_logs`
+  ADD COLUMN `request_id` VARCHAR(64) DEFAULT NULL COMMENT '请求ID' AFTER `detail`,
+  ADD KEY `idx_request_id` (`request_id`);
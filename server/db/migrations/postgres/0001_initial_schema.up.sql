@@ -0,0 +1,503 @@
+-- 初始表结构（PostgreSQL）：用户、大学、学生、院系专业、课程成绩、考勤、导师、就业等全部业务表，以及默认评分标准
+-- 与 mysql/0001_initial_schema.up.sql 对应，列注释写在行尾
+
+-- 用户表（用于鉴权）
+CREATE TABLE IF NOT EXISTS users (
+  id BIGSERIAL,                                    -- 用户ID
+  email VARCHAR(100) NOT NULL,                     -- 邮箱(登录账号)
+  username VARCHAR(100) NOT NULL,                  -- 姓名
+  password VARCHAR(255) NOT NULL,                  -- 密码(加密存储)
+  role SMALLINT NOT NULL DEFAULT 2,                -- 角色(1:管理员,2:普通用户)
+  last_login_time TIMESTAMP,                       -- 上次登录时间
+  status SMALLINT NOT NULL DEFAULT 1,              -- 状态(0:禁用,1:启用)
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  deleted_at TIMESTAMP,                            -- 删除时间
+  PRIMARY KEY (id)
+); -- 用户表
+CREATE UNIQUE INDEX IF NOT EXISTS users_email ON users (email);
+CREATE INDEX IF NOT EXISTS users_role ON users (role);
+
+-- 大学表
+CREATE TABLE IF NOT EXISTS universities (
+  id BIGSERIAL,                                    -- 大学ID
+  name VARCHAR(100) NOT NULL,                      -- 大学名称
+  code VARCHAR(20),                                -- 院校代码（如教育部院校标识码）
+  short_name VARCHAR(50),                          -- 简称
+  english_name VARCHAR(200),                       -- 英文名称
+  province VARCHAR(50),                            -- 所在省份
+  city VARCHAR(50),                                -- 所在城市
+  level_tags VARCHAR(100),                         -- 层次标签，逗号分隔(985,211,双一流)
+  website VARCHAR(255),                            -- 官网地址
+  logo VARCHAR(255),                               -- 校徽/Logo URL
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  deleted_at TIMESTAMP,                            -- 删除时间
+  created_by BIGINT,                               -- 创建人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 大学信息表
+CREATE UNIQUE INDEX IF NOT EXISTS universities_name ON universities (name);
+CREATE UNIQUE INDEX IF NOT EXISTS universities_code ON universities (code);
+CREATE INDEX IF NOT EXISTS universities_province ON universities (province);
+
+-- 大学别名表（简称、曾用名等，用于按别名查找大学）
+CREATE TABLE IF NOT EXISTS university_aliases (
+  id BIGSERIAL,                                    -- 别名ID
+  university_id BIGINT NOT NULL,                   -- 大学ID（关联universities表）
+  alias VARCHAR(100) NOT NULL,                     -- 别名
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  created_by BIGINT,                               -- 创建人ID
+  PRIMARY KEY (id)
+); -- 大学别名表
+CREATE UNIQUE INDEX IF NOT EXISTS university_aliases_alias ON university_aliases (alias);
+CREATE INDEX IF NOT EXISTS university_aliases_university_id ON university_aliases (university_id);
+
+-- 学生表
+CREATE TABLE IF NOT EXISTS students (
+  id BIGSERIAL,                                    -- 学生ID（主键）
+  name VARCHAR(50) NOT NULL,                       -- 学生姓名
+  password VARCHAR(255) NOT NULL,                  -- 登录密码(加密存储)
+  email VARCHAR(100) NOT NULL,                     -- 电子邮箱(登录账号，唯一标识)
+  last_login_time TIMESTAMP,                       -- 上次登录时间
+  gender SMALLINT DEFAULT 1,                       -- 性别(1:男,2:女,3:其他)
+  birthday DATE,                                   -- 出生日期
+  phone VARCHAR(20),                               -- 联系电话
+  resume_path VARCHAR(255),                        -- 简历文件相对路径
+  university_id BIGINT,                            -- 大学ID（关联universities表）
+  major VARCHAR(100),                              -- 专业名称（自由填写，历史数据保留）
+  major_id BIGINT,                                 -- 专业ID（关联majors表）
+  education VARCHAR(10) DEFAULT '本科',              -- 学历
+  graduation_year SMALLINT,                        -- 毕业年份
+  status VARCHAR(10) DEFAULT '在读',                 -- 学生状态
+  remarks TEXT,                                    -- 备注信息
+  avatar VARCHAR(255),                             -- 头像URL
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  deleted_at TIMESTAMP,                            -- 删除时间
+  created_by BIGINT,                               -- 创建人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  merged_into_id BIGINT,                           -- 合并后保留的学生ID（重复记录合并后软删除）
+  PRIMARY KEY (id)
+); -- 学生信息表
+CREATE UNIQUE INDEX IF NOT EXISTS students_email ON students (email);
+CREATE INDEX IF NOT EXISTS students_name ON students (name);
+CREATE INDEX IF NOT EXISTS students_phone ON students (phone);
+CREATE INDEX IF NOT EXISTS students_university_id ON students (university_id);
+CREATE INDEX IF NOT EXISTS students_major_id ON students (major_id);
+CREATE INDEX IF NOT EXISTS students_graduation_year ON students (graduation_year);
+
+-- 学生全文检索表（SEARCH_ENGINE=mysql 时使用）
+CREATE TABLE IF NOT EXISTS student_search_index (
+  student_id BIGINT NOT NULL,                      -- 学生ID
+  name VARCHAR(50) NOT NULL,                       -- 学生姓名
+  name_pinyin VARCHAR(255),                        -- 姓名拼音(全拼和首字母)
+  email VARCHAR(100) NOT NULL,                     -- 电子邮箱
+  phone VARCHAR(20),                               -- 联系电话
+  major VARCHAR(100),                              -- 专业名称（自由填写，历史数据保留）
+  major_id BIGINT,                                 -- 专业ID（关联majors表）
+  remarks TEXT,                                    -- 备注信息
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  PRIMARY KEY (student_id)
+); -- 学生全文检索表
+
+-- 院系表
+CREATE TABLE IF NOT EXISTS colleges (
+  id BIGSERIAL,                                    -- 院系ID
+  university_id BIGINT NOT NULL,                   -- 大学ID（关联universities表）
+  name VARCHAR(100) NOT NULL,                      -- 院系名称
+  code VARCHAR(50),                                -- 院系代码
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  deleted_at TIMESTAMP,                            -- 删除时间
+  created_by BIGINT,                               -- 创建人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 院系表
+CREATE INDEX IF NOT EXISTS colleges_university_name ON colleges (university_id, name);
+
+-- 专业表
+CREATE TABLE IF NOT EXISTS majors (
+  id BIGSERIAL,                                    -- 专业ID
+  university_id BIGINT NOT NULL,                   -- 大学ID（关联universities表）
+  college_id BIGINT,                               -- 院系ID（关联colleges表）
+  name VARCHAR(100) NOT NULL,                      -- 专业名称
+  code VARCHAR(50),                                -- 专业代码
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  deleted_at TIMESTAMP,                            -- 删除时间
+  created_by BIGINT,                               -- 创建人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 专业表
+CREATE INDEX IF NOT EXISTS majors_university_name ON majors (university_id, name);
+CREATE INDEX IF NOT EXISTS majors_college_id ON majors (college_id);
+
+-- 操作审计日志表
+CREATE TABLE IF NOT EXISTS audit_logs (
+  id BIGSERIAL,                                    -- 日志ID
+  actor_id BIGINT,                                 -- 操作人ID
+  action VARCHAR(50) NOT NULL,                     -- 操作类型，如 university.merge
+  entity_type VARCHAR(50) NOT NULL,                -- 操作对象类型
+  entity_id BIGINT NOT NULL,                       -- 操作对象ID
+  detail TEXT,                                     -- 操作详情(JSON)
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  PRIMARY KEY (id)
+); -- 操作审计日志表
+CREATE INDEX IF NOT EXISTS audit_logs_entity ON audit_logs (entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS audit_logs_actor_id ON audit_logs (actor_id);
+
+-- 学生状态变更记录表
+CREATE TABLE IF NOT EXISTS student_status_histories (
+  id BIGSERIAL,                                    -- 记录ID
+  student_id BIGINT NOT NULL,                      -- 学生ID（关联students表）
+  from_status VARCHAR(10),                         -- 变更前状态
+  to_status VARCHAR(10) NOT NULL,                  -- 变更后状态
+  effective_date DATE NOT NULL,                    -- 生效日期
+  reason VARCHAR(500),                             -- 变更原因
+  approver_id BIGINT,                              -- 审批人ID（关联users表）
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  created_by BIGINT,                               -- 操作人ID
+  PRIMARY KEY (id)
+); -- 学生状态变更记录表
+CREATE INDEX IF NOT EXISTS student_status_histories_student_id ON student_status_histories (student_id);
+
+-- 学生信息变更申请表（退学、更换大学等敏感变更需要他人审批）
+CREATE TABLE IF NOT EXISTS student_change_requests (
+  id BIGSERIAL,                                    -- 申请ID
+  student_id BIGINT NOT NULL,                      -- 学生ID（关联students表）
+  changes TEXT NOT NULL,                           -- 变更内容(JSON)
+  reason VARCHAR(500),                             -- 申请原因
+  status VARCHAR(20) NOT NULL DEFAULT 'pending',   -- 申请状态：pending-待审批，approved-已通过，rejected-已驳回，cancelled-已撤回
+  requester_id BIGINT NOT NULL,                    -- 申请人ID（关联users表）
+  reviewer_id BIGINT,                              -- 审批人ID（关联users表）
+  review_comment VARCHAR(500),                     -- 审批意见
+  reviewed_at TIMESTAMP,                           -- 审批时间
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  PRIMARY KEY (id)
+); -- 学生信息变更申请表
+CREATE INDEX IF NOT EXISTS student_change_requests_status ON student_change_requests (status);
+CREATE INDEX IF NOT EXISTS student_change_requests_student_id ON student_change_requests (student_id);
+CREATE INDEX IF NOT EXISTS student_change_requests_requester_id ON student_change_requests (requester_id);
+
+-- 站内通知表
+CREATE TABLE IF NOT EXISTS notifications (
+  id BIGSERIAL,                                    -- 通知ID
+  user_id BIGINT NOT NULL,                         -- 接收人ID（关联users表）
+  type VARCHAR(50) NOT NULL,                       -- 通知类型，如 change_request.submitted
+  title VARCHAR(200) NOT NULL,                     -- 标题
+  content VARCHAR(1000),                           -- 内容
+  entity_type VARCHAR(50),                         -- 关联对象类型
+  entity_id BIGINT,                                -- 关联对象ID
+  read_at TIMESTAMP,                               -- 已读时间，为空表示未读
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  PRIMARY KEY (id)
+); -- 站内通知表
+CREATE INDEX IF NOT EXISTS notifications_user_read ON notifications (user_id, read_at);
+
+-- 课程表
+CREATE TABLE IF NOT EXISTS courses (
+  id BIGSERIAL,                                    -- 课程ID
+  university_id BIGINT NOT NULL,                   -- 所属大学ID（关联universities表）
+  code VARCHAR(50) NOT NULL,                       -- 课程代码，同一大学内唯一
+  title VARCHAR(200) NOT NULL,                     -- 课程名称
+  credits DECIMAL(4,1) NOT NULL,                   -- 学分
+  description VARCHAR(1000),                       -- 课程简介
+  grading_scale_id BIGINT,                         -- 评分标准ID（关联grading_scales表），为空时使用默认评分标准
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  deleted_at TIMESTAMP,                            -- 删除时间
+  created_by BIGINT,                               -- 创建人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 课程表
+CREATE INDEX IF NOT EXISTS courses_university_code ON courses (university_id, code);
+
+-- 教学班表（课程在某个学期的开课）
+CREATE TABLE IF NOT EXISTS course_sections (
+  id BIGSERIAL,                                    -- 教学班ID
+  course_id BIGINT NOT NULL,                       -- 课程ID（关联courses表）
+  term VARCHAR(20) NOT NULL,                       -- 学期，如 2024-2025-1
+  section_no VARCHAR(20) NOT NULL,                 -- 班号，同一课程同一学期内唯一
+  instructor VARCHAR(50),                          -- 任课教师
+  capacity INTEGER NOT NULL,                       -- 容量（最多选课人数）
+  schedule VARCHAR(200),                           -- 上课时间，如 1 08:00-09:40,3 10:00-11:40（星期 开始-结束）
+  location VARCHAR(100),                           -- 上课地点
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  deleted_at TIMESTAMP,                            -- 删除时间
+  created_by BIGINT,                               -- 创建人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 教学班表
+CREATE INDEX IF NOT EXISTS course_sections_course_term ON course_sections (course_id, term);
+CREATE INDEX IF NOT EXISTS course_sections_term ON course_sections (term);
+
+-- 选课记录表
+CREATE TABLE IF NOT EXISTS enrollments (
+  id BIGSERIAL,                                    -- 选课记录ID
+  student_id BIGINT NOT NULL,                      -- 学生ID（关联students表）
+  section_id BIGINT NOT NULL,                      -- 教学班ID（关联course_sections表）
+  status VARCHAR(20) NOT NULL DEFAULT 'enrolled',  -- 状态：enrolled-已选，dropped-已退，completed-已修完
+  enrolled_at TIMESTAMP,                           -- 选课时间
+  dropped_at TIMESTAMP,                            -- 退课时间
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  created_by BIGINT,                               -- 创建人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 选课记录表
+CREATE INDEX IF NOT EXISTS enrollments_student_section ON enrollments (student_id, section_id);
+CREATE INDEX IF NOT EXISTS enrollments_section_status ON enrollments (section_id, status);
+
+-- 评分标准表
+CREATE TABLE IF NOT EXISTS grading_scales (
+  id BIGSERIAL,                                    -- 评分标准ID
+  name VARCHAR(50) NOT NULL,                       -- 名称
+  type VARCHAR(20) NOT NULL,                       -- 类型：percentage-百分制，letter-等级制，five_point-五级制
+  is_default BOOLEAN NOT NULL DEFAULT FALSE,       -- 是否为默认评分标准（课程未指定时使用）
+  description VARCHAR(500),                        -- 说明
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  deleted_at TIMESTAMP,                            -- 删除时间
+  created_by BIGINT,                               -- 创建人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 评分标准表
+
+-- 评分标准等级表
+CREATE TABLE IF NOT EXISTS grading_scale_items (
+  id BIGSERIAL,                           -- 等级ID
+  scale_id BIGINT NOT NULL,               -- 评分标准ID（关联grading_scales表）
+  grade VARCHAR(10) NOT NULL,             -- 等级，如 A、B+、优秀
+  min_score DECIMAL(5,2),                 -- 百分制的分数下限（含），其他类型为空
+  grade_point DECIMAL(3,2) NOT NULL,      -- 绩点
+  passing BOOLEAN NOT NULL DEFAULT TRUE,  -- 是否及格
+  PRIMARY KEY (id)
+); -- 评分标准等级表
+CREATE INDEX IF NOT EXISTS grading_scale_items_scale_id ON grading_scale_items (scale_id);
+
+-- 课程成绩表
+CREATE TABLE IF NOT EXISTS grades (
+  id BIGSERIAL,                                    -- 成绩ID
+  student_id BIGINT NOT NULL,                      -- 学生ID（关联students表）
+  course_id BIGINT NOT NULL,                       -- 课程ID（关联courses表）
+  enrollment_id BIGINT,                            -- 选课记录ID（关联enrollments表），补录的成绩为空
+  term VARCHAR(20) NOT NULL,                       -- 学期
+  scale_id BIGINT NOT NULL,                        -- 评分标准ID（关联grading_scales表）
+  score DECIMAL(5,2),                              -- 百分制分数，等级制为空
+  grade VARCHAR(10) NOT NULL,                      -- 等级
+  grade_point DECIMAL(3,2) NOT NULL,               -- 绩点
+  credits DECIMAL(4,1) NOT NULL,                   -- 学分（录入时课程的学分）
+  passed BOOLEAN NOT NULL,                         -- 是否及格
+  remarks VARCHAR(500),                            -- 备注
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  created_by BIGINT,                               -- 录入人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 课程成绩表
+CREATE INDEX IF NOT EXISTS grades_student_term ON grades (student_id, term);
+CREATE INDEX IF NOT EXISTS grades_course_id ON grades (course_id);
+
+-- 已签发成绩单表（保存签发时的成绩单内容，可通过验证码核验）
+CREATE TABLE IF NOT EXISTS transcripts (
+  id BIGSERIAL,                             -- 成绩单ID
+  student_id BIGINT NOT NULL,               -- 学生ID（关联students表）
+  verification_code VARCHAR(32) NOT NULL,   -- 验证码
+  official BOOLEAN NOT NULL DEFAULT FALSE,  -- 是否为正式成绩单
+  content TEXT NOT NULL,                    -- 成绩单内容(JSON)
+  cumulative_gpa DECIMAL(4,2) NOT NULL,     -- 累计GPA
+  issued_by BIGINT,                         -- 签发人ID
+  issued_at TIMESTAMP,                      -- 签发时间
+  PRIMARY KEY (id)
+); -- 已签发成绩单表
+CREATE UNIQUE INDEX IF NOT EXISTS transcripts_verification_code ON transcripts (verification_code);
+CREATE INDEX IF NOT EXISTS transcripts_student_id ON transcripts (student_id);
+
+-- 课次表（教学班的每一次上课）
+CREATE TABLE IF NOT EXISTS class_sessions (
+  id BIGSERIAL,                                    -- 课次ID
+  section_id BIGINT NOT NULL,                      -- 教学班ID（关联course_sections表）
+  session_date DATE NOT NULL,                      -- 上课日期
+  start_time CHAR(5) NOT NULL,                     -- 开始时间，如 08:00
+  end_time CHAR(5) NOT NULL,                       -- 结束时间，如 09:40
+  topic VARCHAR(200),                              -- 授课内容
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  created_by BIGINT,                               -- 创建人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 课次表
+CREATE UNIQUE INDEX IF NOT EXISTS class_sessions_section_date_start ON class_sessions (section_id, session_date, start_time);
+
+-- 考勤记录表（同一课次同一学生只有一条，由服务层保证，便于合并学生时迁移）
+CREATE TABLE IF NOT EXISTS attendance_records (
+  id BIGSERIAL,                                    -- 考勤记录ID
+  session_id BIGINT NOT NULL,                      -- 课次ID（关联class_sessions表）
+  student_id BIGINT NOT NULL,                      -- 学生ID（关联students表）
+  status VARCHAR(20) NOT NULL,                     -- 状态：present-出勤，absent-缺勤，late-迟到，excused-请假
+  remarks VARCHAR(255),                            -- 备注
+  marked_by BIGINT,                                -- 记录人ID
+  marked_at TIMESTAMP,                             -- 记录时间
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  PRIMARY KEY (id)
+); -- 考勤记录表
+CREATE INDEX IF NOT EXISTS attendance_records_session_student ON attendance_records (session_id, student_id);
+CREATE INDEX IF NOT EXISTS attendance_records_student_id ON attendance_records (student_id);
+
+-- 教师表（教师档案关联登录用户）
+CREATE TABLE IF NOT EXISTS teachers (
+  id BIGSERIAL,                                    -- 教师ID
+  user_id BIGINT NOT NULL,                         -- 用户ID（关联users表）
+  employee_no VARCHAR(50),                         -- 工号
+  name VARCHAR(50) NOT NULL,                       -- 姓名
+  title VARCHAR(50),                               -- 职称，如 教授、副教授、讲师
+  university_id BIGINT,                            -- 所属大学ID（关联universities表）
+  college_id BIGINT,                               -- 所属院系ID（关联colleges表）
+  email VARCHAR(100),                              -- 联系邮箱
+  phone VARCHAR(20),                               -- 联系电话
+  status VARCHAR(10) NOT NULL DEFAULT '在职',        -- 状态：在职、离职
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  created_by BIGINT,                               -- 创建人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 教师表
+CREATE UNIQUE INDEX IF NOT EXISTS teachers_user_id ON teachers (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS teachers_employee_no ON teachers (employee_no);
+CREATE INDEX IF NOT EXISTS teachers_college_id ON teachers (college_id);
+
+-- 导师分配表（每次分配一条记录，结束日期为空表示仍在指导，历史记录保留）
+CREATE TABLE IF NOT EXISTS advisor_assignments (
+  id BIGSERIAL,                                    -- 分配记录ID
+  student_id BIGINT NOT NULL,                      -- 学生ID（关联students表）
+  teacher_id BIGINT NOT NULL,                      -- 导师ID（关联teachers表）
+  start_date DATE NOT NULL,                        -- 开始日期（含）
+  end_date DATE,                                   -- 结束日期（不含），为空表示仍在指导
+  reason VARCHAR(500),                             -- 分配原因
+  end_reason VARCHAR(500),                         -- 结束原因
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  created_by BIGINT,                               -- 分配人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 导师分配表
+CREATE INDEX IF NOT EXISTS advisor_assignments_student_start ON advisor_assignments (student_id, start_date);
+CREATE INDEX IF NOT EXISTS advisor_assignments_teacher_dates ON advisor_assignments (teacher_id, start_date, end_date);
+
+-- 企业表
+CREATE TABLE IF NOT EXISTS companies (
+  id BIGSERIAL,                                    -- 企业ID
+  name VARCHAR(100) NOT NULL,                      -- 企业名称
+  industry VARCHAR(50),                            -- 所属行业
+  city VARCHAR(50),                                -- 所在城市
+  website VARCHAR(255),                            -- 官网地址
+  contact_name VARCHAR(50),                        -- 联系人
+  contact_email VARCHAR(100),                      -- 联系人邮箱
+  contact_phone VARCHAR(20),                       -- 联系人电话
+  description TEXT,                                -- 企业简介
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  created_by BIGINT,                               -- 创建人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 企业表
+CREATE UNIQUE INDEX IF NOT EXISTS companies_name ON companies (name);
+
+-- 招聘岗位表（全职岗位和实习岗位）
+CREATE TABLE IF NOT EXISTS job_postings (
+  id BIGSERIAL,                                    -- 岗位ID
+  company_id BIGINT NOT NULL,                      -- 企业ID（关联companies表）
+  title VARCHAR(100) NOT NULL,                     -- 岗位名称
+  type VARCHAR(20) NOT NULL,                       -- 岗位类型：job-全职，internship-实习
+  city VARCHAR(50),                                -- 工作城市
+  salary VARCHAR(50),                              -- 薪资范围，如 10k-15k
+  description TEXT,                                -- 岗位描述
+  status VARCHAR(20) NOT NULL DEFAULT 'open',      -- 状态：open-招聘中，closed-已关闭
+  deadline DATE,                                   -- 截止日期（含），为空表示不限
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  created_by BIGINT,                               -- 创建人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 招聘岗位表
+CREATE INDEX IF NOT EXISTS job_postings_company_id ON job_postings (company_id);
+CREATE INDEX IF NOT EXISTS job_postings_type_status ON job_postings (type, status);
+
+-- 岗位申请表（同一学生同一岗位只有一条，由服务层保证，便于合并学生时迁移）
+CREATE TABLE IF NOT EXISTS job_applications (
+  id BIGSERIAL,                                    -- 申请ID
+  posting_id BIGINT NOT NULL,                      -- 岗位ID（关联job_postings表）
+  student_id BIGINT NOT NULL,                      -- 学生ID（关联students表）
+  status VARCHAR(20) NOT NULL DEFAULT 'applied',   -- 状态：applied-已投递，interview-面试中，offer-已发offer，accepted-已接受，rejected-未通过
+  applied_at TIMESTAMP NOT NULL,                   -- 投递时间
+  status_changed_at TIMESTAMP,                     -- 最近一次状态变更时间
+  remarks VARCHAR(500),                            -- 备注
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  created_by BIGINT,                               -- 创建人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 岗位申请表
+CREATE INDEX IF NOT EXISTS job_applications_posting_status ON job_applications (posting_id, status);
+CREATE INDEX IF NOT EXISTS job_applications_student_id ON job_applications (student_id);
+
+-- 毕业去向表（每名学生一条，由服务层保证）
+CREATE TABLE IF NOT EXISTS employment_records (
+  id BIGSERIAL,                                    -- 记录ID
+  student_id BIGINT NOT NULL,                      -- 学生ID（关联students表）
+  outcome VARCHAR(20) NOT NULL,                    -- 去向：employed-就业，further_study-升学，self_employed-自主创业，unemployed-待就业
+  company_id BIGINT,                               -- 就业企业ID（关联companies表）
+  organization VARCHAR(100),                       -- 单位或升学院校名称，未登记企业时填写
+  position VARCHAR(100),                           -- 职位或专业
+  city VARCHAR(50),                                -- 所在城市
+  monthly_salary DECIMAL(10,2),                    -- 月薪（元）
+  start_date DATE,                                 -- 入职或入学日期
+  application_id BIGINT,                           -- 来源岗位申请ID（关联job_applications表）
+  remarks VARCHAR(500),                            -- 备注
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,  -- 更新时间
+  created_by BIGINT,                               -- 创建人ID
+  updated_by BIGINT,                               -- 最后修改人ID
+  PRIMARY KEY (id)
+); -- 毕业去向表
+CREATE INDEX IF NOT EXISTS employment_records_student_id ON employment_records (student_id);
+CREATE INDEX IF NOT EXISTS employment_records_company_id ON employment_records (company_id);
+CREATE INDEX IF NOT EXISTS employment_records_outcome ON employment_records (outcome);
+
+-- 默认评分标准：百分制（4分制绩点）、等级制、五级制
+INSERT INTO grading_scales (id, name, type, is_default, description) VALUES
+  (1, '百分制', 'percentage', TRUE, '按分数换算等级和绩点（4分制）'),
+  (2, '等级制', 'letter', FALSE, '直接录入 A-F 等级'),
+  (3, '五级制', 'five_point', FALSE, '优秀、良好、中等、及格、不及格');
+INSERT INTO grading_scale_items (scale_id, grade, min_score, grade_point, passing) VALUES
+  (1, 'A', 90, 4.0, TRUE),
+  (1, 'A-', 85, 3.7, TRUE),
+  (1, 'B+', 82, 3.3, TRUE),
+  (1, 'B', 78, 3.0, TRUE),
+  (1, 'B-', 75, 2.7, TRUE),
+  (1, 'C+', 72, 2.3, TRUE),
+  (1, 'C', 68, 2.0, TRUE),
+  (1, 'C-', 64, 1.5, TRUE),
+  (1, 'D', 60, 1.0, TRUE),
+  (1, 'F', 0, 0, FALSE),
+  (2, 'A', NULL, 4.0, TRUE),
+  (2, 'A-', NULL, 3.7, TRUE),
+  (2, 'B+', NULL, 3.3, TRUE),
+  (2, 'B', NULL, 3.0, TRUE),
+  (2, 'B-', NULL, 2.7, TRUE),
+  (2, 'C+', NULL, 2.3, TRUE),
+  (2, 'C', NULL, 2.0, TRUE),
+  (2, 'C-', NULL, 1.5, TRUE),
+  (2, 'D', NULL, 1.0, TRUE),
+  (2, 'F', NULL, 0, FALSE),
+  (3, '优秀', NULL, 4.0, TRUE),
+  (3, '良好', NULL, 3.0, TRUE),
+  (3, '中等', NULL, 2.0, TRUE),
+  (3, '及格', NULL, 1.0, TRUE),
+  (3, '不及格', NULL, 0, FALSE);
+-- 显式写入了ID，需要同步自增序列
+SELECT setval(pg_get_serial_sequence('grading_scales', 'id'), (SELECT MAX(id) FROM grading_scales));
@@ -0,0 +1,3 @@
+-- 回滚 audit_log_request_id
+DROP INDEX IF EXISTS audit_logs_request_id;
+ALTER TABLE audit_logs DROP COLUMN request_id;
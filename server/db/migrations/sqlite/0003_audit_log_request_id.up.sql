@@ -0,0 +1,3 @@
+-- 审计日志记录产生该操作的请求ID，便于与请求日志关联
+ALTER TABLE audit_logs ADD COLUMN request_id VARCHAR(64); -- 请求ID
+CREATE INDEX IF NOT EXISTS audit_logs_request_id ON audit_logs (request_id);
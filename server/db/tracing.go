@@ -0,0 +1,33 @@
+package db
+
+import (
+	"regexp"
+
+	"github.com/uptrace/opentelemetry-go-extra/otelgorm"
+	"gorm.io/gorm"
+)
+
+// NewTracingPlugin 创建 GORM 链路追踪插件（otelgorm），为每条 SQL 创建 span，父 span 为 ctx 中的服务方法或请求 span
+// db.statement 不包含参数值，Raw/Exec 中直接写入的字面量由 SanitizeSQL 替换
+func NewTracingPlugin(dbName string) gorm.Plugin {
+	return otelgorm.NewPlugin(
+		otelgorm.WithDBName(dbName),
+		otelgorm.WithoutQueryVariables(),
+		otelgorm.WithQueryFormatter(SanitizeSQL),
+		otelgorm.WithoutMetrics(),
+	)
+}
+
+var (
+	// sqlStringLiteral 单引号字符串，'' 为转义的单引号
+	sqlStringLiteral = regexp.MustCompile(`'(?:[^']|'')*'`)
+	// sqlNumberLiteral 独立的数字，不匹配标识符中的数字（如 t1）和 PostgreSQL 占位符（如 $1）
+	sqlNumberLiteral = regexp.MustCompile(`(^|[^\w$])\d+(?:\.\d+)?\b`)
+)
+
+// SanitizeSQL 把 SQL 中的字符串和数字字面量替换为 ?，span 中不记录具体的数据
+// GORM 生成的 SQL 已使用占位符，这里主要处理 Raw/Exec 中直接写入的值
+func SanitizeSQL(sql string) string {
+	sql = sqlStringLiteral.ReplaceAllString(sql, "?")
+	return sqlNumberLiteral.ReplaceAllString(sql, "${1}?")
+}
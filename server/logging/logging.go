@@ -0,0 +1,177 @@
+// Package logging 基于 log/slog 的结构化日志：
+// 按组件设置日志级别，每个请求一个携带请求ID、用户ID和路由的日志记录器，输出前隐藏密码、令牌等敏感字段。
+package logging
+
+import (
+	"context"
+	"io"
+	"log"
+	"log/slog"
+	"mvc-demo/config"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// ComponentKey 组件名的日志字段，按组件设置的日志级别根据该字段生效
+const ComponentKey = "component"
+
+// 组件名
+const (
+	ComponentHTTP    = "http"    // 访问日志
+	ComponentDB      = "db"      // 数据库连接和 SQL
+	ComponentServer  = "server"  // 服务启动和停止
+	ComponentSearch  = "search"  // 搜索索引
+	ComponentMigrate = "migrate" // 数据库迁移
+	ComponentTracing = "tracing" // 链路追踪导出
+)
+
+// redactedValue 敏感字段输出时的替代值
+const redactedValue = "******"
+
+// sensitiveKeys 字段名包含这些词（不区分大小写）时隐藏字段值
+var sensitiveKeys = []string{"password", "passwd", "secret", "token", "authorization", "cookie", "api_key"}
+
+// levels 全局级别和按组件设置的级别
+type levels struct {
+	level      slog.Level
+	components map[string]slog.Level
+}
+
+// forComponent 组件的日志级别
+func (l *levels) forComponent(component string) slog.Level {
+	if level, ok := l.components[component]; ok {
+		return level
+	}
+	return l.level
+}
+
+// handler 按组件过滤日志级别的 slog.Handler
+// 通过 With(ComponentKey, name) 设置组件后，该日志记录器使用组件的级别
+type handler struct {
+	next   slog.Handler
+	level  slog.Level
+	levels *levels
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level && h.next.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, record slog.Record) error {
+	return h.next.Handle(ctx, record)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	level := h.level
+	for _, attr := range attrs {
+		if attr.Key == ComponentKey {
+			level = h.levels.forComponent(attr.Value.String())
+		}
+	}
+	return &handler{next: h.next.WithAttrs(attrs), level: level, levels: h.levels}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{next: h.next.WithGroup(name), level: h.level, levels: h.levels}
+}
+
+// root 当前的根日志记录器，Setup 之前为 slog 默认记录器
+var root atomic.Pointer[slog.Logger]
+
+// Setup 按配置创建根日志记录器，输出到标准输出，并设置为 slog 和标准库 log 的默认记录器
+// 配置已由 config 包校验，无法识别的级别按 info 处理
+func Setup(cfg config.LogConfig) {
+	SetupWithWriter(cfg, os.Stdout)
+}
+
+// SetupWithWriter 与 Setup 相同，日志输出到 w
+func SetupWithWriter(cfg config.LogConfig, w io.Writer) {
+	lv := &levels{level: parseLevel(cfg.Level), components: make(map[string]slog.Level, len(cfg.Levels))}
+	for component, level := range cfg.Levels {
+		lv.components[component] = parseLevel(level)
+	}
+
+	// 级别由 handler 按组件过滤，底层输出不再过滤
+	options := &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: redact}
+	var next slog.Handler
+	if cfg.Format == "json" {
+		next = slog.NewJSONHandler(w, options)
+	} else {
+		next = slog.NewTextHandler(w, options)
+	}
+
+	logger := slog.New(&handler{next: next, level: lv.level, levels: lv})
+	root.Store(logger)
+	slog.SetDefault(logger)
+	// 标准库 log 的输出（如第三方库）作为 info 级别日志
+	log.SetFlags(0)
+}
+
+// Logger 返回根日志记录器
+func Logger() *slog.Logger {
+	if logger := root.Load(); logger != nil {
+		return logger
+	}
+	return slog.Default()
+}
+
+// Component 返回组件的日志记录器，使用组件的日志级别
+func Component(name string) *slog.Logger {
+	return Logger().With(ComponentKey, name)
+}
+
+// loggerKey 请求日志记录器在 context 中的键
+type loggerKey struct{}
+
+// WithLogger 返回携带日志记录器的 context
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext 返回 ctx 中的请求日志记录器（带请求ID、用户ID、路由），不在请求中时返回根日志记录器
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return Logger()
+}
+
+// With 在 ctx 的日志记录器上追加字段，返回新的 context
+func With(ctx context.Context, args ...any) context.Context {
+	return WithLogger(ctx, FromContext(ctx).With(args...))
+}
+
+// parseLevel 解析日志级别，无法识别时返回 info
+func parseLevel(s string) slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// redact 隐藏敏感字段的值
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	if attr.Value.Kind() != slog.KindGroup && IsSensitive(attr.Key) {
+		return slog.String(attr.Key, redactedValue)
+	}
+	return attr
+}
+
+// IsSensitive 字段名是否为敏感字段（密码、密钥、令牌等）
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// Fatal 记录 error 级别日志后退出进程，用于启动阶段无法继续的错误
+func Fatal(logger *slog.Logger, msg string, args ...any) {
+	logger.Error(msg, args...)
+	os.Exit(1)
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mvc-demo/config"
+	"strings"
+	"testing"
+)
+
+// setupBuffer 把日志以 JSON 格式输出到缓冲区，测试结束后恢复默认输出
+func setupBuffer(t *testing.T, cfg config.LogConfig) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	cfg.Format = "json"
+	SetupWithWriter(cfg, &buf)
+	t.Cleanup(func() { Setup(config.LogConfig{Level: "info"}) })
+	return &buf
+}
+
+// records 解析缓冲区中的每一行日志
+func records(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var result []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("无法解析日志 %q: %v", line, err)
+		}
+		result = append(result, record)
+	}
+	return result
+}
+
+func TestRedact(t *testing.T) {
+	buf := setupBuffer(t, config.LogConfig{Level: "info"})
+	Logger().Info("登录",
+		"email", "user@example.com",
+		"password", "secret123",
+		"Authorization", "Bearer abc",
+		"refresh_token", "def",
+		"request", map[string]string{"path": "/api/login"},
+	)
+	Logger().WithGroup("body").Info("请求体", "new_password", "secret456")
+
+	output := buf.String()
+	for _, value := range []string{"secret123", "Bearer abc", "def", "secret456"} {
+		if strings.Contains(output, value) {
+			t.Errorf("日志中出现了敏感值 %q: %s", value, output)
+		}
+	}
+	logs := records(t, buf)
+	if logs[0]["email"] != "user@example.com" || logs[0]["password"] != redactedValue {
+		t.Errorf("普通字段应原样输出，敏感字段应隐藏: %v", logs[0])
+	}
+}
+
+func TestComponentLevels(t *testing.T) {
+	buf := setupBuffer(t, config.LogConfig{Level: "warn", Levels: map[string]string{ComponentDB: "debug", ComponentHTTP: "error"}})
+
+	Logger().Info("全局 info")
+	Logger().Warn("全局 warn")
+	Component(ComponentDB).Debug("db debug")
+	Component(ComponentHTTP).Warn("http warn")
+	Component(ComponentHTTP).Error("http error")
+	Component(ComponentSearch).Info("search info")
+	// 请求日志记录器上再标记组件时同样使用组件的级别
+	FromContext(With(context.Background(), "request_id", "r1")).With(ComponentKey, ComponentDB).Debug("request db debug")
+
+	var got []string
+	for _, record := range records(t, buf) {
+		got = append(got, record["msg"].(string))
+	}
+	want := []string{"全局 warn", "db debug", "http error", "request db debug"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("输出的日志为 %v，期望 %v", got, want)
+	}
+}
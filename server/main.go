@@ -1,111 +1,205 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"mvc-demo/app"
 	"mvc-demo/config"
-	"mvc-demo/controllers"
-	"mvc-demo/dao"
 	"mvc-demo/db"
+	"mvc-demo/logging"
+	"mvc-demo/metrics"
 	"mvc-demo/routes"
+	"mvc-demo/search"
 	"mvc-demo/service"
+	"mvc-demo/tracing"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
-// 应用依赖
-type AppDependencies struct {
-	DB                   *gorm.DB
-	UserDAO              *dao.UserDAO
-	UniversityDAO        *dao.UniversityDAO
-	StudentDAO           *dao.StudentDAO
-	UserService          *service.UserService
-	UniversityService    *service.UniversityService
-	StudentService       *service.StudentService
-	userController       *controllers.UserController
-	authController       *controllers.AuthController
-	universityController *controllers.UniversityController
-	studentController    *controllers.StudentController
-}
+// tracingShutdownTimeout 停止时导出剩余追踪数据的最长等待时间
+const tracingShutdownTimeout = 5 * time.Second
 
-// GetUserController 获取用户控制器
-func (d *AppDependencies) GetUserController() *controllers.UserController {
-	if d.userController == nil {
-		d.userController = controllers.NewUserController(d.UserService)
+func main() {
+	// 命令行子命令，如 ./main reindex
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		runCommand(os.Args[1], os.Args[2:])
+		return
 	}
-	return d.userController
-}
 
-// GetAuthController 获取认证控制器
-func (d *AppDependencies) GetAuthController() *controllers.AuthController {
-	if d.authController == nil {
-		d.authController = controllers.NewAuthController(d.UserService)
+	// 加载配置，其余参数为配置项，如 ./main -config config.yaml -server.port :9090
+	appConfig, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
 	}
-	return d.authController
-}
-
-// GetUniversityController 获取大学控制器
-func (d *AppDependencies) GetUniversityController() *controllers.UniversityController {
-	if d.universityController == nil {
-		d.universityController = controllers.NewUniversityController(d.UniversityService)
+	if err != nil {
+		log.Fatalf("%v", err)
 	}
-	return d.universityController
-}
 
-// GetStudentController 获取学生控制器
-func (d *AppDependencies) GetStudentController() *controllers.StudentController {
-	if d.studentController == nil {
-		d.studentController = controllers.NewStudentController(d.StudentService, d.UniversityService)
+	// 初始化结构化日志，之后的日志按 LOG_FORMAT 输出
+	logging.Setup(appConfig.Log)
+	logger := logging.Component(logging.ComponentServer)
+	if files := config.EnvFiles(); len(files) > 0 {
+		logger.Info("已加载环境变量文件", "files", files)
 	}
-	return d.studentController
-}
 
-func main() {
-	// 获取配置
-	appConfig := config.GetConfig()
+	// 初始化链路追踪，未配置 OTEL_TRACES_EXPORTER 时不启用
+	shutdownTracing, err := tracing.Setup(appConfig.Tracing)
+	if err != nil {
+		logging.Fatal(logger, "初始化链路追踪失败", "error", err)
+	}
 
 	// 初始化数据库连接
-	db.InitDB()
-	log.Println("数据库初始化完成")
+	db.InitDB(appConfig)
+	if sqlDB, err := db.DB.DB(); err == nil {
+		metrics.RegisterDB(sqlDB, appConfig.DB.Name)
+	}
+
+	// 自动执行数据库迁移
+	if appConfig.DB.AutoMigrate {
+		if err := db.MigrateUp(db.DB); err != nil {
+			logging.Fatal(logger, "数据库迁移失败", "error", err)
+		}
+	}
+
+	// 打开搜索索引
+	index := openSearchIndex(appConfig)
 
 	// 初始化依赖
-	deps := initDependencies(db.DB)
+	deps := app.NewDependencies(db.DB, app.NewRepositories(db.DB), index, appConfig)
 
 	// 设置Gin模式
-	gin.SetMode(appConfig.Mode)
+	gin.SetMode(appConfig.Server.Mode)
 
 	// 设置路由
 	r := routes.SetupRouter(deps)
 
+	server, err := app.NewServer(r, appConfig)
+	if err != nil {
+		logging.Fatal(logger, "加载TLS证书失败", "error", err)
+	}
+
+	// SIGINT、SIGTERM 触发优雅停止，SIGHUP 重新加载TLS证书
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go reloadCertificateOnSIGHUP(ctx, server)
+
+	// 索引为空时（首次启动或索引目录被清理）在后台重建，收到停止信号时中止
+	indexBuilt := make(chan struct{})
+	go func() {
+		defer close(indexBuilt)
+		buildSearchIndexIfEmpty(ctx, deps.StudentSearchService)
+	}()
+
+	// 监控指标使用独立端口时单独启动，随主服务一起停止
+	if appConfig.Metrics.Enabled && appConfig.Metrics.Port != "" {
+		go runMetricsServer(ctx, app.NewMetricsServer(appConfig), appConfig.Metrics.Path)
+	}
+
 	// 日志输出
-	log.Printf("服务器启动于 %s 端口，运行模式: %s\n", appConfig.ServerPort, appConfig.Mode)
+	logger.Info("服务器启动", "addr", appConfig.Server.Port, "mode", appConfig.Server.Mode, "https", server.TLSEnabled())
 
-	// 启动服务器
-	err := r.Run(appConfig.ServerPort)
+	// 启动服务器，收到停止信号后等待进行中的请求完成
+	runErr := server.Run(ctx)
+
+	// 请求处理完毕、后台重建索引结束后关闭搜索索引和数据库连接池
+	<-indexBuilt
+	if err := index.Close(); err != nil {
+		logger.Error("关闭搜索索引失败", "error", err)
+	}
+	if err := db.Close(); err != nil {
+		logger.Error("关闭数据库连接失败", "error", err)
+	}
+	// 导出尚未发送的追踪数据
+	tracingCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	if err := shutdownTracing(tracingCtx); err != nil {
+		logger.Error("导出追踪数据失败", "error", err)
+	}
+	cancel()
+	if runErr != nil {
+		logging.Fatal(logger, "服务器异常退出", "error", runErr)
+	}
+	logger.Info("服务器已停止")
+}
+
+// reloadCertificateOnSIGHUP 收到 SIGHUP 时重新加载TLS证书，直到 ctx 取消
+func reloadCertificateOnSIGHUP(ctx context.Context, server *app.Server) {
+	logger := logging.Component(logging.ComponentServer)
+	if files := config.EnvFiles(); len(files) > 0 {
+		logger.Info("已加载环境变量文件", "files", files)
+	}
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			if !server.TLSEnabled() {
+				logger.Info("收到 SIGHUP，未启用 HTTPS，忽略")
+				continue
+			}
+			if err := server.ReloadCertificate(); err != nil {
+				logger.Error("重新加载TLS证书失败，继续使用原证书", "error", err)
+				continue
+			}
+			logger.Info("TLS证书已重新加载")
+		}
+	}
+}
+
+// runMetricsServer 运行独立端口的监控指标服务，启动失败只记录日志，不影响主服务
+func runMetricsServer(ctx context.Context, server *app.Server, path string) {
+	logger := logging.Component(logging.ComponentServer)
+	if files := config.EnvFiles(); len(files) > 0 {
+		logger.Info("已加载环境变量文件", "files", files)
+	}
+	logger.Info("监控指标服务启动", "addr", server.Addr(), "path", path)
+	if err := server.Run(ctx); err != nil {
+		logger.Error("监控指标服务异常退出", "addr", server.Addr(), "error", err)
+	}
+}
+
+// openSearchIndex 按配置打开学生搜索索引
+func openSearchIndex(appConfig *config.AppConfig) search.Index {
+	index, err := search.Open(appConfig.Search.Engine, appConfig.Search.IndexPath, db.DB)
+	logger := logging.Component(logging.ComponentSearch)
 	if err != nil {
-		log.Fatalf("服务器启动失败: %v", err)
+		logging.Fatal(logger, "打开搜索索引失败", "engine", appConfig.Search.Engine, "error", err)
 	}
+	logger.Info("搜索索引已打开", "engine", appConfig.Search.Engine, "path", appConfig.Search.IndexPath)
+	return index
 }
 
-// 初始化依赖
-func initDependencies(db *gorm.DB) *AppDependencies {
-	// 初始化DAO
-	userDAO := dao.NewUserDAO(db)
-	universityDAO := dao.NewUniversityDAO(db)
-	studentDAO := dao.NewStudentDAO(db)
-
-	// 初始化服务
-	userService := service.NewUserService(userDAO)
-	universityService := service.NewUniversityService(universityDAO)
-	studentService := service.NewStudentService(studentDAO)
-
-	return &AppDependencies{
-		DB:                db,
-		UserDAO:           userDAO,
-		UniversityDAO:     universityDAO,
-		StudentDAO:        studentDAO,
-		UserService:       userService,
-		UniversityService: universityService,
-		StudentService:    studentService,
+// buildSearchIndexIfEmpty 索引为空时重建索引，ctx 取消时中止
+func buildSearchIndexIfEmpty(ctx context.Context, searchService *service.StudentSearchService) {
+	logger := logging.Component(logging.ComponentSearch)
+	empty, err := searchService.IsEmpty(ctx)
+	if err != nil {
+		logger.Error("检查搜索索引失败", "error", err)
+		return
+	}
+	if !empty {
+		return
+	}
+
+	count, err := searchService.Reindex(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			// 中止后索引只包含部分学生，不再为空，下次启动不会自动重建
+			logger.Warn("服务停止，搜索索引重建已中止，请执行 reindex 子命令重建", "indexed", count, "error", err)
+			return
+		}
+		logger.Error("重建搜索索引失败", "error", err)
+		return
 	}
+	logger.Info("搜索索引重建完成", "count", count)
 }
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Default 服务使用的指标注册表，已注册 HTTP、登录、Go 运行时和进程指标，数据库连接池指标在连接数据库后注册
+// 不使用 prometheus.DefaultRegisterer，避免依赖库注册的指标混入
+var Default = prometheus.NewRegistry()
+
+// 业务指标
+var (
+	// HTTPRequests 请求数，code 为响应体中的业务响应码（HTTP 状态码始终为 200，需按 code 区分成功和失败）
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP 请求数",
+	}, []string{"method", "route", "status", "code"})
+	// HTTPRequestDuration 请求耗时
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP 请求耗时（秒）",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "code"})
+	// LoginAttempts 登录次数，account 为 user 或 student，result 为 success 或 failure
+	LoginAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_login_attempts_total",
+		Help: "登录次数",
+	}, []string{"account", "result"})
+)
+
+func init() {
+	Default.MustRegister(
+		HTTPRequests, HTTPRequestDuration, LoginAttempts,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// RecordLogin 记录一次登录结果
+func RecordLogin(account string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	LoginAttempts.WithLabelValues(account, result).Inc()
+}
+
+// RegisterDB 注册数据库连接池指标（go_sql_*），dbName 作为 db_name 标签
+func RegisterDB(db *sql.DB, dbName string) {
+	Default.MustRegister(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// Handler 输出 registry 中全部指标的 HTTP 处理器
+// token 不为空时要求请求头 Authorization: Bearer <token>，否则返回 401
+func Handler(registry *prometheus.Registry, token string) http.Handler {
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" && !validToken(r.Header.Get("Authorization"), token) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// validToken 以固定时间比较令牌，避免通过响应时间猜测令牌
+func validToken(header, token string) bool {
+	const prefix = "Bearer "
+	if len(header) <= len(prefix) || header[:len(prefix)] != prefix {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(header[len(prefix):]), []byte(token)) == 1
+}
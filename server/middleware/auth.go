@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"mvc-demo/logging"
 	"mvc-demo/utils"
 	"strings"
 
@@ -46,6 +47,10 @@ func JWTAuth() gin.HandlerFunc {
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
 		c.Set("role", claims.Role)
+		// 操作人写入请求 ctx，供服务层记录审计日志，请求日志记录器追加用户ID
+		ctx := utils.WithActorID(c.Request.Context(), int64(claims.UserID))
+		ctx = logging.With(ctx, "user_id", claims.UserID)
+		c.Request = c.Request.WithContext(ctx)
 
 		c.Next()
 	}
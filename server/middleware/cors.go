@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"mvc-demo/config"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORS 跨域中间件，只为允许的来源设置跨域响应头，预检请求直接返回 204
+func CORS(cfg config.CORSConfig) gin.HandlerFunc {
+	allowAll := false
+	origins := make(map[string]bool, len(cfg.AllowOrigins))
+	for _, origin := range cfg.AllowOrigins {
+		if origin == "*" {
+			allowAll = true
+		}
+		origins[strings.TrimSuffix(origin, "/")] = true
+	}
+	allowMethods := strings.Join(cfg.AllowMethods, ", ")
+	allowHeaders := strings.Join(cfg.AllowHeaders, ", ")
+	exposeHeaders := strings.Join(cfg.ExposeHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" || !(allowAll || origins[origin]) {
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		header.Add("Vary", "Origin")
+		if allowAll {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+		if cfg.AllowCredentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+		if exposeHeaders != "" {
+			header.Set("Access-Control-Expose-Headers", exposeHeaders)
+		}
+
+		// 预检请求
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			header.Set("Access-Control-Allow-Methods", allowMethods)
+			header.Set("Access-Control-Allow-Headers", allowHeaders)
+			header.Set("Access-Control-Max-Age", maxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"log/slog"
+	"mvc-demo/logging"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessLog 访问日志中间件，请求结束后按 http 组件记录一条日志
+// 字段包括请求ID、用户ID、路由、状态码、业务响应码和耗时；5xx 记为 warn，其余记为 info
+// 需注册在 RequestContext 之后，才能取到请求日志记录器
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		// 使用处理结束后的 ctx，JWTAuth 追加的用户ID也会记录
+		logger := logging.FromContext(c.Request.Context()).With(logging.ComponentKey, logging.ComponentHTTP)
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelWarn
+		}
+		attrs := []slog.Attr{
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.String("code", responseCode(c)),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("size", c.Writer.Size()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", c.Errors.String()))
+		}
+		logger.LogAttrs(c.Request.Context(), level, "请求完成", attrs...)
+	}
+}
+
+// Recovery 恢复处理请求时的 panic，记录错误和调用栈后返回 500
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if err := recover(); err != nil {
+				logging.FromContext(c.Request.Context()).Error("请求处理发生 panic",
+					"error", err,
+					"path", c.Request.URL.Path,
+					"stack", string(debug.Stack()),
+				)
+				c.AbortWithStatus(http.StatusInternalServerError)
+			}
+		}()
+		c.Next()
+	}
+}
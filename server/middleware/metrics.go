@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"mvc-demo/metrics"
+	"mvc-demo/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics 监控指标中间件，按方法、路由、HTTP 状态码和业务响应码统计请求数和耗时
+// 路由使用注册时的模板（如 /api/students/:id），未匹配到路由的请求记为 unmatched，避免标签值过多
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		code := responseCode(c)
+		metrics.HTTPRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status()), code).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+// responseCode 业务响应码，未通过 utils.Response 响应（如文件下载）时为 none
+func responseCode(c *gin.Context) string {
+	if code, ok := c.Get(utils.ResponseCodeKey); ok {
+		if n, ok := code.(int); ok {
+			return strconv.Itoa(n)
+		}
+	}
+	return "none"
+}
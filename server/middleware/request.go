@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"mvc-demo/config"
+	"mvc-demo/logging"
+	"mvc-demo/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader 请求ID的请求头和响应头
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength 客户端传入的请求ID最大长度，超过时重新生成
+const maxRequestIDLength = 64
+
+// RequestContext 请求上下文中间件
+// 为每个请求设置请求ID（优先使用客户端传入的 X-Request-ID）并写入响应头，
+// 按路由设置查询超时，超时后 ctx 被取消，进行中的数据库查询随之中止，
+// 并在 ctx 中放入带请求ID、方法和路由的请求日志记录器（logging.FromContext）和请求内共享的批量加载器（utils.RequestLoader）
+func RequestContext(cfg config.RequestConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = utils.NewRequestID()
+		}
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		ctx := utils.WithRequestID(c.Request.Context(), requestID)
+		ctx = utils.WithLoaders(ctx)
+		ctx = logging.WithLogger(ctx, logging.Logger().With(
+			"request_id", requestID,
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+		))
+		if timeout := cfg.TimeoutFor(c.FullPath()); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"fmt"
+	"mvc-demo/logging"
+	"mvc-demo/tracing"
+	"mvc-demo/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceIDHeader 返回追踪ID的响应头，排查问题时可按追踪ID在追踪后端和日志中查找
+const TraceIDHeader = "X-Trace-ID"
+
+// Tracing 链路追踪中间件
+// otelgin 按请求头中的 traceparent（W3C Trace Context）沿用上游的追踪ID或新建追踪，为请求创建 server span；
+// 之后补充业务响应码等属性，为控制器方法创建子 span，追踪ID写入响应头和请求日志（trace_id）
+// 需注册在 RequestContext 之后、AccessLog 之前
+func Tracing(serviceName string) []gin.HandlerFunc {
+	return []gin.HandlerFunc{otelgin.Middleware(serviceName), requestSpan()}
+}
+
+// requestSpan 补充 otelgin 创建的 server span，并创建控制器 span
+func requestSpan() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		span := trace.SpanFromContext(ctx)
+		route := c.FullPath()
+		if route != "" {
+			span.SetName(c.Request.Method + " " + route)
+		} else {
+			span.SetName(c.Request.Method)
+		}
+		span.SetAttributes(attribute.String("request_id", c.GetString("request_id")))
+		if sc := span.SpanContext(); sc.IsValid() {
+			c.Header(TraceIDHeader, sc.TraceID().String())
+			ctx = logging.With(ctx, "trace_id", sc.TraceID().String())
+		}
+
+		// 控制器 span 以控制器方法命名，如 StudentController.Get，包含路由组中间件（如认证）的耗时
+		if route != "" {
+			var handlerSpan trace.Span
+			ctx, handlerSpan = tracing.Start(ctx, controllerName(c.HandlerName()),
+				trace.WithAttributes(attribute.String("code.function", c.HandlerName())))
+			defer handlerSpan.End()
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		span.SetAttributes(attribute.String("app.response_code", responseCode(c)))
+		// HTTP 状态码始终为 200，内部错误和超时通过业务响应码判断；HTTP 状态码和 c.Errors 由 otelgin 记录
+		if code, _ := c.Get(utils.ResponseCodeKey); code == utils.ERROR_INTERNAL || code == utils.ERROR_TIMEOUT {
+			span.SetStatus(codes.Error, fmt.Sprintf("响应码 %d", code))
+		}
+	}
+}
+
+// controllerName 从 gin 的处理函数名中取出控制器和方法名，
+// 如 mvc-demo/controllers.(*StudentController).Get-fm 返回 StudentController.Get
+func controllerName(handlerName string) string {
+	name := handlerName[strings.LastIndex(handlerName, "/")+1:]
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSuffix(name, "-fm")
+	return strings.NewReplacer("(*", "", ")", "").Replace(name)
+}
@@ -0,0 +1,78 @@
+package dto
+
+import "time"
+
+// 课次请求
+type SessionRequest struct {
+	SectionID   int64   `json:"section_id" binding:"required"`
+	SessionDate string  `json:"session_date" binding:"required,datetime=2006-01-02"` // 上课日期，格式 2006-01-02
+	StartTime   string  `json:"start_time" binding:"required"`                       // 开始时间，如 08:00
+	EndTime     string  `json:"end_time" binding:"required"`                         // 结束时间，如 09:40
+	Topic       *string `json:"topic,omitempty" binding:"omitempty,max=200"`
+}
+
+// 按上课时间批量生成课次请求
+type GenerateSessionsRequest struct {
+	StartDate string `json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate   string `json:"end_date" binding:"required,datetime=2006-01-02"`
+}
+
+// 课次响应
+type SessionResponse struct {
+	ID          int64      `json:"id"`
+	SectionID   int64      `json:"section_id"`
+	CourseCode  string     `json:"course_code"`
+	CourseTitle string     `json:"course_title"`
+	Term        string     `json:"term"`
+	SectionNo   string     `json:"section_no"`
+	SessionDate string     `json:"session_date"`
+	StartTime   string     `json:"start_time"`
+	EndTime     string     `json:"end_time"`
+	Topic       *string    `json:"topic"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	CreatedBy   *int64     `json:"created_by"`
+	UpdatedBy   *int64     `json:"updated_by"`
+}
+
+// 课次列表响应
+type SessionListResponse struct {
+	List  []*SessionResponse `json:"list"`
+	Total int64              `json:"total"`
+	Page  int                `json:"page"`
+	Size  int                `json:"size"`
+}
+
+// 单个学生的考勤
+type AttendanceMarkRequest struct {
+	StudentID int64   `json:"student_id" binding:"required"`
+	Status    string  `json:"status" binding:"required"` // present、absent、late、excused
+	Remarks   *string `json:"remarks,omitempty" binding:"omitempty,max=255"`
+}
+
+// 批量记录考勤请求
+type AttendanceRequest struct {
+	Records []*AttendanceMarkRequest `json:"records" binding:"required,min=1,dive"`
+}
+
+// 全部学生记为同一考勤状态请求
+type AttendanceAllRequest struct {
+	Status       string `json:"status" binding:"required"`
+	OnlyUnmarked bool   `json:"only_unmarked"` // 只记录还没有考勤记录的学生
+}
+
+// 点名册中一名学生的考勤
+type AttendanceResponse struct {
+	StudentID   int64      `json:"student_id"`
+	StudentName string     `json:"student_name"`
+	Status      *string    `json:"status"` // 还没有考勤记录时为空
+	Remarks     *string    `json:"remarks"`
+	MarkedBy    *int64     `json:"marked_by"`
+	MarkedAt    *time.Time `json:"marked_at"`
+}
+
+// 课次考勤响应
+type SessionAttendanceResponse struct {
+	Session *SessionResponse      `json:"session"`
+	Records []*AttendanceResponse `json:"records"`
+}
@@ -0,0 +1,160 @@
+package dto
+
+import "time"
+
+// 企业请求
+type CompanyRequest struct {
+	Name         string  `json:"name" binding:"required,max=100"`
+	Industry     *string `json:"industry,omitempty" binding:"omitempty,max=50"`
+	City         *string `json:"city,omitempty" binding:"omitempty,max=50"`
+	Website      *string `json:"website,omitempty" binding:"omitempty,url"`
+	ContactName  *string `json:"contact_name,omitempty" binding:"omitempty,max=50"`
+	ContactEmail *string `json:"contact_email,omitempty" binding:"omitempty,email"`
+	ContactPhone *string `json:"contact_phone,omitempty" binding:"omitempty,max=20"`
+	Description  *string `json:"description,omitempty"`
+}
+
+// 企业响应
+type CompanyResponse struct {
+	ID           int64      `json:"id"`
+	Name         string     `json:"name"`
+	Industry     *string    `json:"industry"`
+	City         *string    `json:"city"`
+	Website      *string    `json:"website"`
+	ContactName  *string    `json:"contact_name"`
+	ContactEmail *string    `json:"contact_email"`
+	ContactPhone *string    `json:"contact_phone"`
+	Description  *string    `json:"description"`
+	CreatedAt    *time.Time `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+	CreatedBy    *int64     `json:"created_by"`
+	UpdatedBy    *int64     `json:"updated_by"`
+}
+
+// 企业列表响应
+type CompanyListResponse struct {
+	List  []*CompanyResponse `json:"list"`
+	Total int64              `json:"total"`
+	Page  int                `json:"page"`
+	Size  int                `json:"size"`
+}
+
+// 招聘岗位请求
+type JobPostingRequest struct {
+	CompanyID   int64   `json:"company_id" binding:"required"`
+	Title       string  `json:"title" binding:"required,max=100"`
+	Type        string  `json:"type" binding:"required"` // job-全职，internship-实习
+	City        *string `json:"city,omitempty" binding:"omitempty,max=50"`
+	Salary      *string `json:"salary,omitempty" binding:"omitempty,max=50"`
+	Description *string `json:"description,omitempty"`
+	Status      string  `json:"status,omitempty"`                                           // open-招聘中，closed-已关闭，默认招聘中
+	Deadline    string  `json:"deadline,omitempty" binding:"omitempty,datetime=2006-01-02"` // 截止日期（含），为空表示不限
+}
+
+// 招聘岗位响应
+type JobPostingResponse struct {
+	ID          int64      `json:"id"`
+	CompanyID   int64      `json:"company_id"`
+	CompanyName string     `json:"company_name"`
+	Title       string     `json:"title"`
+	Type        string     `json:"type"`
+	City        *string    `json:"city"`
+	Salary      *string    `json:"salary"`
+	Description *string    `json:"description"`
+	Status      string     `json:"status"`
+	Deadline    *string    `json:"deadline"`
+	Open        bool       `json:"open"` // 当前是否可以申请
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	CreatedBy   *int64     `json:"created_by"`
+	UpdatedBy   *int64     `json:"updated_by"`
+}
+
+// 招聘岗位列表响应
+type JobPostingListResponse struct {
+	List  []*JobPostingResponse `json:"list"`
+	Total int64                 `json:"total"`
+	Page  int                   `json:"page"`
+	Size  int                   `json:"size"`
+}
+
+// 岗位申请请求
+type JobApplicationRequest struct {
+	PostingID int64   `json:"posting_id" binding:"required"`
+	StudentID int64   `json:"student_id" binding:"required"`
+	Remarks   *string `json:"remarks,omitempty" binding:"omitempty,max=500"`
+}
+
+// 岗位申请状态变更请求
+type ApplicationStatusRequest struct {
+	Status  string  `json:"status" binding:"required"` // interview、offer、accepted、rejected
+	Remarks *string `json:"remarks,omitempty" binding:"omitempty,max=500"`
+}
+
+// 岗位申请响应
+type JobApplicationResponse struct {
+	ID              int64      `json:"id"`
+	PostingID       int64      `json:"posting_id"`
+	PostingTitle    string     `json:"posting_title"`
+	PostingType     string     `json:"posting_type"`
+	CompanyID       int64      `json:"company_id"`
+	CompanyName     string     `json:"company_name"`
+	StudentID       int64      `json:"student_id"`
+	Status          string     `json:"status"`
+	AppliedAt       time.Time  `json:"applied_at"`
+	StatusChangedAt *time.Time `json:"status_changed_at"`
+	Remarks         *string    `json:"remarks"`
+	CreatedAt       *time.Time `json:"created_at"`
+	UpdatedAt       *time.Time `json:"updated_at"`
+	CreatedBy       *int64     `json:"created_by"`
+	UpdatedBy       *int64     `json:"updated_by"`
+}
+
+// 岗位申请列表响应
+type JobApplicationListResponse struct {
+	List  []*JobApplicationResponse `json:"list"`
+	Total int64                     `json:"total"`
+	Page  int                       `json:"page"`
+	Size  int                       `json:"size"`
+}
+
+// 毕业去向请求
+type EmploymentRecordRequest struct {
+	StudentID     int64    `json:"student_id" binding:"required"`
+	Outcome       string   `json:"outcome" binding:"required"` // employed、further_study、self_employed、unemployed
+	CompanyID     *int64   `json:"company_id,omitempty"`
+	Organization  *string  `json:"organization,omitempty" binding:"omitempty,max=100"` // 未登记企业的单位或升学院校名称
+	Position      *string  `json:"position,omitempty" binding:"omitempty,max=100"`
+	City          *string  `json:"city,omitempty" binding:"omitempty,max=50"`
+	MonthlySalary *float64 `json:"monthly_salary,omitempty" binding:"omitempty,gte=0"`
+	StartDate     string   `json:"start_date,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	Remarks       *string  `json:"remarks,omitempty" binding:"omitempty,max=500"`
+}
+
+// 毕业去向响应
+type EmploymentRecordResponse struct {
+	ID            int64      `json:"id"`
+	StudentID     int64      `json:"student_id"`
+	Outcome       string     `json:"outcome"`
+	CompanyID     *int64     `json:"company_id"`
+	CompanyName   *string    `json:"company_name"`
+	Organization  *string    `json:"organization"`
+	Position      *string    `json:"position"`
+	City          *string    `json:"city"`
+	MonthlySalary *float64   `json:"monthly_salary"`
+	StartDate     *string    `json:"start_date"`
+	ApplicationID *int64     `json:"application_id"`
+	Remarks       *string    `json:"remarks"`
+	CreatedAt     *time.Time `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+	CreatedBy     *int64     `json:"created_by"`
+	UpdatedBy     *int64     `json:"updated_by"`
+}
+
+// 毕业去向列表响应
+type EmploymentRecordListResponse struct {
+	List  []*EmploymentRecordResponse `json:"list"`
+	Total int64                       `json:"total"`
+	Page  int                         `json:"page"`
+	Size  int                         `json:"size"`
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// 学生变更内容，字段为空表示不修改
+type StudentChangeFields struct {
+	Name           *string              `json:"name,omitempty"`
+	Gender         *int32               `json:"gender,omitempty"`
+	Birthday       *time.Time           `json:"birthday,omitempty"`
+	Phone          *string              `json:"phone,omitempty"`
+	UniversityID   *int64               `json:"university_id,omitempty"`
+	Major          *string              `json:"major,omitempty"`
+	MajorID        *int64               `json:"major_id,omitempty"`
+	Education      *string              `json:"education,omitempty"`
+	GraduationYear *int64               `json:"graduation_year,omitempty"`
+	Remarks        *string              `json:"remarks,omitempty"`
+	Avatar         *string              `json:"avatar,omitempty"`
+	Status         *StudentChangeStatus `json:"status,omitempty"` // 状态变更，原因使用申请原因
+}
+
+// 变更申请中的状态变更
+type StudentChangeStatus struct {
+	Status        string `json:"status" binding:"required"`
+	EffectiveDate string `json:"effective_date" binding:"required,datetime=2006-01-02"` // 生效日期，格式 2006-01-02
+}
+
+// 提交学生变更申请请求
+type ChangeRequestCreateRequest struct {
+	StudentID int64               `json:"student_id" binding:"required"`
+	Changes   StudentChangeFields `json:"changes"`
+	Reason    *string             `json:"reason,omitempty" binding:"omitempty,max=500"`
+}
+
+// 审批变更申请请求
+type ChangeRequestReviewRequest struct {
+	Comment *string `json:"comment,omitempty" binding:"omitempty,max=500"` // 审批意见，驳回时必填
+}
+
+// 学生变更申请响应
+type ChangeRequestResponse struct {
+	ID            int64           `json:"id"`
+	StudentID     int64           `json:"student_id"`
+	Changes       json.RawMessage `json:"changes"`
+	Reason        *string         `json:"reason"`
+	Status        string          `json:"status"`
+	RequesterID   int64           `json:"requester_id"`
+	ReviewerID    *int64          `json:"reviewer_id"`
+	ReviewComment *string         `json:"review_comment"`
+	ReviewedAt    *time.Time      `json:"reviewed_at"`
+	CreatedAt     *time.Time      `json:"created_at"`
+	UpdatedAt     *time.Time      `json:"updated_at"`
+}
+
+// 学生变更申请列表响应
+type ChangeRequestListResponse struct {
+	List  []*ChangeRequestResponse `json:"list"`
+	Total int64                    `json:"total"`
+	Page  int                      `json:"page"`
+	Size  int                      `json:"size"`
+}
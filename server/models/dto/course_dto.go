@@ -0,0 +1,107 @@
+package dto
+
+import "time"
+
+// 课程请求
+type CourseRequest struct {
+	UniversityID   int64   `json:"university_id" binding:"required"`
+	Code           string  `json:"code" binding:"required,max=50"`
+	Title          string  `json:"title" binding:"required,max=200"`
+	Credits        float64 `json:"credits" binding:"gt=0,lte=99"`
+	Description    *string `json:"description,omitempty" binding:"omitempty,max=1000"`
+	GradingScaleID *int64  `json:"grading_scale_id,omitempty"` // 评分标准，为空时使用默认评分标准
+}
+
+// 课程响应
+type CourseResponse struct {
+	ID             int64      `json:"id"`
+	UniversityID   int64      `json:"university_id"`
+	Code           string     `json:"code"`
+	Title          string     `json:"title"`
+	Credits        float64    `json:"credits"`
+	Description    *string    `json:"description"`
+	GradingScaleID *int64     `json:"grading_scale_id"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+	CreatedBy      *int64     `json:"created_by"`
+	UpdatedBy      *int64     `json:"updated_by"`
+}
+
+// 课程列表响应
+type CourseListResponse struct {
+	List  []*CourseResponse `json:"list"`
+	Total int64             `json:"total"`
+	Page  int               `json:"page"`
+	Size  int               `json:"size"`
+}
+
+// 教学班请求
+type SectionRequest struct {
+	CourseID   int64   `json:"course_id" binding:"required"`
+	Term       string  `json:"term" binding:"required,max=20"`       // 学期，如 2024-2025-1
+	SectionNo  string  `json:"section_no" binding:"required,max=20"` // 班号
+	Instructor *string `json:"instructor,omitempty" binding:"omitempty,max=50"`
+	Capacity   int32   `json:"capacity" binding:"required,gt=0"`
+	Schedule   *string `json:"schedule,omitempty" binding:"omitempty,max=200"` // 上课时间，如 1 08:00-09:40,3 10:00-11:40
+	Location   *string `json:"location,omitempty" binding:"omitempty,max=100"`
+}
+
+// 教学班响应
+type SectionResponse struct {
+	ID            int64      `json:"id"`
+	CourseID      int64      `json:"course_id"`
+	CourseCode    string     `json:"course_code"`
+	CourseTitle   string     `json:"course_title"`
+	Credits       float64    `json:"credits"`
+	Term          string     `json:"term"`
+	SectionNo     string     `json:"section_no"`
+	Instructor    *string    `json:"instructor"`
+	Capacity      int32      `json:"capacity"`
+	EnrolledCount int64      `json:"enrolled_count"` // 已选课人数
+	Schedule      *string    `json:"schedule"`
+	Location      *string    `json:"location"`
+	CreatedAt     *time.Time `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+	CreatedBy     *int64     `json:"created_by"`
+	UpdatedBy     *int64     `json:"updated_by"`
+}
+
+// 教学班列表响应
+type SectionListResponse struct {
+	List  []*SectionResponse `json:"list"`
+	Total int64              `json:"total"`
+	Page  int                `json:"page"`
+	Size  int                `json:"size"`
+}
+
+// 选课请求
+type EnrollmentRequest struct {
+	StudentID int64 `json:"student_id" binding:"required"`
+	SectionID int64 `json:"section_id" binding:"required"`
+}
+
+// 选课记录响应
+type EnrollmentResponse struct {
+	ID          int64      `json:"id"`
+	StudentID   int64      `json:"student_id"`
+	SectionID   int64      `json:"section_id"`
+	Status      string     `json:"status"`
+	CourseID    int64      `json:"course_id"`
+	CourseCode  string     `json:"course_code"`
+	CourseTitle string     `json:"course_title"`
+	Credits     float64    `json:"credits"`
+	Term        string     `json:"term"`
+	SectionNo   string     `json:"section_no"`
+	EnrolledAt  *time.Time `json:"enrolled_at"`
+	DroppedAt   *time.Time `json:"dropped_at"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+// 选课记录列表响应
+type EnrollmentListResponse struct {
+	List  []*EnrollmentResponse `json:"list"`
+	Total int64                 `json:"total"`
+	Page  int                   `json:"page"`
+	Size  int                   `json:"size"`
+}
@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// 评分标准等级请求
+type GradingScaleItemRequest struct {
+	Grade      string   `json:"grade" binding:"required,max=20"`
+	MinScore   *float64 `json:"min_score,omitempty"` // 分数下限，仅百分制需要
+	GradePoint float64  `json:"grade_point"`
+	Passing    bool     `json:"passing"`
+}
+
+// 评分标准请求
+type GradingScaleRequest struct {
+	Name        string                     `json:"name" binding:"required,max=50"`
+	Type        string                     `json:"type" binding:"required"` // percentage、letter、five_point
+	IsDefault   bool                       `json:"is_default"`
+	Description *string                    `json:"description,omitempty" binding:"omitempty,max=255"`
+	Items       []*GradingScaleItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// 评分标准等级响应
+type GradingScaleItemResponse struct {
+	ID         int64    `json:"id"`
+	Grade      string   `json:"grade"`
+	MinScore   *float64 `json:"min_score"`
+	GradePoint float64  `json:"grade_point"`
+	Passing    bool     `json:"passing"`
+}
+
+// 评分标准响应
+type GradingScaleResponse struct {
+	ID          int64                       `json:"id"`
+	Name        string                      `json:"name"`
+	Type        string                      `json:"type"`
+	IsDefault   bool                        `json:"is_default"`
+	Description *string                     `json:"description"`
+	Items       []*GradingScaleItemResponse `json:"items"`
+	CreatedAt   *time.Time                  `json:"created_at"`
+	UpdatedAt   *time.Time                  `json:"updated_at"`
+}
+
+// 录入成绩请求，指定 enrollment_id 时学生、课程、学期取自选课记录
+type GradeRequest struct {
+	EnrollmentID *int64   `json:"enrollment_id,omitempty"`
+	StudentID    int64    `json:"student_id"`
+	CourseID     int64    `json:"course_id"`
+	Term         string   `json:"term" binding:"max=20"`
+	Score        *float64 `json:"score,omitempty"`        // 百分制录入分数
+	Grade        string   `json:"grade" binding:"max=20"` // 等级制、五级制录入等级
+	Remarks      *string  `json:"remarks,omitempty" binding:"omitempty,max=255"`
+}
+
+// 更新成绩请求
+type GradeUpdateRequest struct {
+	Score   *float64 `json:"score,omitempty"`
+	Grade   string   `json:"grade" binding:"max=20"`
+	Remarks *string  `json:"remarks,omitempty" binding:"omitempty,max=255"`
+}
+
+// 成绩响应
+type GradeResponse struct {
+	ID           int64      `json:"id"`
+	StudentID    int64      `json:"student_id"`
+	CourseID     int64      `json:"course_id"`
+	CourseCode   string     `json:"course_code"`
+	CourseTitle  string     `json:"course_title"`
+	EnrollmentID *int64     `json:"enrollment_id"`
+	Term         string     `json:"term"`
+	ScaleID      int64      `json:"scale_id"`
+	Score        *float64   `json:"score"`
+	Grade        string     `json:"grade"`
+	GradePoint   float64    `json:"grade_point"`
+	Credits      float64    `json:"credits"` // 录入时课程的学分
+	Passed       bool       `json:"passed"`
+	Remarks      *string    `json:"remarks"`
+	CreatedAt    *time.Time `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+	CreatedBy    *int64     `json:"created_by"`
+	UpdatedBy    *int64     `json:"updated_by"`
+}
+
+// 成绩列表响应
+type GradeListResponse struct {
+	List  []*GradeResponse `json:"list"`
+	Total int64            `json:"total"`
+	Page  int              `json:"page"`
+	Size  int              `json:"size"`
+}
+
+// 签发成绩单请求
+type IssueTranscriptRequest struct {
+	Official bool `json:"official"` // 是否为正式成绩单
+}
+
+// 已签发成绩单响应
+type IssuedTranscriptResponse struct {
+	ID               int64           `json:"id"`
+	StudentID        int64           `json:"student_id"`
+	VerificationCode string          `json:"verification_code"` // 显示格式 XXXX-XXXX-XXXX
+	Official         bool            `json:"official"`
+	CumulativeGPA    float64         `json:"cumulative_gpa"`
+	IssuedBy         *int64          `json:"issued_by"`
+	IssuedAt         *time.Time      `json:"issued_at"`
+	Content          json.RawMessage `json:"content,omitempty"` // 签发时的成绩单快照，列表中不返回
+}
+
+// 成绩单验证响应，只返回核对所需的信息
+type TranscriptVerifyResponse struct {
+	VerificationCode string     `json:"verification_code"`
+	Official         bool       `json:"official"`
+	StudentName      string     `json:"student_name"`
+	UniversityName   string     `json:"university_name"`
+	CumulativeGPA    float64    `json:"cumulative_gpa"`
+	EarnedCredits    float64    `json:"earned_credits"`
+	IssuedAt         *time.Time `json:"issued_at"`
+}
@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+// 院系请求
+type CollegeRequest struct {
+	UniversityID int64   `json:"university_id" binding:"required"`
+	Name         string  `json:"name" binding:"required"`
+	Code         *string `json:"code,omitempty"`
+}
+
+// 院系响应
+type CollegeResponse struct {
+	ID           int64      `json:"id"`
+	UniversityID int64      `json:"university_id"`
+	Name         string     `json:"name"`
+	Code         *string    `json:"code"`
+	CreatedAt    *time.Time `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+	CreatedBy    *int64     `json:"created_by"`
+	UpdatedBy    *int64     `json:"updated_by"`
+}
+
+// 专业请求
+type MajorRequest struct {
+	UniversityID int64   `json:"university_id" binding:"required"`
+	CollegeID    *int64  `json:"college_id,omitempty"`
+	Name         string  `json:"name" binding:"required"`
+	Code         *string `json:"code,omitempty"`
+}
+
+// 专业响应
+type MajorResponse struct {
+	ID           int64      `json:"id"`
+	UniversityID int64      `json:"university_id"`
+	CollegeID    *int64     `json:"college_id"`
+	Name         string     `json:"name"`
+	Code         *string    `json:"code"`
+	CreatedAt    *time.Time `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+	CreatedBy    *int64     `json:"created_by"`
+	UpdatedBy    *int64     `json:"updated_by"`
+}
+
+// 未匹配的历史专业名称
+type UnmatchedMajorResponse struct {
+	UniversityID *int64 `json:"university_id"`
+	Major        string `json:"major"`
+	Count        int    `json:"count"`
+	Reason       string `json:"reason"`
+}
+
+// 历史专业名称匹配结果
+type MajorMatchResponse struct {
+	Matched   int                       `json:"matched"`
+	Applied   bool                      `json:"applied"`
+	Unmatched []*UnmatchedMajorResponse `json:"unmatched"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// 通知响应
+type NotificationResponse struct {
+	ID         int64      `json:"id"`
+	Type       string     `json:"type"`
+	Title      string     `json:"title"`
+	Content    *string    `json:"content"`
+	EntityType *string    `json:"entity_type"`
+	EntityID   *int64     `json:"entity_id"`
+	ReadAt     *time.Time `json:"read_at"` // 为空表示未读
+	CreatedAt  *time.Time `json:"created_at"`
+}
+
+// 通知列表响应
+type NotificationListResponse struct {
+	List   []*NotificationResponse `json:"list"`
+	Total  int64                   `json:"total"`
+	Unread int64                   `json:"unread"` // 未读通知数
+	Page   int                     `json:"page"`
+	Size   int                     `json:"size"`
+}
@@ -12,6 +12,7 @@ type StudentCreateRequest struct {
 	Phone          *string    `json:"phone,omitempty"`
 	UniversityID   *int64     `json:"university_id,omitempty"`
 	Major          *string    `json:"major,omitempty"`
+	MajorID        *int64     `json:"major_id,omitempty"`
 	Education      *string    `json:"education,omitempty"`
 	GraduationYear *int64     `json:"graduation_year,omitempty"`
 	Status         *string    `json:"status,omitempty"`
@@ -26,6 +27,7 @@ type StudentUpdateRequest struct {
 	Phone          *string    `json:"phone,omitempty"`
 	UniversityID   *int64     `json:"university_id,omitempty"`
 	Major          *string    `json:"major,omitempty"`
+	MajorID        *int64     `json:"major_id,omitempty"`
 	Education      *string    `json:"education,omitempty"`
 	GraduationYear *int64     `json:"graduation_year,omitempty"`
 	Status         *string    `json:"status,omitempty"`
@@ -50,6 +52,7 @@ type StudentResponse struct {
 	UniversityID   *int64              `json:"university_id"`
 	University     *UniversityResponse `json:"university,omitempty"`
 	Major          *string             `json:"major"`
+	MajorID        *int64              `json:"major_id"`
 	Education      *string             `json:"education"`
 	GraduationYear *int64              `json:"graduation_year"`
 	Status         *string             `json:"status"`
@@ -64,8 +67,68 @@ type StudentResponse struct {
 
 // 学生列表响应
 type StudentListResponse struct {
-	List  []*StudentResponse `json:"list"`
-	Total int64              `json:"total"`
-	Page  int                `json:"page"`
-	Size  int                `json:"size"`
+	List       []*StudentResponse `json:"list"`
+	Total      *int64             `json:"total,omitempty"` // 总数，游标分页时仅在 with_total=1 时返回
+	Page       int                `json:"page,omitempty"`  // 页码，仅偏移分页返回
+	Size       int                `json:"size"`
+	NextCursor string             `json:"next_cursor,omitempty"` // 下一页游标，仅游标分页返回，为空表示没有更多数据
+}
+
+// 学生搜索结果项
+type StudentSearchItem struct {
+	*StudentResponse
+	Score      float64           `json:"score"`      // 相关度得分
+	Highlights map[string]string `json:"highlights"` // 命中字段的高亮文本，关键词用 <em> 标记
+}
+
+// 学生搜索响应
+type StudentSearchResponse struct {
+	List  []*StudentSearchItem `json:"list"`
+	Total int64                `json:"total"`
+	Page  int                  `json:"page"`
+	Size  int                  `json:"size"`
+}
+
+// 学生状态变更请求
+type StudentStatusRequest struct {
+	Status         string  `json:"status" binding:"required"`
+	EffectiveDate  string  `json:"effective_date" binding:"required,datetime=2006-01-02"` // 生效日期，格式 2006-01-02
+	Reason         *string `json:"reason,omitempty" binding:"omitempty,max=500"`
+	ApproverID     *int64  `json:"approver_id,omitempty"`
+	GraduationYear *int64  `json:"graduation_year,omitempty"` // 变更为毕业时使用，未填写时使用学生已有的毕业年份
+}
+
+// 学生状态变更记录响应
+type StudentStatusHistoryResponse struct {
+	ID            int64      `json:"id"`
+	StudentID     int64      `json:"student_id"`
+	FromStatus    *string    `json:"from_status"`
+	ToStatus      string     `json:"to_status"`
+	EffectiveDate string     `json:"effective_date"`
+	Reason        *string    `json:"reason"`
+	ApproverID    *int64     `json:"approver_id"`
+	CreatedAt     *time.Time `json:"created_at"`
+	CreatedBy     *int64     `json:"created_by"`
+}
+
+// 疑似重复学生
+type StudentDuplicateResponse struct {
+	Score    int                `json:"score"`    // 重复得分，满分100
+	Reasons  []string           `json:"reasons"`  // 命中原因，如 姓名相同、电话相同
+	Students []*StudentResponse `json:"students"` // 两个学生，ID小的在前
+}
+
+// 疑似重复学生列表响应
+type StudentDuplicateListResponse struct {
+	List  []*StudentDuplicateResponse `json:"list"`
+	Total int64                       `json:"total"`
+	Page  int                         `json:"page"`
+	Size  int                         `json:"size"`
+}
+
+// 学生合并请求
+type StudentMergeRequest struct {
+	SurvivorID int64             `json:"survivor_id" binding:"required"` // 保留的学生
+	LoserID    int64             `json:"loser_id" binding:"required"`    // 被合并（将被删除）的学生
+	Fields     map[string]string `json:"fields,omitempty"`               // 字段 => survivor/loser，指定各字段取哪一方的值
 }
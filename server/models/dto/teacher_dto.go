@@ -0,0 +1,72 @@
+package dto
+
+import "time"
+
+// 教师请求
+type TeacherRequest struct {
+	UserID       int64   `json:"user_id" binding:"required"` // 关联的登录用户ID
+	EmployeeNo   *string `json:"employee_no,omitempty" binding:"omitempty,max=50"`
+	Name         string  `json:"name" binding:"required,max=50"`
+	Title        *string `json:"title,omitempty" binding:"omitempty,max=50"`
+	UniversityID *int64  `json:"university_id,omitempty"`
+	CollegeID    *int64  `json:"college_id,omitempty"`
+	Email        *string `json:"email,omitempty" binding:"omitempty,email"`
+	Phone        *string `json:"phone,omitempty" binding:"omitempty,max=20"`
+	Status       string  `json:"status,omitempty"` // 在职、离职，默认在职
+}
+
+// 教师响应
+type TeacherResponse struct {
+	ID           int64      `json:"id"`
+	UserID       int64      `json:"user_id"`
+	EmployeeNo   *string    `json:"employee_no"`
+	Name         string     `json:"name"`
+	Title        *string    `json:"title"`
+	UniversityID *int64     `json:"university_id"`
+	CollegeID    *int64     `json:"college_id"`
+	Email        *string    `json:"email"`
+	Phone        *string    `json:"phone"`
+	Status       string     `json:"status"`
+	CreatedAt    *time.Time `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+	CreatedBy    *int64     `json:"created_by"`
+	UpdatedBy    *int64     `json:"updated_by"`
+}
+
+// 教师列表响应
+type TeacherListResponse struct {
+	List  []*TeacherResponse `json:"list"`
+	Total int64              `json:"total"`
+	Page  int                `json:"page"`
+	Size  int                `json:"size"`
+}
+
+// 分配导师请求
+type AdvisorAssignRequest struct {
+	TeacherID int64   `json:"teacher_id" binding:"required"`
+	StartDate string  `json:"start_date,omitempty" binding:"omitempty,datetime=2006-01-02"` // 开始日期，默认当天
+	Reason    *string `json:"reason,omitempty" binding:"omitempty,max=500"`
+}
+
+// 结束导师分配请求
+type AdvisorEndRequest struct {
+	EndDate string  `json:"end_date,omitempty" binding:"omitempty,datetime=2006-01-02"` // 结束日期（不含），默认当天
+	Reason  *string `json:"reason,omitempty" binding:"omitempty,max=500"`
+}
+
+// 导师分配记录响应
+type AdvisorAssignmentResponse struct {
+	ID          int64      `json:"id"`
+	StudentID   int64      `json:"student_id"`
+	TeacherID   int64      `json:"teacher_id"`
+	TeacherName string     `json:"teacher_name"`
+	StartDate   string     `json:"start_date"`
+	EndDate     *string    `json:"end_date"` // 为空表示仍在指导
+	Current     bool       `json:"current"`  // 当天是否有效
+	Reason      *string    `json:"reason"`
+	EndReason   *string    `json:"end_reason"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	CreatedBy   *int64     `json:"created_by"`
+	UpdatedBy   *int64     `json:"updated_by"`
+}
@@ -3,24 +3,68 @@ package dto
 import "time"
 
 // 大学请求
+// 更新时除 name 外未传的字段保持不变；level_tags、aliases 传空数组表示清空
 type UniversityRequest struct {
-	Name string `json:"name" binding:"required"`
+	Name        string   `json:"name" binding:"required"`
+	Code        *string  `json:"code,omitempty" binding:"omitempty,max=20"`
+	ShortName   *string  `json:"short_name,omitempty" binding:"omitempty,max=50"`
+	EnglishName *string  `json:"english_name,omitempty" binding:"omitempty,max=200"`
+	Province    *string  `json:"province,omitempty" binding:"omitempty,max=50"`
+	City        *string  `json:"city,omitempty" binding:"omitempty,max=50"`
+	LevelTags   []string `json:"level_tags,omitempty" binding:"omitempty,dive,oneof=985 211 双一流"`
+	Website     *string  `json:"website,omitempty" binding:"omitempty,url,max=255"`
+	Logo        *string  `json:"logo,omitempty" binding:"omitempty,max=255"`
+	Aliases     []string `json:"aliases,omitempty" binding:"omitempty,dive,max=100"`
 }
 
 // 大学响应
 type UniversityResponse struct {
-	ID        int64      `json:"id"`
-	Name      string     `json:"name"`
-	CreatedAt *time.Time `json:"created_at"`
-	UpdatedAt *time.Time `json:"updated_at"`
-	CreatedBy *int64     `json:"created_by"`
-	UpdatedBy *int64     `json:"updated_by"`
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Code        *string    `json:"code"`
+	ShortName   *string    `json:"short_name"`
+	EnglishName *string    `json:"english_name"`
+	Province    *string    `json:"province"`
+	City        *string    `json:"city"`
+	LevelTags   []string   `json:"level_tags"`
+	Website     *string    `json:"website"`
+	Logo        *string    `json:"logo"`
+	Aliases     []string   `json:"aliases,omitempty"` // 别名，作为学生所属大学返回时不包含
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	CreatedBy   *int64     `json:"created_by"`
+	UpdatedBy   *int64     `json:"updated_by"`
 }
 
 // 大学列表响应
 type UniversityListResponse struct {
-	List  []*UniversityResponse `json:"list"`
-	Total int64                 `json:"total"`
-	Page  int                   `json:"page"`
-	Size  int                   `json:"size"`
+	List       []*UniversityResponse `json:"list"`
+	Total      *int64                `json:"total,omitempty"` // 总数，游标分页时仅在 with_total=1 时返回
+	Page       int                   `json:"page,omitempty"`  // 页码，仅偏移分页返回
+	Size       int                   `json:"size"`
+	NextCursor string                `json:"next_cursor,omitempty"` // 下一页游标，仅游标分页返回，为空表示没有更多数据
+}
+
+// 大学合并请求
+type UniversityMergeRequest struct {
+	SourceID int64 `json:"source_id" binding:"required"` // 被合并（将被删除）的大学
+	TargetID int64 `json:"target_id" binding:"required"` // 保留的大学
+	DryRun   bool  `json:"dry_run"`                      // 为 true 时只预览，不修改数据
+}
+
+// 大学合并响应
+type UniversityMergeResponse struct {
+	Source         *UniversityResponse `json:"source"`
+	Target         *UniversityResponse `json:"target"`
+	DryRun         bool                `json:"dry_run"`
+	Students       int64               `json:"students"`
+	Courses        int64               `json:"courses"`
+	Teachers       int64               `json:"teachers"`
+	ConflictCodes  []string            `json:"conflict_codes"` // 两所大学都有的课程代码，不为空时不能合并
+	CollegesMoved  int                 `json:"colleges_moved"`
+	CollegesMerged int                 `json:"colleges_merged"`
+	MajorsMoved    int                 `json:"majors_moved"`
+	MajorsMerged   int                 `json:"majors_merged"`
+	AddedAliases   []string            `json:"added_aliases"`
+	SkippedAliases []string            `json:"skipped_aliases"`
 }
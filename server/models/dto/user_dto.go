@@ -45,8 +45,9 @@ type UserResponse struct {
 
 // 用户列表响应
 type UserListResponse struct {
-	List  []*UserResponse `json:"list"`
-	Total int64           `json:"total"`
-	Page  int             `json:"page"`
-	Size  int             `json:"size"`
+	List       []*UserResponse `json:"list"`
+	Total      *int64          `json:"total,omitempty"` // 总数，游标分页时仅在 with_total=1 时返回
+	Page       int             `json:"page,omitempty"`  // 页码，仅偏移分页返回
+	Size       int             `json:"size"`
+	NextCursor string          `json:"next_cursor,omitempty"` // 下一页游标，仅游标分页返回，为空表示没有更多数据
 }
@@ -1,7 +1,9 @@
 package routes
 
 import (
+	"mvc-demo/config"
 	"mvc-demo/controllers"
+	"mvc-demo/metrics"
 	"mvc-demo/middleware"
 
 	"github.com/gin-gonic/gin"
@@ -9,18 +11,56 @@ import (
 
 // AppDependencies 应用依赖接口
 type AppDependencies interface {
+	GetConfig() *config.AppConfig
 	GetUserController() *controllers.UserController
 	GetAuthController() *controllers.AuthController
 	GetUniversityController() *controllers.UniversityController
 	GetStudentController() *controllers.StudentController
+	GetCollegeController() *controllers.CollegeController
+	GetMajorController() *controllers.MajorController
+	GetStudentDuplicateController() *controllers.StudentDuplicateController
+	GetChangeRequestController() *controllers.ChangeRequestController
+	GetNotificationController() *controllers.NotificationController
+	GetCourseController() *controllers.CourseController
+	GetCourseSectionController() *controllers.CourseSectionController
+	GetEnrollmentController() *controllers.EnrollmentController
+	GetGradingScaleController() *controllers.GradingScaleController
+	GetGradeController() *controllers.GradeController
+	GetTranscriptController() *controllers.TranscriptController
+	GetAttendanceController() *controllers.AttendanceController
+	GetTeacherController() *controllers.TeacherController
+	GetAdvisorController() *controllers.AdvisorController
+	GetCompanyController() *controllers.CompanyController
+	GetJobPostingController() *controllers.JobPostingController
+	GetJobApplicationController() *controllers.JobApplicationController
+	GetEmploymentController() *controllers.EmploymentController
 }
 
 // SetupRouter 配置所有路由
 func SetupRouter(deps AppDependencies) *gin.Engine {
-	r := gin.Default()
+	r := gin.New()
 
 	// 使用中间件
-	// gin.Default() 已经默认添加了 Logger 和 Recovery 中间件，无需重复添加
+	// 不使用 gin.Default() 自带的 Logger 和 Recovery，访问日志和 panic 日志统一写入结构化日志
+	appConfig := deps.GetConfig()
+	r.Use(middleware.Recovery())
+
+	// 监控指标与接口共用端口时，在其他中间件之前注册，抓取请求不计入访问日志和请求指标
+	if appConfig.Metrics.Enabled && appConfig.Metrics.Port == "" {
+		r.GET(appConfig.Metrics.Path, gin.WrapH(metrics.Handler(metrics.Default, appConfig.Metrics.Token)))
+	}
+
+	if appConfig.CORS.Enabled() {
+		r.Use(middleware.CORS(appConfig.CORS))
+	}
+	r.Use(middleware.RequestContext(appConfig.Request))
+	if appConfig.Tracing.Enabled() {
+		r.Use(middleware.Tracing(appConfig.Tracing.ServiceName)...)
+	}
+	r.Use(middleware.AccessLog())
+	if appConfig.Metrics.Enabled {
+		r.Use(middleware.Metrics())
+	}
 
 	// API 路由组
 	api := r.Group("/api")
@@ -30,6 +70,24 @@ func SetupRouter(deps AppDependencies) *gin.Engine {
 		userController := deps.GetUserController()
 		universityController := deps.GetUniversityController()
 		studentController := deps.GetStudentController()
+		collegeController := deps.GetCollegeController()
+		majorController := deps.GetMajorController()
+		studentDuplicateController := deps.GetStudentDuplicateController()
+		changeRequestController := deps.GetChangeRequestController()
+		notificationController := deps.GetNotificationController()
+		courseController := deps.GetCourseController()
+		courseSectionController := deps.GetCourseSectionController()
+		enrollmentController := deps.GetEnrollmentController()
+		gradingScaleController := deps.GetGradingScaleController()
+		gradeController := deps.GetGradeController()
+		transcriptController := deps.GetTranscriptController()
+		attendanceController := deps.GetAttendanceController()
+		teacherController := deps.GetTeacherController()
+		advisorController := deps.GetAdvisorController()
+		companyController := deps.GetCompanyController()
+		jobPostingController := deps.GetJobPostingController()
+		jobApplicationController := deps.GetJobApplicationController()
+		employmentController := deps.GetEmploymentController()
 
 		// 认证相关路由（无需认证）
 		auth := api.Group("/auth")
@@ -41,6 +99,9 @@ func SetupRouter(deps AppDependencies) *gin.Engine {
 			auth.POST("/register", userController.Create)
 		}
 
+		// 成绩单验证（无需认证，供用人单位等第三方核对）
+		api.GET("/transcripts/verify/:code", transcriptController.Verify)
+
 		// 需要认证的路由
 		authorized := api.Group("/")
 		authorized.Use(middleware.JWTAuth())
@@ -61,15 +122,121 @@ func SetupRouter(deps AppDependencies) *gin.Engine {
 				universityGroup.GET("/:id", universityController.Get)
 				universityGroup.GET("", universityController.List)
 				universityGroup.GET("/all", universityController.All)
+				universityGroup.GET("/resolve", universityController.Resolve)
+			}
+
+			// 院系路由
+			collegeGroup := authorized.Group("/colleges")
+			{
+				collegeGroup.GET("/:id", collegeController.Get)
+				collegeGroup.GET("", collegeController.List)
+			}
+
+			// 专业路由
+			majorGroup := authorized.Group("/majors")
+			{
+				majorGroup.GET("/:id", majorController.Get)
+				majorGroup.GET("", majorController.List)
 			}
 
 			// 学生路由
 			studentGroup := authorized.Group("/students")
 			{
+				studentGroup.GET("/search", studentController.Search)
 				studentGroup.GET("/:id", studentController.Get)
+				studentGroup.GET("/:id/status-history", studentController.StatusHistory)
+				studentGroup.GET("/:id/transcript", transcriptController.Get)
+				studentGroup.GET("/:id/transcripts", transcriptController.ListIssued)
+				studentGroup.GET("/:id/attendance", attendanceController.StudentReport)
+				studentGroup.GET("/:id/advisors", advisorController.History)
 				studentGroup.GET("", studentController.List)
 			}
 
+			// 课程路由
+			courseGroup := authorized.Group("/courses")
+			{
+				courseGroup.GET("/:id", courseController.Get)
+				courseGroup.GET("", courseController.List)
+			}
+
+			// 教学班路由
+			sectionGroup := authorized.Group("/sections")
+			{
+				sectionGroup.GET("/:id", courseSectionController.Get)
+				sectionGroup.GET("", courseSectionController.List)
+				sectionGroup.GET("/:id/attendance", attendanceController.SectionReport)
+				sectionGroup.POST("/:id/sessions/generate", attendanceController.GenerateSessions)
+			}
+
+			// 选课记录路由
+			enrollmentGroup := authorized.Group("/enrollments")
+			{
+				enrollmentGroup.GET("/:id", enrollmentController.Get)
+				enrollmentGroup.GET("", enrollmentController.List)
+			}
+
+			// 教师与导师，advisees 为当前用户作为导师正在指导的学生
+			authorized.GET("/teachers", teacherController.List)
+			authorized.GET("/teachers/:id", teacherController.Get)
+			authorized.GET("/advisees", studentController.MyAdvisees)
+
+			// 就业与实习
+			authorized.GET("/companies", companyController.List)
+			authorized.GET("/companies/:id", companyController.Get)
+			authorized.GET("/postings", jobPostingController.List)
+			authorized.GET("/postings/:id", jobPostingController.Get)
+			authorized.GET("/applications", jobApplicationController.List)
+			authorized.GET("/applications/:id", jobApplicationController.Get)
+			authorized.GET("/employment-records", employmentController.List)
+			authorized.GET("/employment-records/:id", employmentController.Get)
+			authorized.GET("/reports/employment", employmentController.Report)
+
+			// 课次与考勤，任课老师等登录用户可以维护课次和记录考勤
+			sessionGroup := authorized.Group("/sessions")
+			{
+				sessionGroup.GET("", attendanceController.ListSessions)
+				sessionGroup.GET("/:id", attendanceController.GetSession)
+				sessionGroup.POST("", attendanceController.CreateSession)
+				sessionGroup.PUT("/:id", attendanceController.UpdateSession)
+				sessionGroup.GET("/:id/attendance", attendanceController.GetAttendance)
+				sessionGroup.POST("/:id/attendance", attendanceController.MarkAttendance)
+				sessionGroup.POST("/:id/attendance/all", attendanceController.MarkAll)
+			}
+
+			// 评分标准相关
+			gradingScaleGroup := authorized.Group("/grading-scales")
+			{
+				gradingScaleGroup.GET("", gradingScaleController.List)
+				gradingScaleGroup.GET("/:id", gradingScaleController.Get)
+			}
+
+			// 成绩相关
+			gradeGroup := authorized.Group("/grades")
+			{
+				gradeGroup.GET("", gradeController.List)
+				gradeGroup.GET("/:id", gradeController.Get)
+			}
+
+			// 已签发成绩单
+			authorized.GET("/transcripts/:id", transcriptController.GetIssued)
+
+			// 学生信息变更申请路由
+			changeRequestGroup := authorized.Group("/change-requests")
+			{
+				changeRequestGroup.POST("", changeRequestController.Create)
+				changeRequestGroup.GET("", changeRequestController.List)
+				changeRequestGroup.GET("/:id", changeRequestController.Get)
+				changeRequestGroup.POST("/:id/cancel", changeRequestController.Cancel)
+			}
+
+			// 站内通知路由
+			notificationGroup := authorized.Group("/notifications")
+			{
+				notificationGroup.GET("", notificationController.List)
+				notificationGroup.POST("/:id/read", notificationController.Read)
+				notificationGroup.POST("/read-all", notificationController.ReadAll)
+			}
+
 			// 需要管理员权限的路由
 			admin := authorized.Group("/admin")
 			admin.Use(middleware.AdminAuth())
@@ -83,11 +250,83 @@ func SetupRouter(deps AppDependencies) *gin.Engine {
 				admin.POST("/universities", universityController.Create)
 				admin.PUT("/universities/:id", universityController.Update)
 				admin.DELETE("/universities/:id", universityController.Delete)
+				admin.POST("/universities/merge", universityController.Merge)
+
+				// 院系管理
+				admin.POST("/colleges", collegeController.Create)
+				admin.PUT("/colleges/:id", collegeController.Update)
+				admin.DELETE("/colleges/:id", collegeController.Delete)
+
+				// 专业管理
+				admin.POST("/majors", majorController.Create)
+				admin.PUT("/majors/:id", majorController.Update)
+				admin.DELETE("/majors/:id", majorController.Delete)
+				admin.POST("/majors/match", majorController.Match)
 
 				// 学生管理
 				admin.POST("/students", studentController.Create)
 				admin.PUT("/students/:id", studentController.Update)
 				admin.DELETE("/students/:id", studentController.Delete)
+				admin.POST("/students/:id/status", studentController.ChangeStatus)
+				admin.POST("/students/reindex", studentController.Reindex)
+				admin.GET("/students/duplicates", studentDuplicateController.List)
+				admin.POST("/students/merge", studentDuplicateController.Merge)
+
+				// 课程管理
+				admin.POST("/courses", courseController.Create)
+				admin.PUT("/courses/:id", courseController.Update)
+				admin.DELETE("/courses/:id", courseController.Delete)
+
+				// 教学班管理
+				admin.POST("/sections", courseSectionController.Create)
+				admin.PUT("/sections/:id", courseSectionController.Update)
+				admin.DELETE("/sections/:id", courseSectionController.Delete)
+
+				// 选课管理
+				admin.POST("/enrollments", enrollmentController.Create)
+				admin.POST("/enrollments/:id/drop", enrollmentController.Drop)
+				admin.POST("/enrollments/:id/complete", enrollmentController.Complete)
+
+				// 教师与导师分配管理
+				admin.POST("/teachers", teacherController.Create)
+				admin.PUT("/teachers/:id", teacherController.Update)
+				admin.DELETE("/teachers/:id", teacherController.Delete)
+				admin.POST("/students/:id/advisor", advisorController.Assign)
+				admin.POST("/students/:id/advisor/end", advisorController.End)
+
+				// 就业与实习管理
+				admin.POST("/companies", companyController.Create)
+				admin.PUT("/companies/:id", companyController.Update)
+				admin.DELETE("/companies/:id", companyController.Delete)
+				admin.POST("/postings", jobPostingController.Create)
+				admin.PUT("/postings/:id", jobPostingController.Update)
+				admin.DELETE("/postings/:id", jobPostingController.Delete)
+				admin.POST("/applications", jobApplicationController.Create)
+				admin.POST("/applications/:id/status", jobApplicationController.ChangeStatus)
+				admin.DELETE("/applications/:id", jobApplicationController.Delete)
+				admin.POST("/employment-records", employmentController.Create)
+				admin.PUT("/employment-records/:id", employmentController.Update)
+				admin.DELETE("/employment-records/:id", employmentController.Delete)
+
+				// 课次管理
+				admin.DELETE("/sessions/:id", attendanceController.DeleteSession)
+
+				// 评分标准管理
+				admin.POST("/grading-scales", gradingScaleController.Create)
+				admin.PUT("/grading-scales/:id", gradingScaleController.Update)
+				admin.DELETE("/grading-scales/:id", gradingScaleController.Delete)
+
+				// 成绩管理
+				admin.POST("/grades", gradeController.Create)
+				admin.PUT("/grades/:id", gradeController.Update)
+				admin.DELETE("/grades/:id", gradeController.Delete)
+
+				// 签发成绩单，official 为 true 时签发正式成绩单
+				admin.POST("/students/:id/transcripts", transcriptController.Issue)
+
+				// 变更申请审批
+				admin.POST("/change-requests/:id/approve", changeRequestController.Approve)
+				admin.POST("/change-requests/:id/reject", changeRequestController.Reject)
 			}
 		}
 	}
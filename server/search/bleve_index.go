@@ -0,0 +1,260 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/lang/cjk"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/token/ngram"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/single"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+// 自定义分析器名称
+const (
+	substringAnalyzer = "substring"       // 整个字段切分为 n-gram，支持任意子串匹配
+	substringNgram    = "substring_ngram" // n-gram 过滤器
+)
+
+// substringFields 支持子串匹配的字段及其权重
+var substringFields = []struct {
+	name  string
+	boost float64
+}{
+	{"name", 5},
+	{"name_pinyin", 3},
+	{"email", 3},
+	{"phone", 3},
+	{"major", 2},
+}
+
+// BleveIndex 基于 bleve 的嵌入式索引
+// 索引保存在本地目录中，多副本部署时每个副本各自维护一份，建议改用 MySQL 引擎
+type BleveIndex struct {
+	mu    sync.RWMutex
+	path  string
+	index bleve.Index
+}
+
+// openTimeout 打开索引时等待文件锁的超时时间，避免索引被其他进程占用时一直阻塞
+const openTimeout = "3s"
+
+// OpenBleveIndex 打开索引目录，不存在时自动创建
+func OpenBleveIndex(path string) (*BleveIndex, error) {
+	index, err := bleve.OpenUsing(path, map[string]interface{}{"bolt_timeout": openTimeout})
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		index, err = newBleveIndex(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &BleveIndex{path: path, index: index}, nil
+}
+
+// newBleveIndex 按学生索引映射创建新索引
+func newBleveIndex(path string) (bleve.Index, error) {
+	indexMapping, err := newStudentMapping()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return bleve.New(path, indexMapping)
+}
+
+// newStudentMapping 学生索引映射
+// 姓名、拼音、邮箱、电话、专业在索引时切分为 n-gram，搜索时按整词匹配，从而支持部分匹配；
+// 备注使用 CJK 分词，支持中文二元切分和英文模糊匹配
+func newStudentMapping() (mapping.IndexMapping, error) {
+	indexMapping := bleve.NewIndexMapping()
+
+	err := indexMapping.AddCustomTokenFilter(substringNgram, map[string]interface{}{
+		"type": ngram.Name,
+		"min":  1.0,
+		"max":  float64(maxTermLength),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = indexMapping.AddCustomAnalyzer(substringAnalyzer, map[string]interface{}{
+		"type":          custom.Name,
+		"tokenizer":     single.Name,
+		"token_filters": []string{lowercase.Name, substringNgram},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	substringField := bleve.NewTextFieldMapping()
+	substringField.Analyzer = substringAnalyzer
+	substringField.Store = false
+	substringField.IncludeTermVectors = false
+
+	textField := bleve.NewTextFieldMapping()
+	textField.Analyzer = cjk.AnalyzerName
+	textField.Store = false
+
+	studentMapping := bleve.NewDocumentStaticMapping()
+	for _, field := range substringFields {
+		studentMapping.AddFieldMappingsAt(field.name, substringField)
+	}
+	studentMapping.AddFieldMappingsAt("major_text", textField)
+	studentMapping.AddFieldMappingsAt("remarks", textField)
+
+	indexMapping.DefaultMapping = studentMapping
+	indexMapping.DefaultAnalyzer = cjk.AnalyzerName
+	return indexMapping, nil
+}
+
+// Index 新增或更新文档
+func (b *BleveIndex) Index(ctx context.Context, doc *StudentDocument) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.index.Index(strconv.FormatInt(doc.ID, 10), bleveDocument(doc))
+}
+
+// IndexBatch 批量新增或更新文档
+func (b *BleveIndex) IndexBatch(ctx context.Context, docs []*StudentDocument) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	batch := b.index.NewBatch()
+	for _, doc := range docs {
+		if err := batch.Index(strconv.FormatInt(doc.ID, 10), bleveDocument(doc)); err != nil {
+			return err
+		}
+	}
+	return b.index.Batch(batch)
+}
+
+// Delete 删除文档
+func (b *BleveIndex) Delete(ctx context.Context, id int64) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.index.Delete(strconv.FormatInt(id, 10))
+}
+
+// Search 按相关度搜索
+func (b *BleveIndex) Search(ctx context.Context, q *Query) (*Result, error) {
+	keywords := Keywords(q.Keyword)
+	if len(keywords) == 0 {
+		return &Result{}, nil
+	}
+
+	// 每个关键词都需要在某个字段中命中
+	conjunction := bleve.NewConjunctionQuery()
+	for _, keyword := range keywords {
+		conjunction.AddQuery(keywordQuery(keyword))
+	}
+
+	request := bleve.NewSearchRequestOptions(conjunction, q.PageSize, (q.Page-1)*q.PageSize, false)
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	searchResult, err := b.index.SearchInContext(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Total: int64(searchResult.Total)}
+	for _, hit := range searchResult.Hits {
+		id, err := strconv.ParseInt(hit.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		result.Hits = append(result.Hits, &Hit{ID: id, Score: hit.Score})
+	}
+	return result, nil
+}
+
+// keywordQuery 单个关键词的查询：子串字段精确匹配 n-gram，文本字段分词匹配，英文关键词额外做模糊匹配
+func keywordQuery(keyword string) query.Query {
+	disjunction := bleve.NewDisjunctionQuery()
+
+	term := truncateTerm(keyword)
+	for _, field := range substringFields {
+		termQuery := bleve.NewTermQuery(term)
+		termQuery.SetField(field.name)
+		termQuery.SetBoost(field.boost)
+		disjunction.AddQuery(termQuery)
+	}
+
+	for _, field := range []string{"major_text", "remarks"} {
+		matchQuery := bleve.NewMatchQuery(keyword)
+		matchQuery.SetField(field)
+		if isASCII(keyword) {
+			matchQuery.SetFuzziness(1)
+		}
+		disjunction.AddQuery(matchQuery)
+	}
+
+	// 拼音和邮箱容忍一个字符的拼写错误
+	if isASCII(keyword) && len(keyword) >= 4 {
+		for _, field := range []string{"name_pinyin", "email"} {
+			fuzzyQuery := bleve.NewFuzzyQuery(term)
+			fuzzyQuery.SetField(field)
+			fuzzyQuery.SetFuzziness(1)
+			fuzzyQuery.SetBoost(0.5)
+			disjunction.AddQuery(fuzzyQuery)
+		}
+	}
+
+	return disjunction
+}
+
+// Count 已索引文档数
+func (b *BleveIndex) Count(ctx context.Context) (uint64, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.index.DocCount()
+}
+
+// Reset 删除索引目录并重新创建空索引
+func (b *BleveIndex) Reset(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.index.Close(); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(b.path); err != nil {
+		return err
+	}
+
+	index, err := newBleveIndex(b.path)
+	if err != nil {
+		return err
+	}
+	b.index = index
+	return nil
+}
+
+// Close 关闭索引
+func (b *BleveIndex) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.index.Close()
+}
+
+// bleveDocument 把索引文档转换为 bleve 文档
+func bleveDocument(doc *StudentDocument) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        doc.Name,
+		"name_pinyin": doc.NamePinyin,
+		"email":       doc.Email,
+		"phone":       doc.Phone,
+		"major":       doc.Major,
+		"major_text":  doc.Major,
+		"remarks":     doc.Remarks,
+	}
+}
@@ -0,0 +1,106 @@
+package search
+
+import (
+	"context"
+	"mvc-demo/dao/model"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MySQLIndex 基于 MySQL FULLTEXT（ngram 分词）的索引
+// 文档保存在 student_search_index 表中，多副本部署时共享同一份索引
+type MySQLIndex struct {
+	DB *gorm.DB
+}
+
+// NewMySQLIndex 创建 MySQL 索引
+func NewMySQLIndex(db *gorm.DB) *MySQLIndex {
+	return &MySQLIndex{DB: db}
+}
+
+// matchExpr 全文检索表达式
+const matchExpr = "MATCH(name, name_pinyin, email, phone, major, remarks) AGAINST(? IN NATURAL LANGUAGE MODE)"
+
+// Index 新增或更新文档
+func (m *MySQLIndex) Index(ctx context.Context, doc *StudentDocument) error {
+	return m.IndexBatch(ctx, []*StudentDocument{doc})
+}
+
+// IndexBatch 批量新增或更新文档
+func (m *MySQLIndex) IndexBatch(ctx context.Context, docs []*StudentDocument) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	rows := make([]*model.StudentSearchIndex, 0, len(docs))
+	for _, doc := range docs {
+		rows = append(rows, &model.StudentSearchIndex{
+			StudentID:  doc.ID,
+			Name:       doc.Name,
+			NamePinyin: &doc.NamePinyin,
+			Email:      doc.Email,
+			Phone:      &doc.Phone,
+			Major:      &doc.Major,
+			Remarks:    &doc.Remarks,
+		})
+	}
+	return m.DB.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&rows).Error
+}
+
+// Delete 删除文档
+func (m *MySQLIndex) Delete(ctx context.Context, id int64) error {
+	return m.DB.WithContext(ctx).Delete(&model.StudentSearchIndex{}, id).Error
+}
+
+// Search 按相关度搜索
+func (m *MySQLIndex) Search(ctx context.Context, q *Query) (*Result, error) {
+	keyword := strings.Join(Keywords(q.Keyword), " ")
+	if keyword == "" {
+		return &Result{}, nil
+	}
+
+	result := &Result{}
+	query := m.DB.WithContext(ctx).Model(&model.StudentSearchIndex{}).Where(matchExpr, keyword)
+	if err := query.Count(&result.Total).Error; err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		StudentID int64
+		Score     float64
+	}
+	err := m.DB.WithContext(ctx).Model(&model.StudentSearchIndex{}).
+		Select("student_id, "+matchExpr+" AS score", keyword).
+		Where(matchExpr, keyword).
+		Order("score DESC").
+		Offset((q.Page - 1) * q.PageSize).
+		Limit(q.PageSize).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result.Hits = append(result.Hits, &Hit{ID: row.StudentID, Score: row.Score})
+	}
+	return result, nil
+}
+
+// Count 已索引文档数
+func (m *MySQLIndex) Count(ctx context.Context) (uint64, error) {
+	var count int64
+	err := m.DB.WithContext(ctx).Model(&model.StudentSearchIndex{}).Count(&count).Error
+	return uint64(count), err
+}
+
+// Reset 清空索引表
+func (m *MySQLIndex) Reset(ctx context.Context) error {
+	return m.DB.WithContext(ctx).Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&model.StudentSearchIndex{}).Error
+}
+
+// Close MySQL 索引与应用共用数据库连接，无需单独关闭
+func (m *MySQLIndex) Close() error {
+	return nil
+}
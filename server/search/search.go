@@ -0,0 +1,196 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"mvc-demo/dao/model"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mozillazg/go-pinyin"
+	"gorm.io/gorm"
+)
+
+// 搜索引擎类型
+const (
+	EngineBleve = "bleve" // 内置嵌入式索引
+	EngineMySQL = "mysql" // MySQL FULLTEXT 索引
+)
+
+// maxTermLength 子串匹配的最大关键词长度（与索引时的 n-gram 上限一致）
+const maxTermLength = 30
+
+// StudentDocument 学生索引文档
+type StudentDocument struct {
+	ID         int64
+	Name       string
+	NamePinyin string // 姓名全拼和首字母，如 "zhangsan zs"
+	Email      string
+	Phone      string
+	Major      string
+	Remarks    string
+}
+
+// Query 搜索参数
+type Query struct {
+	Keyword  string
+	Page     int
+	PageSize int
+}
+
+// Hit 搜索命中结果
+type Hit struct {
+	ID    int64
+	Score float64
+}
+
+// Result 搜索结果
+type Result struct {
+	Total int64
+	Hits  []*Hit
+}
+
+// Index 学生搜索索引接口，可替换为不同的实现
+// 方法的 ctx 用于取消和链路追踪，MySQL 索引的查询在 ctx 上执行
+type Index interface {
+	// Index 新增或更新文档
+	Index(ctx context.Context, doc *StudentDocument) error
+	// IndexBatch 批量新增或更新文档
+	IndexBatch(ctx context.Context, docs []*StudentDocument) error
+	// Delete 删除文档
+	Delete(ctx context.Context, id int64) error
+	// Search 按相关度搜索
+	Search(ctx context.Context, q *Query) (*Result, error)
+	// Count 已索引文档数
+	Count(ctx context.Context) (uint64, error)
+	// Reset 清空索引，用于重建
+	Reset(ctx context.Context) error
+	// Close 关闭索引
+	Close() error
+}
+
+// Open 根据引擎类型打开索引
+func Open(engine, path string, db *gorm.DB) (Index, error) {
+	switch engine {
+	case EngineBleve:
+		return OpenBleveIndex(path)
+	case EngineMySQL:
+		// FULLTEXT ngram 索引只有 MySQL 支持，其他数据库请使用 bleve
+		if db.Dialector.Name() != "mysql" {
+			return nil, fmt.Errorf("搜索引擎 mysql 需要使用 MySQL 数据库，当前数据库: %s", db.Dialector.Name())
+		}
+		return NewMySQLIndex(db), nil
+	default:
+		return nil, fmt.Errorf("不支持的搜索引擎: %s", engine)
+	}
+}
+
+// NewStudentDocument 根据学生信息构建索引文档
+func NewStudentDocument(student *model.Student) *StudentDocument {
+	return &StudentDocument{
+		ID:         student.ID,
+		Name:       student.Name,
+		NamePinyin: Pinyin(student.Name),
+		Email:      student.Email,
+		Phone:      stringValue(student.Phone),
+		Major:      stringValue(student.Major),
+		Remarks:    stringValue(student.Remarks),
+	}
+}
+
+// Pinyin 生成姓名的全拼和首字母，如 "张三" => "zhangsan zs"
+func Pinyin(name string) string {
+	syllables := pinyin.LazyPinyin(name, pinyin.NewArgs())
+	if len(syllables) == 0 {
+		return ""
+	}
+
+	var initials strings.Builder
+	for _, syllable := range syllables {
+		initials.WriteByte(syllable[0])
+	}
+	return strings.Join(syllables, "") + " " + initials.String()
+}
+
+// Keywords 把搜索词按空白拆分为关键词
+func Keywords(keyword string) []string {
+	return strings.Fields(strings.ToLower(keyword))
+}
+
+// Highlight 用 <em> 标记文本中出现的关键词（不区分大小写），文本会做 HTML 转义
+// 没有命中任何关键词时第二个返回值为 false
+func Highlight(text string, keywords []string) (string, bool) {
+	if text == "" {
+		return "", false
+	}
+
+	// 标记每个字节是否命中关键词
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// 大小写转换改变了字节长度时退化为区分大小写匹配
+		lower = text
+	}
+	marked := make([]bool, len(text))
+	found := false
+	for _, keyword := range keywords {
+		if keyword == "" {
+			continue
+		}
+		for start := 0; start < len(lower); {
+			idx := strings.Index(lower[start:], keyword)
+			if idx < 0 {
+				break
+			}
+			for i := start + idx; i < start+idx+len(keyword); i++ {
+				marked[i] = true
+			}
+			found = true
+			start += idx + len(keyword)
+		}
+	}
+	if !found {
+		return "", false
+	}
+
+	// 合并相邻的命中区间输出
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		j := i
+		for j < len(text) && marked[j] == marked[i] {
+			j++
+		}
+		if marked[i] {
+			b.WriteString("<em>" + html.EscapeString(text[i:j]) + "</em>")
+		} else {
+			b.WriteString(html.EscapeString(text[i:j]))
+		}
+		i = j
+	}
+	return b.String(), true
+}
+
+// truncateTerm 截断超出 n-gram 上限的关键词
+func truncateTerm(term string) string {
+	if utf8.RuneCountInString(term) <= maxTermLength {
+		return term
+	}
+	return string([]rune(term)[:maxTermLength])
+}
+
+// isASCII 判断字符串是否只包含 ASCII 字符
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAdvisorTeacherNotFound = errors.New("导师不存在")
+	ErrAdvisorTeacherInactive = errors.New("导师已离职，不能分配学生")
+	ErrAdvisorUnchanged       = errors.New("学生当前导师已是该教师")
+	ErrAdvisorStartDate       = errors.New("开始日期须晚于上一位导师的开始日期，且不能早于其结束日期")
+	ErrNoActiveAdvisor        = errors.New("学生当前没有导师")
+	ErrAdvisorEndDate         = errors.New("结束日期须晚于开始日期")
+)
+
+// AdvisorService 导师分配服务，学生同一时间只有一位导师，更换导师时结束上一条分配记录
+type AdvisorService struct {
+	tx            dao.Transactor
+	assignmentDAO dao.AdvisorAssignmentRepository
+	teacherDAO    dao.TeacherRepository
+	studentDAO    dao.StudentRepository
+}
+
+// NewAdvisorService 创建导师分配服务实例
+func NewAdvisorService(tx dao.Transactor, assignmentDAO dao.AdvisorAssignmentRepository, teacherDAO dao.TeacherRepository, studentDAO dao.StudentRepository) *AdvisorService {
+	return &AdvisorService{
+		tx:            tx,
+		assignmentDAO: assignmentDAO,
+		teacherDAO:    teacherDAO,
+		studentDAO:    studentDAO,
+	}
+}
+
+// Assign 从 startDate 起为学生分配导师，学生已有导师时上一条分配记录在 startDate 结束
+func (s *AdvisorService) Assign(ctx context.Context, studentID, teacherID int64, startDate time.Time, reason *string, actorID *int64) (*model.AdvisorAssignment, error) {
+	ctx, span := tracing.Start(ctx, "AdvisorService.Assign")
+	defer span.End()
+	if _, err := s.studentDAO.GetByID(ctx, studentID); err != nil {
+		return nil, err
+	}
+	teacher, err := s.teacherDAO.GetByID(ctx, teacherID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAdvisorTeacherNotFound
+		}
+		return nil, err
+	}
+	if teacher.Status != TeacherStatusActive {
+		return nil, ErrAdvisorTeacherInactive
+	}
+
+	startDate = truncateDate(startDate)
+	assignment := &model.AdvisorAssignment{
+		StudentID: studentID,
+		TeacherID: teacherID,
+		StartDate: startDate,
+		Reason:    reason,
+		CreatedBy: actorID,
+		UpdatedBy: actorID,
+	}
+	err = s.tx.Transaction(ctx, func(ctx context.Context) error {
+		previous, err := s.assignmentDAO.GetLatestByStudent(ctx, studentID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil {
+			if previous.EndDate == nil && previous.TeacherID == teacherID {
+				return ErrAdvisorUnchanged
+			}
+			if !startDate.After(previous.StartDate) || (previous.EndDate != nil && previous.EndDate.After(startDate)) {
+				return ErrAdvisorStartDate
+			}
+			if previous.EndDate == nil {
+				previous.EndDate = &startDate
+				previous.UpdatedBy = actorID
+				if err := s.assignmentDAO.Update(ctx, previous); err != nil {
+					return err
+				}
+			}
+		}
+		return s.assignmentDAO.Create(ctx, assignment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	assignment.Teacher = teacher
+	return assignment, nil
+}
+
+// End 在 endDate 结束学生当前的导师分配，endDate 当天起学生没有导师
+func (s *AdvisorService) End(ctx context.Context, studentID int64, endDate time.Time, reason *string, actorID *int64) (*model.AdvisorAssignment, error) {
+	ctx, span := tracing.Start(ctx, "AdvisorService.End")
+	defer span.End()
+	if _, err := s.studentDAO.GetByID(ctx, studentID); err != nil {
+		return nil, err
+	}
+
+	endDate = truncateDate(endDate)
+	assignment, err := s.assignmentDAO.GetLatestByStudent(ctx, studentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoActiveAdvisor
+		}
+		return nil, err
+	}
+	if assignment.EndDate != nil {
+		return nil, ErrNoActiveAdvisor
+	}
+	if !endDate.After(assignment.StartDate) {
+		return nil, ErrAdvisorEndDate
+	}
+
+	assignment.EndDate = &endDate
+	assignment.EndReason = reason
+	assignment.UpdatedBy = actorID
+	if err := s.assignmentDAO.Update(ctx, assignment); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// GetHistory 获取学生的导师分配记录，按开始日期倒序
+func (s *AdvisorService) GetHistory(ctx context.Context, studentID int64) ([]*model.AdvisorAssignment, error) {
+	ctx, span := tracing.Start(ctx, "AdvisorService.GetHistory")
+	defer span.End()
+	if _, err := s.studentDAO.GetByID(ctx, studentID); err != nil {
+		return nil, err
+	}
+	return s.assignmentDAO.GetByStudent(ctx, studentID)
+}
+
+// IsAssignmentActive 导师分配在某天是否有效，有效期为开始日期（含）到结束日期（不含）
+func IsAssignmentActive(assignment *model.AdvisorAssignment, date time.Time) bool {
+	date = truncateDate(date)
+	if date.Before(truncateDate(assignment.StartDate)) {
+		return false
+	}
+	return assignment.EndDate == nil || truncateDate(*assignment.EndDate).After(date)
+}
+
+// truncateDate 去掉时间部分，只保留本地时区的日期
+func truncateDate(t time.Time) time.Time {
+	year, month, day := t.In(time.Local).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// IsAdvisorError 是否为导师分配的业务错误
+func IsAdvisorError(err error) bool {
+	for _, target := range []error{
+		ErrAdvisorTeacherNotFound, ErrAdvisorTeacherInactive, ErrAdvisorUnchanged, ErrAdvisorStartDate,
+		ErrNoActiveAdvisor, ErrAdvisorEndDate,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
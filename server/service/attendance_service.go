@@ -0,0 +1,639 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 考勤状态
+const (
+	AttendancePresent = "present" // 出勤
+	AttendanceAbsent  = "absent"  // 缺勤
+	AttendanceLate    = "late"    // 迟到
+	AttendanceExcused = "excused" // 请假
+)
+
+// AttendanceStatuses 所有考勤状态
+var AttendanceStatuses = []string{AttendancePresent, AttendanceAbsent, AttendanceLate, AttendanceExcused}
+
+// maxGenerateDays 按上课时间批量生成课次时日期范围的最大天数
+const maxGenerateDays = 366
+
+// rosterEnrollmentStatuses 需要考勤的选课状态
+var rosterEnrollmentStatuses = []string{EnrollmentStatusEnrolled, EnrollmentStatusCompleted}
+
+var (
+	ErrInvalidAttendanceStatus = errors.New("无效的考勤状态，可选值: " + strings.Join(AttendanceStatuses, "、"))
+	ErrInvalidSessionTime      = errors.New("无效的上课时间，格式为 HH:MM，且开始时间须早于结束时间")
+	ErrSessionExists           = errors.New("教学班同一天同一开始时间已有课次")
+	ErrAttendanceMarksRequired = errors.New("至少需要一条考勤记录")
+	ErrDuplicateAttendanceMark = errors.New("同一学生只能有一条考勤记录")
+	ErrStudentNotInSection     = errors.New("学生未选该教学班")
+	ErrInvalidSessionDateRange = errors.New("无效的日期范围，结束日期不能早于开始日期，且不能超过一年")
+	ErrSectionScheduleRequired = errors.New("教学班未设置上课时间，不能批量生成课次")
+)
+
+// AttendanceMark 一名学生的考勤
+type AttendanceMark struct {
+	StudentID int64
+	Status    string
+	Remarks   *string
+}
+
+// AttendanceStats 考勤统计，出勤率 = (出勤 + 迟到) / (总次数 - 请假)，以百分比表示
+type AttendanceStats struct {
+	Present int64    `json:"present"`
+	Absent  int64    `json:"absent"`
+	Late    int64    `json:"late"`
+	Excused int64    `json:"excused"`
+	Total   int64    `json:"total"`
+	Rate    *float64 `json:"rate"` // 没有需要计入的考勤记录时为空
+}
+
+// RosterEntry 课次点名册中的一名学生
+type RosterEntry struct {
+	StudentID   int64
+	StudentName string
+	Record      *model.AttendanceRecord // 还没有考勤记录时为空
+}
+
+// SectionAttendance 学生在一个教学班的考勤统计
+type SectionAttendance struct {
+	SectionID   int64  `json:"section_id"`
+	CourseCode  string `json:"course_code"`
+	CourseTitle string `json:"course_title"`
+	Term        string `json:"term"`
+	SectionNo   string `json:"section_no"`
+	AttendanceStats
+}
+
+// StudentAttendanceReport 学生的考勤报表
+type StudentAttendanceReport struct {
+	StudentID   int64                `json:"student_id"`
+	StudentName string               `json:"student_name"`
+	Term        string               `json:"term,omitempty"`
+	Sections    []*SectionAttendance `json:"sections"`
+	Overall     AttendanceStats      `json:"overall"`
+}
+
+// StudentAttendance 教学班中一名学生的考勤统计
+type StudentAttendance struct {
+	StudentID   int64  `json:"student_id"`
+	StudentName string `json:"student_name"`
+	AttendanceStats
+}
+
+// SectionAttendanceReport 教学班的考勤报表
+type SectionAttendanceReport struct {
+	SectionID    int64                `json:"section_id"`
+	CourseCode   string               `json:"course_code"`
+	CourseTitle  string               `json:"course_title"`
+	Term         string               `json:"term"`
+	SectionNo    string               `json:"section_no"`
+	SessionCount int64                `json:"session_count"`
+	Students     []*StudentAttendance `json:"students"`
+	Overall      AttendanceStats      `json:"overall"`
+}
+
+// AttendanceService 课次与考勤服务
+type AttendanceService struct {
+	tx            dao.Transactor
+	sessionDAO    dao.ClassSessionRepository
+	attendanceDAO dao.AttendanceRepository
+	sectionDAO    dao.CourseSectionRepository
+	enrollmentDAO dao.EnrollmentRepository
+	studentDAO    dao.StudentRepository
+}
+
+// NewAttendanceService 创建课次与考勤服务实例
+func NewAttendanceService(tx dao.Transactor, sessionDAO dao.ClassSessionRepository, attendanceDAO dao.AttendanceRepository, sectionDAO dao.CourseSectionRepository, enrollmentDAO dao.EnrollmentRepository, studentDAO dao.StudentRepository) *AttendanceService {
+	return &AttendanceService{
+		tx:            tx,
+		sessionDAO:    sessionDAO,
+		attendanceDAO: attendanceDAO,
+		sectionDAO:    sectionDAO,
+		enrollmentDAO: enrollmentDAO,
+		studentDAO:    studentDAO,
+	}
+}
+
+// CreateSession 创建课次，校验上课时间和重复课次
+func (s *AttendanceService) CreateSession(ctx context.Context, session *model.ClassSession) error {
+	ctx, span := tracing.Start(ctx, "AttendanceService.CreateSession")
+	defer span.End()
+	if _, err := s.sectionDAO.GetByID(ctx, session.SectionID); err != nil {
+		return err
+	}
+	if err := s.validateSession(ctx, session, 0); err != nil {
+		return err
+	}
+	if err := s.sessionDAO.Create(ctx, session); err != nil {
+		return sessionSaveError(err)
+	}
+	return s.reload(ctx, session)
+}
+
+// GenerateSessions 按教学班的上课时间为日期范围内（含首尾）的每次课生成课次，已存在的课次跳过
+func (s *AttendanceService) GenerateSessions(ctx context.Context, sectionID int64, from, to time.Time, actorID *int64) ([]*model.ClassSession, error) {
+	ctx, span := tracing.Start(ctx, "AttendanceService.GenerateSessions")
+	defer span.End()
+	section, err := s.sectionDAO.GetByID(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	if to.Before(from) || to.Sub(from) > maxGenerateDays*24*time.Hour {
+		return nil, ErrInvalidSessionDateRange
+	}
+	slots := sectionSlots(section)
+	if len(slots) == 0 {
+		return nil, ErrSectionScheduleRequired
+	}
+
+	existing, err := s.sessionDAO.GetBySectionBetween(ctx, sectionID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	exists := make(map[string]bool, len(existing))
+	for _, session := range existing {
+		exists[session.SessionDate.Format("2006-01-02")+" "+session.StartTime] = true
+	}
+
+	var sessions []*model.ClassSession
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		// time.Weekday 周日为 0，上课时间中周日为 7
+		weekday := int(date.Weekday())
+		if weekday == 0 {
+			weekday = 7
+		}
+		for _, slot := range slots {
+			if slot.Weekday != weekday {
+				continue
+			}
+			start := formatClock(slot.Start)
+			if exists[date.Format("2006-01-02")+" "+start] {
+				continue
+			}
+			sessions = append(sessions, &model.ClassSession{
+				SectionID:   sectionID,
+				SessionDate: date,
+				StartTime:   start,
+				EndTime:     formatClock(slot.End),
+				CreatedBy:   actorID,
+				UpdatedBy:   actorID,
+			})
+		}
+	}
+
+	if err := s.sessionDAO.CreateBatch(ctx, sessions); err != nil {
+		return nil, sessionSaveError(err)
+	}
+	return sessions, nil
+}
+
+// GetSessionByID 根据ID获取课次
+func (s *AttendanceService) GetSessionByID(ctx context.Context, id int64) (*model.ClassSession, error) {
+	ctx, span := tracing.Start(ctx, "AttendanceService.GetSessionByID")
+	defer span.End()
+	return s.sessionDAO.GetByID(ctx, id)
+}
+
+// UpdateSession 更新课次的日期、时间和授课内容
+func (s *AttendanceService) UpdateSession(ctx context.Context, session *model.ClassSession) error {
+	ctx, span := tracing.Start(ctx, "AttendanceService.UpdateSession")
+	defer span.End()
+	if err := s.validateSession(ctx, session, session.ID); err != nil {
+		return err
+	}
+	if err := s.sessionDAO.Update(ctx, session); err != nil {
+		return sessionSaveError(err)
+	}
+	return s.reload(ctx, session)
+}
+
+// DeleteSession 删除课次及其考勤记录
+func (s *AttendanceService) DeleteSession(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "AttendanceService.DeleteSession")
+	defer span.End()
+	if _, err := s.sessionDAO.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.attendanceDAO.DeleteBySession(ctx, id); err != nil {
+			return err
+		}
+		return s.sessionDAO.Delete(ctx, id)
+	})
+}
+
+// GetSessionList 获取课次列表，filters 支持 section_id、date_from、date_to
+func (s *AttendanceService) GetSessionList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.ClassSession, int64, error) {
+	ctx, span := tracing.Start(ctx, "AttendanceService.GetSessionList")
+	defer span.End()
+	return s.sessionDAO.GetList(ctx, page, pageSize, filters)
+}
+
+// GetRoster 获取课次的点名册：已选或已修完该教学班的学生及其考勤记录
+// 已有考勤记录但之后退课的学生也会列出
+func (s *AttendanceService) GetRoster(ctx context.Context, sessionID int64) ([]*RosterEntry, error) {
+	ctx, span := tracing.Start(ctx, "AttendanceService.GetRoster")
+	defer span.End()
+	session, err := s.sessionDAO.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.attendanceDAO.GetBySession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	enrolled, err := s.enrolledStudentIDs(ctx, session.SectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordByStudent := make(map[int64]*model.AttendanceRecord, len(records))
+	ids := append([]int64{}, enrolled...)
+	inRoster := make(map[int64]bool, len(enrolled))
+	for _, id := range enrolled {
+		inRoster[id] = true
+	}
+	for _, record := range records {
+		recordByStudent[record.StudentID] = record
+		if !inRoster[record.StudentID] {
+			inRoster[record.StudentID] = true
+			ids = append(ids, record.StudentID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	names, err := s.studentNames(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	roster := make([]*RosterEntry, 0, len(ids))
+	for _, id := range ids {
+		roster = append(roster, &RosterEntry{
+			StudentID:   id,
+			StudentName: names[id],
+			Record:      recordByStudent[id],
+		})
+	}
+	return roster, nil
+}
+
+// MarkAttendance 批量记录课次考勤，已有记录的学生更新状态，学生须已选或已修完该教学班
+func (s *AttendanceService) MarkAttendance(ctx context.Context, sessionID int64, marks []*AttendanceMark, actorID *int64) ([]*RosterEntry, error) {
+	ctx, span := tracing.Start(ctx, "AttendanceService.MarkAttendance")
+	defer span.End()
+	if len(marks) == 0 {
+		return nil, ErrAttendanceMarksRequired
+	}
+	session, err := s.sessionDAO.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolled, err := s.enrolledStudentIDs(ctx, session.SectionID)
+	if err != nil {
+		return nil, err
+	}
+	inSection := make(map[int64]bool, len(enrolled))
+	for _, id := range enrolled {
+		inSection[id] = true
+	}
+
+	seen := make(map[int64]bool, len(marks))
+	var notInSection []string
+	for _, mark := range marks {
+		if !containsString(AttendanceStatuses, mark.Status) {
+			return nil, ErrInvalidAttendanceStatus
+		}
+		if seen[mark.StudentID] {
+			return nil, ErrDuplicateAttendanceMark
+		}
+		seen[mark.StudentID] = true
+		if !inSection[mark.StudentID] {
+			notInSection = append(notInSection, fmt.Sprint(mark.StudentID))
+		}
+	}
+	if len(notInSection) > 0 {
+		return nil, fmt.Errorf("%w: 学生ID %s", ErrStudentNotInSection, strings.Join(notInSection, "、"))
+	}
+
+	if err := s.saveMarks(ctx, sessionID, marks, false, actorID); err != nil {
+		return nil, err
+	}
+	return s.GetRoster(ctx, sessionID)
+}
+
+// MarkAll 把教学班全部已选学生的考勤记为同一状态，onlyUnmarked 为 true 时只记录还没有考勤记录的学生
+// 常用于先全部记为出勤，再单独修改缺勤、迟到的学生
+func (s *AttendanceService) MarkAll(ctx context.Context, sessionID int64, status string, onlyUnmarked bool, actorID *int64) ([]*RosterEntry, error) {
+	ctx, span := tracing.Start(ctx, "AttendanceService.MarkAll")
+	defer span.End()
+	if !containsString(AttendanceStatuses, status) {
+		return nil, ErrInvalidAttendanceStatus
+	}
+	session, err := s.sessionDAO.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	enrolled, err := s.enrolledStudentIDs(ctx, session.SectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	marks := make([]*AttendanceMark, 0, len(enrolled))
+	for _, id := range enrolled {
+		marks = append(marks, &AttendanceMark{StudentID: id, Status: status})
+	}
+	if err := s.saveMarks(ctx, sessionID, marks, onlyUnmarked, actorID); err != nil {
+		return nil, err
+	}
+	return s.GetRoster(ctx, sessionID)
+}
+
+// GetStudentReport 获取学生各教学班及总体的考勤统计，term 不为空时只统计该学期
+func (s *AttendanceService) GetStudentReport(ctx context.Context, studentID int64, term string) (*StudentAttendanceReport, error) {
+	ctx, span := tracing.Start(ctx, "AttendanceService.GetStudentReport")
+	defer span.End()
+	student, err := s.studentDAO.GetByID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := map[string]interface{}{"student_id": studentID}
+	if term != "" {
+		filters["term"] = term
+	}
+	rows, err := s.attendanceDAO.Summarize(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	sectionIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		sectionIDs = append(sectionIDs, row.SectionID)
+	}
+	sections, err := s.sectionDAO.GetByIDs(ctx, sectionIDs)
+	if err != nil {
+		return nil, err
+	}
+	sectionByID := make(map[int64]*model.CourseSection, len(sections))
+	for _, section := range sections {
+		sectionByID[section.ID] = section
+	}
+
+	report := &StudentAttendanceReport{
+		StudentID:   student.ID,
+		StudentName: student.Name,
+		Term:        term,
+		Sections:    []*SectionAttendance{},
+	}
+	for _, row := range rows {
+		item := &SectionAttendance{SectionID: row.SectionID, AttendanceStats: newAttendanceStats(row)}
+		if section := sectionByID[row.SectionID]; section != nil {
+			item.Term = section.Term
+			item.SectionNo = section.SectionNo
+			if section.Course != nil {
+				item.CourseCode = section.Course.Code
+				item.CourseTitle = section.Course.Title
+			}
+		}
+		report.Sections = append(report.Sections, item)
+		report.Overall.add(row)
+	}
+	report.Overall.computeRate()
+	return report, nil
+}
+
+// GetSectionReport 获取教学班每名学生及总体的考勤统计，已选但还没有考勤记录的学生也会列出
+func (s *AttendanceService) GetSectionReport(ctx context.Context, sectionID int64) (*SectionAttendanceReport, error) {
+	ctx, span := tracing.Start(ctx, "AttendanceService.GetSectionReport")
+	defer span.End()
+	section, err := s.sectionDAO.GetByID(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	sessionCount, err := s.sessionDAO.CountBySection(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.attendanceDAO.Summarize(ctx, map[string]interface{}{"section_id": sectionID})
+	if err != nil {
+		return nil, err
+	}
+	enrolled, err := s.enrolledStudentIDs(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	rowByStudent := make(map[int64]*dao.AttendanceSummary, len(rows))
+	ids := append([]int64{}, enrolled...)
+	inReport := make(map[int64]bool, len(enrolled))
+	for _, id := range enrolled {
+		inReport[id] = true
+	}
+	for _, row := range rows {
+		rowByStudent[row.StudentID] = row
+		if !inReport[row.StudentID] {
+			inReport[row.StudentID] = true
+			ids = append(ids, row.StudentID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	names, err := s.studentNames(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SectionAttendanceReport{
+		SectionID:    section.ID,
+		Term:         section.Term,
+		SectionNo:    section.SectionNo,
+		SessionCount: sessionCount,
+		Students:     []*StudentAttendance{},
+	}
+	if section.Course != nil {
+		report.CourseCode = section.Course.Code
+		report.CourseTitle = section.Course.Title
+	}
+	for _, id := range ids {
+		item := &StudentAttendance{StudentID: id, StudentName: names[id]}
+		if row := rowByStudent[id]; row != nil {
+			item.AttendanceStats = newAttendanceStats(row)
+			report.Overall.add(row)
+		}
+		report.Students = append(report.Students, item)
+	}
+	report.Overall.computeRate()
+	return report, nil
+}
+
+// saveMarks 在事务中保存考勤，已有记录的更新（skipExisting 为 true 时跳过），没有的新建
+func (s *AttendanceService) saveMarks(ctx context.Context, sessionID int64, marks []*AttendanceMark, skipExisting bool, actorID *int64) error {
+	now := time.Now()
+	return s.tx.Transaction(ctx, func(ctx context.Context) error {
+		records, err := s.attendanceDAO.GetBySession(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+		existing := make(map[int64]*model.AttendanceRecord, len(records))
+		for _, record := range records {
+			existing[record.StudentID] = record
+		}
+
+		for _, mark := range marks {
+			if record, ok := existing[mark.StudentID]; ok {
+				if skipExisting {
+					continue
+				}
+				record.Status = mark.Status
+				record.Remarks = mark.Remarks
+				record.MarkedBy = actorID
+				record.MarkedAt = &now
+				if err := s.attendanceDAO.Update(ctx, record); err != nil {
+					return err
+				}
+				continue
+			}
+
+			record := &model.AttendanceRecord{
+				SessionID: sessionID,
+				StudentID: mark.StudentID,
+				Status:    mark.Status,
+				Remarks:   mark.Remarks,
+				MarkedBy:  actorID,
+				MarkedAt:  &now,
+			}
+			if err := s.attendanceDAO.Create(ctx, record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// enrolledStudentIDs 已选或已修完教学班的学生ID
+func (s *AttendanceService) enrolledStudentIDs(ctx context.Context, sectionID int64) ([]int64, error) {
+	enrollments, err := s.enrollmentDAO.GetBySection(ctx, sectionID, rosterEnrollmentStatuses)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(enrollments))
+	seen := make(map[int64]bool, len(enrollments))
+	for _, enrollment := range enrollments {
+		if !seen[enrollment.StudentID] {
+			seen[enrollment.StudentID] = true
+			ids = append(ids, enrollment.StudentID)
+		}
+	}
+	return ids, nil
+}
+
+// studentNames 批量获取学生姓名，返回 学生ID => 姓名
+func (s *AttendanceService) studentNames(ctx context.Context, ids []int64) (map[int64]string, error) {
+	students, err := s.studentDAO.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(students))
+	for _, student := range students {
+		names[student.ID] = student.Name
+	}
+	return names, nil
+}
+
+// validateSession 校验课次的上课时间，并检查同一天同一开始时间的重复课次
+func (s *AttendanceService) validateSession(ctx context.Context, session *model.ClassSession, excludeID int64) error {
+	start, okStart := parseClock(session.StartTime)
+	end, okEnd := parseClock(session.EndTime)
+	if !okStart || !okEnd || start >= end {
+		return ErrInvalidSessionTime
+	}
+	session.StartTime = formatClock(start)
+	session.EndTime = formatClock(end)
+
+	exists, err := s.sessionDAO.CheckExists(ctx, session.SectionID, session.SessionDate, session.StartTime, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrSessionExists
+	}
+	return nil
+}
+
+// sessionSaveError 并发请求在检查之后写入了同一课次时，唯一索引冲突按重复课次处理
+func sessionSaveError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrSessionExists
+	}
+	return err
+}
+
+// reload 重新加载课次所属教学班和课程
+func (s *AttendanceService) reload(ctx context.Context, session *model.ClassSession) error {
+	saved, err := s.sessionDAO.GetByID(ctx, session.ID)
+	if err != nil {
+		return err
+	}
+	*session = *saved
+	return nil
+}
+
+// newAttendanceStats 由统计结果计算考勤统计
+func newAttendanceStats(row *dao.AttendanceSummary) AttendanceStats {
+	var stats AttendanceStats
+	stats.add(row)
+	stats.computeRate()
+	return stats
+}
+
+// add 累加统计结果
+func (a *AttendanceStats) add(row *dao.AttendanceSummary) {
+	a.Present += row.Present
+	a.Absent += row.Absent
+	a.Late += row.Late
+	a.Excused += row.Excused
+	a.Total += row.Present + row.Absent + row.Late + row.Excused
+}
+
+// computeRate 计算出勤率，请假不计入，保留两位小数
+func (a *AttendanceStats) computeRate() {
+	counted := a.Present + a.Absent + a.Late
+	if counted == 0 {
+		a.Rate = nil
+		return
+	}
+	rate := math.Round(float64(a.Present+a.Late)/float64(counted)*10000) / 100
+	a.Rate = &rate
+}
+
+// formatClock 把当天的分钟数格式化为 HH:MM
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// IsAttendanceError 是否为课次与考勤的业务错误
+func IsAttendanceError(err error) bool {
+	for _, target := range []error{
+		ErrInvalidAttendanceStatus, ErrInvalidSessionTime, ErrSessionExists, ErrAttendanceMarksRequired,
+		ErrDuplicateAttendanceMark, ErrStudentNotInSection, ErrInvalidSessionDateRange, ErrSectionScheduleRequired,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/utils"
+)
+
+// writeAuditLog 写入审计日志，detail 序列化为 JSON 保存
+// ctx 中有事务时与业务修改在同一事务中写入；actorID 为 nil 时使用 ctx 中的当前操作人，请求ID取自 ctx
+func writeAuditLog(ctx context.Context, auditLogDAO dao.AuditLogRepository, actorID *int64, action, entityType string, entityID int64, detail interface{}) error {
+	if actorID == nil {
+		actorID = utils.ActorID(ctx)
+	}
+	log := &model.AuditLog{
+		ActorID:    actorID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+	if requestID := utils.RequestID(ctx); requestID != "" {
+		log.RequestID = &requestID
+	}
+	if detail != nil {
+		raw, err := json.Marshal(detail)
+		if err != nil {
+			return err
+		}
+		text := string(raw)
+		log.Detail = &text
+	}
+	return auditLogDAO.Create(ctx, log)
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+)
+
+// ErrCollegeHasMajors 院系下仍有专业
+var ErrCollegeHasMajors = errors.New("院系下仍有专业，不能删除")
+
+// CollegeService 院系服务
+type CollegeService struct {
+	collegeDAO dao.CollegeRepository
+	majorDAO   dao.MajorRepository
+}
+
+// NewCollegeService 创建院系服务实例
+func NewCollegeService(collegeDAO dao.CollegeRepository, majorDAO dao.MajorRepository) *CollegeService {
+	return &CollegeService{
+		collegeDAO: collegeDAO,
+		majorDAO:   majorDAO,
+	}
+}
+
+// CreateCollege 创建院系
+func (s *CollegeService) CreateCollege(ctx context.Context, college *model.College) error {
+	ctx, span := tracing.Start(ctx, "CollegeService.CreateCollege")
+	defer span.End()
+	return s.collegeDAO.Create(ctx, college)
+}
+
+// GetCollegeByID 根据ID获取院系
+func (s *CollegeService) GetCollegeByID(ctx context.Context, id int64) (*model.College, error) {
+	ctx, span := tracing.Start(ctx, "CollegeService.GetCollegeByID")
+	defer span.End()
+	return s.collegeDAO.GetByID(ctx, id)
+}
+
+// CheckCollegeNameExists 检查同一大学下排除某ID外是否存在同名院系，excludeID 为 0 时不排除
+func (s *CollegeService) CheckCollegeNameExists(ctx context.Context, universityID int64, name string, excludeID int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "CollegeService.CheckCollegeNameExists")
+	defer span.End()
+	return s.collegeDAO.CheckNameExists(ctx, universityID, name, excludeID)
+}
+
+// UpdateCollege 更新院系信息
+func (s *CollegeService) UpdateCollege(ctx context.Context, college *model.College) error {
+	ctx, span := tracing.Start(ctx, "CollegeService.UpdateCollege")
+	defer span.End()
+	return s.collegeDAO.Update(ctx, college)
+}
+
+// DeleteCollege 删除院系，院系下仍有专业时返回 ErrCollegeHasMajors
+func (s *CollegeService) DeleteCollege(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "CollegeService.DeleteCollege")
+	defer span.End()
+	count, err := s.majorDAO.CountByCollege(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrCollegeHasMajors
+	}
+	return s.collegeDAO.Delete(ctx, id)
+}
+
+// GetCollegesByUniversity 获取大学下的所有院系
+func (s *CollegeService) GetCollegesByUniversity(ctx context.Context, universityID int64) ([]*model.College, error) {
+	ctx, span := tracing.Start(ctx, "CollegeService.GetCollegesByUniversity")
+	defer span.End()
+	return s.collegeDAO.GetListByUniversity(ctx, universityID)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+)
+
+var (
+	ErrCompanyNameExists = errors.New("企业名称已存在")
+	ErrCompanyInUse      = errors.New("企业仍有招聘岗位或毕业去向记录，不能删除")
+)
+
+// CompanyService 企业服务
+type CompanyService struct {
+	companyDAO    dao.CompanyRepository
+	postingDAO    dao.JobPostingRepository
+	employmentDAO dao.EmploymentRecordRepository
+}
+
+// NewCompanyService 创建企业服务实例
+func NewCompanyService(companyDAO dao.CompanyRepository, postingDAO dao.JobPostingRepository, employmentDAO dao.EmploymentRecordRepository) *CompanyService {
+	return &CompanyService{
+		companyDAO:    companyDAO,
+		postingDAO:    postingDAO,
+		employmentDAO: employmentDAO,
+	}
+}
+
+// CreateCompany 创建企业，企业名称不能重复
+func (s *CompanyService) CreateCompany(ctx context.Context, company *model.Company) error {
+	ctx, span := tracing.Start(ctx, "CompanyService.CreateCompany")
+	defer span.End()
+	if err := s.checkName(ctx, company); err != nil {
+		return err
+	}
+	return s.companyDAO.Create(ctx, company)
+}
+
+// GetCompanyByID 根据ID获取企业
+func (s *CompanyService) GetCompanyByID(ctx context.Context, id int64) (*model.Company, error) {
+	ctx, span := tracing.Start(ctx, "CompanyService.GetCompanyByID")
+	defer span.End()
+	return s.companyDAO.GetByID(ctx, id)
+}
+
+// UpdateCompany 更新企业信息
+func (s *CompanyService) UpdateCompany(ctx context.Context, company *model.Company) error {
+	ctx, span := tracing.Start(ctx, "CompanyService.UpdateCompany")
+	defer span.End()
+	if err := s.checkName(ctx, company); err != nil {
+		return err
+	}
+	return s.companyDAO.Update(ctx, company)
+}
+
+// DeleteCompany 删除企业，企业仍有招聘岗位或毕业去向记录时返回 ErrCompanyInUse
+func (s *CompanyService) DeleteCompany(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "CompanyService.DeleteCompany")
+	defer span.End()
+	if _, err := s.companyDAO.GetByID(ctx, id); err != nil {
+		return err
+	}
+	postings, err := s.postingDAO.CountByCompany(ctx, id)
+	if err != nil {
+		return err
+	}
+	records, err := s.employmentDAO.CountByCompany(ctx, id)
+	if err != nil {
+		return err
+	}
+	if postings > 0 || records > 0 {
+		return ErrCompanyInUse
+	}
+	return s.companyDAO.Delete(ctx, id)
+}
+
+// GetCompanyList 获取企业列表，filters 支持 name（模糊）、industry、city
+func (s *CompanyService) GetCompanyList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Company, int64, error) {
+	ctx, span := tracing.Start(ctx, "CompanyService.GetCompanyList")
+	defer span.End()
+	return s.companyDAO.GetList(ctx, page, pageSize, filters)
+}
+
+// checkName 检查企业名称是否与其他企业重复
+func (s *CompanyService) checkName(ctx context.Context, company *model.Company) error {
+	exists, err := s.companyDAO.CheckNameExists(ctx, company.Name, company.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrCompanyNameExists
+	}
+	return nil
+}
+
+// IsCompanyError 是否为企业的业务错误
+func IsCompanyError(err error) bool {
+	return errors.Is(err, ErrCompanyNameExists) || errors.Is(err, ErrCompanyInUse)
+}
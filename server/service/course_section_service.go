@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrSectionNoExists        = errors.New("该课程本学期已存在相同班号的教学班")
+	ErrSectionHasEnrollments  = errors.New("教学班已有选课记录，不能删除或修改课程、学期")
+	ErrSectionHasSessions     = errors.New("教学班已有课次，不能删除")
+	ErrCapacityBelowEnrolled  = errors.New("容量不能小于已选课人数")
+	ErrSectionCourseImmutable = errors.New("不能修改教学班所属课程")
+	ErrInvalidSchedule        = errors.New("无效的上课时间，格式如 1 08:00-09:40,3 10:00-11:40（星期1-7 开始-结束）")
+)
+
+// scheduleSlot 一次上课时间，Start、End 为当天的分钟数
+type scheduleSlot struct {
+	Weekday int
+	Start   int
+	End     int
+}
+
+// overlaps 两个时间段是否冲突（同一天且时间有重叠）
+func (a scheduleSlot) overlaps(b scheduleSlot) bool {
+	return a.Weekday == b.Weekday && a.Start < b.End && b.Start < a.End
+}
+
+// String 格式化为 "星期 开始-结束"
+func (a scheduleSlot) String() string {
+	return fmt.Sprintf("%d %02d:%02d-%02d:%02d", a.Weekday, a.Start/60, a.Start%60, a.End/60, a.End%60)
+}
+
+// parseSchedule 解析上课时间，格式为逗号分隔的 "星期 开始-结束"，星期 1-7 表示周一到周日
+func parseSchedule(schedule string) ([]scheduleSlot, error) {
+	var slots []scheduleSlot
+	for _, part := range strings.Split(schedule, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, ErrInvalidSchedule
+		}
+		weekday, err := strconv.Atoi(fields[0])
+		if err != nil || weekday < 1 || weekday > 7 {
+			return nil, ErrInvalidSchedule
+		}
+		times := strings.Split(fields[1], "-")
+		if len(times) != 2 {
+			return nil, ErrInvalidSchedule
+		}
+		start, okStart := parseClock(times[0])
+		end, okEnd := parseClock(times[1])
+		if !okStart || !okEnd || start >= end {
+			return nil, ErrInvalidSchedule
+		}
+		slots = append(slots, scheduleSlot{Weekday: weekday, Start: start, End: end})
+	}
+	return slots, nil
+}
+
+// parseClock 解析 HH:MM 为当天的分钟数
+func parseClock(value string) (int, bool) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 {
+		return 0, false
+	}
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, false
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, false
+	}
+	return hour*60 + minute, true
+}
+
+// normalizeSchedule 校验并规范化上课时间，为空时返回 nil
+func normalizeSchedule(schedule *string) (*string, error) {
+	if isEmpty(schedule) {
+		return nil, nil
+	}
+	slots, err := parseSchedule(*schedule)
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]string, 0, len(slots))
+	for _, slot := range slots {
+		parts = append(parts, slot.String())
+	}
+	normalized := strings.Join(parts, ",")
+	return &normalized, nil
+}
+
+// sectionSlots 获取教学班的上课时间，已保存的时间均经过校验
+func sectionSlots(section *model.CourseSection) []scheduleSlot {
+	if section.Schedule == nil {
+		return nil
+	}
+	slots, _ := parseSchedule(*section.Schedule)
+	return slots
+}
+
+// CourseSectionService 教学班服务
+type CourseSectionService struct {
+	sectionDAO    dao.CourseSectionRepository
+	enrollmentDAO dao.EnrollmentRepository
+	sessionDAO    dao.ClassSessionRepository
+}
+
+// NewCourseSectionService 创建教学班服务实例
+func NewCourseSectionService(sectionDAO dao.CourseSectionRepository, enrollmentDAO dao.EnrollmentRepository, sessionDAO dao.ClassSessionRepository) *CourseSectionService {
+	return &CourseSectionService{
+		sectionDAO:    sectionDAO,
+		enrollmentDAO: enrollmentDAO,
+		sessionDAO:    sessionDAO,
+	}
+}
+
+// CreateSection 创建教学班，校验班号和上课时间
+func (s *CourseSectionService) CreateSection(ctx context.Context, section *model.CourseSection) error {
+	ctx, span := tracing.Start(ctx, "CourseSectionService.CreateSection")
+	defer span.End()
+	if err := s.validateSection(ctx, section, 0); err != nil {
+		return err
+	}
+	if err := s.sectionDAO.Create(ctx, section); err != nil {
+		return err
+	}
+	return s.reload(ctx, section)
+}
+
+// GetSectionByID 根据ID获取教学班
+func (s *CourseSectionService) GetSectionByID(ctx context.Context, id int64) (*model.CourseSection, error) {
+	ctx, span := tracing.Start(ctx, "CourseSectionService.GetSectionByID")
+	defer span.End()
+	return s.sectionDAO.GetByID(ctx, id)
+}
+
+// UpdateSection 更新教学班，已有选课记录时不能修改学期，容量不能小于已选课人数
+func (s *CourseSectionService) UpdateSection(ctx context.Context, section *model.CourseSection) error {
+	ctx, span := tracing.Start(ctx, "CourseSectionService.UpdateSection")
+	defer span.End()
+	existing, err := s.sectionDAO.GetByID(ctx, section.ID)
+	if err != nil {
+		return err
+	}
+	if section.CourseID != existing.CourseID {
+		return ErrSectionCourseImmutable
+	}
+	if err := s.validateSection(ctx, section, section.ID); err != nil {
+		return err
+	}
+
+	if section.Term != existing.Term {
+		count, err := s.enrollmentDAO.CountBySection(ctx, section.ID, "")
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrSectionHasEnrollments
+		}
+	}
+	enrolled, err := s.enrollmentDAO.CountBySection(ctx, section.ID, EnrollmentStatusEnrolled)
+	if err != nil {
+		return err
+	}
+	if int64(section.Capacity) < enrolled {
+		return ErrCapacityBelowEnrolled
+	}
+
+	if err := s.sectionDAO.Update(ctx, section); err != nil {
+		return err
+	}
+	return s.reload(ctx, section)
+}
+
+// DeleteSection 删除教学班，已有选课记录或课次时不能删除
+func (s *CourseSectionService) DeleteSection(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "CourseSectionService.DeleteSection")
+	defer span.End()
+	count, err := s.enrollmentDAO.CountBySection(ctx, id, "")
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrSectionHasEnrollments
+	}
+
+	sessions, err := s.sessionDAO.CountBySection(ctx, id)
+	if err != nil {
+		return err
+	}
+	if sessions > 0 {
+		return ErrSectionHasSessions
+	}
+	return s.sectionDAO.Delete(ctx, id)
+}
+
+// GetSectionList 获取教学班列表，filters 支持 course_id、term，同时返回各教学班的已选课人数
+func (s *CourseSectionService) GetSectionList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.CourseSection, int64, map[int64]int64, error) {
+	ctx, span := tracing.Start(ctx, "CourseSectionService.GetSectionList")
+	defer span.End()
+	sections, total, err := s.sectionDAO.GetList(ctx, page, pageSize, filters)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	counts, err := s.CountEnrolled(ctx, sections)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return sections, total, counts, nil
+}
+
+// CountEnrolled 统计教学班的已选课人数，返回 教学班ID => 人数
+func (s *CourseSectionService) CountEnrolled(ctx context.Context, sections []*model.CourseSection) (map[int64]int64, error) {
+	ctx, span := tracing.Start(ctx, "CourseSectionService.CountEnrolled")
+	defer span.End()
+	ids := make([]int64, 0, len(sections))
+	for _, section := range sections {
+		ids = append(ids, section.ID)
+	}
+	return s.enrollmentDAO.CountBySections(ctx, ids, EnrollmentStatusEnrolled)
+}
+
+// validateSection 校验班号是否重复并规范化上课时间
+func (s *CourseSectionService) validateSection(ctx context.Context, section *model.CourseSection, excludeID int64) error {
+	schedule, err := normalizeSchedule(section.Schedule)
+	if err != nil {
+		return err
+	}
+	section.Schedule = schedule
+
+	exists, err := s.sectionDAO.CheckSectionNoExists(ctx, section.CourseID, section.Term, section.SectionNo, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrSectionNoExists
+	}
+	return nil
+}
+
+// reload 重新加载教学班所属课程
+func (s *CourseSectionService) reload(ctx context.Context, section *model.CourseSection) error {
+	saved, err := s.sectionDAO.GetByID(ctx, section.ID)
+	if err != nil {
+		return err
+	}
+	*section = *saved
+	return nil
+}
+
+// IsCourseSectionError 是否为教学班的业务错误
+func IsCourseSectionError(err error) bool {
+	for _, target := range []error{
+		ErrSectionNoExists, ErrSectionHasEnrollments, ErrSectionHasSessions, ErrCapacityBelowEnrolled, ErrSectionCourseImmutable, ErrInvalidSchedule,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
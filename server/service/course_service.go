@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+)
+
+// ErrCourseHasSections 课程下仍有教学班
+var ErrCourseHasSections = errors.New("课程下仍有教学班，不能删除")
+
+// CourseService 课程服务
+type CourseService struct {
+	courseDAO  dao.CourseRepository
+	sectionDAO dao.CourseSectionRepository
+}
+
+// NewCourseService 创建课程服务实例
+func NewCourseService(courseDAO dao.CourseRepository, sectionDAO dao.CourseSectionRepository) *CourseService {
+	return &CourseService{
+		courseDAO:  courseDAO,
+		sectionDAO: sectionDAO,
+	}
+}
+
+// CreateCourse 创建课程
+func (s *CourseService) CreateCourse(ctx context.Context, course *model.Course) error {
+	ctx, span := tracing.Start(ctx, "CourseService.CreateCourse")
+	defer span.End()
+	return s.courseDAO.Create(ctx, course)
+}
+
+// GetCourseByID 根据ID获取课程
+func (s *CourseService) GetCourseByID(ctx context.Context, id int64) (*model.Course, error) {
+	ctx, span := tracing.Start(ctx, "CourseService.GetCourseByID")
+	defer span.End()
+	return s.courseDAO.GetByID(ctx, id)
+}
+
+// CheckCourseCodeExists 检查同一大学下排除某ID外是否存在相同代码的课程，excludeID 为 0 时不排除
+func (s *CourseService) CheckCourseCodeExists(ctx context.Context, universityID int64, code string, excludeID int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "CourseService.CheckCourseCodeExists")
+	defer span.End()
+	return s.courseDAO.CheckCodeExists(ctx, universityID, code, excludeID)
+}
+
+// UpdateCourse 更新课程信息
+func (s *CourseService) UpdateCourse(ctx context.Context, course *model.Course) error {
+	ctx, span := tracing.Start(ctx, "CourseService.UpdateCourse")
+	defer span.End()
+	return s.courseDAO.Update(ctx, course)
+}
+
+// DeleteCourse 删除课程，课程下仍有教学班时返回 ErrCourseHasSections
+func (s *CourseService) DeleteCourse(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "CourseService.DeleteCourse")
+	defer span.End()
+	count, err := s.sectionDAO.CountByCourse(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrCourseHasSections
+	}
+	return s.courseDAO.Delete(ctx, id)
+}
+
+// GetCourseList 获取课程列表，filters 支持 university_id、keyword（课程代码或名称）
+func (s *CourseService) GetCourseList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Course, int64, error) {
+	ctx, span := tracing.Start(ctx, "CourseService.GetCourseList")
+	defer span.End()
+	return s.courseDAO.GetList(ctx, page, pageSize, filters)
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// 毕业去向
+const (
+	EmploymentOutcomeEmployed     = "employed"      // 就业
+	EmploymentOutcomeFurtherStudy = "further_study" // 升学
+	EmploymentOutcomeSelfEmployed = "self_employed" // 自主创业
+	EmploymentOutcomeUnemployed   = "unemployed"    // 待就业
+)
+
+// EmploymentOutcomes 所有毕业去向
+var EmploymentOutcomes = []string{
+	EmploymentOutcomeEmployed, EmploymentOutcomeFurtherStudy, EmploymentOutcomeSelfEmployed, EmploymentOutcomeUnemployed,
+}
+
+// EmploymentReportGroups 就业率报表可用的分组方式
+var EmploymentReportGroups = []string{"university", "major", "graduation_year"}
+
+var (
+	ErrInvalidEmploymentOutcome  = errors.New("无效的毕业去向，可选值: " + strings.Join(EmploymentOutcomes, "、"))
+	ErrEmploymentExists          = errors.New("学生已有毕业去向记录，请修改原记录")
+	ErrEmploymentCompanyNotFound = errors.New("就业企业不存在")
+	ErrInvalidEmploymentGroup    = errors.New("无效的分组方式，可选值: " + strings.Join(EmploymentReportGroups, "、"))
+)
+
+// EmploymentRate 一个分组的毕业去向统计，就业率 = (就业 + 升学 + 自主创业) / 毕业生人数
+type EmploymentRate struct {
+	GroupID      *int64   `json:"group_id"`   // 大学ID、专业ID或毕业年份，未填写时为空
+	GroupName    string   `json:"group_name"` // 大学名称、专业名称或毕业年份
+	Graduates    int64    `json:"graduates"`
+	Employed     int64    `json:"employed"`
+	FurtherStudy int64    `json:"further_study"`
+	SelfEmployed int64    `json:"self_employed"`
+	Unemployed   int64    `json:"unemployed"`
+	Unreported   int64    `json:"unreported"` // 没有毕业去向记录的人数
+	Placed       int64    `json:"placed"`     // 就业、升学、自主创业人数之和
+	Rate         *float64 `json:"rate"`       // 就业率（百分比），没有毕业生时为空
+}
+
+// EmploymentReport 就业率报表
+type EmploymentReport struct {
+	GroupBy string            `json:"group_by"`
+	Groups  []*EmploymentRate `json:"groups"`
+	Overall EmploymentRate    `json:"overall"`
+}
+
+// EmploymentService 毕业去向服务
+type EmploymentService struct {
+	employmentDAO dao.EmploymentRecordRepository
+	studentDAO    dao.StudentRepository
+	companyDAO    dao.CompanyRepository
+	universityDAO dao.UniversityRepository
+	majorDAO      dao.MajorRepository
+}
+
+// NewEmploymentService 创建毕业去向服务实例
+func NewEmploymentService(employmentDAO dao.EmploymentRecordRepository, studentDAO dao.StudentRepository, companyDAO dao.CompanyRepository, universityDAO dao.UniversityRepository, majorDAO dao.MajorRepository) *EmploymentService {
+	return &EmploymentService{
+		employmentDAO: employmentDAO,
+		studentDAO:    studentDAO,
+		companyDAO:    companyDAO,
+		universityDAO: universityDAO,
+		majorDAO:      majorDAO,
+	}
+}
+
+// CreateRecord 登记毕业去向，每名学生只有一条记录
+func (s *EmploymentService) CreateRecord(ctx context.Context, record *model.EmploymentRecord) error {
+	ctx, span := tracing.Start(ctx, "EmploymentService.CreateRecord")
+	defer span.End()
+	if _, err := s.studentDAO.GetByID(ctx, record.StudentID); err != nil {
+		return err
+	}
+	if err := s.validateRecord(ctx, record); err != nil {
+		return err
+	}
+	if err := s.employmentDAO.Create(ctx, record); err != nil {
+		return err
+	}
+	return s.reload(ctx, record)
+}
+
+// GetRecordByID 根据ID获取毕业去向记录
+func (s *EmploymentService) GetRecordByID(ctx context.Context, id int64) (*model.EmploymentRecord, error) {
+	ctx, span := tracing.Start(ctx, "EmploymentService.GetRecordByID")
+	defer span.End()
+	return s.employmentDAO.GetByID(ctx, id)
+}
+
+// UpdateRecord 更新毕业去向记录
+func (s *EmploymentService) UpdateRecord(ctx context.Context, record *model.EmploymentRecord) error {
+	ctx, span := tracing.Start(ctx, "EmploymentService.UpdateRecord")
+	defer span.End()
+	if err := s.validateRecord(ctx, record); err != nil {
+		return err
+	}
+	if err := s.employmentDAO.Update(ctx, record); err != nil {
+		return err
+	}
+	return s.reload(ctx, record)
+}
+
+// DeleteRecord 删除毕业去向记录
+func (s *EmploymentService) DeleteRecord(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "EmploymentService.DeleteRecord")
+	defer span.End()
+	if _, err := s.employmentDAO.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.employmentDAO.Delete(ctx, id)
+}
+
+// GetRecordList 获取毕业去向记录列表，filters 支持 student_id、outcome、company_id
+func (s *EmploymentService) GetRecordList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.EmploymentRecord, int64, error) {
+	ctx, span := tracing.Start(ctx, "EmploymentService.GetRecordList")
+	defer span.End()
+	return s.employmentDAO.GetList(ctx, page, pageSize, filters)
+}
+
+// GetRateReport 统计毕业生的就业率，按 groupBy 分组，filters 支持 university_id、major_id、graduation_year
+// 只统计状态为毕业的学生
+func (s *EmploymentService) GetRateReport(ctx context.Context, groupBy string, filters map[string]interface{}) (*EmploymentReport, error) {
+	ctx, span := tracing.Start(ctx, "EmploymentService.GetRateReport")
+	defer span.End()
+	if !containsString(EmploymentReportGroups, groupBy) {
+		return nil, ErrInvalidEmploymentGroup
+	}
+	studentFilters := map[string]interface{}{"status": StudentStatusGraduated}
+	for key, value := range filters {
+		studentFilters[key] = value
+	}
+
+	rows, err := s.employmentDAO.RateReport(ctx, groupBy, studentFilters)
+	if err != nil {
+		return nil, err
+	}
+	names, err := s.groupNames(ctx, groupBy, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &EmploymentReport{GroupBy: groupBy, Groups: []*EmploymentRate{}}
+	for _, row := range rows {
+		item := &EmploymentRate{
+			GroupID:      row.GroupID,
+			Graduates:    row.Students,
+			Employed:     row.Employed,
+			FurtherStudy: row.FurtherStudy,
+			SelfEmployed: row.SelfEmployed,
+			Unemployed:   row.Unemployed,
+		}
+		if row.GroupID != nil {
+			item.GroupName = names[*row.GroupID]
+		}
+		item.calculate()
+		report.Groups = append(report.Groups, item)
+
+		report.Overall.Graduates += item.Graduates
+		report.Overall.Employed += item.Employed
+		report.Overall.FurtherStudy += item.FurtherStudy
+		report.Overall.SelfEmployed += item.SelfEmployed
+		report.Overall.Unemployed += item.Unemployed
+	}
+	report.Overall.GroupName = "合计"
+	report.Overall.calculate()
+	return report, nil
+}
+
+// groupNames 获取分组ID对应的名称，按毕业年份分组时名称即年份
+func (s *EmploymentService) groupNames(ctx context.Context, groupBy string, rows []*dao.EmploymentRateRow) (map[int64]string, error) {
+	names := make(map[int64]string)
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		if row.GroupID != nil {
+			ids = append(ids, *row.GroupID)
+		}
+	}
+
+	switch groupBy {
+	case "university":
+		universities, err := s.universityDAO.GetByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, university := range universities {
+			names[university.ID] = university.Name
+		}
+	case "major":
+		majors, err := s.majorDAO.GetByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, major := range majors {
+			names[major.ID] = major.Name
+		}
+	default:
+		for _, id := range ids {
+			names[id] = strconv.FormatInt(id, 10)
+		}
+	}
+	return names, nil
+}
+
+// validateRecord 校验毕业去向、就业企业，并检查学生是否已有其他记录
+func (s *EmploymentService) validateRecord(ctx context.Context, record *model.EmploymentRecord) error {
+	if !containsString(EmploymentOutcomes, record.Outcome) {
+		return ErrInvalidEmploymentOutcome
+	}
+	if record.CompanyID != nil {
+		if _, err := s.companyDAO.GetByID(ctx, *record.CompanyID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrEmploymentCompanyNotFound
+			}
+			return err
+		}
+	}
+	exists, err := s.employmentDAO.CheckStudentExists(ctx, record.StudentID, record.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrEmploymentExists
+	}
+	return nil
+}
+
+// reload 重新加载毕业去向记录及其企业
+func (s *EmploymentService) reload(ctx context.Context, record *model.EmploymentRecord) error {
+	loaded, err := s.employmentDAO.GetByID(ctx, record.ID)
+	if err != nil {
+		return err
+	}
+	*record = *loaded
+	return nil
+}
+
+// calculate 根据各去向人数计算未登记人数、落实去向人数和就业率
+func (r *EmploymentRate) calculate() {
+	r.Placed = r.Employed + r.FurtherStudy + r.SelfEmployed
+	r.Unreported = r.Graduates - r.Placed - r.Unemployed
+	if r.Unreported < 0 {
+		// 合并重复学生后同一学生可能有多条记录，分别计入不同去向
+		r.Unreported = 0
+	}
+	r.Rate = nil
+	if r.Graduates > 0 {
+		rate := math.Round(float64(r.Placed)/float64(r.Graduates)*10000) / 100
+		r.Rate = &rate
+	}
+}
+
+// IsEmploymentError 是否为毕业去向的业务错误
+func IsEmploymentError(err error) bool {
+	for _, target := range []error{
+		ErrInvalidEmploymentOutcome, ErrEmploymentExists, ErrEmploymentCompanyNotFound, ErrInvalidEmploymentGroup,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 选课状态
+const (
+	EnrollmentStatusEnrolled  = "enrolled"
+	EnrollmentStatusDropped   = "dropped"
+	EnrollmentStatusCompleted = "completed"
+)
+
+// EnrollmentStatuses 所有选课状态
+var EnrollmentStatuses = []string{EnrollmentStatusEnrolled, EnrollmentStatusDropped, EnrollmentStatusCompleted}
+
+var (
+	ErrInvalidEnrollmentStatus      = errors.New("无效的选课状态，可选值: " + strings.Join(EnrollmentStatuses, "、"))
+	ErrStudentCannotEnroll          = errors.New("只有在读学生可以选课")
+	ErrEnrollmentUniversityMismatch = errors.New("课程不属于学生所在大学")
+	ErrAlreadyEnrolled              = errors.New("学生已选该教学班")
+	ErrSectionCompleted             = errors.New("学生已修完该教学班")
+	ErrCourseAlreadyEnrolled        = errors.New("学生本学期已选该课程的其他教学班")
+	ErrScheduleConflict             = errors.New("上课时间冲突")
+	ErrSectionFull                  = errors.New("教学班已满")
+	ErrEnrollmentNotActive          = errors.New("选课记录不是已选状态")
+)
+
+// EnrollmentService 选课服务
+type EnrollmentService struct {
+	tx            dao.Transactor
+	enrollmentDAO dao.EnrollmentRepository
+	sectionDAO    dao.CourseSectionRepository
+	studentDAO    dao.StudentRepository
+}
+
+// NewEnrollmentService 创建选课服务实例
+func NewEnrollmentService(tx dao.Transactor, enrollmentDAO dao.EnrollmentRepository, sectionDAO dao.CourseSectionRepository, studentDAO dao.StudentRepository) *EnrollmentService {
+	return &EnrollmentService{
+		tx:            tx,
+		enrollmentDAO: enrollmentDAO,
+		sectionDAO:    sectionDAO,
+		studentDAO:    studentDAO,
+	}
+}
+
+// Enroll 学生选课：检查学生状态、重复选课、同学期时间冲突和容量，已退课的教学班可重新选
+// 容量检查时锁定教学班，避免并发选课超出容量
+func (s *EnrollmentService) Enroll(ctx context.Context, studentID, sectionID int64, actorID *int64) (*model.Enrollment, error) {
+	ctx, span := tracing.Start(ctx, "EnrollmentService.Enroll")
+	defer span.End()
+	student, err := s.studentDAO.GetByID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student.Status != nil && *student.Status != StudentStatusEnrolled {
+		return nil, ErrStudentCannotEnroll
+	}
+
+	var enrollmentID int64
+	err = s.tx.Transaction(ctx, func(ctx context.Context) error {
+		section, err := s.sectionDAO.GetByIDForUpdate(ctx, sectionID)
+		if err != nil {
+			return err
+		}
+		if student.UniversityID != nil && section.Course != nil && section.Course.UniversityID != *student.UniversityID {
+			return ErrEnrollmentUniversityMismatch
+		}
+
+		// 已有选课记录时只允许重新选已退的课
+		existing, err := s.enrollmentDAO.GetByStudentAndSection(ctx, studentID, sectionID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil {
+			switch existing.Status {
+			case EnrollmentStatusEnrolled:
+				return ErrAlreadyEnrolled
+			case EnrollmentStatusCompleted:
+				return ErrSectionCompleted
+			}
+		} else {
+			existing = nil
+		}
+
+		current, err := s.enrollmentDAO.GetByStudentAndTerm(ctx, studentID, section.Term, EnrollmentStatusEnrolled)
+		if err != nil {
+			return err
+		}
+		if err := checkEnrollmentConflicts(section, current); err != nil {
+			return err
+		}
+
+		count, err := s.enrollmentDAO.CountBySection(ctx, sectionID, EnrollmentStatusEnrolled)
+		if err != nil {
+			return err
+		}
+		if count >= int64(section.Capacity) {
+			return ErrSectionFull
+		}
+
+		if existing != nil {
+			enrollmentID = existing.ID
+			_, err := s.enrollmentDAO.UpdateStatus(ctx, existing.ID, EnrollmentStatusDropped, EnrollmentStatusEnrolled, actorID)
+			return err
+		}
+
+		now := time.Now()
+		enrollment := &model.Enrollment{
+			StudentID:  studentID,
+			SectionID:  sectionID,
+			Status:     EnrollmentStatusEnrolled,
+			EnrolledAt: &now,
+			CreatedBy:  actorID,
+			UpdatedBy:  actorID,
+		}
+		if err := s.enrollmentDAO.Create(ctx, enrollment); err != nil {
+			return err
+		}
+		enrollmentID = enrollment.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.enrollmentDAO.GetByID(ctx, enrollmentID)
+}
+
+// Drop 退课
+func (s *EnrollmentService) Drop(ctx context.Context, id int64, actorID *int64) (*model.Enrollment, error) {
+	ctx, span := tracing.Start(ctx, "EnrollmentService.Drop")
+	defer span.End()
+	return s.changeStatus(ctx, id, EnrollmentStatusDropped, actorID)
+}
+
+// Complete 标记为已修完
+func (s *EnrollmentService) Complete(ctx context.Context, id int64, actorID *int64) (*model.Enrollment, error) {
+	ctx, span := tracing.Start(ctx, "EnrollmentService.Complete")
+	defer span.End()
+	return s.changeStatus(ctx, id, EnrollmentStatusCompleted, actorID)
+}
+
+// GetEnrollmentByID 根据ID获取选课记录
+func (s *EnrollmentService) GetEnrollmentByID(ctx context.Context, id int64) (*model.Enrollment, error) {
+	ctx, span := tracing.Start(ctx, "EnrollmentService.GetEnrollmentByID")
+	defer span.End()
+	return s.enrollmentDAO.GetByID(ctx, id)
+}
+
+// GetEnrollmentList 获取选课记录列表，filters 支持 student_id、section_id、status、term
+func (s *EnrollmentService) GetEnrollmentList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Enrollment, int64, error) {
+	ctx, span := tracing.Start(ctx, "EnrollmentService.GetEnrollmentList")
+	defer span.End()
+	if status, ok := filters["status"].(string); ok && !isEnrollmentStatus(status) {
+		return nil, 0, ErrInvalidEnrollmentStatus
+	}
+	return s.enrollmentDAO.GetList(ctx, page, pageSize, filters)
+}
+
+// changeStatus 把已选的选课记录变更为目标状态
+func (s *EnrollmentService) changeStatus(ctx context.Context, id int64, status string, actorID *int64) (*model.Enrollment, error) {
+	if _, err := s.enrollmentDAO.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	updated, err := s.enrollmentDAO.UpdateStatus(ctx, id, EnrollmentStatusEnrolled, status, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrEnrollmentNotActive
+	}
+	return s.enrollmentDAO.GetByID(ctx, id)
+}
+
+// checkEnrollmentConflicts 检查教学班与学生本学期已选课程是否重复或上课时间冲突
+func checkEnrollmentConflicts(section *model.CourseSection, current []*model.Enrollment) error {
+	slots := sectionSlots(section)
+	for _, enrollment := range current {
+		other := enrollment.Section
+		if other == nil || other.ID == section.ID {
+			continue
+		}
+		if other.CourseID == section.CourseID {
+			return ErrCourseAlreadyEnrolled
+		}
+		for _, a := range slots {
+			for _, b := range sectionSlots(other) {
+				if a.overlaps(b) {
+					return fmt.Errorf("%w: 与 %s 的 %s", ErrScheduleConflict, sectionLabel(other), b)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// sectionLabel 教学班的显示名称，如 CS101-01
+func sectionLabel(section *model.CourseSection) string {
+	if section.Course == nil {
+		return section.SectionNo
+	}
+	return section.Course.Code + "-" + section.SectionNo
+}
+
+// isEnrollmentStatus 是否为有效的选课状态
+func isEnrollmentStatus(status string) bool {
+	for _, s := range EnrollmentStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEnrollmentError 是否为选课的业务错误
+func IsEnrollmentError(err error) bool {
+	for _, target := range []error{
+		ErrInvalidEnrollmentStatus, ErrStudentCannotEnroll, ErrEnrollmentUniversityMismatch, ErrAlreadyEnrolled,
+		ErrSectionCompleted, ErrCourseAlreadyEnrolled, ErrScheduleConflict, ErrSectionFull, ErrEnrollmentNotActive,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
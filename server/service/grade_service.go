@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrGradeExists            = errors.New("学生本学期该课程已有成绩")
+	ErrGradeTermRequired      = errors.New("未关联选课记录时需要指定学期")
+	ErrGradeEnrollmentDropped = errors.New("已退课的选课记录不能录入成绩")
+	ErrGradeStudentMismatch   = errors.New("选课记录与学生不一致")
+	ErrNoGradingScale         = errors.New("课程未设置评分标准，且没有默认评分标准")
+)
+
+// GradeInput 录入或更新成绩的参数
+// 指定 EnrollmentID 时学生、课程、学期取自选课记录，否则需要直接指定
+type GradeInput struct {
+	EnrollmentID *int64
+	StudentID    int64
+	CourseID     int64
+	Term         string
+	Score        *float64
+	Grade        string
+	Remarks      *string
+	ActorID      *int64
+}
+
+// GradeService 课程成绩服务
+type GradeService struct {
+	tx            dao.Transactor
+	gradeDAO      dao.GradeRepository
+	scaleDAO      dao.GradingScaleRepository
+	courseDAO     dao.CourseRepository
+	studentDAO    dao.StudentRepository
+	enrollmentDAO dao.EnrollmentRepository
+}
+
+// NewGradeService 创建课程成绩服务实例
+func NewGradeService(tx dao.Transactor, gradeDAO dao.GradeRepository, scaleDAO dao.GradingScaleRepository, courseDAO dao.CourseRepository, studentDAO dao.StudentRepository, enrollmentDAO dao.EnrollmentRepository) *GradeService {
+	return &GradeService{
+		tx:            tx,
+		gradeDAO:      gradeDAO,
+		scaleDAO:      scaleDAO,
+		courseDAO:     courseDAO,
+		studentDAO:    studentDAO,
+		enrollmentDAO: enrollmentDAO,
+	}
+}
+
+// RecordGrade 录入成绩：按课程的评分标准（未设置时用默认评分标准）换算等级和绩点，并记录课程当时的学分
+// 关联的选课记录为已选状态时，同一事务中标记为已修完
+func (s *GradeService) RecordGrade(ctx context.Context, input *GradeInput) (*model.Grade, error) {
+	ctx, span := tracing.Start(ctx, "GradeService.RecordGrade")
+	defer span.End()
+	var enrollment *model.Enrollment
+	if input.EnrollmentID != nil {
+		var err error
+		enrollment, err = s.enrollmentDAO.GetByID(ctx, *input.EnrollmentID)
+		if err != nil {
+			return nil, err
+		}
+		if enrollment.Status == EnrollmentStatusDropped {
+			return nil, ErrGradeEnrollmentDropped
+		}
+		if input.StudentID != 0 && input.StudentID != enrollment.StudentID {
+			return nil, ErrGradeStudentMismatch
+		}
+		input.StudentID = enrollment.StudentID
+		if enrollment.Section != nil {
+			input.CourseID = enrollment.Section.CourseID
+			input.Term = enrollment.Section.Term
+		}
+	}
+
+	input.Term = strings.TrimSpace(input.Term)
+	if input.Term == "" {
+		return nil, ErrGradeTermRequired
+	}
+	if _, err := s.studentDAO.GetByID(ctx, input.StudentID); err != nil {
+		return nil, err
+	}
+	course, err := s.courseDAO.GetByID(ctx, input.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.gradeDAO.CheckExists(ctx, input.StudentID, input.CourseID, input.Term)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrGradeExists
+	}
+
+	scale, err := s.courseScale(ctx, course)
+	if err != nil {
+		return nil, err
+	}
+
+	grade := &model.Grade{
+		StudentID:    input.StudentID,
+		CourseID:     input.CourseID,
+		EnrollmentID: input.EnrollmentID,
+		Term:         input.Term,
+		Credits:      course.Credits,
+		Remarks:      input.Remarks,
+		CreatedBy:    input.ActorID,
+		UpdatedBy:    input.ActorID,
+	}
+	if err := applyGradeScale(grade, scale, input.Score, input.Grade); err != nil {
+		return nil, err
+	}
+
+	err = s.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.gradeDAO.Create(ctx, grade); err != nil {
+			return err
+		}
+		if enrollment != nil && enrollment.Status == EnrollmentStatusEnrolled {
+			_, err := s.enrollmentDAO.UpdateStatus(ctx, enrollment.ID, EnrollmentStatusEnrolled, EnrollmentStatusCompleted, input.ActorID)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.gradeDAO.GetByID(ctx, grade.ID)
+}
+
+// UpdateGrade 更新成绩的分数或等级，按成绩原来的评分标准重新换算
+func (s *GradeService) UpdateGrade(ctx context.Context, id int64, input *GradeInput) (*model.Grade, error) {
+	ctx, span := tracing.Start(ctx, "GradeService.UpdateGrade")
+	defer span.End()
+	grade, err := s.gradeDAO.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	scale, err := s.scaleDAO.GetByID(ctx, grade.ScaleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGradingScaleNotFound
+		}
+		return nil, err
+	}
+	if err := applyGradeScale(grade, scale, input.Score, input.Grade); err != nil {
+		return nil, err
+	}
+	grade.Remarks = input.Remarks
+	grade.UpdatedBy = input.ActorID
+
+	if err := s.gradeDAO.Update(ctx, grade); err != nil {
+		return nil, err
+	}
+	return s.gradeDAO.GetByID(ctx, id)
+}
+
+// DeleteGrade 删除成绩
+func (s *GradeService) DeleteGrade(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "GradeService.DeleteGrade")
+	defer span.End()
+	if _, err := s.gradeDAO.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.gradeDAO.Delete(ctx, id)
+}
+
+// GetGradeByID 根据ID获取成绩
+func (s *GradeService) GetGradeByID(ctx context.Context, id int64) (*model.Grade, error) {
+	ctx, span := tracing.Start(ctx, "GradeService.GetGradeByID")
+	defer span.End()
+	return s.gradeDAO.GetByID(ctx, id)
+}
+
+// GetGradeList 获取成绩列表，filters 支持 student_id、course_id、term
+func (s *GradeService) GetGradeList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Grade, int64, error) {
+	ctx, span := tracing.Start(ctx, "GradeService.GetGradeList")
+	defer span.End()
+	return s.gradeDAO.GetList(ctx, page, pageSize, filters)
+}
+
+// courseScale 获取课程的评分标准，课程未设置时使用默认评分标准
+func (s *GradeService) courseScale(ctx context.Context, course *model.Course) (*model.GradingScale, error) {
+	if course.GradingScaleID != nil {
+		scale, err := s.scaleDAO.GetByID(ctx, *course.GradingScaleID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGradingScaleNotFound
+		}
+		return scale, err
+	}
+
+	scale, err := s.scaleDAO.GetDefault(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNoGradingScale
+	}
+	return scale, err
+}
+
+// applyGradeScale 按评分标准换算并写入成绩的分数、等级、绩点和是否通过
+func applyGradeScale(grade *model.Grade, scale *model.GradingScale, score *float64, label string) error {
+	item, score, err := resolveGrade(scale, score, label)
+	if err != nil {
+		return err
+	}
+	grade.ScaleID = scale.ID
+	grade.Score = score
+	grade.Grade = item.Grade
+	grade.GradePoint = item.GradePoint
+	grade.Passed = item.Passing
+	return nil
+}
+
+// IsGradeError 是否为成绩的业务错误（含评分换算错误）
+func IsGradeError(err error) bool {
+	if IsGradingScaleError(err) {
+		return true
+	}
+	for _, target := range []error{
+		ErrGradeExists, ErrGradeTermRequired, ErrGradeEnrollmentDropped, ErrGradeStudentMismatch, ErrNoGradingScale,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"sort"
+	"strings"
+)
+
+// 评分标准类型
+const (
+	GradingScalePercentage = "percentage" // 百分制：录入分数，按分数下限换算等级和绩点
+	GradingScaleLetter     = "letter"     // 等级制：直接录入 A、B+ 等等级
+	GradingScaleFivePoint  = "five_point" // 五级制：直接录入 优秀、良好 等等级
+)
+
+// GradingScaleTypes 所有评分标准类型
+var GradingScaleTypes = []string{GradingScalePercentage, GradingScaleLetter, GradingScaleFivePoint}
+
+var (
+	ErrInvalidGradingScaleType   = errors.New("无效的评分标准类型，可选值: " + strings.Join(GradingScaleTypes, "、"))
+	ErrGradingScaleItemsRequired = errors.New("评分标准至少需要一个等级")
+	ErrDuplicateScaleGrade       = errors.New("评分标准中的等级或分数下限重复")
+	ErrScaleMinScoreRequired     = errors.New("百分制的每个等级都需要 0-100 的分数下限，且需要有下限为 0 的等级")
+	ErrInvalidGradePoint         = errors.New("绩点须在 0 到 5 之间")
+	ErrGradingScaleInUse         = errors.New("评分标准已被课程或成绩使用，不能删除")
+	ErrDeleteDefaultScale        = errors.New("不能删除默认评分标准")
+	ErrGradingScaleNotFound      = errors.New("评分标准不存在")
+	ErrScoreRequired             = errors.New("百分制评分标准需要录入分数")
+	ErrInvalidScore              = errors.New("分数须在 0 到 100 之间")
+	ErrGradeNotInScale           = errors.New("等级不在评分标准中")
+)
+
+// GradingScaleService 评分标准服务
+type GradingScaleService struct {
+	tx       dao.Transactor
+	scaleDAO dao.GradingScaleRepository
+}
+
+// NewGradingScaleService 创建评分标准服务实例
+func NewGradingScaleService(tx dao.Transactor, scaleDAO dao.GradingScaleRepository) *GradingScaleService {
+	return &GradingScaleService{
+		tx:       tx,
+		scaleDAO: scaleDAO,
+	}
+}
+
+// CreateScale 创建评分标准及其等级，设为默认时取消其他评分标准的默认标记
+func (s *GradingScaleService) CreateScale(ctx context.Context, scale *model.GradingScale, items []*model.GradingScaleItem) error {
+	ctx, span := tracing.Start(ctx, "GradingScaleService.CreateScale")
+	defer span.End()
+	if err := validateGradingScale(scale, items); err != nil {
+		return err
+	}
+
+	err := s.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.scaleDAO.Create(ctx, scale); err != nil {
+			return err
+		}
+		return s.saveItemsAndDefault(ctx, scale, items)
+	})
+	if err != nil {
+		return err
+	}
+	return s.reload(ctx, scale)
+}
+
+// UpdateScale 更新评分标准并替换全部等级，已录入的成绩保留录入时的等级和绩点
+func (s *GradingScaleService) UpdateScale(ctx context.Context, scale *model.GradingScale, items []*model.GradingScaleItem) error {
+	ctx, span := tracing.Start(ctx, "GradingScaleService.UpdateScale")
+	defer span.End()
+	if err := validateGradingScale(scale, items); err != nil {
+		return err
+	}
+
+	err := s.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.scaleDAO.Update(ctx, scale); err != nil {
+			return err
+		}
+		return s.saveItemsAndDefault(ctx, scale, items)
+	})
+	if err != nil {
+		return err
+	}
+	return s.reload(ctx, scale)
+}
+
+// DeleteScale 删除评分标准，默认评分标准和已被使用的评分标准不能删除
+func (s *GradingScaleService) DeleteScale(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "GradingScaleService.DeleteScale")
+	defer span.End()
+	scale, err := s.scaleDAO.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if scale.IsDefault {
+		return ErrDeleteDefaultScale
+	}
+
+	count, err := s.scaleDAO.CountUsage(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrGradingScaleInUse
+	}
+	return s.scaleDAO.Delete(ctx, id)
+}
+
+// GetScaleByID 根据ID获取评分标准
+func (s *GradingScaleService) GetScaleByID(ctx context.Context, id int64) (*model.GradingScale, error) {
+	ctx, span := tracing.Start(ctx, "GradingScaleService.GetScaleByID")
+	defer span.End()
+	return s.scaleDAO.GetByID(ctx, id)
+}
+
+// GetAllScales 获取所有评分标准
+func (s *GradingScaleService) GetAllScales(ctx context.Context) ([]*model.GradingScale, error) {
+	ctx, span := tracing.Start(ctx, "GradingScaleService.GetAllScales")
+	defer span.End()
+	return s.scaleDAO.GetAll(ctx)
+}
+
+// saveItemsAndDefault 保存等级，设为默认时取消其他评分标准的默认标记
+func (s *GradingScaleService) saveItemsAndDefault(ctx context.Context, scale *model.GradingScale, items []*model.GradingScaleItem) error {
+	if err := s.scaleDAO.ReplaceItems(ctx, scale.ID, items); err != nil {
+		return err
+	}
+	if scale.IsDefault {
+		return s.scaleDAO.ClearDefault(ctx, scale.ID)
+	}
+	return nil
+}
+
+// reload 重新加载评分标准及其等级
+func (s *GradingScaleService) reload(ctx context.Context, scale *model.GradingScale) error {
+	saved, err := s.scaleDAO.GetByID(ctx, scale.ID)
+	if err != nil {
+		return err
+	}
+	*scale = *saved
+	return nil
+}
+
+// validateGradingScale 校验评分标准类型和等级，等级名称去除首尾空白，非百分制清空分数下限
+func validateGradingScale(scale *model.GradingScale, items []*model.GradingScaleItem) error {
+	if !containsString(GradingScaleTypes, scale.Type) {
+		return ErrInvalidGradingScaleType
+	}
+	if len(items) == 0 {
+		return ErrGradingScaleItemsRequired
+	}
+
+	grades := make(map[string]bool, len(items))
+	minScores := make(map[float64]bool, len(items))
+	for _, item := range items {
+		item.Grade = strings.TrimSpace(item.Grade)
+		key := strings.ToUpper(item.Grade)
+		if item.Grade == "" || grades[key] {
+			return ErrDuplicateScaleGrade
+		}
+		grades[key] = true
+
+		if item.GradePoint < 0 || item.GradePoint > 5 {
+			return ErrInvalidGradePoint
+		}
+
+		if scale.Type != GradingScalePercentage {
+			item.MinScore = nil
+			continue
+		}
+		if item.MinScore == nil || *item.MinScore < 0 || *item.MinScore > 100 {
+			return ErrScaleMinScoreRequired
+		}
+		if minScores[*item.MinScore] {
+			return ErrDuplicateScaleGrade
+		}
+		minScores[*item.MinScore] = true
+	}
+
+	// 百分制需要覆盖 0 分，保证任何分数都能换算
+	if scale.Type == GradingScalePercentage && !minScores[0] {
+		return ErrScaleMinScoreRequired
+	}
+	return nil
+}
+
+// resolveGrade 按评分标准换算等级：百分制按分数匹配分数下限最高的等级，其他类型按等级名称匹配
+// 返回匹配的等级和需要保存的分数（非百分制为 nil）
+func resolveGrade(scale *model.GradingScale, score *float64, grade string) (*model.GradingScaleItem, *float64, error) {
+	if scale.Type == GradingScalePercentage {
+		if score == nil {
+			return nil, nil, ErrScoreRequired
+		}
+		if *score < 0 || *score > 100 {
+			return nil, nil, ErrInvalidScore
+		}
+
+		items := make([]*model.GradingScaleItem, 0, len(scale.Items))
+		for _, item := range scale.Items {
+			if item.MinScore != nil {
+				items = append(items, item)
+			}
+		}
+		sort.Slice(items, func(i, j int) bool { return *items[i].MinScore > *items[j].MinScore })
+		for _, item := range items {
+			if *score >= *item.MinScore {
+				return item, score, nil
+			}
+		}
+		return nil, nil, ErrGradeNotInScale
+	}
+
+	grade = strings.TrimSpace(grade)
+	for _, item := range scale.Items {
+		if strings.EqualFold(item.Grade, grade) {
+			return item, nil, nil
+		}
+	}
+	return nil, nil, ErrGradeNotInScale
+}
+
+// containsString 切片中是否包含字符串
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// IsGradingScaleError 是否为评分标准的业务错误（含成绩换算错误）
+func IsGradingScaleError(err error) bool {
+	for _, target := range []error{
+		ErrInvalidGradingScaleType, ErrGradingScaleItemsRequired, ErrDuplicateScaleGrade, ErrScaleMinScoreRequired,
+		ErrInvalidGradePoint, ErrGradingScaleInUse, ErrDeleteDefaultScale, ErrGradingScaleNotFound,
+		ErrScoreRequired, ErrInvalidScore, ErrGradeNotInScale,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"strings"
+	"time"
+)
+
+// 岗位申请状态
+const (
+	ApplicationStatusApplied   = "applied"   // 已投递
+	ApplicationStatusInterview = "interview" // 面试中
+	ApplicationStatusOffer     = "offer"     // 已发 offer
+	ApplicationStatusAccepted  = "accepted"  // 已接受
+	ApplicationStatusRejected  = "rejected"  // 未通过（含学生拒绝 offer）
+)
+
+// ApplicationStatuses 所有岗位申请状态
+var ApplicationStatuses = []string{
+	ApplicationStatusApplied, ApplicationStatusInterview, ApplicationStatusOffer, ApplicationStatusAccepted, ApplicationStatusRejected,
+}
+
+// applicationTransitions 允许的申请状态变更：当前状态 => 可变更为的状态，已接受和未通过为终态
+var applicationTransitions = map[string][]string{
+	ApplicationStatusApplied:   {ApplicationStatusInterview, ApplicationStatusOffer, ApplicationStatusRejected},
+	ApplicationStatusInterview: {ApplicationStatusOffer, ApplicationStatusRejected},
+	ApplicationStatusOffer:     {ApplicationStatusAccepted, ApplicationStatusRejected},
+}
+
+var (
+	ErrInvalidApplicationStatus  = errors.New("无效的申请状态，可选值: " + strings.Join(ApplicationStatuses, "、"))
+	ErrApplicationExists         = errors.New("学生已申请该岗位")
+	ErrPostingNotOpen            = errors.New("岗位已关闭或已过截止日期")
+	ErrApplicationTransition     = errors.New("不允许的申请状态变更")
+	ErrApplicationStatusConflict = errors.New("申请状态已被其他操作修改，请刷新后重试")
+	ErrApplicationAccepted       = errors.New("已接受的申请不能删除")
+)
+
+// JobApplicationService 岗位申请服务
+type JobApplicationService struct {
+	tx             dao.Transactor
+	applicationDAO dao.JobApplicationRepository
+	postingDAO     dao.JobPostingRepository
+	studentDAO     dao.StudentRepository
+	employmentDAO  dao.EmploymentRecordRepository
+}
+
+// NewJobApplicationService 创建岗位申请服务实例
+func NewJobApplicationService(tx dao.Transactor, applicationDAO dao.JobApplicationRepository, postingDAO dao.JobPostingRepository, studentDAO dao.StudentRepository, employmentDAO dao.EmploymentRecordRepository) *JobApplicationService {
+	return &JobApplicationService{
+		tx:             tx,
+		applicationDAO: applicationDAO,
+		postingDAO:     postingDAO,
+		studentDAO:     studentDAO,
+		employmentDAO:  employmentDAO,
+	}
+}
+
+// Apply 学生申请岗位，岗位须在招聘中且未过截止日期，同一学生同一岗位只能申请一次
+func (s *JobApplicationService) Apply(ctx context.Context, postingID, studentID int64, remarks *string, actorID *int64) (*model.JobApplication, error) {
+	ctx, span := tracing.Start(ctx, "JobApplicationService.Apply")
+	defer span.End()
+	if _, err := s.studentDAO.GetByID(ctx, studentID); err != nil {
+		return nil, err
+	}
+	posting, err := s.postingDAO.GetByID(ctx, postingID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !IsPostingOpen(posting, now) {
+		return nil, ErrPostingNotOpen
+	}
+	exists, err := s.applicationDAO.CheckExists(ctx, postingID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrApplicationExists
+	}
+
+	application := &model.JobApplication{
+		PostingID: postingID,
+		StudentID: studentID,
+		Status:    ApplicationStatusApplied,
+		AppliedAt: now,
+		Remarks:   remarks,
+		CreatedBy: actorID,
+		UpdatedBy: actorID,
+	}
+	if err := s.applicationDAO.Create(ctx, application); err != nil {
+		return nil, err
+	}
+	application.Posting = posting
+	return application, nil
+}
+
+// GetApplicationByID 根据ID获取岗位申请
+func (s *JobApplicationService) GetApplicationByID(ctx context.Context, id int64) (*model.JobApplication, error) {
+	ctx, span := tracing.Start(ctx, "JobApplicationService.GetApplicationByID")
+	defer span.End()
+	return s.applicationDAO.GetByID(ctx, id)
+}
+
+// ChangeStatus 按申请流程变更状态，remarks 不为空时同时更新备注
+// 接受全职岗位的 offer 时，学生还没有毕业去向记录则同时登记为就业
+func (s *JobApplicationService) ChangeStatus(ctx context.Context, id int64, status string, remarks *string, actorID *int64) (*model.JobApplication, error) {
+	ctx, span := tracing.Start(ctx, "JobApplicationService.ChangeStatus")
+	defer span.End()
+	if !containsString(ApplicationStatuses, status) {
+		return nil, ErrInvalidApplicationStatus
+	}
+	application, err := s.applicationDAO.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(applicationTransitions[application.Status], status) {
+		return nil, ErrApplicationTransition
+	}
+
+	err = s.tx.Transaction(ctx, func(ctx context.Context) error {
+		ok, err := s.applicationDAO.UpdateStatus(ctx, id, application.Status, status, remarks, actorID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrApplicationStatusConflict
+		}
+		if status != ApplicationStatusAccepted || application.Posting == nil || application.Posting.Type != PostingTypeJob {
+			return nil
+		}
+
+		exists, err := s.employmentDAO.CheckStudentExists(ctx, application.StudentID, 0)
+		if err != nil || exists {
+			return err
+		}
+		posting := application.Posting
+		return s.employmentDAO.Create(ctx, &model.EmploymentRecord{
+			StudentID:     application.StudentID,
+			Outcome:       EmploymentOutcomeEmployed,
+			CompanyID:     &posting.CompanyID,
+			Position:      &posting.Title,
+			City:          posting.City,
+			ApplicationID: &application.ID,
+			CreatedBy:     actorID,
+			UpdatedBy:     actorID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applicationDAO.GetByID(ctx, id)
+}
+
+// DeleteApplication 删除岗位申请，已接受的申请不能删除
+func (s *JobApplicationService) DeleteApplication(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "JobApplicationService.DeleteApplication")
+	defer span.End()
+	application, err := s.applicationDAO.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if application.Status == ApplicationStatusAccepted {
+		return ErrApplicationAccepted
+	}
+	return s.applicationDAO.Delete(ctx, id)
+}
+
+// GetApplicationList 获取岗位申请列表，filters 支持 posting_id、student_id、status
+func (s *JobApplicationService) GetApplicationList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.JobApplication, int64, error) {
+	ctx, span := tracing.Start(ctx, "JobApplicationService.GetApplicationList")
+	defer span.End()
+	return s.applicationDAO.GetList(ctx, page, pageSize, filters)
+}
+
+// IsJobApplicationError 是否为岗位申请的业务错误
+func IsJobApplicationError(err error) bool {
+	for _, target := range []error{
+		ErrInvalidApplicationStatus, ErrApplicationExists, ErrPostingNotOpen, ErrApplicationTransition,
+		ErrApplicationStatusConflict, ErrApplicationAccepted,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 岗位类型
+const (
+	PostingTypeJob        = "job"        // 全职
+	PostingTypeInternship = "internship" // 实习
+)
+
+// 岗位状态
+const (
+	PostingStatusOpen   = "open"   // 招聘中
+	PostingStatusClosed = "closed" // 已关闭
+)
+
+// PostingTypes 所有岗位类型
+var PostingTypes = []string{PostingTypeJob, PostingTypeInternship}
+
+// PostingStatuses 所有岗位状态
+var PostingStatuses = []string{PostingStatusOpen, PostingStatusClosed}
+
+var (
+	ErrInvalidPostingType     = errors.New("无效的岗位类型，可选值: job、internship")
+	ErrInvalidPostingStatus   = errors.New("无效的岗位状态，可选值: open、closed")
+	ErrPostingCompanyNotFound = errors.New("所属企业不存在")
+	ErrPostingHasApplications = errors.New("岗位已有申请，不能删除，可以关闭岗位")
+)
+
+// JobPostingService 招聘岗位服务
+type JobPostingService struct {
+	postingDAO     dao.JobPostingRepository
+	companyDAO     dao.CompanyRepository
+	applicationDAO dao.JobApplicationRepository
+}
+
+// NewJobPostingService 创建招聘岗位服务实例
+func NewJobPostingService(postingDAO dao.JobPostingRepository, companyDAO dao.CompanyRepository, applicationDAO dao.JobApplicationRepository) *JobPostingService {
+	return &JobPostingService{
+		postingDAO:     postingDAO,
+		companyDAO:     companyDAO,
+		applicationDAO: applicationDAO,
+	}
+}
+
+// CreatePosting 发布招聘岗位，状态为空时为招聘中
+func (s *JobPostingService) CreatePosting(ctx context.Context, posting *model.JobPosting) error {
+	ctx, span := tracing.Start(ctx, "JobPostingService.CreatePosting")
+	defer span.End()
+	if posting.Status == "" {
+		posting.Status = PostingStatusOpen
+	}
+	if err := s.validatePosting(ctx, posting); err != nil {
+		return err
+	}
+	if err := s.postingDAO.Create(ctx, posting); err != nil {
+		return err
+	}
+	return s.reload(ctx, posting)
+}
+
+// GetPostingByID 根据ID获取招聘岗位
+func (s *JobPostingService) GetPostingByID(ctx context.Context, id int64) (*model.JobPosting, error) {
+	ctx, span := tracing.Start(ctx, "JobPostingService.GetPostingByID")
+	defer span.End()
+	return s.postingDAO.GetByID(ctx, id)
+}
+
+// UpdatePosting 更新招聘岗位
+func (s *JobPostingService) UpdatePosting(ctx context.Context, posting *model.JobPosting) error {
+	ctx, span := tracing.Start(ctx, "JobPostingService.UpdatePosting")
+	defer span.End()
+	if err := s.validatePosting(ctx, posting); err != nil {
+		return err
+	}
+	if err := s.postingDAO.Update(ctx, posting); err != nil {
+		return err
+	}
+	return s.reload(ctx, posting)
+}
+
+// DeletePosting 删除招聘岗位，已有申请时返回 ErrPostingHasApplications
+func (s *JobPostingService) DeletePosting(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "JobPostingService.DeletePosting")
+	defer span.End()
+	if _, err := s.postingDAO.GetByID(ctx, id); err != nil {
+		return err
+	}
+	count, err := s.applicationDAO.CountByPosting(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrPostingHasApplications
+	}
+	return s.postingDAO.Delete(ctx, id)
+}
+
+// GetPostingList 获取招聘岗位列表，filters 支持 keyword（岗位名称）、company_id、type、status
+func (s *JobPostingService) GetPostingList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.JobPosting, int64, error) {
+	ctx, span := tracing.Start(ctx, "JobPostingService.GetPostingList")
+	defer span.End()
+	return s.postingDAO.GetList(ctx, page, pageSize, filters)
+}
+
+// IsPostingOpen 岗位是否可以申请：招聘中且未过截止日期（截止日期当天仍可申请）
+func IsPostingOpen(posting *model.JobPosting, now time.Time) bool {
+	if posting.Status != PostingStatusOpen {
+		return false
+	}
+	return posting.Deadline == nil || !truncateDate(now).After(truncateDate(*posting.Deadline))
+}
+
+// validatePosting 校验岗位类型、状态和所属企业
+func (s *JobPostingService) validatePosting(ctx context.Context, posting *model.JobPosting) error {
+	if !containsString(PostingTypes, posting.Type) {
+		return ErrInvalidPostingType
+	}
+	if !containsString(PostingStatuses, posting.Status) {
+		return ErrInvalidPostingStatus
+	}
+	if _, err := s.companyDAO.GetByID(ctx, posting.CompanyID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPostingCompanyNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// reload 重新加载岗位及其企业
+func (s *JobPostingService) reload(ctx context.Context, posting *model.JobPosting) error {
+	loaded, err := s.postingDAO.GetByID(ctx, posting.ID)
+	if err != nil {
+		return err
+	}
+	*posting = *loaded
+	return nil
+}
+
+// IsJobPostingError 是否为招聘岗位的业务错误
+func IsJobPostingError(err error) bool {
+	for _, target := range []error{
+		ErrInvalidPostingType, ErrInvalidPostingStatus, ErrPostingCompanyNotFound, ErrPostingHasApplications,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
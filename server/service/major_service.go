@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMajorNotFound             = errors.New("专业不存在")
+	ErrMajorInUse                = errors.New("仍有学生关联该专业，不能删除")
+	ErrCollegeUniversityMismatch = errors.New("院系不属于该专业所在大学")
+	ErrMajorUniversityMismatch   = errors.New("专业不属于学生所在大学")
+)
+
+// matchBatchSize 匹配历史专业名称时每批处理的学生数
+const matchBatchSize = 500
+
+// UnmatchedMajor 未能匹配到专业目录的历史专业名称
+type UnmatchedMajor struct {
+	UniversityID *int64
+	Major        string
+	Count        int    // 填写该名称的学生数
+	Reason       string // 未找到 / 匹配到多个专业
+}
+
+// MajorMatchReport 历史专业名称匹配结果
+type MajorMatchReport struct {
+	Matched   int  // 可关联（或已关联）的学生数
+	Applied   bool // 是否已写入 major_id
+	Unmatched []*UnmatchedMajor
+}
+
+// MajorService 专业服务
+type MajorService struct {
+	majorDAO   dao.MajorRepository
+	collegeDAO dao.CollegeRepository
+	studentDAO dao.StudentRepository
+}
+
+// NewMajorService 创建专业服务实例
+func NewMajorService(majorDAO dao.MajorRepository, collegeDAO dao.CollegeRepository, studentDAO dao.StudentRepository) *MajorService {
+	return &MajorService{
+		majorDAO:   majorDAO,
+		collegeDAO: collegeDAO,
+		studentDAO: studentDAO,
+	}
+}
+
+// CreateMajor 创建专业，指定院系时院系须属于同一大学
+func (s *MajorService) CreateMajor(ctx context.Context, major *model.Major) error {
+	ctx, span := tracing.Start(ctx, "MajorService.CreateMajor")
+	defer span.End()
+	if err := s.validateCollege(ctx, major); err != nil {
+		return err
+	}
+	return s.majorDAO.Create(ctx, major)
+}
+
+// GetMajorByID 根据ID获取专业
+func (s *MajorService) GetMajorByID(ctx context.Context, id int64) (*model.Major, error) {
+	ctx, span := tracing.Start(ctx, "MajorService.GetMajorByID")
+	defer span.End()
+	return s.majorDAO.GetByID(ctx, id)
+}
+
+// CheckMajorNameExists 检查同一大学下排除某ID外是否存在同名专业，excludeID 为 0 时不排除
+func (s *MajorService) CheckMajorNameExists(ctx context.Context, universityID int64, name string, excludeID int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "MajorService.CheckMajorNameExists")
+	defer span.End()
+	return s.majorDAO.CheckNameExists(ctx, universityID, name, excludeID)
+}
+
+// UpdateMajor 更新专业信息，指定院系时院系须属于同一大学
+func (s *MajorService) UpdateMajor(ctx context.Context, major *model.Major) error {
+	ctx, span := tracing.Start(ctx, "MajorService.UpdateMajor")
+	defer span.End()
+	if err := s.validateCollege(ctx, major); err != nil {
+		return err
+	}
+	return s.majorDAO.Update(ctx, major)
+}
+
+// DeleteMajor 删除专业，仍有学生关联时返回 ErrMajorInUse
+func (s *MajorService) DeleteMajor(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "MajorService.DeleteMajor")
+	defer span.End()
+	count, err := s.studentDAO.CountByMajor(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrMajorInUse
+	}
+	return s.majorDAO.Delete(ctx, id)
+}
+
+// GetMajorList 获取专业列表，filters 支持 university_id、college_id
+func (s *MajorService) GetMajorList(ctx context.Context, filters map[string]interface{}) ([]*model.Major, error) {
+	ctx, span := tracing.Start(ctx, "MajorService.GetMajorList")
+	defer span.End()
+	return s.majorDAO.GetList(ctx, filters)
+}
+
+// MatchLegacyMajors 把学生自由填写的专业名称与专业目录匹配
+// 有所属大学的学生只在该大学的专业中匹配；没有所属大学的学生只在全目录唯一同名时匹配。
+// apply 为 false 时只生成报告，为 true 时为匹配成功的学生写入 major_id（不修改专业名称）
+func (s *MajorService) MatchLegacyMajors(ctx context.Context, apply bool) (*MajorMatchReport, error) {
+	ctx, span := tracing.Start(ctx, "MajorService.MatchLegacyMajors")
+	defer span.End()
+	majors, err := s.majorDAO.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按（大学, 规范化名称）和规范化名称建立索引
+	type catalogKey struct {
+		universityID int64
+		name         string
+	}
+	byUniversity := make(map[catalogKey][]int64)
+	byName := make(map[string][]int64)
+	for _, major := range majors {
+		name := normalizeMajorName(major.Name)
+		key := catalogKey{major.UniversityID, name}
+		byUniversity[key] = append(byUniversity[key], major.ID)
+		byName[name] = append(byName[name], major.ID)
+	}
+
+	type unmatchedKey struct {
+		universityID int64 // 0 表示未填写大学
+		major        string
+	}
+	unmatched := make(map[unmatchedKey]*UnmatchedMajor)
+	linked := make(map[int64][]int64) // 专业ID => 学生ID
+	report := &MajorMatchReport{Applied: apply}
+
+	err = s.studentDAO.FindUnlinkedMajors(ctx, matchBatchSize, func(students []*model.Student) error {
+		for _, student := range students {
+			name := normalizeMajorName(*student.Major)
+			var candidates []int64
+			if student.UniversityID != nil {
+				candidates = byUniversity[catalogKey{*student.UniversityID, name}]
+			} else {
+				candidates = byName[name]
+			}
+
+			if len(candidates) == 1 {
+				linked[candidates[0]] = append(linked[candidates[0]], student.ID)
+				report.Matched++
+				continue
+			}
+
+			key := unmatchedKey{major: strings.TrimSpace(*student.Major)}
+			if student.UniversityID != nil {
+				key.universityID = *student.UniversityID
+			}
+			item, ok := unmatched[key]
+			if !ok {
+				item = &UnmatchedMajor{UniversityID: student.UniversityID, Major: key.major, Reason: "未找到"}
+				if len(candidates) > 1 {
+					item.Reason = "匹配到多个专业"
+				}
+				unmatched[key] = item
+			}
+			item.Count++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if apply {
+		for majorID, studentIDs := range linked {
+			if err := s.studentDAO.LinkMajor(ctx, studentIDs, majorID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, item := range unmatched {
+		report.Unmatched = append(report.Unmatched, item)
+	}
+	sort.Slice(report.Unmatched, func(i, j int) bool {
+		if report.Unmatched[i].Count != report.Unmatched[j].Count {
+			return report.Unmatched[i].Count > report.Unmatched[j].Count
+		}
+		return report.Unmatched[i].Major < report.Unmatched[j].Major
+	})
+	return report, nil
+}
+
+// validateCollege 校验专业所属院系与大学一致
+func (s *MajorService) validateCollege(ctx context.Context, major *model.Major) error {
+	if major.CollegeID == nil {
+		return nil
+	}
+	college, err := s.collegeDAO.GetByID(ctx, *major.CollegeID)
+	if err != nil {
+		return err
+	}
+	if college.UniversityID != major.UniversityID {
+		return ErrCollegeUniversityMismatch
+	}
+	return nil
+}
+
+// normalizeMajorName 规范化专业名称用于匹配：去除空白、统一全角括号、忽略大小写和末尾的“专业”
+func normalizeMajorName(name string) string {
+	name = strings.Join(strings.Fields(name), "")
+	name = strings.NewReplacer("（", "(", "）", ")").Replace(name)
+	name = strings.TrimSuffix(name, "专业")
+	return strings.ToLower(name)
+}
+
+// resolveStudentMajor 校验学生关联的专业并同步专业名称，未填写大学时使用专业所在大学
+func resolveStudentMajor(ctx context.Context, majorDAO dao.MajorRepository, student *model.Student) error {
+	if student.MajorID == nil {
+		return nil
+	}
+	major, err := majorDAO.GetByID(ctx, *student.MajorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrMajorNotFound
+		}
+		return err
+	}
+
+	if student.UniversityID == nil {
+		student.UniversityID = &major.UniversityID
+	} else if *student.UniversityID != major.UniversityID {
+		return ErrMajorUniversityMismatch
+	}
+	student.Major = &major.Name
+	return nil
+}
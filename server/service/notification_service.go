@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+)
+
+// 通知类型
+const (
+	NotificationChangeRequestSubmitted = "change_request.submitted"
+	NotificationChangeRequestApproved  = "change_request.approved"
+	NotificationChangeRequestRejected  = "change_request.rejected"
+)
+
+var ErrNotificationNotFound = errors.New("通知不存在")
+
+// NotificationService 站内通知服务
+type NotificationService struct {
+	notificationDAO dao.NotificationRepository
+}
+
+// NewNotificationService 创建站内通知服务实例
+func NewNotificationService(notificationDAO dao.NotificationRepository) *NotificationService {
+	return &NotificationService{
+		notificationDAO: notificationDAO,
+	}
+}
+
+// Notify 给每个用户发送一条内容相同的通知
+func (s *NotificationService) Notify(ctx context.Context, userIDs []int64, notification *model.Notification) error {
+	ctx, span := tracing.Start(ctx, "NotificationService.Notify")
+	defer span.End()
+	notifications := make([]*model.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		n := *notification
+		n.UserID = userID
+		notifications = append(notifications, &n)
+	}
+	return s.notificationDAO.CreateBatch(ctx, notifications)
+}
+
+// GetNotificationList 获取用户的通知列表，unreadOnly 为 true 时只返回未读通知
+func (s *NotificationService) GetNotificationList(ctx context.Context, userID int64, unreadOnly bool, page, pageSize int) ([]*model.Notification, int64, error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.GetNotificationList")
+	defer span.End()
+	return s.notificationDAO.GetListByUser(ctx, userID, unreadOnly, page, pageSize)
+}
+
+// CountUnread 统计用户的未读通知数
+func (s *NotificationService) CountUnread(ctx context.Context, userID int64) (int64, error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.CountUnread")
+	defer span.End()
+	return s.notificationDAO.CountUnread(ctx, userID)
+}
+
+// MarkRead 把用户的一条通知标记为已读
+func (s *NotificationService) MarkRead(ctx context.Context, userID, id int64) error {
+	ctx, span := tracing.Start(ctx, "NotificationService.MarkRead")
+	defer span.End()
+	found, err := s.notificationDAO.MarkRead(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllRead 把用户的所有未读通知标记为已读，返回标记的数量
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID int64) (int64, error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.MarkAllRead")
+	defer span.End()
+	return s.notificationDAO.MarkAllRead(ctx, userID)
+}
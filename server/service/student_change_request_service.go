@@ -0,0 +1,454 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/logging"
+	"mvc-demo/tracing"
+	"strings"
+	"time"
+)
+
+// 变更申请状态
+const (
+	ChangeRequestPending   = "pending"
+	ChangeRequestApproved  = "approved"
+	ChangeRequestRejected  = "rejected"
+	ChangeRequestCancelled = "cancelled"
+)
+
+// ChangeRequestStatuses 所有变更申请状态
+var ChangeRequestStatuses = []string{ChangeRequestPending, ChangeRequestApproved, ChangeRequestRejected, ChangeRequestCancelled}
+
+// userRoleAdmin 管理员角色，与 middleware.AdminAuth 的判断一致，管理员可以审批变更申请
+const userRoleAdmin = 1
+
+var (
+	ErrEmptyStudentChange           = errors.New("变更内容不能为空")
+	ErrChangeApprovalRequired       = errors.New("退学、更换大学需要提交变更申请，经他人审批后生效")
+	ErrChangeRequestNotPending      = errors.New("变更申请已被处理")
+	ErrChangeRequestSelfReview      = errors.New("不能审批自己提交的变更申请")
+	ErrChangeRequestCommentRequired = errors.New("驳回变更申请需要填写审批意见")
+	ErrChangeRequestNotRequester    = errors.New("只能撤回自己提交的变更申请")
+	ErrInvalidChangeRequestStatus   = errors.New("无效的申请状态，可选值: " + strings.Join(ChangeRequestStatuses, "、"))
+	ErrInvalidEffectiveDate         = errors.New("无效的生效日期")
+)
+
+// StudentChange 学生变更内容，字段为 nil 表示不修改
+// 字段变更通过 StudentService.UpdateStudent 应用，Status 非空时按状态机变更状态
+type StudentChange struct {
+	Name           *string              `json:"name,omitempty"`
+	Gender         *int32               `json:"gender,omitempty"`
+	Birthday       *time.Time           `json:"birthday,omitempty"`
+	Phone          *string              `json:"phone,omitempty"`
+	UniversityID   *int64               `json:"university_id,omitempty"`
+	Major          *string              `json:"major,omitempty"`
+	MajorID        *int64               `json:"major_id,omitempty"`
+	Education      *string              `json:"education,omitempty"`
+	GraduationYear *int64               `json:"graduation_year,omitempty"`
+	Remarks        *string              `json:"remarks,omitempty"`
+	Avatar         *string              `json:"avatar,omitempty"`
+	Status         *StudentStatusChange `json:"status,omitempty"`
+}
+
+// StudentStatusChange 变更申请中的状态变更，原因使用申请原因
+type StudentStatusChange struct {
+	Status        string `json:"status"`
+	EffectiveDate string `json:"effective_date"` // 生效日期，格式 2006-01-02
+}
+
+// RequiresApproval 变更是否需要他人审批：变更为退学、更换已有的所属大学
+func (c *StudentChange) RequiresApproval(student *model.Student) bool {
+	if c.Status != nil && StatusChangeRequiresApproval(c.Status.Status) {
+		return true
+	}
+	return c.UniversityID != nil && student.UniversityID != nil && *student.UniversityID != *c.UniversityID
+}
+
+// StatusChangeRequiresApproval 变更为该状态是否需要他人审批
+func StatusChangeRequiresApproval(status string) bool {
+	return status == StudentStatusWithdrawn
+}
+
+// hasFields 是否包含状态以外的字段变更
+func (c *StudentChange) hasFields() bool {
+	return c.Name != nil || c.Gender != nil || c.Birthday != nil || c.Phone != nil || c.UniversityID != nil ||
+		c.Major != nil || c.MajorID != nil || c.Education != nil || c.GraduationYear != nil ||
+		c.Remarks != nil || c.Avatar != nil
+}
+
+// applyTo 把字段变更写入学生对象，规则与学生更新接口一致
+func (c *StudentChange) applyTo(student *model.Student) {
+	if c.Name != nil {
+		student.Name = *c.Name
+	}
+	if c.Gender != nil {
+		student.Gender = c.Gender
+	}
+	if c.Birthday != nil {
+		student.Birthday = c.Birthday
+	}
+	if c.Phone != nil {
+		student.Phone = c.Phone
+	}
+	if c.UniversityID != nil {
+		// 更换大学且未同时指定专业时，原专业不再适用
+		if c.MajorID == nil && (student.UniversityID == nil || *student.UniversityID != *c.UniversityID) {
+			student.MajorID = nil
+		}
+		student.UniversityID = c.UniversityID
+	}
+	if c.Major != nil {
+		student.Major = c.Major
+	}
+	if c.MajorID != nil {
+		student.MajorID = c.MajorID
+	}
+	if c.Education != nil {
+		student.Education = c.Education
+	}
+	if c.GraduationYear != nil {
+		student.GraduationYear = c.GraduationYear
+	}
+	if c.Remarks != nil {
+		student.Remarks = c.Remarks
+	}
+	if c.Avatar != nil {
+		student.Avatar = c.Avatar
+	}
+}
+
+// statusTransition 把状态变更转换为状态机的变更请求
+func (c *StudentChange) statusTransition(reason *string) (*StatusTransition, error) {
+	effectiveDate, err := time.ParseInLocation("2006-01-02", c.Status.EffectiveDate, time.Local)
+	if err != nil {
+		return nil, ErrInvalidEffectiveDate
+	}
+	return &StatusTransition{
+		Status:         c.Status.Status,
+		EffectiveDate:  effectiveDate,
+		Reason:         reason,
+		GraduationYear: c.GraduationYear,
+	}, nil
+}
+
+// StudentChangeRequestService 学生信息变更申请服务：提交后待审批，审批通过时应用变更
+type StudentChangeRequestService struct {
+	tx                   dao.Transactor
+	requestDAO           dao.StudentChangeRequestRepository
+	studentDAO           dao.StudentRepository
+	majorDAO             dao.MajorRepository
+	userDAO              dao.UserRepository
+	auditLogDAO          dao.AuditLogRepository
+	studentService       *StudentService
+	studentStatusService *StudentStatusService
+	notificationService  *NotificationService
+}
+
+// NewStudentChangeRequestService 创建学生信息变更申请服务实例
+func NewStudentChangeRequestService(tx dao.Transactor, requestDAO dao.StudentChangeRequestRepository, studentDAO dao.StudentRepository, majorDAO dao.MajorRepository, userDAO dao.UserRepository, auditLogDAO dao.AuditLogRepository, studentService *StudentService, studentStatusService *StudentStatusService, notificationService *NotificationService) *StudentChangeRequestService {
+	return &StudentChangeRequestService{
+		tx:                   tx,
+		requestDAO:           requestDAO,
+		studentDAO:           studentDAO,
+		majorDAO:             majorDAO,
+		userDAO:              userDAO,
+		auditLogDAO:          auditLogDAO,
+		studentService:       studentService,
+		studentStatusService: studentStatusService,
+		notificationService:  notificationService,
+	}
+}
+
+// Submit 提交变更申请：预检变更内容后保存为待审批，并通知管理员审批
+func (s *StudentChangeRequestService) Submit(ctx context.Context, studentID int64, change *StudentChange, reason *string, requesterID int64) (*model.StudentChangeRequest, error) {
+	ctx, span := tracing.Start(ctx, "StudentChangeRequestService.Submit")
+	defer span.End()
+	student, err := s.studentDAO.GetByID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkChange(ctx, student, change, reason); err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(change)
+	if err != nil {
+		return nil, err
+	}
+	request := &model.StudentChangeRequest{
+		StudentID:   studentID,
+		Changes:     string(raw),
+		Reason:      reason,
+		Status:      ChangeRequestPending,
+		RequesterID: requesterID,
+	}
+	if err := s.requestDAO.Create(ctx, request); err != nil {
+		return nil, err
+	}
+
+	s.notifyReviewers(ctx, request, student)
+	return request, nil
+}
+
+// Approve 审批通过并应用变更，审批人不能是申请人
+// 标记申请已通过、应用变更和写入审计日志在同一事务中完成，变更未能应用（如学生状态已变化）时申请保持待审批
+func (s *StudentChangeRequestService) Approve(ctx context.Context, id, reviewerID int64, comment *string) (*model.StudentChangeRequest, error) {
+	ctx, span := tracing.Start(ctx, "StudentChangeRequestService.Approve")
+	defer span.End()
+	request, change, err := s.getForReview(ctx, id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.Transaction(ctx, func(ctx context.Context) error {
+		// 先按状态条件把申请标记为已通过，避免并发审批重复应用变更
+		updated, err := s.requestDAO.UpdateStatus(ctx, id, ChangeRequestPending, ChangeRequestApproved, &reviewerID, comment)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return ErrChangeRequestNotPending
+		}
+		if err := s.apply(ctx, request, change, reviewerID); err != nil {
+			return err
+		}
+		// 审计日志与变更在同一事务中提交，写入失败时整个审批回滚
+		return s.writeReviewLog(ctx, "student.change_request.approve", request, change, reviewerID, comment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyRequester(ctx, request, NotificationChangeRequestApproved, "学生信息变更申请已通过", comment)
+	return s.requestDAO.GetByID(ctx, id)
+}
+
+// Reject 驳回变更申请，需要填写审批意见
+func (s *StudentChangeRequestService) Reject(ctx context.Context, id, reviewerID int64, comment *string) (*model.StudentChangeRequest, error) {
+	ctx, span := tracing.Start(ctx, "StudentChangeRequestService.Reject")
+	defer span.End()
+	if isEmpty(comment) {
+		return nil, ErrChangeRequestCommentRequired
+	}
+	request, change, err := s.getForReview(ctx, id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.Transaction(ctx, func(ctx context.Context) error {
+		updated, err := s.requestDAO.UpdateStatus(ctx, id, ChangeRequestPending, ChangeRequestRejected, &reviewerID, comment)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return ErrChangeRequestNotPending
+		}
+		return s.writeReviewLog(ctx, "student.change_request.reject", request, change, reviewerID, comment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyRequester(ctx, request, NotificationChangeRequestRejected, "学生信息变更申请被驳回", comment)
+	return s.requestDAO.GetByID(ctx, id)
+}
+
+// Cancel 申请人撤回待审批的变更申请
+func (s *StudentChangeRequestService) Cancel(ctx context.Context, id, requesterID int64) (*model.StudentChangeRequest, error) {
+	ctx, span := tracing.Start(ctx, "StudentChangeRequestService.Cancel")
+	defer span.End()
+	request, err := s.requestDAO.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.RequesterID != requesterID {
+		return nil, ErrChangeRequestNotRequester
+	}
+
+	updated, err := s.requestDAO.UpdateStatus(ctx, id, ChangeRequestPending, ChangeRequestCancelled, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrChangeRequestNotPending
+	}
+	return s.requestDAO.GetByID(ctx, id)
+}
+
+// GetChangeRequestByID 根据ID获取变更申请
+func (s *StudentChangeRequestService) GetChangeRequestByID(ctx context.Context, id int64) (*model.StudentChangeRequest, error) {
+	ctx, span := tracing.Start(ctx, "StudentChangeRequestService.GetChangeRequestByID")
+	defer span.End()
+	return s.requestDAO.GetByID(ctx, id)
+}
+
+// GetChangeRequestList 获取变更申请列表，filters 支持 status、student_id、requester_id、reviewer_id
+func (s *StudentChangeRequestService) GetChangeRequestList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.StudentChangeRequest, int64, error) {
+	ctx, span := tracing.Start(ctx, "StudentChangeRequestService.GetChangeRequestList")
+	defer span.End()
+	if status, ok := filters["status"].(string); ok && !isChangeRequestStatus(status) {
+		return nil, 0, ErrInvalidChangeRequestStatus
+	}
+	return s.requestDAO.GetList(ctx, page, pageSize, filters)
+}
+
+// checkChange 提交时预检变更内容，审批通过时 UpdateStudent 和状态机会再次校验
+func (s *StudentChangeRequestService) checkChange(ctx context.Context, student *model.Student, change *StudentChange, reason *string) error {
+	if !change.hasFields() && change.Status == nil {
+		return ErrEmptyStudentChange
+	}
+
+	if change.hasFields() {
+		preview := *student
+		change.applyTo(&preview)
+		if err := resolveStudentMajor(ctx, s.majorDAO, &preview); err != nil {
+			return err
+		}
+	}
+
+	if change.Status != nil {
+		transition, err := change.statusTransition(reason)
+		if err != nil {
+			return err
+		}
+		if _, err := s.studentStatusService.CheckTransition(ctx, student, transition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getForReview 获取待审批的申请并解析变更内容
+func (s *StudentChangeRequestService) getForReview(ctx context.Context, id, reviewerID int64) (*model.StudentChangeRequest, *StudentChange, error) {
+	request, err := s.requestDAO.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if request.Status != ChangeRequestPending {
+		return nil, nil, ErrChangeRequestNotPending
+	}
+	if request.RequesterID == reviewerID {
+		return nil, nil, ErrChangeRequestSelfReview
+	}
+
+	var change StudentChange
+	if err := json.Unmarshal([]byte(request.Changes), &change); err != nil {
+		return nil, nil, fmt.Errorf("解析变更内容失败: %w", err)
+	}
+	return request, &change, nil
+}
+
+// apply 应用变更：字段变更通过 UpdateStudent 保存，状态变更通过状态机完成，审批人记为状态变更的审批人
+func (s *StudentChangeRequestService) apply(ctx context.Context, request *model.StudentChangeRequest, change *StudentChange, reviewerID int64) error {
+	student, err := s.studentDAO.GetByID(ctx, request.StudentID)
+	if err != nil {
+		return err
+	}
+
+	// 先校验状态变更，避免字段已保存而状态变更失败
+	var transition *StatusTransition
+	if change.Status != nil {
+		transition, err = change.statusTransition(request.Reason)
+		if err != nil {
+			return err
+		}
+		transition.ApproverID = &reviewerID
+		transition.ActorID = &reviewerID
+		if err := s.studentStatusService.validateTransition(ctx, student, transition); err != nil {
+			return err
+		}
+	}
+
+	if change.hasFields() {
+		change.applyTo(student)
+		student.UpdatedBy = &reviewerID
+		if err := s.studentService.UpdateStudent(ctx, student); err != nil {
+			return err
+		}
+	}
+
+	if transition != nil {
+		if _, err := s.studentStatusService.Transition(ctx, student.ID, transition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeReviewLog 记录审批操作的审计日志，应在审批的事务中调用，与审批结果一起提交
+func (s *StudentChangeRequestService) writeReviewLog(ctx context.Context, action string, request *model.StudentChangeRequest, change *StudentChange, reviewerID int64, comment *string) error {
+	detail := map[string]interface{}{
+		"request_id":   request.ID,
+		"requester_id": request.RequesterID,
+		"changes":      change,
+		"comment":      comment,
+	}
+	return writeAuditLog(ctx, s.auditLogDAO, &reviewerID, action, "student", request.StudentID, detail)
+}
+
+// notifyReviewers 通知除申请人以外的管理员审批，通知失败不影响申请
+func (s *StudentChangeRequestService) notifyReviewers(ctx context.Context, request *model.StudentChangeRequest, student *model.Student) {
+	adminIDs, err := s.userDAO.GetIDsByRole(ctx, userRoleAdmin)
+	if err != nil {
+		logging.FromContext(ctx).Error("获取审批人失败", "change_request_id", request.ID, "error", err)
+		return
+	}
+	reviewerIDs := make([]int64, 0, len(adminIDs))
+	for _, id := range adminIDs {
+		if id != request.RequesterID {
+			reviewerIDs = append(reviewerIDs, id)
+		}
+	}
+
+	content := fmt.Sprintf("学生 %s（ID: %d）有一条信息变更申请待审批", student.Name, student.ID)
+	s.notify(ctx, reviewerIDs, request, NotificationChangeRequestSubmitted, "待审批的学生信息变更", &content)
+}
+
+// notifyRequester 通知申请人审批结果
+func (s *StudentChangeRequestService) notifyRequester(ctx context.Context, request *model.StudentChangeRequest, notificationType, title string, comment *string) {
+	s.notify(ctx, []int64{request.RequesterID}, request, notificationType, title, comment)
+}
+
+// notify 发送与变更申请关联的通知，通知失败只记录日志
+func (s *StudentChangeRequestService) notify(ctx context.Context, userIDs []int64, request *model.StudentChangeRequest, notificationType, title string, content *string) {
+	entityType := model.TableNameStudentChangeRequest
+	err := s.notificationService.Notify(ctx, userIDs, &model.Notification{
+		Type:       notificationType,
+		Title:      title,
+		Content:    content,
+		EntityType: &entityType,
+		EntityID:   &request.ID,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Error("发送通知失败", "type", notificationType, "change_request_id", request.ID, "error", err)
+	}
+}
+
+// isChangeRequestStatus 是否为有效的申请状态
+func isChangeRequestStatus(status string) bool {
+	for _, s := range ChangeRequestStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsChangeRequestError 是否为变更申请的业务错误（含变更内容校验错误）
+func IsChangeRequestError(err error) bool {
+	for _, target := range []error{
+		ErrEmptyStudentChange, ErrChangeApprovalRequired, ErrChangeRequestNotPending, ErrChangeRequestSelfReview,
+		ErrChangeRequestCommentRequired, ErrChangeRequestNotRequester, ErrInvalidChangeRequestStatus, ErrInvalidEffectiveDate,
+		ErrMajorNotFound, ErrMajorUniversityMismatch,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return IsStudentStatusError(err)
+}
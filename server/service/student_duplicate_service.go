@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/logging"
+	"mvc-demo/search"
+	"mvc-demo/tracing"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrMergeSameStudent       = errors.New("不能把学生合并到自身")
+	ErrUnsupportedMergeField  = errors.New("不支持选择的合并字段")
+	ErrInvalidMergeFieldValue = errors.New("合并字段只能选择 survivor 或 loser")
+	ErrMergeReferenceConflict = errors.New("两个学生有合并后会重复的关联记录，请先处理后再合并")
+)
+
+const (
+	// DefaultDuplicateMinScore 默认的疑似重复得分下限
+	DefaultDuplicateMinScore = 50
+	// duplicateScanBatchSize 查重时每批读取的学生数
+	duplicateScanBatchSize = 1000
+	// duplicateMaxBlockSize 同一姓名/电话下参与两两比较的最大学生数，避免常见姓名产生过多候选
+	duplicateMaxBlockSize = 50
+)
+
+// 查重各项得分，满分 100
+const (
+	scoreSameName       = 40
+	scoreSamePinyin     = 30
+	scoreSamePhone      = 30
+	scoreSameBirthday   = 20
+	scoreSameUniversity = 10
+)
+
+// StudentDuplicatePair 疑似重复的学生
+type StudentDuplicatePair struct {
+	StudentIDs [2]int64
+	Score      int
+	Reasons    []string
+}
+
+// studentMergeFields 合并时可按字段选择取值的学生字段，email、密码始终保留 survivor 的
+// 所属大学和状态不能通过合并修改：大学变更须经信息变更申请，状态变更须经 StudentStatusService.Transition
+var studentMergeFields = map[string]func(dst, src *model.Student){
+	"name":            func(dst, src *model.Student) { dst.Name = src.Name },
+	"gender":          func(dst, src *model.Student) { dst.Gender = src.Gender },
+	"birthday":        func(dst, src *model.Student) { dst.Birthday = src.Birthday },
+	"phone":           func(dst, src *model.Student) { dst.Phone = src.Phone },
+	"resume_path":     func(dst, src *model.Student) { dst.ResumePath = src.ResumePath },
+	"major":           func(dst, src *model.Student) { dst.Major = src.Major },
+	"major_id":        func(dst, src *model.Student) { dst.MajorID = src.MajorID },
+	"education":       func(dst, src *model.Student) { dst.Education = src.Education },
+	"graduation_year": func(dst, src *model.Student) { dst.GraduationYear = src.GraduationYear },
+	"remarks":         func(dst, src *model.Student) { dst.Remarks = src.Remarks },
+	"avatar":          func(dst, src *model.Student) { dst.Avatar = src.Avatar },
+}
+
+// StudentDuplicateService 学生查重与合并服务
+type StudentDuplicateService struct {
+	tx          dao.Transactor
+	studentDAO  dao.StudentRepository
+	majorDAO    dao.MajorRepository
+	auditLogDAO dao.AuditLogRepository
+	indexer     StudentIndexer
+}
+
+// NewStudentDuplicateService 创建学生查重与合并服务实例，indexer 为 nil 时不维护搜索索引
+func NewStudentDuplicateService(tx dao.Transactor, studentDAO dao.StudentRepository, majorDAO dao.MajorRepository, auditLogDAO dao.AuditLogRepository, indexer StudentIndexer) *StudentDuplicateService {
+	return &StudentDuplicateService{
+		tx:          tx,
+		studentDAO:  studentDAO,
+		majorDAO:    majorDAO,
+		auditLogDAO: auditLogDAO,
+		indexer:     indexer,
+	}
+}
+
+// FindDuplicates 查找疑似重复的学生，按得分从高到低排序
+// 只比较姓名（或姓名拼音）相同、或电话相同的学生，再按姓名、电话、生日、大学计算得分
+func (s *StudentDuplicateService) FindDuplicates(ctx context.Context, minScore int) ([]*StudentDuplicatePair, error) {
+	ctx, span := tracing.Start(ctx, "StudentDuplicateService.FindDuplicates")
+	defer span.End()
+	// 按姓名、拼音、电话分组
+	blocks := make(map[string][]*model.Student)
+	addToBlock := func(key string, student *model.Student) {
+		if len(blocks[key]) < duplicateMaxBlockSize {
+			blocks[key] = append(blocks[key], student)
+		}
+	}
+	err := s.studentDAO.FindDuplicateCandidates(ctx, duplicateScanBatchSize, func(students []*model.Student) error {
+		for _, student := range students {
+			// 批次切片会被复用，需要复制
+			student := *student
+			name := normalizeStudentName(student.Name)
+			if name != "" {
+				addToBlock("n:"+name, &student)
+				addToBlock("p:"+namePinyin(name), &student)
+			}
+			if phone := normalizePhone(student.Phone); phone != "" {
+				addToBlock("t:"+phone, &student)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 组内两两比较，同一对学生只计算一次
+	seen := make(map[[2]int64]bool)
+	var pairs []*StudentDuplicatePair
+	for _, students := range blocks {
+		for i := 0; i < len(students); i++ {
+			for j := i + 1; j < len(students); j++ {
+				a, b := students[i], students[j]
+				if a.ID > b.ID {
+					a, b = b, a
+				}
+				key := [2]int64{a.ID, b.ID}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				score, reasons := scoreStudentPair(a, b)
+				if score >= minScore {
+					pairs = append(pairs, &StudentDuplicatePair{StudentIDs: key, Score: score, Reasons: reasons})
+				}
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Score != pairs[j].Score {
+			return pairs[i].Score > pairs[j].Score
+		}
+		return pairs[i].StudentIDs[0] < pairs[j].StudentIDs[0]
+	})
+	return pairs, nil
+}
+
+// Merge 把 loser 合并到 survivor
+// fields 指定各字段取哪一方的值（survivor/loser），未指定的字段保留 survivor 的值，survivor 为空时取 loser 的值。
+// 在一个事务中更新 survivor、把关联记录移到 survivor、记录 loser 的 merged_into_id 并软删除，写入审计日志
+func (s *StudentDuplicateService) Merge(ctx context.Context, survivorID, loserID int64, fields map[string]string, actorID *int64) (*model.Student, error) {
+	ctx, span := tracing.Start(ctx, "StudentDuplicateService.Merge")
+	defer span.End()
+	if survivorID == loserID {
+		return nil, ErrMergeSameStudent
+	}
+	for field, side := range fields {
+		if _, ok := studentMergeFields[field]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedMergeField, field)
+		}
+		if side != "survivor" && side != "loser" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidMergeFieldValue, field)
+		}
+	}
+
+	var survivor *model.Student
+	err := s.tx.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		survivor, err = s.studentDAO.GetByID(ctx, survivorID)
+		if err != nil {
+			return err
+		}
+		loser, err := s.studentDAO.GetByID(ctx, loserID)
+		if err != nil {
+			return err
+		}
+
+		// 合并字段
+		taken := fillEmptyStudentFields(survivor, loser, fields)
+		for field, side := range fields {
+			if side == "loser" {
+				studentMergeFields[field](survivor, loser)
+				taken = appendUnique(taken, field)
+			}
+		}
+		if actorID != nil {
+			survivor.UpdatedBy = actorID
+		}
+		if err := ValidateStudentStatus(survivor); err != nil {
+			return err
+		}
+		if err := resolveStudentMajor(ctx, s.majorDAO, survivor); err != nil {
+			return err
+		}
+		if err := s.studentDAO.Update(ctx, survivor); err != nil {
+			return err
+		}
+
+		// 关联记录移到 survivor，loser 记录合并去向后软删除
+		// 两人选了同一教学班等情况下移动后会产生重复记录（教学班人数也会重复计算），拒绝合并
+		conflicts, err := s.studentDAO.FindReferenceConflicts(ctx, loser.ID, survivor.ID)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			details := make([]string, len(conflicts))
+			for i, conflict := range conflicts {
+				details[i] = fmt.Sprintf("%s %d 条", conflict.Name, conflict.Count)
+			}
+			return fmt.Errorf("%w：%s", ErrMergeReferenceConflict, strings.Join(details, "、"))
+		}
+		if err := s.studentDAO.ReassignRelated(ctx, loser.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := s.studentDAO.MarkMerged(ctx, loser.ID, survivor.ID); err != nil {
+			return err
+		}
+
+		return writeAuditLog(ctx, s.auditLogDAO, actorID, "student.merge", "student", survivor.ID, map[string]interface{}{
+			"survivor_id":  survivor.ID,
+			"loser_id":     loser.ID,
+			"loser_name":   loser.Name,
+			"loser_email":  loser.Email,
+			"taken_fields": taken,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 更新搜索索引（ctx 中有外层事务时在其提交后更新），失败时只记录日志，可通过重建索引修复
+	if s.indexer != nil {
+		dao.AfterCommit(ctx, func(ctx context.Context) {
+			if err := s.indexer.IndexStudent(ctx, survivor); err != nil {
+				logging.FromContext(ctx).Warn("更新学生索引失败", logging.ComponentKey, logging.ComponentSearch, "student_id", survivor.ID, "error", err)
+			}
+			if err := s.indexer.RemoveStudent(ctx, loserID); err != nil {
+				logging.FromContext(ctx).Warn("删除学生索引失败", logging.ComponentKey, logging.ComponentSearch, "student_id", loserID, "error", err)
+			}
+		})
+	}
+	return survivor, nil
+}
+
+// scoreStudentPair 计算两个学生的重复得分和命中原因
+func scoreStudentPair(a, b *model.Student) (int, []string) {
+	score := 0
+	var reasons []string
+
+	nameA, nameB := normalizeStudentName(a.Name), normalizeStudentName(b.Name)
+	switch {
+	case nameA != "" && nameA == nameB:
+		score += scoreSameName
+		reasons = append(reasons, "姓名相同")
+	case nameA != "" && namePinyin(nameA) == namePinyin(nameB):
+		score += scoreSamePinyin
+		reasons = append(reasons, "姓名拼音相同")
+	}
+
+	if phone := normalizePhone(a.Phone); phone != "" && phone == normalizePhone(b.Phone) {
+		score += scoreSamePhone
+		reasons = append(reasons, "电话相同")
+	}
+
+	if a.Birthday != nil && b.Birthday != nil && a.Birthday.Format("2006-01-02") == b.Birthday.Format("2006-01-02") {
+		score += scoreSameBirthday
+		reasons = append(reasons, "生日相同")
+	}
+
+	if a.UniversityID != nil && b.UniversityID != nil && *a.UniversityID == *b.UniversityID {
+		score += scoreSameUniversity
+		reasons = append(reasons, "大学相同")
+	}
+
+	return score, reasons
+}
+
+// fillEmptyStudentFields 未指定取值方的字段，survivor 为空时取 loser 的值，返回取值的字段
+func fillEmptyStudentFields(survivor, loser *model.Student, fields map[string]string) []string {
+	var taken []string
+	fill := func(field string, empty, other bool) {
+		if _, chosen := fields[field]; chosen {
+			return
+		}
+		if empty && other {
+			studentMergeFields[field](survivor, loser)
+			taken = append(taken, field)
+		}
+	}
+	fill("gender", survivor.Gender == nil, loser.Gender != nil)
+	fill("birthday", survivor.Birthday == nil, loser.Birthday != nil)
+	fill("phone", isEmpty(survivor.Phone), !isEmpty(loser.Phone))
+	fill("resume_path", isEmpty(survivor.ResumePath), !isEmpty(loser.ResumePath))
+	fill("major", isEmpty(survivor.Major), !isEmpty(loser.Major))
+	fill("major_id", survivor.MajorID == nil, loser.MajorID != nil)
+	fill("education", isEmpty(survivor.Education), !isEmpty(loser.Education))
+	fill("graduation_year", survivor.GraduationYear == nil, loser.GraduationYear != nil)
+	fill("remarks", isEmpty(survivor.Remarks), !isEmpty(loser.Remarks))
+	fill("avatar", isEmpty(survivor.Avatar), !isEmpty(loser.Avatar))
+	return taken
+}
+
+// normalizeStudentName 规范化姓名：去除空白
+func normalizeStudentName(name string) string {
+	return strings.Join(strings.Fields(name), "")
+}
+
+// namePinyin 姓名全拼，用于识别同音不同字的录入错误
+func namePinyin(name string) string {
+	full, _, _ := strings.Cut(search.Pinyin(name), " ")
+	if full == "" {
+		return name
+	}
+	return full
+}
+
+// normalizePhone 规范化电话：只保留数字，去掉 86 国家码
+func normalizePhone(phone *string) string {
+	if phone == nil {
+		return ""
+	}
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, *phone)
+	if len(digits) == 13 && strings.HasPrefix(digits, "86") {
+		digits = digits[2:]
+	}
+	return digits
+}
+
+// isEmpty 字符串指针是否为空
+func isEmpty(value *string) bool {
+	return value == nil || strings.TrimSpace(*value) == ""
+}
+
+// appendUnique 追加不重复的元素
+func appendUnique(items []string, item string) []string {
+	for _, existing := range items {
+		if existing == item {
+			return items
+		}
+	}
+	return append(items, item)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/search"
+	"mvc-demo/tracing"
+)
+
+// reindexBatchSize 重建索引时每批处理的学生数
+const reindexBatchSize = 500
+
+// StudentSearchHit 学生搜索结果
+type StudentSearchHit struct {
+	Student    *model.Student
+	Score      float64
+	Highlights map[string]string // 字段名 => 带 <em> 标记的高亮文本
+}
+
+// StudentSearchService 学生搜索服务
+type StudentSearchService struct {
+	index      search.Index
+	studentDAO dao.StudentRepository
+}
+
+// NewStudentSearchService 创建学生搜索服务实例
+func NewStudentSearchService(index search.Index, studentDAO dao.StudentRepository) *StudentSearchService {
+	return &StudentSearchService{
+		index:      index,
+		studentDAO: studentDAO,
+	}
+}
+
+// IndexStudent 新增或更新学生索引
+func (s *StudentSearchService) IndexStudent(ctx context.Context, student *model.Student) error {
+	ctx, span := tracing.Start(ctx, "StudentSearchService.IndexStudent")
+	defer span.End()
+	return s.index.Index(ctx, search.NewStudentDocument(student))
+}
+
+// RemoveStudent 删除学生索引
+func (s *StudentSearchService) RemoveStudent(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "StudentSearchService.RemoveStudent")
+	defer span.End()
+	return s.index.Delete(ctx, id)
+}
+
+// Search 搜索学生，结果按相关度排序并附带高亮
+func (s *StudentSearchService) Search(ctx context.Context, keyword string, page, pageSize int) ([]*StudentSearchHit, int64, error) {
+	ctx, span := tracing.Start(ctx, "StudentSearchService.Search")
+	defer span.End()
+	result, err := s.index.Search(ctx, &search.Query{
+		Keyword:  keyword,
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 根据命中的ID从数据库加载学生，索引中已删除但尚未同步的学生会被忽略
+	ids := make([]int64, 0, len(result.Hits))
+	scores := make(map[int64]float64, len(result.Hits))
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.ID)
+		scores[hit.ID] = hit.Score
+	}
+	students, err := s.studentDAO.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	keywords := search.Keywords(keyword)
+	hits := make([]*StudentSearchHit, 0, len(students))
+	for _, student := range students {
+		hits = append(hits, &StudentSearchHit{
+			Student:    student,
+			Score:      scores[student.ID],
+			Highlights: highlightStudent(student, keywords),
+		})
+	}
+	return hits, result.Total, nil
+}
+
+// Reindex 清空并重建学生索引，返回索引的学生数
+func (s *StudentSearchService) Reindex(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "StudentSearchService.Reindex")
+	defer span.End()
+	if err := s.index.Reset(ctx); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err := s.studentDAO.FindInBatches(ctx, reindexBatchSize, func(students []*model.Student) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		docs := make([]*search.StudentDocument, 0, len(students))
+		for _, student := range students {
+			docs = append(docs, search.NewStudentDocument(student))
+		}
+		count += len(docs)
+		return s.index.IndexBatch(ctx, docs)
+	})
+	return count, err
+}
+
+// IsEmpty 索引中是否还没有文档
+func (s *StudentSearchService) IsEmpty(ctx context.Context) (bool, error) {
+	ctx, span := tracing.Start(ctx, "StudentSearchService.IsEmpty")
+	defer span.End()
+	count, err := s.index.Count(ctx)
+	return count == 0, err
+}
+
+// highlightStudent 对学生的可搜索字段生成高亮
+func highlightStudent(student *model.Student, keywords []string) map[string]string {
+	fields := map[string]string{
+		"name":  student.Name,
+		"email": student.Email,
+	}
+	if student.Phone != nil {
+		fields["phone"] = *student.Phone
+	}
+	if student.Major != nil {
+		fields["major"] = *student.Major
+	}
+	if student.Remarks != nil {
+		fields["remarks"] = *student.Remarks
+	}
+
+	highlights := make(map[string]string)
+	for field, text := range fields {
+		if highlighted, ok := search.Highlight(text, keywords); ok {
+			highlights[field] = highlighted
+		}
+	}
+	return highlights
+}
@@ -1,57 +1,174 @@
 package service
 
 import (
+	"context"
 	"mvc-demo/dao"
 	"mvc-demo/dao/model"
+	"mvc-demo/logging"
+	"mvc-demo/metrics"
+	"mvc-demo/tracing"
+	"mvc-demo/utils"
 	"time"
 )
 
+// StudentIndexer 学生搜索索引的增量更新接口
+type StudentIndexer interface {
+	IndexStudent(ctx context.Context, student *model.Student) error
+	RemoveStudent(ctx context.Context, id int64) error
+}
+
 // StudentService 学生服务
 type StudentService struct {
-	studentDAO *dao.StudentDAO
+	studentDAO    dao.StudentRepository
+	universityDAO dao.UniversityRepository
+	majorDAO      dao.MajorRepository
+	indexer       StudentIndexer
 }
 
-// NewStudentService 创建学生服务实例
-func NewStudentService(studentDAO *dao.StudentDAO) *StudentService {
+// NewStudentService 创建学生服务实例，indexer 为 nil 时不维护搜索索引
+func NewStudentService(studentDAO dao.StudentRepository, universityDAO dao.UniversityRepository, majorDAO dao.MajorRepository, indexer StudentIndexer) *StudentService {
 	return &StudentService{
-		studentDAO: studentDAO,
+		studentDAO:    studentDAO,
+		universityDAO: universityDAO,
+		majorDAO:      majorDAO,
+		indexer:       indexer,
 	}
 }
 
-// CreateStudent 创建学生
-func (s *StudentService) CreateStudent(student *model.Student) error {
-	return s.studentDAO.Create(student)
+// CreateStudent 创建学生，状态须为有效值，关联的专业须属于学生所在大学
+func (s *StudentService) CreateStudent(ctx context.Context, student *model.Student) error {
+	ctx, span := tracing.Start(ctx, "StudentService.CreateStudent")
+	defer span.End()
+	if err := ValidateStudentStatus(student); err != nil {
+		return err
+	}
+	if err := resolveStudentMajor(ctx, s.majorDAO, student); err != nil {
+		return err
+	}
+	if err := s.studentDAO.Create(ctx, student); err != nil {
+		return err
+	}
+	s.indexStudent(ctx, student)
+	return s.LoadUniversities(ctx, []*model.Student{student})
 }
 
 // GetStudentByID 根据ID获取学生
-func (s *StudentService) GetStudentByID(id int64) (*model.Student, error) {
-	return s.studentDAO.GetByID(id)
+func (s *StudentService) GetStudentByID(ctx context.Context, id int64) (*model.Student, error) {
+	ctx, span := tracing.Start(ctx, "StudentService.GetStudentByID")
+	defer span.End()
+	return s.studentDAO.GetByID(ctx, id)
+}
+
+// GetStudentByIDWithFields 根据ID获取学生，只查询选择的字段
+func (s *StudentService) GetStudentByIDWithFields(ctx context.Context, id int64, fs *utils.FieldSet) (*model.Student, error) {
+	ctx, span := tracing.Start(ctx, "StudentService.GetStudentByIDWithFields")
+	defer span.End()
+	return s.studentDAO.GetByIDWithFields(ctx, id, fs)
+}
+
+// GetStudentsByIDs 根据ID批量获取学生，按传入的ID顺序返回，不存在的ID会被忽略
+func (s *StudentService) GetStudentsByIDs(ctx context.Context, ids []int64) ([]*model.Student, error) {
+	ctx, span := tracing.Start(ctx, "StudentService.GetStudentsByIDs")
+	defer span.End()
+	return s.studentDAO.GetByIDs(ctx, ids)
 }
 
 // GetStudentByEmail 根据邮箱获取学生
-func (s *StudentService) GetStudentByEmail(email string) (*model.Student, error) {
-	return s.studentDAO.GetByEmail(email)
+func (s *StudentService) GetStudentByEmail(ctx context.Context, email string) (*model.Student, error) {
+	ctx, span := tracing.Start(ctx, "StudentService.GetStudentByEmail")
+	defer span.End()
+	return s.studentDAO.GetByEmail(ctx, email)
 }
 
-// UpdateStudent 更新学生信息
-func (s *StudentService) UpdateStudent(student *model.Student) error {
-	return s.studentDAO.Update(student)
+// UpdateStudent 更新学生信息，状态须为有效值，关联的专业须属于学生所在大学；ctx 中有事务时加入该事务
+// 状态变更应通过 StudentStatusService.Transition 进行，以校验变更规则并记录变更历史
+func (s *StudentService) UpdateStudent(ctx context.Context, student *model.Student) error {
+	ctx, span := tracing.Start(ctx, "StudentService.UpdateStudent")
+	defer span.End()
+	if err := ValidateStudentStatus(student); err != nil {
+		return err
+	}
+	if err := resolveStudentMajor(ctx, s.majorDAO, student); err != nil {
+		return err
+	}
+	if err := s.studentDAO.Update(ctx, student); err != nil {
+		return err
+	}
+	s.indexStudent(ctx, student)
+
+	// 所属大学变更后重新加载关联的大学
+	if student.University != nil && (student.UniversityID == nil || student.University.ID != *student.UniversityID) {
+		student.University = nil
+	}
+	return s.LoadUniversities(ctx, []*model.Student{student})
 }
 
 // DeleteStudent 删除学生
-func (s *StudentService) DeleteStudent(id int64) error {
-	return s.studentDAO.Delete(id)
+func (s *StudentService) DeleteStudent(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "StudentService.DeleteStudent")
+	defer span.End()
+	if err := s.studentDAO.Delete(ctx, id); err != nil {
+		return err
+	}
+	if s.indexer != nil {
+		// ctx 中有事务时在提交后删除索引；索引更新失败不影响业务操作，可通过重建索引修复
+		dao.AfterCommit(ctx, func(ctx context.Context) {
+			if err := s.indexer.RemoveStudent(ctx, id); err != nil {
+				logging.FromContext(ctx).Warn("删除学生索引失败", logging.ComponentKey, logging.ComponentSearch, "student_id", id, "error", err)
+			}
+		})
+	}
+	return nil
+}
+
+// GetStudentList 获取学生列表（支持分页和筛选），fs 为 nil 时查询全部字段
+func (s *StudentService) GetStudentList(ctx context.Context, page, pageSize int, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.Student, int64, error) {
+	ctx, span := tracing.Start(ctx, "StudentService.GetStudentList")
+	defer span.End()
+	return s.studentDAO.GetList(ctx, page, pageSize, filters, fs)
 }
 
-// GetStudentList 获取学生列表（支持分页和筛选）
-func (s *StudentService) GetStudentList(page, pageSize int, filters map[string]interface{}) ([]*model.Student, int64, error) {
-	return s.studentDAO.GetList(page, pageSize, filters)
+// GetStudentListByCursor 获取学生列表（游标分页，支持筛选），fs 为 nil 时查询全部字段
+func (s *StudentService) GetStudentListByCursor(ctx context.Context, page *utils.PageQuery, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.Student, *utils.CursorResult, error) {
+	ctx, span := tracing.Start(ctx, "StudentService.GetStudentListByCursor")
+	defer span.End()
+	return s.studentDAO.GetListByCursor(ctx, page, filters, fs)
+}
+
+// LoadUniversities 为尚未加载所属大学的学生批量加载大学信息，整批只查询一次
+func (s *StudentService) LoadUniversities(ctx context.Context, students []*model.Student) error {
+	ctx, span := tracing.Start(ctx, "StudentService.LoadUniversities")
+	defer span.End()
+	var ids []int64
+	for _, student := range students {
+		if student.University == nil && student.UniversityID != nil {
+			ids = append(ids, *student.UniversityID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	universities, err := universityLoader(ctx, s.universityDAO).LoadMany(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, student := range students {
+		if student.University == nil && student.UniversityID != nil {
+			student.University = universities[*student.UniversityID]
+		}
+	}
+	return nil
 }
 
 // Login 学生登录
-func (s *StudentService) Login(email, password string) (*model.Student, error) {
+func (s *StudentService) Login(ctx context.Context, email, password string) (*model.Student, error) {
+	ctx, span := tracing.Start(ctx, "StudentService.Login")
+	defer span.End()
 	// 验证登录信息
-	student, err := s.studentDAO.ValidateLogin(email, password)
+	student, err := s.studentDAO.ValidateLogin(ctx, email, password)
+	metrics.RecordLogin("student", err)
 	if err != nil {
 		return nil, err
 	}
@@ -59,16 +176,30 @@ func (s *StudentService) Login(email, password string) (*model.Student, error) {
 	// 更新最后登录时间
 	now := time.Now()
 	student.LastLoginTime = &now
-	err = s.studentDAO.UpdateLastLoginTime(student.ID)
+	err = s.studentDAO.UpdateLastLoginTime(ctx, student.ID)
 	if err != nil {
 		// 只记录错误，不影响登录
-		// log.Printf("更新登录时间失败: %v", err)
+		logging.FromContext(ctx).Warn("更新登录时间失败", "error", err)
 	}
 
 	return student, nil
 }
 
+// indexStudent 更新学生搜索索引，ctx 中有事务时在提交后更新；失败时只记录日志，可通过重建索引修复
+func (s *StudentService) indexStudent(ctx context.Context, student *model.Student) {
+	if s.indexer == nil {
+		return
+	}
+	dao.AfterCommit(ctx, func(ctx context.Context) {
+		if err := s.indexer.IndexStudent(ctx, student); err != nil {
+			logging.FromContext(ctx).Warn("更新学生索引失败", logging.ComponentKey, logging.ComponentSearch, "student_id", student.ID, "error", err)
+		}
+	})
+}
+
 // ResetPassword 重置密码
-func (s *StudentService) ResetPassword(email, newPassword string) error {
-	return s.studentDAO.ResetPassword(email, newPassword)
+func (s *StudentService) ResetPassword(ctx context.Context, email, newPassword string) error {
+	ctx, span := tracing.Start(ctx, "StudentService.ResetPassword")
+	defer span.End()
+	return s.studentDAO.ResetPassword(ctx, email, newPassword)
 }
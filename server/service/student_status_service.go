@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 学生状态
+const (
+	StudentStatusEnrolled  = "在读"
+	StudentStatusSuspended = "休学"
+	StudentStatusWithdrawn = "退学"
+	StudentStatusGraduated = "毕业"
+)
+
+// StudentStatuses 所有学生状态
+var StudentStatuses = []string{StudentStatusEnrolled, StudentStatusSuspended, StudentStatusWithdrawn, StudentStatusGraduated}
+
+// StudentEducations 所有学历
+var StudentEducations = []string{"专科", "本科", "硕士", "博士"}
+
+// StatusTransitionRule 状态变更需要填写的信息，所有变更都需要生效日期
+type StatusTransitionRule struct {
+	RequireReason   bool // 需要填写原因
+	RequireApprover bool // 需要审批人
+}
+
+// studentStatusTransitions 允许的状态变更：当前状态 => 目标状态 => 规则，退学和毕业为终态
+var studentStatusTransitions = map[string]map[string]StatusTransitionRule{
+	StudentStatusEnrolled: {
+		StudentStatusSuspended: {RequireReason: true, RequireApprover: true},
+		StudentStatusWithdrawn: {RequireReason: true, RequireApprover: true},
+		StudentStatusGraduated: {},
+	},
+	StudentStatusSuspended: {
+		StudentStatusEnrolled:  {RequireReason: true},
+		StudentStatusWithdrawn: {RequireReason: true, RequireApprover: true},
+	},
+}
+
+var (
+	ErrInvalidStudentStatus    = errors.New("无效的学生状态，可选值: " + strings.Join(StudentStatuses, "、"))
+	ErrInvalidEducation        = errors.New("无效的学历，可选值: " + strings.Join(StudentEducations, "、"))
+	ErrGraduationYearRequired  = errors.New("毕业状态需要填写毕业年份")
+	ErrStatusTransition        = errors.New("不允许的状态变更")
+	ErrStatusReasonRequired    = errors.New("该状态变更需要填写原因")
+	ErrStatusApproverRequired  = errors.New("该状态变更需要指定审批人")
+	ErrStatusApproverNotFound  = errors.New("审批人不存在")
+	ErrStudentStatusConflict   = errors.New("学生状态已被其他操作修改，请刷新后重试")
+	ErrStudentStatusNotAllowed = errors.New("学生状态请通过状态变更接口修改")
+)
+
+// ValidateStudentStatus 校验学生状态和学历取值，毕业状态需要毕业年份；状态为空时使用数据库默认值（在读）
+func ValidateStudentStatus(student *model.Student) error {
+	if student.Education != nil && !containsString(StudentEducations, *student.Education) {
+		return ErrInvalidEducation
+	}
+	if student.Status == nil {
+		return nil
+	}
+	if !isStudentStatus(*student.Status) {
+		return ErrInvalidStudentStatus
+	}
+	if *student.Status == StudentStatusGraduated && student.GraduationYear == nil {
+		return ErrGraduationYearRequired
+	}
+	return nil
+}
+
+// StatusTransition 状态变更请求
+type StatusTransition struct {
+	Status         string
+	EffectiveDate  time.Time
+	Reason         *string
+	ApproverID     *int64
+	GraduationYear *int64 // 变更为毕业时使用，未填写时使用学生已有的毕业年份
+	ActorID        *int64
+}
+
+// StudentStatusService 学生状态服务
+type StudentStatusService struct {
+	tx         dao.Transactor
+	studentDAO dao.StudentRepository
+	historyDAO dao.StudentStatusHistoryRepository
+	userDAO    dao.UserRepository
+}
+
+// NewStudentStatusService 创建学生状态服务实例
+func NewStudentStatusService(tx dao.Transactor, studentDAO dao.StudentRepository, historyDAO dao.StudentStatusHistoryRepository, userDAO dao.UserRepository) *StudentStatusService {
+	return &StudentStatusService{
+		tx:         tx,
+		studentDAO: studentDAO,
+		historyDAO: historyDAO,
+		userDAO:    userDAO,
+	}
+}
+
+// Transition 按状态机变更学生状态，并在同一事务中写入状态变更记录；ctx 中已有事务时加入该事务
+func (s *StudentStatusService) Transition(ctx context.Context, studentID int64, t *StatusTransition) (*model.Student, error) {
+	ctx, span := tracing.Start(ctx, "StudentStatusService.Transition")
+	defer span.End()
+	student, err := s.studentDAO.GetByID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateTransition(ctx, student, t); err != nil {
+		return nil, err
+	}
+
+	err = s.tx.Transaction(ctx, func(ctx context.Context) error {
+		updated, err := s.studentDAO.UpdateStatus(ctx, student.ID, student.Status, t.Status, t.GraduationYear, t.ActorID)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return ErrStudentStatusConflict
+		}
+
+		return s.historyDAO.Create(ctx, &model.StudentStatusHistory{
+			StudentID:     student.ID,
+			FromStatus:    student.Status,
+			ToStatus:      t.Status,
+			EffectiveDate: t.EffectiveDate,
+			Reason:        t.Reason,
+			ApproverID:    t.ApproverID,
+			CreatedBy:     t.ActorID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.studentDAO.GetByID(ctx, studentID)
+}
+
+// GetStatusHistory 获取学生的状态变更记录，按时间倒序
+func (s *StudentStatusService) GetStatusHistory(ctx context.Context, studentID int64) ([]*model.StudentStatusHistory, error) {
+	ctx, span := tracing.Start(ctx, "StudentStatusService.GetStatusHistory")
+	defer span.End()
+	return s.historyDAO.GetListByStudent(ctx, studentID)
+}
+
+// CheckTransition 校验状态变更是否允许以及原因、毕业年份是否齐全，不校验审批人
+// 用于提交变更申请时预检，审批人在审批通过时才确定
+func (s *StudentStatusService) CheckTransition(ctx context.Context, student *model.Student, t *StatusTransition) (StatusTransitionRule, error) {
+	if !isStudentStatus(t.Status) {
+		return StatusTransitionRule{}, ErrInvalidStudentStatus
+	}
+
+	from := StudentStatusEnrolled
+	if student.Status != nil {
+		from = *student.Status
+	}
+	rule, ok := studentStatusTransitions[from][t.Status]
+	if !ok {
+		return rule, fmt.Errorf("%w: %s => %s", ErrStatusTransition, from, t.Status)
+	}
+
+	if rule.RequireReason && isEmpty(t.Reason) {
+		return rule, ErrStatusReasonRequired
+	}
+	if t.Status == StudentStatusGraduated && t.GraduationYear == nil && student.GraduationYear == nil {
+		return rule, ErrGraduationYearRequired
+	}
+	return rule, nil
+}
+
+// validateTransition 校验状态变更是否允许以及所需信息是否齐全
+func (s *StudentStatusService) validateTransition(ctx context.Context, student *model.Student, t *StatusTransition) error {
+	rule, err := s.CheckTransition(ctx, student, t)
+	if err != nil {
+		return err
+	}
+	if rule.RequireApprover {
+		if t.ApproverID == nil {
+			return ErrStatusApproverRequired
+		}
+		if _, err := s.userDAO.GetByID(ctx, *t.ApproverID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrStatusApproverNotFound
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// isStudentStatus 是否为有效的学生状态
+func isStudentStatus(status string) bool {
+	for _, s := range StudentStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStudentStatusError 是否为学生状态校验错误（业务错误）
+func IsStudentStatusError(err error) bool {
+	for _, target := range []error{
+		ErrInvalidStudentStatus, ErrInvalidEducation, ErrGraduationYearRequired, ErrStatusTransition, ErrStatusReasonRequired,
+		ErrStatusApproverRequired, ErrStatusApproverNotFound, ErrStudentStatusConflict, ErrStudentStatusNotAllowed,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
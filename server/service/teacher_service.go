@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 教师状态
+const (
+	TeacherStatusActive = "在职"
+	TeacherStatusLeft   = "离职"
+)
+
+// TeacherStatuses 所有教师状态
+var TeacherStatuses = []string{TeacherStatusActive, TeacherStatusLeft}
+
+var (
+	ErrInvalidTeacherStatus    = errors.New("无效的教师状态，可选值: " + strings.Join(TeacherStatuses, "、"))
+	ErrTeacherUserNotFound     = errors.New("关联的用户不存在")
+	ErrUserAlreadyTeacher      = errors.New("该用户已关联教师档案")
+	ErrTeacherEmployeeNoExists = errors.New("工号已存在")
+	ErrTeacherUniversity       = errors.New("所属大学不存在")
+	ErrTeacherCollege          = errors.New("所属院系不存在或不属于所属大学")
+	ErrTeacherHasAdvisees      = errors.New("教师仍在指导学生，请先为学生更换导师")
+	ErrTeacherHasAssignments   = errors.New("教师有导师分配记录，不能删除，可以将状态改为离职")
+)
+
+// TeacherService 教师服务
+type TeacherService struct {
+	teacherDAO    dao.TeacherRepository
+	userDAO       dao.UserRepository
+	universityDAO dao.UniversityRepository
+	collegeDAO    dao.CollegeRepository
+	assignmentDAO dao.AdvisorAssignmentRepository
+}
+
+// NewTeacherService 创建教师服务实例
+func NewTeacherService(teacherDAO dao.TeacherRepository, userDAO dao.UserRepository, universityDAO dao.UniversityRepository, collegeDAO dao.CollegeRepository, assignmentDAO dao.AdvisorAssignmentRepository) *TeacherService {
+	return &TeacherService{
+		teacherDAO:    teacherDAO,
+		userDAO:       userDAO,
+		universityDAO: universityDAO,
+		collegeDAO:    collegeDAO,
+		assignmentDAO: assignmentDAO,
+	}
+}
+
+// CreateTeacher 创建教师档案，状态为空时为在职
+func (s *TeacherService) CreateTeacher(ctx context.Context, teacher *model.Teacher) error {
+	ctx, span := tracing.Start(ctx, "TeacherService.CreateTeacher")
+	defer span.End()
+	if teacher.Status == "" {
+		teacher.Status = TeacherStatusActive
+	}
+	if err := s.validateTeacher(ctx, teacher); err != nil {
+		return err
+	}
+	return s.teacherDAO.Create(ctx, teacher)
+}
+
+// GetTeacherByID 根据ID获取教师
+func (s *TeacherService) GetTeacherByID(ctx context.Context, id int64) (*model.Teacher, error) {
+	ctx, span := tracing.Start(ctx, "TeacherService.GetTeacherByID")
+	defer span.End()
+	return s.teacherDAO.GetByID(ctx, id)
+}
+
+// GetTeacherByUserID 获取用户关联的教师档案
+func (s *TeacherService) GetTeacherByUserID(ctx context.Context, userID int64) (*model.Teacher, error) {
+	ctx, span := tracing.Start(ctx, "TeacherService.GetTeacherByUserID")
+	defer span.End()
+	return s.teacherDAO.GetByUserID(ctx, userID)
+}
+
+// UpdateTeacher 更新教师档案，仍在指导学生的教师不能改为离职
+func (s *TeacherService) UpdateTeacher(ctx context.Context, teacher *model.Teacher) error {
+	ctx, span := tracing.Start(ctx, "TeacherService.UpdateTeacher")
+	defer span.End()
+	if err := s.validateTeacher(ctx, teacher); err != nil {
+		return err
+	}
+	if teacher.Status == TeacherStatusLeft {
+		count, err := s.assignmentDAO.CountCurrentByTeacher(ctx, teacher.ID, time.Now())
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrTeacherHasAdvisees
+		}
+	}
+	return s.teacherDAO.Update(ctx, teacher)
+}
+
+// DeleteTeacher 删除教师档案，有导师分配记录（含历史记录）时返回 ErrTeacherHasAssignments
+func (s *TeacherService) DeleteTeacher(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "TeacherService.DeleteTeacher")
+	defer span.End()
+	if _, err := s.teacherDAO.GetByID(ctx, id); err != nil {
+		return err
+	}
+	count, err := s.assignmentDAO.CountByTeacher(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrTeacherHasAssignments
+	}
+	return s.teacherDAO.Delete(ctx, id)
+}
+
+// GetTeacherList 获取教师列表，filters 支持 name（模糊）、university_id、college_id、status
+func (s *TeacherService) GetTeacherList(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.Teacher, int64, error) {
+	ctx, span := tracing.Start(ctx, "TeacherService.GetTeacherList")
+	defer span.End()
+	return s.teacherDAO.GetList(ctx, page, pageSize, filters)
+}
+
+// validateTeacher 校验教师状态、关联用户、工号和所属大学院系，指定院系未指定大学时使用院系所属大学
+func (s *TeacherService) validateTeacher(ctx context.Context, teacher *model.Teacher) error {
+	if !containsString(TeacherStatuses, teacher.Status) {
+		return ErrInvalidTeacherStatus
+	}
+
+	if _, err := s.userDAO.GetByID(ctx, teacher.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTeacherUserNotFound
+		}
+		return err
+	}
+	exists, err := s.teacherDAO.CheckUserExists(ctx, teacher.UserID, teacher.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrUserAlreadyTeacher
+	}
+
+	if isEmpty(teacher.EmployeeNo) {
+		teacher.EmployeeNo = nil
+	} else {
+		exists, err := s.teacherDAO.CheckEmployeeNoExists(ctx, *teacher.EmployeeNo, teacher.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrTeacherEmployeeNoExists
+		}
+	}
+
+	if teacher.CollegeID != nil {
+		college, err := s.collegeDAO.GetByID(ctx, *teacher.CollegeID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTeacherCollege
+			}
+			return err
+		}
+		if teacher.UniversityID == nil {
+			teacher.UniversityID = &college.UniversityID
+		} else if *teacher.UniversityID != college.UniversityID {
+			return ErrTeacherCollege
+		}
+	}
+	if teacher.UniversityID != nil {
+		if _, err := s.universityDAO.GetByID(ctx, *teacher.UniversityID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTeacherUniversity
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// IsTeacherError 是否为教师档案的业务错误
+func IsTeacherError(err error) bool {
+	for _, target := range []error{
+		ErrInvalidTeacherStatus, ErrTeacherUserNotFound, ErrUserAlreadyTeacher, ErrTeacherEmployeeNoExists,
+		ErrTeacherUniversity, ErrTeacherCollege, ErrTeacherHasAdvisees, ErrTeacherHasAssignments,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/go-pdf/fpdf"
+)
+
+var ErrTranscriptFontNotConfigured = errors.New("未配置成绩单字体（TRANSCRIPT_FONT_PATH），不能生成 PDF")
+
+// transcriptFont PDF 中注册的字体名称
+const transcriptFont = "transcript"
+
+// 成绩表各列宽度（毫米），合计为 A4 纵向去掉左右边距后的 190
+var transcriptColumns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{"课程代码", 30, "L"},
+	{"课程名称", 80, "L"},
+	{"学分", 20, "C"},
+	{"分数", 20, "C"},
+	{"等级", 20, "C"},
+	{"绩点", 20, "C"},
+}
+
+// RenderPDF 把成绩单渲染为 PDF 写入 w
+// 签发的成绩单在页脚打印验证码，未签发的成绩单标注为预览
+func (s *TranscriptService) RenderPDF(ctx context.Context, transcript *Transcript, w io.Writer) error {
+	if s.fontPath == "" {
+		return ErrTranscriptFontNotConfigured
+	}
+	font, err := os.ReadFile(s.fontPath)
+	if err != nil {
+		return fmt.Errorf("读取成绩单字体失败: %w", err)
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(transcriptFont, "", font)
+	pdf.SetTitle(transcript.StudentName+" 成绩单", true)
+	pdf.SetMargins(10, 15, 10)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont(transcriptFont, "", 8)
+		pdf.SetTextColor(100, 100, 100)
+		footer := "预览，未签发，不作为成绩证明"
+		if transcript.VerificationCode != "" {
+			footer = "验证码: " + FormatVerificationCode(transcript.VerificationCode) +
+				"    签发时间: " + transcript.GeneratedAt.Format("2006-01-02 15:04")
+		}
+		pdf.CellFormat(150, 6, footer, "", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 6, fmt.Sprintf("第 %d 页", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+	pdf.AddPage()
+
+	// 标题
+	title := "成绩单（非正式）"
+	if transcript.Official {
+		title = "成绩单（正式）"
+	}
+	pdf.SetFont(transcriptFont, "", 18)
+	pdf.CellFormat(0, 12, title, "", 1, "C", false, 0, "")
+	pdf.Ln(2)
+
+	// 学生信息
+	pdf.SetFont(transcriptFont, "", 10)
+	info := [][2]string{
+		{"姓名", transcript.StudentName},
+		{"学号", strconv.FormatInt(transcript.StudentID, 10)},
+		{"学校", transcript.UniversityName},
+		{"专业", transcript.Major},
+		{"学历", transcript.Education},
+		{"状态", transcript.Status},
+	}
+	for i, item := range info {
+		ln := 0
+		if i%2 == 1 {
+			ln = 1
+		}
+		pdf.CellFormat(95, 7, item[0]+": "+item[1], "", ln, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// 各学期成绩
+	for _, term := range transcript.Terms {
+		pdf.SetFont(transcriptFont, "", 11)
+		pdf.CellFormat(0, 8, term.Term, "", 1, "L", false, 0, "")
+
+		pdf.SetFont(transcriptFont, "", 9)
+		pdf.SetFillColor(230, 230, 230)
+		for _, col := range transcriptColumns {
+			pdf.CellFormat(col.width, 7, col.title, "1", 0, "C", true, 0, "")
+		}
+		pdf.Ln(-1)
+
+		for _, course := range term.Courses {
+			score := "-"
+			if course.Score != nil {
+				score = formatNumber(*course.Score)
+			}
+			grade := course.Grade
+			if course.Superseded {
+				grade += "（重修前）"
+			}
+			values := []string{
+				course.CourseCode,
+				fitText(pdf, course.CourseTitle, transcriptColumns[1].width-2),
+				formatNumber(course.Credits),
+				score,
+				grade,
+				formatNumber(course.GradePoint),
+			}
+			for i, col := range transcriptColumns {
+				pdf.CellFormat(col.width, 7, values[i], "1", 0, col.align, false, 0, "")
+			}
+			pdf.Ln(-1)
+		}
+
+		pdf.CellFormat(0, 7, fmt.Sprintf("本学期学分 %s，获得学分 %s，学期绩点 %.2f",
+			formatNumber(term.AttemptedCredits), formatNumber(term.EarnedCredits), term.GPA), "", 1, "R", false, 0, "")
+		pdf.Ln(2)
+	}
+
+	if len(transcript.Terms) == 0 {
+		pdf.CellFormat(0, 8, "暂无成绩记录", "", 1, "L", false, 0, "")
+	}
+
+	// 汇总
+	pdf.Ln(2)
+	pdf.SetFont(transcriptFont, "", 11)
+	pdf.CellFormat(0, 8, fmt.Sprintf("累计学分 %s，获得学分 %s，累计绩点 %.2f",
+		formatNumber(transcript.AttemptedCredits), formatNumber(transcript.EarnedCredits), transcript.CumulativeGPA), "T", 1, "L", false, 0, "")
+	if transcript.VerificationCode != "" {
+		pdf.SetFont(transcriptFont, "", 9)
+		pdf.CellFormat(0, 7, "可通过验证码 "+FormatVerificationCode(transcript.VerificationCode)+" 查验本成绩单的真实性", "", 1, "L", false, 0, "")
+	}
+
+	return pdf.Output(w)
+}
+
+// fitText 截断超出宽度的文本，末尾加省略号
+func fitText(pdf *fpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"…") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// formatNumber 格式化学分、分数等数值，去掉多余的 0
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
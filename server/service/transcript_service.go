@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"strings"
+	"time"
+)
+
+// 验证码字符集，去掉了容易混淆的 0、O、1、I
+const verificationCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// verificationCodeLength 验证码长度，显示时每 4 位用 - 分隔
+const verificationCodeLength = 12
+
+var ErrInvalidVerificationCode = errors.New("无效的验证码")
+
+// Transcript 成绩单
+type Transcript struct {
+	StudentID        int64             `json:"student_id"`
+	StudentName      string            `json:"student_name"`
+	UniversityName   string            `json:"university_name"`
+	Major            string            `json:"major"`
+	Education        string            `json:"education"`
+	Status           string            `json:"status"`
+	Terms            []*TranscriptTerm `json:"terms"`
+	AttemptedCredits float64           `json:"attempted_credits"` // 计入累计绩点的学分，重修只计最后一次
+	EarnedCredits    float64           `json:"earned_credits"`    // 已获得学分，只计通过的课程
+	CumulativeGPA    float64           `json:"cumulative_gpa"`
+	Official         bool              `json:"official"`
+	VerificationCode string            `json:"verification_code,omitempty"`
+	GeneratedAt      time.Time         `json:"generated_at"`
+}
+
+// TranscriptTerm 成绩单中的一个学期
+type TranscriptTerm struct {
+	Term             string              `json:"term"`
+	Courses          []*TranscriptCourse `json:"courses"`
+	AttemptedCredits float64             `json:"attempted_credits"`
+	EarnedCredits    float64             `json:"earned_credits"`
+	GPA              float64             `json:"gpa"`
+}
+
+// TranscriptCourse 成绩单中的一门课程成绩
+type TranscriptCourse struct {
+	CourseID    int64    `json:"course_id"`
+	CourseCode  string   `json:"course_code"`
+	CourseTitle string   `json:"course_title"`
+	Credits     float64  `json:"credits"`
+	Score       *float64 `json:"score"`
+	Grade       string   `json:"grade"`
+	GradePoint  float64  `json:"grade_point"`
+	Passed      bool     `json:"passed"`
+	Superseded  bool     `json:"superseded"` // 已被之后学期的重修成绩取代，不计入累计绩点
+}
+
+// TranscriptService 成绩单服务
+type TranscriptService struct {
+	studentService *StudentService
+	gradeDAO       dao.GradeRepository
+	transcriptDAO  dao.TranscriptRepository
+	fontPath       string
+}
+
+// NewTranscriptService 创建成绩单服务实例，fontPath 为生成 PDF 使用的中文字体
+func NewTranscriptService(studentService *StudentService, gradeDAO dao.GradeRepository, transcriptDAO dao.TranscriptRepository, fontPath string) *TranscriptService {
+	return &TranscriptService{
+		studentService: studentService,
+		gradeDAO:       gradeDAO,
+		transcriptDAO:  transcriptDAO,
+		fontPath:       fontPath,
+	}
+}
+
+// GetTranscript 根据学生当前的成绩生成成绩单（非正式，不保存）
+func (s *TranscriptService) GetTranscript(ctx context.Context, studentID int64) (*Transcript, error) {
+	ctx, span := tracing.Start(ctx, "TranscriptService.GetTranscript")
+	defer span.End()
+	student, err := s.studentService.GetStudentByID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.studentService.LoadUniversities(ctx, []*model.Student{student}); err != nil {
+		return nil, err
+	}
+
+	grades, err := s.gradeDAO.GetByStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return buildTranscript(student, grades), nil
+}
+
+// Issue 签发成绩单：生成验证码并保存成绩单快照，之后成绩变化不影响已签发的成绩单
+// official 标记是否为正式成绩单，只有管理员可以签发
+func (s *TranscriptService) Issue(ctx context.Context, studentID int64, official bool, actorID *int64) (*model.Transcript, *Transcript, error) {
+	ctx, span := tracing.Start(ctx, "TranscriptService.Issue")
+	defer span.End()
+	transcript, err := s.GetTranscript(ctx, studentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	code, err := generateVerificationCode()
+	if err != nil {
+		return nil, nil, err
+	}
+	transcript.Official = official
+	transcript.VerificationCode = code
+
+	content, err := json.Marshal(transcript)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	record := &model.Transcript{
+		StudentID:        studentID,
+		VerificationCode: code,
+		Official:         official,
+		Content:          string(content),
+		CumulativeGpa:    transcript.CumulativeGPA,
+		IssuedBy:         actorID,
+		IssuedAt:         &transcript.GeneratedAt,
+	}
+	if err := s.transcriptDAO.Create(ctx, record); err != nil {
+		return nil, nil, err
+	}
+	return record, transcript, nil
+}
+
+// GetIssuedTranscript 获取已签发的成绩单及其快照内容
+func (s *TranscriptService) GetIssuedTranscript(ctx context.Context, id int64) (*model.Transcript, *Transcript, error) {
+	ctx, span := tracing.Start(ctx, "TranscriptService.GetIssuedTranscript")
+	defer span.End()
+	record, err := s.transcriptDAO.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	transcript, err := decodeTranscript(record)
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, transcript, nil
+}
+
+// GetIssuedList 获取学生的已签发成绩单列表（不含内容）
+func (s *TranscriptService) GetIssuedList(ctx context.Context, studentID int64) ([]*model.Transcript, error) {
+	ctx, span := tracing.Start(ctx, "TranscriptService.GetIssuedList")
+	defer span.End()
+	return s.transcriptDAO.GetListByStudent(ctx, studentID)
+}
+
+// Verify 根据验证码查询已签发的成绩单，验证码不区分大小写，可以带 - 分隔符
+func (s *TranscriptService) Verify(ctx context.Context, code string) (*model.Transcript, *Transcript, error) {
+	ctx, span := tracing.Start(ctx, "TranscriptService.Verify")
+	defer span.End()
+	code = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	if len(code) != verificationCodeLength {
+		return nil, nil, ErrInvalidVerificationCode
+	}
+
+	record, err := s.transcriptDAO.GetByCode(ctx, code)
+	if err != nil {
+		return nil, nil, err
+	}
+	transcript, err := decodeTranscript(record)
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, transcript, nil
+}
+
+// buildTranscript 按学期汇总成绩并计算学期绩点和累计绩点
+// 学期绩点计入该学期的全部成绩；同一课程重修时，累计绩点只计最后一次成绩
+// 绩点按学分加权，不及格的课程以实际绩点计入，已获得学分只计通过的课程
+func buildTranscript(student *model.Student, grades []*model.Grade) *Transcript {
+	transcript := &Transcript{
+		StudentID:   student.ID,
+		StudentName: student.Name,
+		Terms:       []*TranscriptTerm{},
+		GeneratedAt: time.Now(),
+	}
+	if student.University != nil {
+		transcript.UniversityName = student.University.Name
+	}
+	if student.Major != nil {
+		transcript.Major = *student.Major
+	}
+	if student.Education != nil {
+		transcript.Education = *student.Education
+	}
+	if student.Status != nil {
+		transcript.Status = *student.Status
+	}
+
+	// grades 已按学期排序，记录每门课程最后一次成绩
+	latest := make(map[int64]*model.Grade)
+	for _, grade := range grades {
+		latest[grade.CourseID] = grade
+	}
+
+	var cumulativePoints float64
+	termIndex := make(map[string]*TranscriptTerm)
+	termPoints := make(map[string]float64)
+	for _, grade := range grades {
+		term, ok := termIndex[grade.Term]
+		if !ok {
+			term = &TranscriptTerm{Term: grade.Term, Courses: []*TranscriptCourse{}}
+			termIndex[grade.Term] = term
+			transcript.Terms = append(transcript.Terms, term)
+		}
+
+		course := &TranscriptCourse{
+			CourseID:   grade.CourseID,
+			Credits:    grade.Credits,
+			Score:      grade.Score,
+			Grade:      grade.Grade,
+			GradePoint: grade.GradePoint,
+			Passed:     grade.Passed,
+			Superseded: latest[grade.CourseID] != grade,
+		}
+		if grade.Course != nil {
+			course.CourseCode = grade.Course.Code
+			course.CourseTitle = grade.Course.Title
+		}
+		term.Courses = append(term.Courses, course)
+
+		term.AttemptedCredits += grade.Credits
+		termPoints[grade.Term] += grade.Credits * grade.GradePoint
+		if grade.Passed {
+			term.EarnedCredits += grade.Credits
+		}
+
+		if !course.Superseded {
+			transcript.AttemptedCredits += grade.Credits
+			cumulativePoints += grade.Credits * grade.GradePoint
+			if grade.Passed {
+				transcript.EarnedCredits += grade.Credits
+			}
+		}
+	}
+
+	for _, term := range transcript.Terms {
+		term.GPA = weightedGPA(termPoints[term.Term], term.AttemptedCredits)
+	}
+	transcript.CumulativeGPA = weightedGPA(cumulativePoints, transcript.AttemptedCredits)
+	return transcript
+}
+
+// weightedGPA 学分加权平均绩点，保留两位小数
+func weightedGPA(points, credits float64) float64 {
+	if credits <= 0 {
+		return 0
+	}
+	return math.Round(points/credits*100) / 100
+}
+
+// decodeTranscript 解析已签发成绩单的快照内容
+func decodeTranscript(record *model.Transcript) (*Transcript, error) {
+	var transcript Transcript
+	if err := json.Unmarshal([]byte(record.Content), &transcript); err != nil {
+		return nil, err
+	}
+	return &transcript, nil
+}
+
+// generateVerificationCode 生成随机验证码
+func generateVerificationCode() (string, error) {
+	max := big.NewInt(int64(len(verificationCodeAlphabet)))
+	code := make([]byte, verificationCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = verificationCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// FormatVerificationCode 验证码的显示格式，每 4 位用 - 分隔
+func FormatVerificationCode(code string) string {
+	var parts []string
+	for len(code) > 4 {
+		parts = append(parts, code[:4])
+		code = code[4:]
+	}
+	parts = append(parts, code)
+	return strings.Join(parts, "-")
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mvc-demo/dao"
+	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"strings"
+)
+
+var (
+	ErrMergeSameUniversity     = errors.New("不能把大学合并到自身")
+	ErrMergeCourseCodeConflict = errors.New("两所大学有代码相同的课程，请先修改课程代码后再合并")
+)
+
+// UniversityMergeResult 大学合并结果（预览时为将要发生的变更）
+type UniversityMergeResult struct {
+	Source         *model.University
+	Target         *model.University
+	DryRun         bool
+	Students       int64    // 改为关联目标大学的学生数
+	Courses        int64    // 移动到目标大学的课程数
+	Teachers       int64    // 改为属于目标大学的教师数
+	ConflictCodes  []string // 两所大学都有的课程代码，存在时不能合并
+	CollegesMoved  int      // 移动到目标大学的院系数
+	CollegesMerged int      // 与目标大学同名院系合并的院系数
+	MajorsMoved    int      // 移动到目标大学的专业数
+	MajorsMerged   int      // 与目标大学同名专业合并的专业数
+	AddedAliases   []string // 为目标大学新增的别名（源大学的名称、简称和别名）
+	SkippedAliases []string // 已被其他大学使用而未添加的别名
+}
+
+// UniversityMergeService 大学合并服务，用于合并手工录入产生的重复大学
+type UniversityMergeService struct {
+	tx            dao.Transactor
+	universityDAO dao.UniversityRepository
+	studentDAO    dao.StudentRepository
+	collegeDAO    dao.CollegeRepository
+	majorDAO      dao.MajorRepository
+	courseDAO     dao.CourseRepository
+	teacherDAO    dao.TeacherRepository
+	auditLogDAO   dao.AuditLogRepository
+}
+
+// NewUniversityMergeService 创建大学合并服务实例
+func NewUniversityMergeService(tx dao.Transactor, universityDAO dao.UniversityRepository, studentDAO dao.StudentRepository, collegeDAO dao.CollegeRepository, majorDAO dao.MajorRepository, courseDAO dao.CourseRepository, teacherDAO dao.TeacherRepository, auditLogDAO dao.AuditLogRepository) *UniversityMergeService {
+	return &UniversityMergeService{
+		tx:            tx,
+		universityDAO: universityDAO,
+		studentDAO:    studentDAO,
+		collegeDAO:    collegeDAO,
+		majorDAO:      majorDAO,
+		courseDAO:     courseDAO,
+		teacherDAO:    teacherDAO,
+		auditLogDAO:   auditLogDAO,
+	}
+}
+
+// Merge 把源大学合并到目标大学
+// 在一个事务中：学生改为关联目标大学；课程移动到目标大学（两所大学有代码相同的课程时不能合并）；
+// 院系、专业移动到目标大学，同名的与目标大学的合并；教师改为属于目标大学，所在院系被合并的改为属于目标院系；
+// 源大学的名称、简称和别名记为目标大学的别名；软删除源大学并写入审计日志。
+// dryRun 为 true 时只返回预览，不修改数据
+func (s *UniversityMergeService) Merge(ctx context.Context, sourceID, targetID int64, actorID *int64, dryRun bool) (*UniversityMergeResult, error) {
+	ctx, span := tracing.Start(ctx, "UniversityMergeService.Merge")
+	defer span.End()
+	if sourceID == targetID {
+		return nil, ErrMergeSameUniversity
+	}
+
+	if dryRun {
+		return s.merge(ctx, sourceID, targetID, actorID, false)
+	}
+
+	var result *UniversityMergeResult
+	err := s.tx.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.merge(ctx, sourceID, targetID, actorID, true)
+		return err
+	})
+	return result, err
+}
+
+// merge 计算合并方案，apply 为 true 时执行合并（此时 ctx 中应有事务）
+func (s *UniversityMergeService) merge(ctx context.Context, sourceID, targetID int64, actorID *int64, apply bool) (*UniversityMergeResult, error) {
+	source, err := s.universityDAO.GetByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.universityDAO.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	result := &UniversityMergeResult{Source: source, Target: target, DryRun: !apply}
+
+	// 学生
+	if apply {
+		result.Students, err = s.studentDAO.ReassignUniversity(ctx, source.ID, target.ID)
+	} else {
+		result.Students, err = s.studentDAO.CountByUniversity(ctx, source.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 课程：同一大学内课程代码唯一，代码相同的课程需要先手工处理
+	if err := s.mergeCourses(ctx, source.ID, target.ID, result, apply); err != nil {
+		return nil, err
+	}
+
+	// 院系：同名的合并到目标院系，其余移动到目标大学
+	collegeMap, err := s.mergeColleges(ctx, source.ID, target.ID, result, apply)
+	if err != nil {
+		return nil, err
+	}
+
+	// 专业：同名的合并到目标专业，其余移动到目标大学
+	if err := s.mergeMajors(ctx, source.ID, target.ID, collegeMap, result, apply); err != nil {
+		return nil, err
+	}
+
+	// 教师：改为属于目标大学，被合并院系的教师改为属于目标院系
+	if err := s.mergeTeachers(ctx, source.ID, target.ID, collegeMap, result, apply); err != nil {
+		return nil, err
+	}
+
+	// 别名
+	if err := s.mergeAliases(ctx, source, target, actorID, result, apply); err != nil {
+		return nil, err
+	}
+
+	if !apply {
+		return result, nil
+	}
+
+	// 软删除源大学并记录审计日志
+	if err := s.universityDAO.Delete(ctx, source.ID); err != nil {
+		return nil, err
+	}
+	err = writeAuditLog(ctx, s.auditLogDAO, actorID, "university.merge", "university", target.ID, map[string]interface{}{
+		"source_id":       source.ID,
+		"source_name":     source.Name,
+		"target_id":       target.ID,
+		"target_name":     target.Name,
+		"students":        result.Students,
+		"courses":         result.Courses,
+		"teachers":        result.Teachers,
+		"colleges_moved":  result.CollegesMoved,
+		"colleges_merged": result.CollegesMerged,
+		"majors_moved":    result.MajorsMoved,
+		"majors_merged":   result.MajorsMerged,
+		"added_aliases":   result.AddedAliases,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// mergeCourses 把源大学的课程移动到目标大学，有代码相同的课程时执行合并返回错误，预览时记录在结果中
+func (s *UniversityMergeService) mergeCourses(ctx context.Context, sourceID, targetID int64, result *UniversityMergeResult, apply bool) error {
+	conflicts, err := s.courseDAO.GetSharedCodes(ctx, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+	result.ConflictCodes = conflicts
+	if !apply {
+		result.Courses, err = s.courseDAO.CountByUniversity(ctx, sourceID)
+		return err
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%w：%s", ErrMergeCourseCodeConflict, strings.Join(conflicts, "、"))
+	}
+	result.Courses, err = s.courseDAO.ReassignUniversity(ctx, sourceID, targetID)
+	return err
+}
+
+// mergeColleges 合并院系，返回被合并的源院系ID => 目标院系ID
+func (s *UniversityMergeService) mergeColleges(ctx context.Context, sourceID, targetID int64, result *UniversityMergeResult, apply bool) (map[int64]int64, error) {
+	sourceColleges, err := s.collegeDAO.GetListByUniversity(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	targetColleges, err := s.collegeDAO.GetListByUniversity(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetByName := make(map[string]int64, len(targetColleges))
+	for _, college := range targetColleges {
+		targetByName[college.Name] = college.ID
+	}
+
+	collegeMap := make(map[int64]int64)
+	for _, college := range sourceColleges {
+		if targetCollegeID, ok := targetByName[college.Name]; ok {
+			collegeMap[college.ID] = targetCollegeID
+			result.CollegesMerged++
+			if apply {
+				// 源院系下的专业在合并专业时改为归属目标院系
+				if err := s.collegeDAO.Delete(ctx, college.ID); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		result.CollegesMoved++
+		if apply {
+			if err := s.collegeDAO.MoveToUniversity(ctx, college.ID, targetID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return collegeMap, nil
+}
+
+// mergeMajors 合并专业，被合并专业的学生改为关联目标专业
+func (s *UniversityMergeService) mergeMajors(ctx context.Context, sourceID, targetID int64, collegeMap map[int64]int64, result *UniversityMergeResult, apply bool) error {
+	sourceMajors, err := s.majorDAO.GetList(ctx, map[string]interface{}{"university_id": sourceID})
+	if err != nil {
+		return err
+	}
+	targetMajors, err := s.majorDAO.GetList(ctx, map[string]interface{}{"university_id": targetID})
+	if err != nil {
+		return err
+	}
+
+	targetByName := make(map[string]int64, len(targetMajors))
+	for _, major := range targetMajors {
+		targetByName[major.Name] = major.ID
+	}
+
+	for _, major := range sourceMajors {
+		if targetMajorID, ok := targetByName[major.Name]; ok {
+			result.MajorsMerged++
+			if apply {
+				if err := s.studentDAO.ReassignMajor(ctx, major.ID, targetMajorID); err != nil {
+					return err
+				}
+				if err := s.majorDAO.Delete(ctx, major.ID); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		result.MajorsMoved++
+		if apply {
+			collegeID := major.CollegeID
+			if collegeID != nil {
+				if targetCollegeID, ok := collegeMap[*collegeID]; ok {
+					collegeID = &targetCollegeID
+				}
+			}
+			if err := s.majorDAO.MoveToUniversity(ctx, major.ID, targetID, collegeID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mergeTeachers 把源大学的教师改为属于目标大学，所在院系已被合并的改为属于对应的目标院系
+func (s *UniversityMergeService) mergeTeachers(ctx context.Context, sourceID, targetID int64, collegeMap map[int64]int64, result *UniversityMergeResult, apply bool) error {
+	var err error
+	if !apply {
+		result.Teachers, err = s.teacherDAO.CountByUniversity(ctx, sourceID)
+		return err
+	}
+	for sourceCollegeID, targetCollegeID := range collegeMap {
+		if err := s.teacherDAO.ReassignCollege(ctx, sourceCollegeID, targetCollegeID); err != nil {
+			return err
+		}
+	}
+	result.Teachers, err = s.teacherDAO.ReassignUniversity(ctx, sourceID, targetID)
+	return err
+}
+
+// mergeAliases 把源大学的名称、简称和别名添加为目标大学的别名，已被其他大学使用的跳过
+func (s *UniversityMergeService) mergeAliases(ctx context.Context, source, target *model.University, actorID *int64, result *UniversityMergeResult, apply bool) error {
+	candidates := []string{source.Name}
+	if source.ShortName != nil {
+		candidates = append(candidates, *source.ShortName)
+	}
+	for _, alias := range source.Aliases {
+		candidates = append(candidates, alias.Alias)
+	}
+	candidates = NormalizeUniversityAliases(candidates, target.Name)
+
+	owners, err := s.universityDAO.GetAliasOwners(ctx, candidates)
+	if err != nil {
+		return err
+	}
+	for _, alias := range candidates {
+		owner, used := owners[alias]
+		switch {
+		case !used || owner == source.ID:
+			result.AddedAliases = append(result.AddedAliases, alias)
+		case owner != target.ID:
+			result.SkippedAliases = append(result.SkippedAliases, alias)
+		}
+	}
+
+	if !apply {
+		return nil
+	}
+	// 先删除源大学的别名，避免与目标大学的新别名冲突
+	if err := s.universityDAO.ReplaceAliases(ctx, source.ID, nil, actorID); err != nil {
+		return err
+	}
+	return s.universityDAO.AddAliases(ctx, target.ID, result.AddedAliases, actorID)
+}
@@ -1,63 +1,260 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"mvc-demo/dao"
 	"mvc-demo/dao/model"
+	"mvc-demo/tracing"
+	"mvc-demo/utils"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// UniversityLevelTags 支持的大学层次标签
+var UniversityLevelTags = []string{"985", "211", "双一流"}
+
+var (
+	ErrUniversityNameExists    = errors.New("大学名称已存在")
+	ErrUniversityCodeExists    = errors.New("院校代码已被其他大学使用")
+	ErrUniversityAliasConflict = errors.New("别名已被其他大学使用")
 )
 
 // UniversityService 大学服务
 type UniversityService struct {
-	universityDAO *dao.UniversityDAO
+	tx            dao.Transactor
+	universityDAO dao.UniversityRepository
 }
 
 // NewUniversityService 创建大学服务实例
-func NewUniversityService(universityDAO *dao.UniversityDAO) *UniversityService {
+func NewUniversityService(tx dao.Transactor, universityDAO dao.UniversityRepository) *UniversityService {
 	return &UniversityService{
+		tx:            tx,
 		universityDAO: universityDAO,
 	}
 }
 
-// CreateUniversity 创建大学
-func (s *UniversityService) CreateUniversity(university *model.University) error {
-	return s.universityDAO.Create(university)
+// CreateUniversity 创建大学及其别名，名称、院校代码、别名与其他大学（包括已软删除的）冲突时返回错误
+// 冲突检查与创建在同一事务中完成
+func (s *UniversityService) CreateUniversity(ctx context.Context, university *model.University, aliases []string) error {
+	ctx, span := tracing.Start(ctx, "UniversityService.CreateUniversity")
+	defer span.End()
+	aliases = NormalizeUniversityAliases(aliases, university.Name)
+	return s.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := checkUniversityConflicts(ctx, s.universityDAO, university, aliases); err != nil {
+			return err
+		}
+		if err := s.universityDAO.Create(ctx, university); err != nil {
+			return err
+		}
+		if err := s.universityDAO.ReplaceAliases(ctx, university.ID, aliases, university.CreatedBy); err != nil {
+			return err
+		}
+		university.Aliases = aliasRecords(university.ID, aliases)
+		return nil
+	})
 }
 
 // GetUniversityByID 根据ID获取大学
-func (s *UniversityService) GetUniversityByID(id int64) (*model.University, error) {
-	return s.universityDAO.GetByID(id)
+func (s *UniversityService) GetUniversityByID(ctx context.Context, id int64) (*model.University, error) {
+	ctx, span := tracing.Start(ctx, "UniversityService.GetUniversityByID")
+	defer span.End()
+	return s.universityDAO.GetByID(ctx, id)
+}
+
+// GetUniversityByIDWithFields 根据ID获取大学，只查询选择的字段
+func (s *UniversityService) GetUniversityByIDWithFields(ctx context.Context, id int64, fs *utils.FieldSet) (*model.University, error) {
+	ctx, span := tracing.Start(ctx, "UniversityService.GetUniversityByIDWithFields")
+	defer span.End()
+	return s.universityDAO.GetByIDWithFields(ctx, id, fs)
+}
+
+// GetUniversitiesByIDs 根据ID批量获取大学，返回以ID为键的映射，不存在的ID不出现在结果中
+func (s *UniversityService) GetUniversitiesByIDs(ctx context.Context, ids []int64) (map[int64]*model.University, error) {
+	ctx, span := tracing.Start(ctx, "UniversityService.GetUniversitiesByIDs")
+	defer span.End()
+	return universityLoader(ctx, s.universityDAO).LoadMany(ctx, ids)
+}
+
+// GetUniversityByName 根据名称获取大学，名称不存在时按别名和简称查找，如“北大”可解析为北京大学
+func (s *UniversityService) GetUniversityByName(ctx context.Context, name string) (*model.University, error) {
+	ctx, span := tracing.Start(ctx, "UniversityService.GetUniversityByName")
+	defer span.End()
+	name = strings.TrimSpace(name)
+	university, err := s.universityDAO.GetByName(ctx, name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return s.universityDAO.GetByAlias(ctx, name)
+	}
+	return university, err
+}
+
+// CheckUniversityCodeExists 检查排除某ID外是否存在相同院校代码的大学（包括已软删除的记录），excludeID 为 0 时不排除
+func (s *UniversityService) CheckUniversityCodeExists(ctx context.Context, code string, excludeID int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "UniversityService.CheckUniversityCodeExists")
+	defer span.End()
+	return s.universityDAO.CheckCodeExistsExcludeID(ctx, code, excludeID)
 }
 
-// GetUniversityByName 根据名称获取大学
-func (s *UniversityService) GetUniversityByName(name string) (*model.University, error) {
-	return s.universityDAO.GetByName(name)
+// FindUniversityAliasConflicts 查找已被其他大学用作别名或名称的别名，excludeID 为 0 时不排除
+func (s *UniversityService) FindUniversityAliasConflicts(ctx context.Context, aliases []string, excludeID int64) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "UniversityService.FindUniversityAliasConflicts")
+	defer span.End()
+	return s.universityDAO.FindAliasConflicts(ctx, aliases, excludeID)
 }
 
 // CheckUniversityNameExistsWithDeleted 检查大学名称是否存在（包括已软删除的记录）
-func (s *UniversityService) CheckUniversityNameExistsWithDeleted(name string) (bool, error) {
-	return s.universityDAO.CheckNameExistsWithDeleted(name)
+func (s *UniversityService) CheckUniversityNameExistsWithDeleted(ctx context.Context, name string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "UniversityService.CheckUniversityNameExistsWithDeleted")
+	defer span.End()
+	return s.universityDAO.CheckNameExistsWithDeleted(ctx, name)
 }
 
 // CheckUniversityNameExistsExcludeID 检查排除某ID外是否存在同名大学（包括已软删除的记录）
-func (s *UniversityService) CheckUniversityNameExistsExcludeID(name string, excludeID int64) (bool, error) {
-	return s.universityDAO.CheckNameExistsExcludeID(name, excludeID)
+func (s *UniversityService) CheckUniversityNameExistsExcludeID(ctx context.Context, name string, excludeID int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "UniversityService.CheckUniversityNameExistsExcludeID")
+	defer span.End()
+	return s.universityDAO.CheckNameExistsExcludeID(ctx, name, excludeID)
 }
 
-// UpdateUniversity 更新大学信息
-func (s *UniversityService) UpdateUniversity(university *model.University) error {
-	return s.universityDAO.Update(university)
+// UpdateUniversity 更新大学信息，aliases 为 nil 时不修改别名
+// 名称、院校代码、别名与其他大学（包括已软删除的）冲突时返回错误，冲突检查与更新在同一事务中完成
+func (s *UniversityService) UpdateUniversity(ctx context.Context, university *model.University, aliases []string) error {
+	ctx, span := tracing.Start(ctx, "UniversityService.UpdateUniversity")
+	defer span.End()
+	if aliases != nil {
+		aliases = NormalizeUniversityAliases(aliases, university.Name)
+	}
+	return s.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := checkUniversityConflicts(ctx, s.universityDAO, university, aliases); err != nil {
+			return err
+		}
+		if err := s.universityDAO.Update(ctx, university); err != nil {
+			return err
+		}
+		if aliases == nil {
+			return nil
+		}
+		if err := s.universityDAO.ReplaceAliases(ctx, university.ID, aliases, university.UpdatedBy); err != nil {
+			return err
+		}
+		university.Aliases = aliasRecords(university.ID, aliases)
+		return nil
+	})
 }
 
 // DeleteUniversity 删除大学
-func (s *UniversityService) DeleteUniversity(id int64) error {
-	return s.universityDAO.Delete(id)
+func (s *UniversityService) DeleteUniversity(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "UniversityService.DeleteUniversity")
+	defer span.End()
+	return s.universityDAO.Delete(ctx, id)
+}
+
+// GetUniversityList 获取大学列表（支持分页和筛选），fs 为 nil 时查询全部字段
+func (s *UniversityService) GetUniversityList(ctx context.Context, page, pageSize int, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.University, int64, error) {
+	ctx, span := tracing.Start(ctx, "UniversityService.GetUniversityList")
+	defer span.End()
+	return s.universityDAO.GetList(ctx, page, pageSize, filters, fs)
+}
+
+// GetUniversityListByCursor 获取大学列表（游标分页，支持筛选），fs 为 nil 时查询全部字段
+func (s *UniversityService) GetUniversityListByCursor(ctx context.Context, page *utils.PageQuery, filters map[string]interface{}, fs *utils.FieldSet) ([]*model.University, *utils.CursorResult, error) {
+	ctx, span := tracing.Start(ctx, "UniversityService.GetUniversityListByCursor")
+	defer span.End()
+	return s.universityDAO.GetListByCursor(ctx, page, filters, fs)
+}
+
+// GetAllUniversities 获取所有大学（不分页），fs 为 nil 时查询全部字段
+func (s *UniversityService) GetAllUniversities(ctx context.Context, fs *utils.FieldSet) ([]*model.University, error) {
+	ctx, span := tracing.Start(ctx, "UniversityService.GetAllUniversities")
+	defer span.End()
+	return s.universityDAO.GetAll(ctx, fs)
 }
 
-// GetUniversityList 获取大学列表（支持分页）
-func (s *UniversityService) GetUniversityList(page, pageSize int) ([]*model.University, int64, error) {
-	return s.universityDAO.GetList(page, pageSize)
+// checkUniversityConflicts 检查名称、院校代码、别名是否与其他大学冲突，university.ID 为 0 时表示新建
+func checkUniversityConflicts(ctx context.Context, universityDAO dao.UniversityRepository, university *model.University, aliases []string) error {
+	exists, err := universityDAO.CheckNameExistsExcludeID(ctx, university.Name, university.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrUniversityNameExists
+	}
+
+	if university.Code != nil && *university.Code != "" {
+		exists, err := universityDAO.CheckCodeExistsExcludeID(ctx, *university.Code, university.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrUniversityCodeExists
+		}
+	}
+
+	if len(aliases) > 0 {
+		conflicts, err := universityDAO.FindAliasConflicts(ctx, aliases, university.ID)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return fmt.Errorf("%w: %s", ErrUniversityAliasConflict, strings.Join(conflicts, "、"))
+		}
+	}
+	return nil
+}
+
+// IsUniversityConflictError 是否为大学名称、院校代码、别名冲突的业务错误
+func IsUniversityConflictError(err error) bool {
+	for _, target := range []error{ErrUniversityNameExists, ErrUniversityCodeExists, ErrUniversityAliasConflict} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeUniversityAliases 整理别名：去除首尾空白、空值、重复值以及与大学名称相同的别名
+func NormalizeUniversityAliases(aliases []string, name string) []string {
+	result := make([]string, 0, len(aliases))
+	seen := map[string]bool{strings.TrimSpace(name): true}
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" || seen[alias] {
+			continue
+		}
+		seen[alias] = true
+		result = append(result, alias)
+	}
+	return result
+}
+
+// aliasRecords 把别名列表转换为别名记录，用于保存后直接返回
+func aliasRecords(universityID int64, aliases []string) []*model.UniversityAlias {
+	records := make([]*model.UniversityAlias, 0, len(aliases))
+	for _, alias := range aliases {
+		records = append(records, &model.UniversityAlias{UniversityID: universityID, Alias: alias})
+	}
+	return records
 }
 
-// GetAllUniversities 获取所有大学（不分页）
-func (s *UniversityService) GetAllUniversities() ([]*model.University, error) {
-	return s.universityDAO.GetAll()
+// universityLoader 当前请求共享的大学批量加载器，同一请求中已加载的大学不再重复查询
+func universityLoader(ctx context.Context, universityDAO dao.UniversityRepository) *utils.BatchLoader[int64, *model.University] {
+	return utils.RequestLoader(ctx, "universities", func(ctx context.Context, ids []int64) (map[int64]*model.University, error) {
+		return loadUniversities(ctx, universityDAO, ids)
+	})
+}
+
+// loadUniversities 批量查询大学并按ID建立映射
+func loadUniversities(ctx context.Context, universityDAO dao.UniversityRepository, ids []int64) (map[int64]*model.University, error) {
+	universities, err := universityDAO.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64]*model.University, len(universities))
+	for _, university := range universities {
+		result[university.ID] = university
+	}
+	return result, nil
 }
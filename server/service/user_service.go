@@ -1,57 +1,91 @@
 package service
 
 import (
+	"context"
 	"mvc-demo/dao"
 	"mvc-demo/dao/model"
+	"mvc-demo/logging"
+	"mvc-demo/metrics"
+	"mvc-demo/tracing"
+	"mvc-demo/utils"
 	"time"
 )
 
 // UserService 用户服务
 type UserService struct {
-	userDAO *dao.UserDAO
+	userDAO dao.UserRepository
 }
 
 // NewUserService 创建用户服务实例
-func NewUserService(userDAO *dao.UserDAO) *UserService {
+func NewUserService(userDAO dao.UserRepository) *UserService {
 	return &UserService{
 		userDAO: userDAO,
 	}
 }
 
 // CreateUser 创建用户
-func (s *UserService) CreateUser(user *model.User) error {
-	return s.userDAO.Create(user)
+func (s *UserService) CreateUser(ctx context.Context, user *model.User) error {
+	ctx, span := tracing.Start(ctx, "UserService.CreateUser")
+	defer span.End()
+	return s.userDAO.Create(ctx, user)
 }
 
 // GetUserByID 根据ID获取用户
-func (s *UserService) GetUserByID(id int64) (*model.User, error) {
-	return s.userDAO.GetByID(id)
+func (s *UserService) GetUserByID(ctx context.Context, id int64) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByID")
+	defer span.End()
+	return s.userDAO.GetByID(ctx, id)
+}
+
+// GetUserByIDWithFields 根据ID获取用户，只查询选择的字段
+func (s *UserService) GetUserByIDWithFields(ctx context.Context, id int64, fs *utils.FieldSet) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByIDWithFields")
+	defer span.End()
+	return s.userDAO.GetByIDWithFields(ctx, id, fs)
 }
 
 // GetUserByEmail 根据邮箱获取用户
-func (s *UserService) GetUserByEmail(email string) (*model.User, error) {
-	return s.userDAO.GetByEmail(email)
+func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByEmail")
+	defer span.End()
+	return s.userDAO.GetByEmail(ctx, email)
 }
 
 // UpdateUser 更新用户信息
-func (s *UserService) UpdateUser(user *model.User) error {
-	return s.userDAO.Update(user)
+func (s *UserService) UpdateUser(ctx context.Context, user *model.User) error {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateUser")
+	defer span.End()
+	return s.userDAO.Update(ctx, user)
 }
 
 // DeleteUser 删除用户
-func (s *UserService) DeleteUser(id int64) error {
-	return s.userDAO.Delete(id)
+func (s *UserService) DeleteUser(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteUser")
+	defer span.End()
+	return s.userDAO.Delete(ctx, id)
+}
+
+// GetUserList 获取用户列表（支持分页），fs 为 nil 时查询全部字段
+func (s *UserService) GetUserList(ctx context.Context, page, pageSize int, fs *utils.FieldSet) ([]*model.User, int64, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserList")
+	defer span.End()
+	return s.userDAO.GetList(ctx, page, pageSize, fs)
 }
 
-// GetUserList 获取用户列表（支持分页）
-func (s *UserService) GetUserList(page, pageSize int) ([]*model.User, int64, error) {
-	return s.userDAO.GetList(page, pageSize)
+// GetUserListByCursor 获取用户列表（游标分页），fs 为 nil 时查询全部字段
+func (s *UserService) GetUserListByCursor(ctx context.Context, page *utils.PageQuery, fs *utils.FieldSet) ([]*model.User, *utils.CursorResult, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserListByCursor")
+	defer span.End()
+	return s.userDAO.GetListByCursor(ctx, page, fs)
 }
 
 // Login 用户登录
-func (s *UserService) Login(email, password string) (*model.User, error) {
+func (s *UserService) Login(ctx context.Context, email, password string) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Login")
+	defer span.End()
 	// 验证登录信息
-	user, err := s.userDAO.ValidateLogin(email, password)
+	user, err := s.userDAO.ValidateLogin(ctx, email, password)
+	metrics.RecordLogin("user", err)
 	if err != nil {
 		return nil, err
 	}
@@ -59,16 +93,18 @@ func (s *UserService) Login(email, password string) (*model.User, error) {
 	// 更新最后登录时间
 	now := time.Now()
 	user.LastLoginTime = &now
-	err = s.userDAO.UpdateLastLoginTime(user.ID)
+	err = s.userDAO.UpdateLastLoginTime(ctx, user.ID)
 	if err != nil {
 		// 只记录错误，不影响登录
-		// log.Printf("更新登录时间失败: %v", err)
+		logging.FromContext(ctx).Warn("更新登录时间失败", "error", err)
 	}
 
 	return user, nil
 }
 
 // ResetPassword 重置密码
-func (s *UserService) ResetPassword(email, newPassword string) error {
-	return s.userDAO.ResetPassword(email, newPassword)
+func (s *UserService) ResetPassword(ctx context.Context, email, newPassword string) error {
+	ctx, span := tracing.Start(ctx, "UserService.ResetPassword")
+	defer span.End()
+	return s.userDAO.ResetPassword(ctx, email, newPassword)
 }
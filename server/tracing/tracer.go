@@ -0,0 +1,104 @@
+// Package tracing 链路追踪：基于 OpenTelemetry SDK，按 W3C Trace Context 接收上游的追踪上下文，
+// 为请求（otelgin）、服务方法和数据库查询（otelgorm）创建 span，
+// 以 OTLP/HTTP 导出到 OpenTelemetry Collector、Jaeger 等，或写入标准输出、文件供本地查看。
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mvc-demo/config"
+	"mvc-demo/logging"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName 服务方法 span 的 instrumentation scope
+const instrumentationName = "mvc-demo"
+
+// Setup 按配置启用链路追踪，未启用（exporter 为 none）时不做任何事，此时创建的 span 均为空操作
+// 返回的 shutdown 在服务停止时调用，导出尚未发送的 span
+func Setup(cfg config.TracingConfig) (shutdown func(context.Context) error, err error) {
+	noop := func(context.Context) error { return nil }
+	if !cfg.Enabled() {
+		return noop, nil
+	}
+
+	exporter, closeExporter, err := newExporter(cfg)
+	if err != nil {
+		return noop, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", cfg.ServiceName))),
+		// 有上游追踪上下文时沿用上游的采样结果，否则按追踪ID和比例采样，多个实例的结果一致
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	// 追踪后端不可用时只记录日志，不影响请求处理
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logging.Component(logging.ComponentTracing).Warn("导出追踪数据失败", "error", err)
+	}))
+
+	return func(ctx context.Context) error {
+		return errors.Join(provider.Shutdown(ctx), closeExporter())
+	}, nil
+}
+
+// newExporter 按配置创建导出器，返回的 close 关闭导出器使用的文件
+func newExporter(cfg config.TracingConfig) (sdktrace.SpanExporter, func() error, error) {
+	noClose := func() error { return nil }
+	switch cfg.Exporter {
+	case "otlp":
+		exporter, err := otlptracehttp.New(context.Background(),
+			otlptracehttp.WithEndpointURL(strings.TrimSuffix(cfg.OTLPEndpoint, "/")+"/v1/traces"),
+			otlptracehttp.WithHeaders(cfg.OTLPHeaders),
+		)
+		return exporter, noClose, err
+	case "console":
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		return exporter, noClose, err
+	case "file":
+		file, err := openTraceFile(cfg.File)
+		if err != nil {
+			return nil, noClose, err
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, noClose, err
+		}
+		return exporter, file.Close, nil
+	default:
+		return nil, noClose, fmt.Errorf("不支持的追踪导出方式 %s", cfg.Exporter)
+	}
+}
+
+// openTraceFile 以追加方式打开 span 文件，目录不存在时创建，每行一个 span（JSON）
+func openTraceFile(path string) (io.WriteCloser, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+}
+
+// Start 创建以 ctx 中的 span 为父 span 的新 span，返回携带新 span 的 ctx；用完后调用 span.End()
+// 未启用追踪时返回空操作的 span
+//
+//	ctx, span := tracing.Start(ctx, "StudentService.CreateStudent")
+//	defer span.End()
+func Start(ctx context.Context, name string, options ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, options...)
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchLoader 批量加载器
+// 把多次按键查询合并为一次批量查询，并缓存已加载的结果，用于避免逐条查询关联数据（N+1）。
+// 加载器应按请求创建（见 RequestLoader），不要跨请求复用，以免读到过期数据
+type BatchLoader[K comparable, V any] struct {
+	mu    sync.Mutex
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+	cache map[K]V
+}
+
+// NewBatchLoader 创建批量加载器，fetch 根据一批键查询数据，不存在的键不需要出现在返回结果中
+// fetch 使用调用 Load/LoadMany 时传入的 ctx，查询随请求取消，并在事务中使用事务连接
+func NewBatchLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *BatchLoader[K, V] {
+	return &BatchLoader[K, V]{
+		fetch: fetch,
+		cache: make(map[K]V),
+	}
+}
+
+// Prime 预先放入已知数据，之后加载该键时不再查询
+func (l *BatchLoader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cache[key] = value
+}
+
+// LoadMany 批量加载，只查询缓存中没有的键，返回结果中不包含不存在的键
+func (l *BatchLoader[K, V]) LoadMany(ctx context.Context, keys []K) (map[K]V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// 收集未缓存的键（去重）
+	var missing []K
+	seen := make(map[K]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		if _, ok := l.cache[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		fetched, err := l.fetch(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range fetched {
+			l.cache[key] = value
+		}
+	}
+
+	result := make(map[K]V, len(seen))
+	for key := range seen {
+		if value, ok := l.cache[key]; ok {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
+
+// Load 加载单个键，第二个返回值表示是否存在
+func (l *BatchLoader[K, V]) Load(ctx context.Context, key K) (V, bool, error) {
+	result, err := l.LoadMany(ctx, []K{key})
+	if err != nil {
+		var zero V
+		return zero, false, err
+	}
+	value, ok := result[key]
+	return value, ok, nil
+}
+
+// loadersKey 请求内共享的批量加载器在 context 中的键
+type loadersKey struct{}
+
+// requestLoaders 一个请求内按名称共享的批量加载器
+type requestLoaders struct {
+	mu      sync.Mutex
+	loaders map[string]interface{}
+}
+
+// WithLoaders 返回可在请求内共享批量加载器的 context，由请求上下文中间件设置
+func WithLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &requestLoaders{loaders: make(map[string]interface{})})
+}
+
+// RequestLoader 获取当前请求中名为 name 的批量加载器，不存在时用 fetch 创建，同一请求内的各个服务共用已加载的数据
+// ctx 不在请求中（如命令行任务）时每次返回新的加载器；同一名称须始终使用相同的键值类型
+func RequestLoader[K comparable, V any](ctx context.Context, name string, fetch func(ctx context.Context, keys []K) (map[K]V, error)) *BatchLoader[K, V] {
+	shared, ok := ctx.Value(loadersKey{}).(*requestLoaders)
+	if !ok {
+		return NewBatchLoader(fetch)
+	}
+
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+	if loader, ok := shared.loaders[name].(*BatchLoader[K, V]); ok {
+		return loader
+	}
+	loader := NewBatchLoader(fetch)
+	shared.loaders[name] = loader
+	return loader
+}
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// countingFetch 记录每次批量查询的键，键为偶数时视为不存在
+type countingFetch struct {
+	calls [][]int
+}
+
+func (f *countingFetch) fetch(ctx context.Context, keys []int) (map[int]string, error) {
+	f.calls = append(f.calls, append([]int(nil), keys...))
+	result := make(map[int]string)
+	for _, key := range keys {
+		if key%2 == 1 {
+			result[key] = string(rune('a' + key))
+		}
+	}
+	return result, nil
+}
+
+func TestBatchLoaderLoadManyDeduplicates(t *testing.T) {
+	f := &countingFetch{}
+	loader := NewBatchLoader(f.fetch)
+	ctx := context.Background()
+
+	result, err := loader.LoadMany(ctx, []int{1, 3, 1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.calls) != 1 {
+		t.Fatalf("查询了 %d 次，期望 1 次", len(f.calls))
+	}
+	keys := f.calls[0]
+	sort.Ints(keys)
+	if !reflect.DeepEqual(keys, []int{1, 2, 3}) {
+		t.Fatalf("查询的键为 %v，期望去重后的 [1 2 3]", keys)
+	}
+	if !reflect.DeepEqual(result, map[int]string{1: "b", 3: "d"}) {
+		t.Fatalf("结果为 %v，不应包含不存在的键", result)
+	}
+
+	// 已加载的键不再查询，只查询新的键
+	if _, err := loader.LoadMany(ctx, []int{1, 3, 5}); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.calls) != 2 || !reflect.DeepEqual(f.calls[1], []int{5}) {
+		t.Fatalf("第二次查询的键为 %v，期望只有 [5]", f.calls[1:])
+	}
+}
+
+func TestBatchLoaderLoad(t *testing.T) {
+	f := &countingFetch{}
+	loader := NewBatchLoader(f.fetch)
+	ctx := context.Background()
+
+	value, ok, err := loader.Load(ctx, 1)
+	if err != nil || !ok || value != "b" {
+		t.Fatalf("Load(1) 返回 %q, %v, %v", value, ok, err)
+	}
+	if _, ok, err := loader.Load(ctx, 2); err != nil || ok {
+		t.Fatalf("Load(2) 返回 %v, %v，期望不存在", ok, err)
+	}
+	if _, _, err := loader.Load(ctx, 1); err != nil || len(f.calls) != 2 {
+		t.Fatalf("重复加载已缓存的键时查询了 %d 次，期望 2 次", len(f.calls))
+	}
+
+	errFetch := errors.New("fetch failed")
+	failing := NewBatchLoader(func(ctx context.Context, keys []int) (map[int]string, error) {
+		return nil, errFetch
+	})
+	if _, _, err := failing.Load(ctx, 1); !errors.Is(err, errFetch) {
+		t.Fatalf("查询失败时返回 %v", err)
+	}
+}
+
+func TestBatchLoaderPrime(t *testing.T) {
+	f := &countingFetch{}
+	loader := NewBatchLoader(f.fetch)
+	loader.Prime(2, "primed")
+
+	value, ok, err := loader.Load(context.Background(), 2)
+	if err != nil || !ok || value != "primed" {
+		t.Fatalf("Load(2) 返回 %q, %v, %v，期望预先放入的值", value, ok, err)
+	}
+	if len(f.calls) != 0 {
+		t.Fatalf("预先放入的键仍查询了 %d 次", len(f.calls))
+	}
+}
+
+// 同一请求内按名称共享加载器，不在请求中时每次创建新的加载器
+func TestRequestLoader(t *testing.T) {
+	f := &countingFetch{}
+	ctx := WithLoaders(context.Background())
+	if _, err := RequestLoader(ctx, "letters", f.fetch).LoadMany(ctx, []int{1, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RequestLoader(ctx, "letters", f.fetch).LoadMany(ctx, []int{3, 1}); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.calls) != 1 {
+		t.Fatalf("同一请求查询了 %d 次，期望 1 次", len(f.calls))
+	}
+
+	if RequestLoader(context.Background(), "letters", f.fetch) == RequestLoader(context.Background(), "letters", f.fetch) {
+		t.Fatal("不在请求中时不应共享加载器")
+	}
+}
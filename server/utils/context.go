@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// requestIDKey 请求ID在 context 中的键
+type requestIDKey struct{}
+
+// actorIDKey 当前操作人ID在 context 中的键
+type actorIDKey struct{}
+
+// WithRequestID 返回携带请求ID的 context
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID 获取 context 中的请求ID，没有时返回空字符串
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// NewRequestID 生成随机请求ID（32 位十六进制）
+func NewRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
+
+// WithActorID 返回携带当前操作人ID的 context，由认证中间件设置
+func WithActorID(ctx context.Context, actorID int64) context.Context {
+	return context.WithValue(ctx, actorIDKey{}, actorID)
+}
+
+// ActorID 获取 context 中的当前操作人ID，未登录或不在请求中时返回 nil
+func ActorID(ctx context.Context) *int64 {
+	if actorID, ok := ctx.Value(actorIDKey{}).(int64); ok {
+		return &actorID
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FieldSpec 某类资源可选择的响应字段和可展开的关联
+type FieldSpec struct {
+	Columns  map[string]string   // 响应字段（json名） => 数据库列
+	Required []string            // 无论选择哪些字段都需要查询的列，如 id
+	Includes map[string][]string // 可展开的关联 => 展开时需要查询的列，如 university => university_id
+}
+
+// NewFieldSpec 创建字段规格，响应字段名与数据库列名相同，并始终查询 id 列
+func NewFieldSpec(fields ...string) *FieldSpec {
+	spec := &FieldSpec{
+		Columns:  make(map[string]string, len(fields)),
+		Required: []string{"id"},
+		Includes: make(map[string][]string),
+	}
+	for _, field := range fields {
+		spec.Columns[field] = field
+	}
+	return spec
+}
+
+// FieldSet 请求选择的响应字段（?fields=）和展开的关联（?include=）
+type FieldSet struct {
+	Fields   []string // 选择的响应字段，为空表示全部字段
+	Includes []string // 展开的关联
+	Columns  []string // 需要查询的数据库列，为空表示全部列
+}
+
+// ParseFieldSet 从请求中解析字段选择，字段或关联不在 spec 中时返回错误
+func ParseFieldSet(c *gin.Context, spec *FieldSpec) (*FieldSet, error) {
+	fs := &FieldSet{
+		Fields:   splitList(c.Query("fields")),
+		Includes: splitList(c.Query("include")),
+	}
+
+	for _, include := range fs.Includes {
+		if _, ok := spec.Includes[include]; !ok {
+			return nil, fmt.Errorf("不支持展开的关联: %s", include)
+		}
+	}
+
+	if len(fs.Fields) == 0 {
+		return fs, nil
+	}
+
+	// 根据选择的字段计算需要查询的列
+	seen := make(map[string]bool)
+	addColumn := func(column string) {
+		if !seen[column] {
+			seen[column] = true
+			fs.Columns = append(fs.Columns, column)
+		}
+	}
+	for _, column := range spec.Required {
+		addColumn(column)
+	}
+	for _, field := range fs.Fields {
+		column, ok := spec.Columns[field]
+		if !ok {
+			return nil, fmt.Errorf("不支持的字段: %s", field)
+		}
+		addColumn(column)
+	}
+	for _, include := range fs.Includes {
+		for _, column := range spec.Includes[include] {
+			addColumn(column)
+		}
+	}
+
+	return fs, nil
+}
+
+// HasInclude 是否需要加载某个关联
+// 未指定 fields 和 include 时保持原有行为，加载全部关联
+func (fs *FieldSet) HasInclude(name string) bool {
+	if fs == nil || (len(fs.Fields) == 0 && len(fs.Includes) == 0) {
+		return true
+	}
+	for _, include := range fs.Includes {
+		if include == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectColumns 需要查询的列，extra 为额外需要的列（如游标排序字段），返回空表示查询全部列
+func (fs *FieldSet) SelectColumns(extra ...string) []string {
+	if fs == nil || len(fs.Columns) == 0 {
+		return nil
+	}
+
+	columns := append([]string{}, fs.Columns...)
+	for _, column := range extra {
+		found := false
+		for _, existing := range columns {
+			if existing == column {
+				found = true
+				break
+			}
+		}
+		if !found {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// Apply 按选择的字段裁剪单个资源或资源数组的响应，只保留选择的字段和展开的关联
+func (fs *FieldSet) Apply(data interface{}) interface{} {
+	if fs == nil || len(fs.Fields) == 0 {
+		return data
+	}
+
+	var full interface{}
+	if err := remarshal(data, &full); err != nil {
+		return data
+	}
+	return fs.trimValue(full)
+}
+
+// ApplyList 按选择的字段裁剪列表响应中 list 的每一项，分页等其他字段保持不变
+func (fs *FieldSet) ApplyList(response interface{}) interface{} {
+	if fs == nil || len(fs.Fields) == 0 {
+		return response
+	}
+
+	var full map[string]interface{}
+	if err := remarshal(response, &full); err != nil {
+		return response
+	}
+	if list, ok := full["list"]; ok {
+		full["list"] = fs.trimValue(list)
+	}
+	return full
+}
+
+// trimValue 裁剪对象或对象数组
+func (fs *FieldSet) trimValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return fs.trim(v)
+	case []interface{}:
+		for i, item := range v {
+			v[i] = fs.trimValue(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// trim 只保留选择的字段和展开的关联
+func (fs *FieldSet) trim(full map[string]interface{}) map[string]interface{} {
+	trimmed := make(map[string]interface{}, len(fs.Fields)+len(fs.Includes))
+	for _, fields := range [][]string{fs.Fields, fs.Includes} {
+		for _, field := range fields {
+			if value, ok := full[field]; ok {
+				trimmed[field] = value
+			}
+		}
+	}
+	return trimmed
+}
+
+// remarshal 通过 JSON 把结构体转换为 map，数字保持原样避免大整数丢失精度
+func remarshal(data interface{}, out interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	return decoder.Decode(out)
+}
+
+// splitList 解析逗号分隔的参数
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
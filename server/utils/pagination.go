@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 分页默认值
+const (
+	DefaultPageSize = 10  // 默认每页条数
+	MaxPageSize     = 100 // 每页条数上限
+)
+
+// 分页错误
+var (
+	ErrInvalidCursor = errors.New("无效的分页游标")
+	ErrInvalidSort   = errors.New("不支持的排序字段")
+)
+
+// PageQuery 列表分页参数
+type PageQuery struct {
+	Page      int    // 页码（偏移分页）
+	PageSize  int    // 每页条数
+	UseCursor bool   // 是否使用游标分页
+	Cursor    string // 上一页返回的 next_cursor，为空表示第一页
+	Sort      string // 游标分页的排序字段
+	Desc      bool   // 是否倒序
+	WithTotal bool   // 是否统计总数
+}
+
+// CursorResult 游标分页结果
+type CursorResult struct {
+	NextCursor string // 下一页游标，为空表示没有更多数据
+	Total      *int64 // 总数，仅在 WithTotal 时有值
+}
+
+// CursorToken 游标内容：排序字段、排序方向、本页最后一条记录的排序值和ID
+type CursorToken struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d,omitempty"`
+	Value string `json:"v,omitempty"`
+	Null  bool   `json:"n,omitempty"` // 排序值为 NULL
+	ID    int64  `json:"i"`
+}
+
+// GetPageQuery 从请求中解析分页参数
+// 传入 cursor 或 pagination=cursor 时使用游标分页，否则沿用 page/page_size 偏移分页
+func GetPageQuery(c *gin.Context) *PageQuery {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", strconv.Itoa(DefaultPageSize)))
+
+	query := &PageQuery{
+		Page:     page,
+		PageSize: pageSize,
+		Cursor:   c.Query("cursor"),
+		Sort:     c.DefaultQuery("sort", "id"),
+		Desc:     c.Query("order") == "desc",
+	}
+	query.UseCursor = query.Cursor != "" || c.Query("pagination") == "cursor"
+
+	// 偏移分页默认统计总数（兼容现有前端），游标分页默认不统计
+	query.WithTotal = !query.UseCursor
+	if withTotal := c.Query("with_total"); withTotal != "" {
+		query.WithTotal = withTotal == "1" || withTotal == "true"
+	}
+
+	query.Normalize()
+	return query
+}
+
+// Normalize 修正非法的页码和每页条数
+func (q *PageQuery) Normalize() {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PageSize < 1 {
+		q.PageSize = DefaultPageSize
+	}
+	if q.PageSize > MaxPageSize {
+		q.PageSize = MaxPageSize
+	}
+}
+
+// Offset 偏移分页的起始位置
+func (q *PageQuery) Offset() int {
+	return (q.Page - 1) * q.PageSize
+}
+
+// EncodeCursor 把游标内容编码为不透明字符串
+func EncodeCursor(token *CursorToken) string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor 解析游标字符串
+func DecodeCursor(cursor string) (*CursorToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var token CursorToken
+	if err := json.Unmarshal(data, &token); err != nil || token.Sort == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &token, nil
+}
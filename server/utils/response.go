@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"mvc-demo/models"
 	"net/http"
 
@@ -19,11 +21,16 @@ const (
 	ERROR_FORBIDDEN    = -3  // 禁止访问
 	ERROR_NOT_FOUND    = -4  // 资源不存在
 	ERROR_INTERNAL     = -5  // 内部服务器错误
+	ERROR_TIMEOUT      = -6  // 请求处理超时
 	ERROR_BUSINESS     = -10 // 业务错误
 )
 
+// ResponseCodeKey 响应码在 gin.Context 中的键，供访问日志和监控指标按响应码统计
+const ResponseCodeKey = "response_code"
+
 // Response 统一响应处理
 func Response(c *gin.Context, code int, msg string, data interface{}) {
+	c.Set(ResponseCodeKey, code)
 	// 始终返回 HTTP 200 状态码
 	c.JSON(http.StatusOK, models.Response{
 		Code: code,
@@ -67,8 +74,12 @@ func NotFound(c *gin.Context, msg string) {
 	FailWithMsg(c, ERROR_NOT_FOUND, msg)
 }
 
-// InternalError 内部服务器错误响应
+// InternalError 内部服务器错误响应，请求因超时被取消时返回超时错误
 func InternalError(c *gin.Context, msg string) {
+	if errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) {
+		FailWithMsg(c, ERROR_TIMEOUT, "请求处理超时，请稍后重试")
+		return
+	}
 	FailWithMsg(c, ERROR_INTERNAL, msg)
 }
 